@@ -0,0 +1,99 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"powervoting-server/constant"
+	"powervoting-server/model/api"
+	"powervoting-server/service"
+)
+
+type ConfHandler struct {
+	confService service.IConfService
+}
+
+func NewConfHandler(confService service.IConfService) *ConfHandler {
+	return &ConfHandler{
+		confService: confService,
+	}
+}
+
+// GetGithubRepoList returns the GitHub repositories of the PowerVotingConf contract.
+func (h *ConfHandler) GetGithubRepoList(c *constant.Context) {
+	var req api.GithubRepoListReq
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	res, err := h.confService.GetGithubRepoList(c.Context, req)
+	if err != nil {
+		SystemError(c.Context)
+		return
+	}
+
+	SuccessWithData(c.Context, res)
+}
+
+// GetSnapshotHeightList returns the snapshot heights set on the PowerVotingConf contract.
+func (h *ConfHandler) GetSnapshotHeightList(c *constant.Context) {
+	var req api.SnapshotHeightListReq
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	res, err := h.confService.GetSnapshotHeightList(c.Context, req)
+	if err != nil {
+		SystemError(c.Context)
+		return
+	}
+
+	SuccessWithData(c.Context, res)
+}
+
+// GetSnapshotExpirationHistory returns the changes of the snapshot expiration days.
+func (h *ConfHandler) GetSnapshotExpirationHistory(c *constant.Context) {
+	var req api.ChainIdParam
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	res, err := h.confService.GetSnapshotExpirationHistory(c.Context, req.ChainId)
+	if err != nil {
+		SystemError(c.Context)
+		return
+	}
+
+	SuccessWithData(c.Context, res)
+}
+
+// GetCountingAlgorithmHistory returns the changes of the vote counting algorithm.
+func (h *ConfHandler) GetCountingAlgorithmHistory(c *constant.Context) {
+	var req api.ChainIdParam
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	res, err := h.confService.GetCountingAlgorithmHistory(c.Context, req.ChainId)
+	if err != nil {
+		SystemError(c.Context)
+		return
+	}
+
+	SuccessWithData(c.Context, res)
+}
@@ -0,0 +1,48 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"powervoting-server/constant"
+	"powervoting-server/model/api"
+	"powervoting-server/service"
+)
+
+type EventHandler struct {
+	eventService service.IEventService
+}
+
+func NewEventHandler(eventService service.IEventService) *EventHandler {
+	return &EventHandler{
+		eventService: eventService,
+	}
+}
+
+// GetDeadLetterList returns the event logs that failed to be applied and are waiting for a replay or an operator.
+func (h *EventHandler) GetDeadLetterList(c *constant.Context) {
+	var req api.DeadLetterListReq
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	res, err := h.eventService.GetDeadLetterList(c.Context, req)
+	if err != nil {
+		SystemError(c.Context)
+		return
+	}
+
+	SuccessWithData(c.Context, res)
+}
@@ -0,0 +1,69 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.uber.org/zap"
+
+	"powervoting-server/constant"
+	"powervoting-server/model/api"
+	"powervoting-server/service"
+)
+
+type ExportHandler struct {
+	exportService service.IExportService
+}
+
+func NewExportHandler(exportService service.IExportService) *ExportHandler {
+	return &ExportHandler{
+		exportService: exportService,
+	}
+}
+
+// Export streams a dataset of a network as a CSV or JSON Lines attachment.
+// The rows are written while they are read, so an export that fails once rows were sent ends early instead of answering an error.
+func (h *ExportHandler) Export(c *constant.Context) {
+	var req api.ExportReq
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	if req.Format == "" {
+		req.Format = constant.ExportFormatCsv
+	}
+	contentType := "text/csv; charset=utf-8"
+	if req.Format == constant.ExportFormatNdjson {
+		contentType = "application/x-ndjson"
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-%d.%s"`, req.Dataset, req.ChainId, req.Format))
+	c.Status(http.StatusOK)
+
+	if err := h.exportService.Export(c.Request.Context(), req, c.Writer); err != nil {
+		if c.Writer.Written() {
+			zap.L().Warn("Export ended early", zap.String("dataset", req.Dataset), zap.Error(err))
+			return
+		}
+
+		c.Writer.Header().Del("Content-Type")
+		c.Writer.Header().Del("Content-Disposition")
+		SystemError(c.Context)
+	}
+}
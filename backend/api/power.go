@@ -32,7 +32,7 @@ func GetAddressPower(c *constant.Context) {
 		return
 	}
 
-	ethAddr, err := req.AddressReq.ToEthAddr()
+	ethAddr, err := req.AddressReq.ToEthAddr(req.ChainId)
 	if err != nil {
 		zap.L().Error("GetAddressPower invalid address: ", zap.String("address", req.AddressReq.Address), zap.Error(err))
 		Error(c.Context, err)
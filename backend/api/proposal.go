@@ -30,7 +30,7 @@ func NewProposalHandler(ps service.IProposalService) *ProposalHandler {
 	}
 }
 
-// AddDraft function handles an HTTP request to add a draft proposal to the database.
+// AddDraft function handles an HTTP request to add a draft proposal, or a new version of it, to the database.
 func (p *ProposalHandler) PostDraft(c *constant.Context) {
 	var draft api.AddProposalDraftReq
 	if err := c.BindAndValidate(&draft); err != nil {
@@ -38,12 +38,13 @@ func (p *ProposalHandler) PostDraft(c *constant.Context) {
 		return
 	}
 
-	if err := p.proposqlService.AddDraft(c.Request.Context(), &draft); err != nil {
+	res, err := p.proposqlService.AddDraft(c.Request.Context(), &draft)
+	if err != nil {
 		Error(c.Context, err)
 		return
 	}
 
-	Success(c.Context)
+	SuccessWithData(c.Context, res)
 }
 
 func (p *ProposalHandler) DeleteDraft(c *constant.Context) {
@@ -63,7 +64,7 @@ func (p *ProposalHandler) DeleteDraft(c *constant.Context) {
 
 // GetDraft function handles an HTTP request to retrieve a draft proposal from the database.
 func (p *ProposalHandler) GetDraft(c *constant.Context) {
-	var req api.AddressReq
+	var req api.ProposalDraftReq
 	if err := c.BindAndValidate(&req); err != nil {
 		ParamError(c.Context)
 		return
@@ -78,6 +79,73 @@ func (p *ProposalHandler) GetDraft(c *constant.Context) {
 	SuccessWithData(c.Context, res)
 }
 
+// GetDraftList function handles an HTTP request to retrieve the draft proposals of a creator.
+func (p *ProposalHandler) GetDraftList(c *constant.Context) {
+	var req api.ProposalDraftListReq
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	res, err := p.proposqlService.GetDraftList(c.Context, req)
+	if err != nil {
+		Error(c.Context, err)
+		return
+	}
+
+	SuccessWithData(c.Context, res)
+}
+
+// GetDraftVersions function handles an HTTP request to retrieve the version history of a draft proposal.
+func (p *ProposalHandler) GetDraftVersions(c *constant.Context) {
+	var req api.DraftIdParam
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	res, err := p.proposqlService.GetDraftVersions(c.Context, req)
+	if err != nil {
+		Error(c.Context, err)
+		return
+	}
+
+	SuccessWithData(c.Context, res)
+}
+
+// GetDraftDiff function handles an HTTP request to compare two versions of a draft proposal.
+func (p *ProposalHandler) GetDraftDiff(c *constant.Context) {
+	var req api.ProposalDraftDiffReq
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	res, err := p.proposqlService.GetDraftDiff(c.Context, req)
+	if err != nil {
+		Error(c.Context, err)
+		return
+	}
+
+	SuccessWithData(c.Context, res)
+}
+
+// SubmitDraft function handles an HTTP request to mark the draft version a proposal is created from.
+func (p *ProposalHandler) SubmitDraft(c *constant.Context) {
+	var req api.SubmitProposalDraftReq
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	if err := p.proposqlService.SubmitDraft(c.Request.Context(), req); err != nil {
+		Error(c.Context, err)
+		return
+	}
+
+	Success(c.Context)
+}
+
 // GetProposalDetails is a function that handles the request to get details of a proposal.
 func (p *ProposalHandler) GetProposalDetail(c *constant.Context) {
 	var req api.ProposalReq
@@ -95,6 +163,57 @@ func (p *ProposalHandler) GetProposalDetail(c *constant.Context) {
 	SuccessWithData(c.Context, res)
 }
 
+// GetProposalAudit is a function that handles the request to get the tally audit bundle of a proposal.
+func (p *ProposalHandler) GetProposalAudit(c *constant.Context) {
+	var req api.ProposalReq
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	res, err := p.proposqlService.ProposalAudit(c.Request.Context(), req)
+	if err != nil {
+		Error(c.Context, err)
+		return
+	}
+
+	SuccessWithData(c.Context, res)
+}
+
+// GetProposalResultHistory is a function that handles the request to get the results of a proposal replaced by recounts.
+func (p *ProposalHandler) GetProposalResultHistory(c *constant.Context) {
+	var req api.ProposalReq
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	res, err := p.proposqlService.ProposalResultHistory(c.Request.Context(), req)
+	if err != nil {
+		Error(c.Context, err)
+		return
+	}
+
+	SuccessWithData(c.Context, res)
+}
+
+// GetProposalParticipation is a function that handles the request to get the participation of a proposal over time.
+func (p *ProposalHandler) GetProposalParticipation(c *constant.Context) {
+	var req api.ProposalParticipationReq
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	res, err := p.proposqlService.ProposalParticipation(c.Request.Context(), req)
+	if err != nil {
+		Error(c.Context, err)
+		return
+	}
+
+	SuccessWithData(c.Context, res)
+}
+
 // GetProposalList is a function that handles the request to get a list of proposals.
 func (p *ProposalHandler) GetProposalList(c *constant.Context) {
 	var req api.ProposalListReq
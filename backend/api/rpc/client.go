@@ -178,13 +178,19 @@ func UploadSnapshotInfo(chainId int64, snapshotDay string) (model.SnapshotHeight
 	}, nil
 }
 
-func SyncAddressPower(chainId int64, ethAddr string) {
+// SyncAddressPower asks the snapshot service to sync the power of an address.
+func SyncAddressPower(chainId int64, ethAddr string) error {
 	ctx, cancel := context.WithTimeout(context.Background(), constant.RequestTimeout)
 	defer cancel()
 
-	grpcReq := &pb.SyncAddrPowerRequest{}
+	grpcReq := &pb.SyncAddrPowerRequest{
+		NetId:   chainId,
+		Address: ethAddr,
+	}
 	if _, err := getClient().SyncAddrPower(ctx, grpcReq); err != nil {
-		zap.L().Error("failed to sync address power", zap.Error(err))
+		zap.L().Error("failed to sync address power", zap.String("address", ethAddr), zap.Error(err))
+		return fmt.Errorf("failed to sync address power: %v", err)
 	}
 
+	return nil
 }
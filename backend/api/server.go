@@ -56,3 +56,8 @@ func ParamError(c *gin.Context) {
 func SystemError(c *gin.Context) {
 	R(constant.CodeSystemError, nil, constant.CodeSystemErrorStr, c)
 }
+
+// Unauthorized sends a response indicating a request without the required token.
+func Unauthorized(c *gin.Context) {
+	R(constant.CodeUnauthorized, nil, constant.CodeUnauthorizedStr, c)
+}
@@ -0,0 +1,128 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+	"powervoting-server/model/api"
+	"powervoting-server/service"
+)
+
+type StreamHandler struct {
+	streamService service.IStreamService
+}
+
+func NewStreamHandler(streamService service.IStreamService) *StreamHandler {
+	return &StreamHandler{
+		streamService: streamService,
+	}
+}
+
+// Stream pushes the governance lifecycle events as server-sent events, the ID of every event is its stream sequence, the cursor of the stream.
+// A client resumes after the last event it received with the Last-Event-ID header or the cursor parameter,
+// without them the stream starts with the next emitted event.
+func (h *StreamHandler) Stream(c *constant.Context) {
+	var req api.StreamReq
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	var cursor int64
+	if lastEventId := c.GetHeader("Last-Event-ID"); lastEventId != "" {
+		id, err := strconv.ParseInt(lastEventId, 10, 64)
+		if err != nil || id < 0 {
+			ParamError(c.Context)
+			return
+		}
+		cursor = id
+	} else if req.Cursor != nil {
+		cursor = *req.Cursor
+	} else {
+		id, err := h.streamService.GetStreamCursor(c.Request.Context())
+		if err != nil {
+			SystemError(c.Context)
+			return
+		}
+		cursor = id
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	ctx := c.Request.Context()
+	poll := time.NewTicker(constant.StreamPollInterval * time.Second)
+	defer poll.Stop()
+	lastWrite := time.Now()
+	for {
+		events, err := h.streamService.GetStreamEvents(ctx, req, cursor)
+		if err != nil {
+			// The client reconnects and resumes after the last event it received
+			return
+		}
+
+		for _, event := range events {
+			if err := writeStreamEvent(c.Writer, event); err != nil {
+				return
+			}
+			cursor = event.Seq
+		}
+		if len(events) > 0 {
+			c.Writer.Flush()
+			lastWrite = time.Now()
+		} else if time.Since(lastWrite) >= constant.StreamHeartbeatInterval*time.Second {
+			// A comment keeps the connection open through the proxies
+			if _, err := io.WriteString(c.Writer, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			c.Writer.Flush()
+			lastWrite = time.Now()
+		}
+
+		// A full batch means that more events are waiting
+		if len(events) == constant.StreamBatchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-poll.C:
+		}
+	}
+}
+
+// writeStreamEvent writes an event in the server-sent events format, named by the event and identified by its stream sequence.
+func writeStreamEvent(w io.Writer, event model.StreamEvent) error {
+	data, err := json.Marshal(event.Payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.Seq, event.Payload.Event, data)
+	return err
+}
@@ -47,8 +47,42 @@ func (h *VoteHandler) GetCountedVotesInfo(c *constant.Context) {
 	SuccessWithData(c.Context, res)
 }
 
+// GetVoteHistory returns every vote of a voter on a proposal
+func (h *VoteHandler) GetVoteHistory(c *constant.Context) {
+	var req api.VoteHistoryReq
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	res, err := h.voteServer.GetVoteHistory(c.Request.Context(), req)
+	if err != nil {
+		Error(c.Context, err)
+		return
+	}
+
+	SuccessWithData(c.Context, res)
+}
+
+// GetVoterHistory returns the vote of a voter on every proposal of a network
+func (h *VoteHandler) GetVoterHistory(c *constant.Context) {
+	var req api.VoterHistoryReq
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	res, err := h.voteServer.GetVoterHistory(c.Request.Context(), req)
+	if err != nil {
+		Error(c.Context, err)
+		return
+	}
+
+	SuccessWithData(c.Context, res)
+}
+
 func (f *VoteHandler) GetFipEditorGistInfo(c *constant.Context) {
-	var req api.AddressReq
+	var req api.VoterInfoReq
 	if err := c.BindAndValidate(&req); err != nil {
 		ParamError(c.Context)
 		return
@@ -0,0 +1,114 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"crypto/subtle"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"powervoting-server/config"
+	"powervoting-server/constant"
+	"powervoting-server/model/api"
+	"powervoting-server/service"
+)
+
+type WebhookHandler struct {
+	webhookService service.IWebhookService
+}
+
+func NewWebhookHandler(webhookService service.IWebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// WebhookAuth allows the requests carrying the configured webhook token as a bearer token.
+// Every request is rejected when no token is configured.
+func WebhookAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := config.Client.Webhook.Token
+		bearer, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if token == "" || !ok || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			Unauthorized(c)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// AddWebhook subscribes a URL to governance lifecycle events and returns the secret of its signatures.
+func (h *WebhookHandler) AddWebhook(c *constant.Context) {
+	var req api.AddWebhookReq
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	res, err := h.webhookService.AddWebhook(c.Request.Context(), req)
+	if err != nil {
+		Error(c.Context, err)
+		return
+	}
+
+	SuccessWithData(c.Context, res)
+}
+
+// GetWebhookList returns the webhooks without their secrets.
+func (h *WebhookHandler) GetWebhookList(c *constant.Context) {
+	res, err := h.webhookService.GetWebhookList(c.Request.Context())
+	if err != nil {
+		SystemError(c.Context)
+		return
+	}
+
+	SuccessWithData(c.Context, res)
+}
+
+// DeleteWebhook deletes a webhook and cancels its pending deliveries.
+func (h *WebhookHandler) DeleteWebhook(c *constant.Context) {
+	var req api.WebhookReq
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	if err := h.webhookService.DeleteWebhook(c.Request.Context(), req); err != nil {
+		Error(c.Context, err)
+		return
+	}
+
+	Success(c.Context)
+}
+
+// GetWebhookDeliveryList returns the delivery log of a webhook.
+func (h *WebhookHandler) GetWebhookDeliveryList(c *constant.Context) {
+	var req api.WebhookDeliveryListReq
+	if err := c.BindAndValidate(&req); err != nil {
+		ParamError(c.Context)
+		return
+	}
+
+	res, err := h.webhookService.GetWebhookDeliveryList(c.Request.Context(), req)
+	if err != nil {
+		SystemError(c.Context)
+		return
+	}
+
+	SuccessWithData(c.Context, res)
+}
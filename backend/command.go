@@ -0,0 +1,147 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"powervoting-server/config"
+	"powervoting-server/model/api"
+	"powervoting-server/service"
+	"powervoting-server/task"
+)
+
+// runCommand runs a maintenance command given on the command line, with the same configuration as the server.
+func runCommand(args []string, syncService *service.SyncService, exportService *service.ExportService) error {
+	switch args[0] {
+	case "recount":
+		return recountCommand(args[1:], syncService)
+	case "rebuild":
+		return rebuildCommand(args[1:], syncService)
+	case "export":
+		return exportCommand(args[1:], exportService)
+	default:
+		return fmt.Errorf("unknown command %q, supported commands: recount, rebuild, export", args[0])
+	}
+}
+
+// recountCommand counts an already counted proposal again and keeps its previous result in the result history.
+//
+//	powervoting-server recount -chain 314159 -proposal 12 -reason "snapshot was missing the power of 0x..."
+func recountCommand(args []string, syncService *service.SyncService) error {
+	fs := flag.NewFlagSet("recount", flag.ContinueOnError)
+	chainId := fs.Int64("chain", 0, "chain ID of the proposal, the primary network by default")
+	proposalId := fs.Int64("proposal", 0, "ID of the proposal to recount")
+	reason := fs.String("reason", "", "why the proposal is recounted, saved with the replaced result")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *proposalId <= 0 {
+		return errors.New("-proposal is required")
+	}
+
+	if err := task.RecountHandler(syncService, *chainId, *proposalId, *reason); err != nil {
+		return err
+	}
+
+	log.Printf("proposal %d recounted\n", *proposalId)
+	return nil
+}
+
+// rebuildCommand deletes the tables derived from the contract events and applies the event journal again.
+// The server must be stopped while the tables are rebuilt.
+//
+//	powervoting-server rebuild -chain 314159 -confirm
+func rebuildCommand(args []string, syncService *service.SyncService) error {
+	fs := flag.NewFlagSet("rebuild", flag.ContinueOnError)
+	chainId := fs.Int64("chain", 0, "chain ID of the network to rebuild, the primary network by default")
+	confirm := fs.Bool("confirm", false, "confirm that the proposals, votes, FIP and voter info tables are rebuilt")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if !*confirm {
+		return errors.New("-confirm is required, the derived tables are deleted and rebuilt from the event journal")
+	}
+
+	applied, failed, err := task.RebuildHandler(syncService, *chainId)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("%d event logs applied, %d failed and saved as dead letters\n", applied, failed)
+	return nil
+}
+
+// exportCommand writes a dataset of a network as CSV or JSON Lines to a file, or to the standard output without -out.
+//
+//	powervoting-server export -chain 314159 -dataset votes -format csv -from 1735689600 -out votes.csv
+func exportCommand(args []string, exportService *service.ExportService) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	chainId := fs.Int64("chain", 0, "chain ID of the network to export, the primary network by default")
+	dataset := fs.String("dataset", "", "dataset to export: proposals, votes, fip_proposals, fip_votes or voters")
+	format := fs.String("format", "csv", "export format: csv or ndjson")
+	from := fs.Int64("from", 0, "only export the rows created at or after this unix time")
+	to := fs.Int64("to", 0, "only export the rows created at or before this unix time, no limit by default")
+	out := fs.String("out", "", "file the export is written to, the standard output by default")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	network, ok := config.GetNetwork(*chainId)
+	if !ok {
+		return fmt.Errorf("network %d is not configured", *chainId)
+	}
+
+	if *dataset == "" {
+		return errors.New("-dataset is required")
+	}
+
+	if *to > 0 && *to < *from {
+		return errors.New("-to must not be before -from")
+	}
+
+	w := os.Stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+
+	req := api.ExportReq{
+		ChainIdParam: api.ChainIdParam{ChainId: network.ChainId},
+		Dataset:      *dataset,
+		Format:       *format,
+		From:         *from,
+		To:           *to,
+	}
+	if err := exportService.Export(context.Background(), req, w); err != nil {
+		return err
+	}
+
+	if *out != "" {
+		log.Printf("%s of chain %d exported to %s\n", *dataset, network.ChainId, *out)
+	}
+	return nil
+}
@@ -69,6 +69,11 @@ func InitConfig(path string) {
 
 	}
 
+	// The values inside lists are not listed by AllKeys
+	if networks, ok := viper.Get("networks").([]any); ok {
+		viper.Set("networks", replaceListEnvVariables(networks))
+	}
+
 	err = viper.Unmarshal(&Client)
 	if err != nil {
 		zap.L().Error("unmarshal error:", zap.Error(err))
@@ -83,6 +88,56 @@ func replaceEnvVariables(value string) string {
 	})
 }
 
+// replaceListEnvVariables replaces the environment variables in the string values of the maps of a list.
+func replaceListEnvVariables(list []any) []any {
+	for _, item := range list {
+		values, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		for key, value := range values {
+			if str, ok := value.(string); ok {
+				values[key] = strings.Trim(replaceEnvVariables(str), `'"`)
+			}
+		}
+	}
+
+	return list
+}
+
+// Networks returns the configured networks, the primary network first.
+// Networks without a chain ID and networks whose chain ID is already configured are skipped.
+func Networks() []Network {
+	networks := make([]Network, 0, len(Client.Networks)+1)
+	seen := make(map[int64]bool, len(Client.Networks)+1)
+	for _, network := range append([]Network{Client.Network}, Client.Networks...) {
+		if network.ChainId == 0 || seen[network.ChainId] {
+			continue
+		}
+
+		seen[network.ChainId] = true
+		networks = append(networks, network)
+	}
+
+	return networks
+}
+
+// GetNetwork returns the configuration of the network of a chain ID, chain ID 0 is the primary network.
+func GetNetwork(chainId int64) (Network, bool) {
+	if chainId == 0 {
+		return Client.Network, Client.Network.ChainId != 0
+	}
+
+	for _, network := range Networks() {
+		if network.ChainId == chainId {
+			return network, true
+		}
+	}
+
+	return Network{}, false
+}
+
 func GetDefaultConfig(client ...*Config) {
 	Client = DefaultConfig
 	if len(client) == 0 {
@@ -0,0 +1,60 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNetworks(t *testing.T) {
+	Client = Config{
+		Network: Network{ChainId: 314159, Rpc: "calibration"},
+		Networks: []Network{
+			{ChainId: 314, Rpc: "mainnet"},
+			{Rpc: "no chain id"},
+			{ChainId: 314159, Rpc: "duplicate"},
+		},
+	}
+
+	networks := Networks()
+	assert.Len(t, networks, 2)
+	assert.Equal(t, "calibration", networks[0].Rpc)
+	assert.Equal(t, "mainnet", networks[1].Rpc)
+
+	network, ok := GetNetwork(0)
+	assert.True(t, ok)
+	assert.Equal(t, int64(314159), network.ChainId)
+
+	network, ok = GetNetwork(314)
+	assert.True(t, ok)
+	assert.Equal(t, "mainnet", network.Rpc)
+
+	_, ok = GetNetwork(1)
+	assert.False(t, ok)
+}
+
+func TestReplaceListEnvVariables(t *testing.T) {
+	t.Setenv("TEST_MAINNET_RPC", "http://localhost:1234/rpc/v1")
+
+	list := replaceListEnvVariables([]any{
+		map[string]any{"rpc": "${TEST_MAINNET_RPC}", "chainId": 314},
+	})
+
+	values := list[0].(map[string]any)
+	assert.Equal(t, "http://localhost:1234/rpc/v1", values["rpc"])
+	assert.Equal(t, 314, values["chainId"])
+}
@@ -16,13 +16,18 @@ package config
 
 // Config represents the configuration structure for the PowerVoting application.
 type Config struct {
-	Server   Server   // Server configuration
-	Mysql    Mysql    // MySQL database configuration
-	Drand    Drand    // Drand network configuration
-	Snapshot Snapshot // Snapshot configuration
-	Network  Network  // List of network configurations
-	ABIPath  ABIPath  // Abi path to the contracts
-	Github   Github   // Github configuration
+	Server   Server    // Server configuration
+	Mysql    Mysql     // MySQL database configuration
+	Drand    Drand     // Drand network configuration
+	Snapshot Snapshot  // Snapshot configuration
+	Network  Network   // Primary network configuration
+	Networks []Network // Additional networks served by the same deployment
+	ABIPath  ABIPath   // Abi path to the contracts
+	Github   Github    // Github configuration
+	Quorum   Quorum    // Default quorum rules for new proposals
+	Counting Counting  // Vote counting configuration
+	Backfill Backfill  // Historical event backfill configuration
+	Webhook  Webhook   // Outbound webhook configuration
 }
 
 // Server represents the server configuration.
@@ -50,15 +55,54 @@ type Github struct {
 
 // Network represents the configuration for a specific network.
 type Network struct {
-	ChainId              int64  // Unique identifier for the network
-	Name                 string // Name of the network
-	Rpc                  string // RPC endpoint for the network
-	PowerVotingContract  string // Contract address for PowerVoting
-	SyncEventStartHeight int64  // Deployment height of the PowerVoting contract
-	OracleContract       string // Contract address for Oracle
-	FipContract          string // Contract address for FIP
-	FipInitEditor        string // Initial editor for FIP
-	MinerIdPrefix        string // Prefix for miner IDs
+	ChainId                 int64  // Unique identifier for the network
+	Name                    string // Name of the network
+	Rpc                     string // RPC endpoint for the network
+	PowerVotingContract     string // Contract address for PowerVoting
+	SyncEventStartHeight    int64  // Deployment height of the PowerVoting contract
+	OracleContract          string // Contract address for Oracle
+	FipContract             string // Contract address for FIP
+	FipInitEditor           string // Initial editor for FIP
+	PowerVotingConfContract string // Contract address for PowerVotingConf
+	MinerIdPrefix           string // Prefix for miner IDs
+	ConfirmationDepth       int64  // Blocks behind the head that are synced, blocks closer to the head can still be reorganized
+	IndexerUrl              string // Filfox compatible event API, used when the node fails to return the event logs
+	VerifyLogs              bool   // Compare the event logs of the node with the indexer for every synced range
+}
+
+// Quorum represents the quorum rules recorded on proposals when they are created.
+// Power shares are in basis points (10000 = 100%), like the proposal percentages, and 0 disables a rule.
+type Quorum struct {
+	MinVoters             int64  // Minimum number of counted voters
+	SpPowerShare          uint16 // Minimum share of the network raw byte power that must vote
+	ClientPowerShare      uint16 // Minimum share of the snapshot client power that must vote
+	TokenHolderPowerShare uint16 // Minimum share of the snapshot token holder power that must vote
+	DeveloperPowerShare   uint16 // Minimum share of the snapshot developer power that must vote
+}
+
+// Counting represents the configuration of the vote counting task, all durations are in seconds.
+// A proposal with votes from addresses missing in its power snapshot is not counted until the power is synced,
+// it is retried with an interval doubled after every attempt and counted with the missing power as zero after the deadline.
+type Counting struct {
+	PowerRetryInterval    int64 // Interval before the first retry, 0 for the default
+	PowerRetryMaxInterval int64 // Maximum interval between retries, 0 for the default
+	PowerDeadline         int64 // Time after the proposal end time to wait for missing power, 0 for the default
+}
+
+// Backfill represents the configuration of the backfill of historical events.
+// A network further behind its confirmed head than the threshold is synced in chunks of blocks whose logs are fetched concurrently,
+// the chunks are applied in order and the progress is saved with every applied chunk.
+type Backfill struct {
+	Threshold int64 // Blocks behind the confirmed head above which the backfill is used, 0 for the default
+	ChunkSize int64 // Blocks per chunk, shrunk when the RPC rejects the range, 0 for the default
+	Workers   int   // Chunks fetched concurrently, 0 for the default
+}
+
+// Webhook represents the configuration of the outbound webhooks.
+// The webhooks are managed with the token as a bearer token, the management API is disabled when it is empty.
+type Webhook struct {
+	Token   string // Bearer token of the webhook management API
+	Timeout int64  // Seconds a webhook has to answer a delivery, 0 for the default
 }
 
 type Snapshot struct {
@@ -66,7 +110,8 @@ type Snapshot struct {
 }
 
 type ABIPath struct {
-	PowerVotingAbi  string // ABI (Application Binary Interface) for PowerVoting contract
-	OracleAbi       string // ABI for Oracle contract
-	FipAbi          string // ABI for FIP contract
+	PowerVotingAbi     string // ABI (Application Binary Interface) for PowerVoting contract
+	OracleAbi          string // ABI for Oracle contract
+	FipAbi             string // ABI for FIP contract
+	PowerVotingConfAbi string // ABI for PowerVotingConf contract
 }
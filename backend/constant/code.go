@@ -20,6 +20,7 @@ const (
 	CodeParamError     = 1000 // CodeParamError indicates an error due to invalid input parameters.
 	CodeDataExistError = 1001 // CodeDataExistError indicates an error when the data already exists.
 	CodeError          = 1002 // CodeError indicates an  error.
+	CodeUnauthorized   = 1003 // CodeUnauthorized indicates a request without the required token.
 	CodeSystemError    = 9999 // CodeSystemError indicates an internal system error.
 )
 
@@ -29,4 +30,5 @@ const (
 	CodeParamErrorStr     = "param error"          // CodeParamErrorStr is the message for invalid input parameters.
 	CodeSystemErrorStr    = "system error"         // CodeSystemErrorStr is the message for internal system errors.
 	CodeDataExistErrorStr = "already exists error" // CodeDataExistErrorStr is the message when the data already exists.
+	CodeUnauthorizedStr   = "unauthorized"         // CodeUnauthorizedStr is the message for a request without the required token.
 )
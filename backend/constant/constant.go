@@ -27,12 +27,125 @@ const (
 	// VoteReject represents the rejection vote status.
 	VoteReject = "reject"
 
+	// VoteAbstain represents a vote that takes part without approving or rejecting.
+	VoteAbstain = "abstain"
+
+	// VoteOptionsPrefix starts the line of a proposal content that declares its vote options.
+	VoteOptionsPrefix = "Vote options:"
+	// MaxVoteOptions is the number of vote options a proposal can offer, approve and reject included.
+	MaxVoteOptions = 10
+	// MaxVoteOptionLength is the length of the longest vote option, longer options are ignored.
+	MaxVoteOptionLength = 32
+
+	// vote counting algorithms, named as configured on the PowerVotingConf contract
+	CountingAlgorithmWeightedShare    = "weighted-share"    // category share of power weighted by proposal percentages
+	CountingAlgorithmCategoryMajority = "category-majority" // each category's percentage goes to the option with most power in it
+	CountingAlgorithmQuadratic        = "quadratic"         // weighted share over the square root of each voter's power
+
+	// power categories of a proposal result breakdown
+	PowerCategorySp          = "sp"           // storage provider power
+	PowerCategoryClient      = "client"       // client power
+	PowerCategoryTokenHolder = "token_holder" // token holder power
+	PowerCategoryDeveloper   = "developer"    // developer power
+
 	// http request timeout time
 	RequestTimeout = time.Second * 15
 	MaxFileSize    = 1024 * 2
 
 	// geth The maximum supported event parsing block limit
 	SyncBlockLimit = 2880
+	// Blocks behind the chain head that are synced when the network does not configure it
+	SyncConfirmationDepth = 20
+	// Number of recorded blocks checked to find where the chain was reorganized
+	SyncReorgSearchLimit = 100
+	// Heights searched for a block when the chain has null rounds
+	SyncNullRoundLimit = 30
+	// Recorded blocks older than this many blocks below the synced height are removed
+	SyncBlockRetention = 2880
+	// Blocks behind the confirmed head above which the events are backfilled in concurrent chunks
+	BackfillThreshold = 4 * SyncBlockLimit
+	// Blocks per backfill chunk when it is not configured
+	BackfillChunkSize = SyncBlockLimit
+	// Backfill chunks fetched concurrently when it is not configured
+	BackfillWorkers = 4
+	// Block headers kept in the cache of each network
+	BlockHeaderCacheSize = 4096
+	// Block headers fetched in one JSON-RPC batch
+	BlockHeaderBatchSize = 100
+	// Events requested in one page of the event indexer
+	IndexerPageSize = 100
+	// Event logs that failed to be applied are replayed this many times before they are left for an operator
+	DeadLetterMaxAttempts = 10
+	// Seconds before the first replay of a failed event log, doubled after every attempt
+	DeadLetterRetryInterval = 60
+	// Maximum seconds between two replays of a failed event log
+	DeadLetterRetryMaxInterval = 6 * 60 * 60
+	// Failed event logs replayed per sync
+	DeadLetterReplayLimit = 50
+	// Event logs read from the journal at a time when the derived tables are rebuilt
+	RebuildBatchSize = 500
+	// Webhook deliveries are attempted this many times before they are left in the delivery log as exhausted
+	WebhookMaxAttempts = 8
+	// Seconds before the second attempt of a webhook delivery, doubled after every attempt
+	WebhookRetryInterval = 30
+	// Maximum seconds between two attempts of a webhook delivery
+	WebhookRetryMaxInterval = 60 * 60
+	// Webhook deliveries posted per run of the delivery task
+	WebhookDeliveryLimit = 100
+	// Webhook deliveries posted concurrently by the delivery task
+	WebhookDeliveryWorkers = 8
+	// Seconds a webhook has to answer a delivery when it is not configured
+	WebhookTimeout = 10
+	// Bytes of the answer of a failed webhook delivery kept in the delivery log
+	WebhookResponseLimit = 512
+	// Seconds between two reads of the new events of a stream
+	StreamPollInterval = 2
+	// Seconds without events after which a stream sends a heartbeat comment
+	StreamHeartbeatInterval = 15
+	// Events read at a time by a stream
+	StreamBatchSize = 100
+	// Committed events given their stream sequence at a time
+	StreamPublishBatchSize = 1000
+	// Rows read at a time by an export
+	ExportBatchSize = 1000
+	// Seconds a signed draft payload is accepted before and after its timestamp
+	DraftSignatureWindow = 5 * 60
+
+	DeadLetterStatusPending   = 0 // dead letter waiting to be replayed
+	DeadLetterStatusResolved  = 1 // dead letter replayed successfully
+	DeadLetterStatusExhausted = 2 // dead letter that failed every replay
+
+	WebhookDeliveryStatusPending   = 0 // delivery waiting to be posted
+	WebhookDeliveryStatusDelivered = 1 // delivery answered with a 2xx status
+	WebhookDeliveryStatusExhausted = 2 // delivery that failed every attempt
+	WebhookDeliveryStatusCancelled = 3 // delivery of a deleted webhook
+
+	// Governance lifecycle events posted to the webhooks
+	WebhookProposalCreated       = "proposal.created"
+	WebhookProposalVotingStarted = "proposal.voting_started"
+	WebhookProposalVotingEnded   = "proposal.voting_ended"
+	WebhookProposalCounted       = "proposal.counted"
+	WebhookFipProposalCreated    = "fip_proposal.created"
+	WebhookFipProposalPassed     = "fip_proposal.passed"
+	WebhookVoterIdentityBound    = "voter.identity_bound"
+	WebhookVoteCast              = "vote.cast"
+
+	ExportProposals    = "proposals"     // proposals with their results
+	ExportVotes        = "votes"         // counted votes with the power of the voters
+	ExportFipProposals = "fip_proposals" // FIP editor proposals
+	ExportFipVotes     = "fip_votes"     // votes on FIP editor proposals
+	ExportVoters       = "voters"        // voter identity bindings
+
+	ExportFormatCsv    = "csv"    // comma separated values with a header row
+	ExportFormatNdjson = "ndjson" // one JSON object per line
+
+	DraftActionSave   = "save"   // signed payload saving a draft
+	DraftActionDelete = "delete" // signed payload deleting a draft
+	DraftActionSubmit = "submit" // signed payload marking the draft version a proposal is created from
+
+	DraftDiffEqual  = "equal"  // line in both versions of a draft
+	DraftDiffInsert = "insert" // line only in the version the changes are to
+	DraftDiffDelete = "delete" // line only in the version the changes are from
 
 	// ProposalStatusPending represents the pending proposal status.
 	ProposalStatusPending    = 1
@@ -43,6 +156,10 @@ const (
 	ProposalCreate  = 0 // proposal created
 	ProposalCounted = 1 // proposal counted
 
+	ProposalOutcomePassed       = "passed"        // approve leads and the quorum is met
+	ProposalOutcomeRejected     = "rejected"      // approve does not lead and the quorum is met
+	ProposalOutcomeFailedQuorum = "failed-quorum" // the quorum is not met
+
 	FipProposalRevoke = 0 // fip proposal revoked
 	FipProposalUnpass = 0 // fip proposal unpassed
 	FipProposalPass   = 1 // fip proposal passed
@@ -56,6 +173,16 @@ const (
 	FipVoteEvt              = "FipEditorProposalVoteEvent"
 	OracleUpdateGistIdsEvt  = "UpdateGistIdsEvent"
 	OracleUpdateMinerIdsEvt = "UpdateMinerIdsEvent"
+	// PowerVotingConf contract event logs name
+	ConfGithubRepoAddedEvt         = "GithubRepoAdded"
+	ConfGithubRepoRemovedEvt       = "GithubRepoRemoved"
+	ConfSnapshotDaysEvt            = "SnapshotDays"
+	ConfSnapshotExpirationDayEvt   = "SnapshotExpirationDay"
+	ConfVotingCountingAlgorithmEvt = "VotingCountingAlgorithm"
+
+	// PowerVotingConf settings whose changes are recorded
+	ConfSettingSnapshotExpirationDays = "snapshot_expiration_days"
+	ConfSettingCountingAlgorithm      = "counting_algorithm"
 	// mysql duplicate error code
 	MysqlDuplicateEntryErrorCode = 1062
 
@@ -64,3 +191,18 @@ const (
 	SigTypeSecp256k1 = 1 // Signature type for Secp256k1.
 	SigTypeBLS       = 2 // Signature type for BLS.
 )
+
+// DefaultVoteOptions are the options offered by the proposals that declare none in their content.
+var DefaultVoteOptions = []string{VoteApprove, VoteReject, VoteAbstain}
+
+// WebhookEvents are the events a webhook can subscribe to.
+var WebhookEvents = []string{
+	WebhookProposalCreated,
+	WebhookProposalVotingStarted,
+	WebhookProposalVotingEnded,
+	WebhookProposalCounted,
+	WebhookFipProposalCreated,
+	WebhookFipProposalPassed,
+	WebhookVoterIdentityBound,
+	WebhookVoteCast,
+}
@@ -6,3 +6,19 @@ var ErrAlreadySyncHeight= errors.New("already synchronized to the latest block h
 
 var ErrGistNotFound = errors.New("gist not found")
 
+
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+var ErrDraftSignatureInvalid = errors.New("draft signature is invalid")
+
+var ErrDraftSignatureExpired = errors.New("draft signature has expired")
+
+var ErrDraftNonceUsed = errors.New("draft nonce has already been used")
+
+var ErrDraftNotFound = errors.New("no draft found")
+
+var ErrDraftVersionConflict = errors.New("draft has been saved since it was read")
+
+var ErrInvalidBallot = errors.New("ballot is not a single vote option")
+
+var ErrProposalNotFound = errors.New("proposal not found")
@@ -49,16 +49,20 @@ func GetClient(syncService service.ISyncService, chainId int64) (*model.GoEthCli
 	if ok {
 		return &client, nil
 	}
-	network := config.Client.Network
+	network, ok := config.GetNetwork(chainId)
+	if !ok {
+		return nil, fmt.Errorf("network %d is not configured", chainId)
+	}
 
 	clientConfig := model.ClientConfig{
-		ChainId:              network.ChainId,
-		Name:                 network.Name,
-		Rpc:                  network.Rpc,
-		PowerVotingContract:  network.PowerVotingContract,
-		OracleContract:       network.OracleContract,
-		SyncEventStartHeight: network.SyncEventStartHeight,
-		FipContract:          network.FipContract,
+		ChainId:                 network.ChainId,
+		Name:                    network.Name,
+		Rpc:                     network.Rpc,
+		PowerVotingContract:     network.PowerVotingContract,
+		OracleContract:          network.OracleContract,
+		SyncEventStartHeight:    network.SyncEventStartHeight,
+		FipContract:             network.FipContract,
+		PowerVotingConfContract: network.PowerVotingConfContract,
 	}
 
 	if err := syncService.CreateFipEditor(context.Background(), &model.FipEditorTbl{
@@ -105,21 +109,29 @@ func getGoEthClient(clientConfig model.ClientConfig, abiPath config.ABIPath) (mo
 	oracleContract := common.HexToAddress(clientConfig.OracleContract)
 	orcalePowersContract := common.HexToAddress(clientConfig.OraclePowersContract)
 	fipContract := common.HexToAddress(clientConfig.FipContract)
+	powerVotingConfContract := common.HexToAddress(clientConfig.PowerVotingConfContract)
 	// generate goEthClient
 	goEthClient := model.GoEthClient{
-		ChainId:              clientConfig.ChainId,
-		Name:                 clientConfig.Name,
-		Client:               client,
-		PowerVotingContract:  powerVotingContract,
-		OracleContract:       oracleContract,
-		OraclePowersContract: orcalePowersContract,
-		FipContract:          fipContract,
+		ChainId:                 clientConfig.ChainId,
+		Name:                    clientConfig.Name,
+		Client:                  client,
+		PowerVotingContract:     powerVotingContract,
+		OracleContract:          oracleContract,
+		OraclePowersContract:    orcalePowersContract,
+		FipContract:             fipContract,
+		PowerVotingConfContract: powerVotingConfContract,
 		ABI: &model.ABI{
-			PowerVotingAbi:  GetAbiFromLocalFile(abiPath.PowerVotingAbi),
-			OracleAbi:       GetAbiFromLocalFile(abiPath.OracleAbi),
-			FipAbi:          GetAbiFromLocalFile(abiPath.FipAbi),
+			PowerVotingAbi: GetAbiFromLocalFile(abiPath.PowerVotingAbi),
+			OracleAbi:      GetAbiFromLocalFile(abiPath.OracleAbi),
+			FipAbi:         GetAbiFromLocalFile(abiPath.FipAbi),
 		},
 	}
+
+	// The PowerVotingConf contract is optional, deployments without it count with the default algorithm
+	if abiPath.PowerVotingConfAbi != "" {
+		goEthClient.ABI.PowerVotingConfAbi = GetAbiFromLocalFile(abiPath.PowerVotingConfAbi)
+	}
+
 	return goEthClient, nil
 }
 
@@ -58,12 +58,64 @@ func NewMysql() *gorm.DB {
 
 	db.AutoMigrate(&model.ProposalTbl{})
 	db.AutoMigrate(&model.VoteTbl{})
+	db.AutoMigrate(&model.VoteHistoryTbl{})
+	// A creator held a single draft before drafts were versioned
+	for _, index := range []string{"creator", "uni_proposal_draft_tbl_creator"} {
+		if db.Migrator().HasIndex(&model.ProposalDraftTbl{}, index) {
+			db.Migrator().DropIndex(&model.ProposalDraftTbl{}, index)
+		}
+	}
 	db.AutoMigrate(&model.ProposalDraftTbl{})
+	// The drafts marked before the proposer address was recorded are proposed by their 0x creator
+	db.Exec(`UPDATE proposal_draft_tbl SET proposer_address = LOWER(creator)
+		WHERE submitted_version > 0 AND proposer_address = '' AND creator LIKE '0x%'`)
+	db.AutoMigrate(&model.ProposalDraftVersionTbl{})
+	// The drafts saved before the version history start it with their current content
+	db.Exec(`INSERT INTO proposal_draft_version_tbl (draft_id, version, start_time, end_time, timezone, title, content,
+		token_holder_percentage, sp_percentage, client_percentage, developer_percentage, created_at, updated_at)
+		SELECT d.id, d.version, d.start_time, d.end_time, d.timezone, d.title, d.content,
+		d.token_holder_percentage, d.sp_percentage, d.client_percentage, d.developer_percentage, d.updated_at, d.updated_at
+		FROM proposal_draft_tbl d
+		WHERE NOT EXISTS (SELECT 1 FROM proposal_draft_version_tbl v WHERE v.draft_id = d.id)`)
+	db.AutoMigrate(&model.DraftNonceTbl{})
+	// The sync events were unique by contract address before they were keyed by chain
+	if db.Migrator().HasIndex(&model.SyncEventTbl{}, "idx_sync_event_tbl_power_voting_contract_address") {
+		db.Migrator().DropIndex(&model.SyncEventTbl{}, "idx_sync_event_tbl_power_voting_contract_address")
+	}
 	db.AutoMigrate(&model.SyncEventTbl{})
+	db.AutoMigrate(&model.SyncBlockTbl{})
+	db.AutoMigrate(&model.SyncUndoTbl{})
+	db.AutoMigrate(&model.SyncBackfillTbl{})
+	db.AutoMigrate(&model.EventDeadLetterTbl{})
+	db.AutoMigrate(&model.EventLogTbl{})
+	db.AutoMigrate(&model.GithubRepoTbl{})
+	db.AutoMigrate(&model.SnapshotHeightTbl{})
+	db.AutoMigrate(&model.ConfChangeTbl{})
 	db.AutoMigrate(&model.VoterInfoTbl{})
 	db.AutoMigrate(&model.FipProposalTbl{})
+	// FIP votes and editors were unique across chains before they were keyed by chain
+	if db.Migrator().HasIndex(&model.FipProposalVoteTbl{}, "idx_id_voter") {
+		db.Migrator().DropIndex(&model.FipProposalVoteTbl{}, "idx_id_voter")
+	}
 	db.AutoMigrate(&model.FipProposalVoteTbl{})
+	if db.Migrator().HasIndex(&model.FipEditorTbl{}, "idx_fip_editor_tbl_editor") {
+		db.Migrator().DropIndex(&model.FipEditorTbl{}, "idx_fip_editor_tbl_editor")
+	}
 	db.AutoMigrate(&model.FipEditorTbl{})
+	db.AutoMigrate(&model.ProposalAuditTbl{})
+	db.AutoMigrate(&model.ProposalResultHistoryTbl{})
+	db.AutoMigrate(&model.WebhookTbl{})
+	// The webhook events were unique by subject before the block hash was part of their key
+	if !db.Migrator().HasColumn(&model.WebhookEventTbl{}, "block_hash") && db.Migrator().HasIndex(&model.WebhookEventTbl{}, "idx_webhook_event") {
+		db.Migrator().DropIndex(&model.WebhookEventTbl{}, "idx_webhook_event")
+	}
+	// The events emitted before the stream sequence keep their ID as their sequence, the cursors of the streams stay valid
+	backfillSeq := !db.Migrator().HasColumn(&model.WebhookEventTbl{}, "seq")
+	db.AutoMigrate(&model.WebhookEventTbl{})
+	if backfillSeq {
+		db.Exec("UPDATE webhook_event_tbl SET seq = id WHERE seq IS NULL")
+	}
+	db.AutoMigrate(&model.WebhookDeliveryTbl{})
 
 	return db
 }
@@ -18,6 +18,7 @@ import (
 	"log"
 	"net"
 	"net/http"
+	"os"
 
 	"github.com/gin-gonic/gin"
 	"github.com/grpc-ecosystem/go-grpc-middleware/v2/interceptors/recovery"
@@ -49,7 +50,11 @@ func main() {
 	syncRepoImpl := repo.NewSyncRepo(mydb)
 	fipRepoImpl := repo.NewFipRepo(mydb)
 	lotusRepoImpl := repo.NewLotusRPCRepo()
-	proposalService := service.NewProposalService(proposalRepoImpl)
+	confRepoImpl := repo.NewConfRepo(mydb)
+	webhookRepoImpl := repo.NewWebhookRepo(mydb)
+	exportRepoImpl := repo.NewExportRepo(mydb)
+	snapshotRepoImpl := repo.NewSnapshotRPCRepo()
+	proposalService := service.NewProposalService(proposalRepoImpl, snapshotRepoImpl, lotusRepoImpl)
 	voteService := service.NewVoteService(voteRepoImpl, lotusRepoImpl)
 	syncService := service.NewSyncService(
 		syncRepoImpl,
@@ -57,8 +62,23 @@ func main() {
 		proposalRepoImpl,
 		fipRepoImpl,
 		lotusRepoImpl,
+		confRepoImpl,
+		webhookRepoImpl,
 	)
 	fipService := service.NewFipService(fipRepoImpl)
+	eventService := service.NewEventService(syncRepoImpl)
+	confService := service.NewConfService(confRepoImpl)
+	webhookService := service.NewWebhookService(webhookRepoImpl)
+	streamService := service.NewStreamService(webhookRepoImpl)
+	exportService := service.NewExportService(exportRepoImpl)
+	// run a maintenance command instead of the server
+	if len(os.Args) > 1 {
+		if err := runCommand(os.Args[1:], syncService, exportService); err != nil {
+			log.Fatalf("%s failed: %v", os.Args[1], err)
+		}
+		return
+	}
+
 	// initialization scheduled task
 	go task.TaskScheduler(syncService)
 
@@ -67,7 +87,16 @@ func main() {
 	// default gin web
 	r := gin.Default()
 	r.Use(Cors())
-	router.InitRouters(r, proposalService, voteService, fipService)
+	router.InitRouters(r, router.Services{
+		Proposal: proposalService,
+		Vote:     voteService,
+		Fip:      fipService,
+		Event:    eventService,
+		Conf:     confService,
+		Webhook:  webhookService,
+		Stream:   streamService,
+		Export:   exportService,
+	})
 	err := r.Run(config.Client.Server.Port)
 	if err != nil {
 		zap.L().Error("start web server failed: ", zap.Error(err))
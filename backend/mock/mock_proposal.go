@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"powervoting-server/model"
 	"powervoting-server/model/api"
@@ -27,9 +28,99 @@ import (
 type MockProposalService struct {
 }
 
+// MockDraftCreator is the creator of the mock drafts, the address of the first development key of Hardhat.
+const MockDraftCreator = "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266"
+
+// mockDraftVersions are the versions of the mock draft 1.
+var mockDraftVersions = []model.ProposalDraftVersionTbl{
+	{DraftId: 1, Version: 1, Title: "Title", Content: "line 1\nline 2\nline 3", StartTime: 1735689600, EndTime: 1736294400, Timezone: "UTC",
+		Percentage: model.Percentage{TokenHolderPercentage: 25, SpPercentage: 25, ClientPercentage: 25, DeveloperPercentage: 25}},
+	{DraftId: 1, Version: 2, Title: "New title", Content: "line 1\nline 3\nline 4", StartTime: 1735689600, EndTime: 1736294400, Timezone: "UTC",
+		Percentage: model.Percentage{TokenHolderPercentage: 40, SpPercentage: 20, ClientPercentage: 20, DeveloperPercentage: 20}},
+}
+
 // DeleteProposalDraft implements service.ProposalRepo.
-func (m *MockProposalService) DeleteProposalDraft(ctx context.Context, req api.DelProposalDraftReq) error {
-	panic("unimplemented")
+func (m *MockProposalService) DeleteProposalDraft(ctx context.Context, id int64) error {
+	return nil
+}
+
+// UpdateProposalDraft implements service.ProposalRepo.
+func (m *MockProposalService) UpdateProposalDraft(ctx context.Context, in *model.ProposalDraftTbl, previousVersion int) error {
+	return nil
+}
+
+// GetProposalDraft implements service.ProposalRepo.
+func (m *MockProposalService) GetProposalDraft(ctx context.Context, id int64) (*model.ProposalDraftTbl, error) {
+	if id != 1 && id != 2 {
+		return nil, nil
+	}
+
+	current := mockDraftVersions[len(mockDraftVersions)-1]
+	draft := &model.ProposalDraftTbl{
+		BaseField:  model.BaseField{ID: id},
+		Creator:    MockDraftCreator,
+		Name:       "draft",
+		Version:    current.Version,
+		StartTime:  current.StartTime,
+		EndTime:    current.EndTime,
+		Timezone:   current.Timezone,
+		ChainId:    314159,
+		Title:      current.Title,
+		Content:    current.Content,
+		Percentage: current.Percentage,
+	}
+	// The draft 2 is linked to the proposal it was created from
+	if id == 2 {
+		draft.SubmittedVersion = current.Version
+		draft.ProposalId = 7
+	}
+
+	return draft, nil
+}
+
+// GetProposalDraftList implements service.ProposalRepo.
+func (m *MockProposalService) GetProposalDraftList(ctx context.Context, req api.ProposalDraftListReq) ([]model.ProposalDraftTbl, error) {
+	var list []model.ProposalDraftTbl
+	for _, id := range []int64{2, 1} {
+		draft, _ := m.GetProposalDraft(ctx, id)
+		if draft.Creator == req.Address {
+			list = append(list, *draft)
+		}
+	}
+
+	return list, nil
+}
+
+// GetProposalDraftVersions implements service.ProposalRepo.
+func (m *MockProposalService) GetProposalDraftVersions(ctx context.Context, draftId int64) ([]model.ProposalDraftVersionTbl, error) {
+	if draftId != 1 {
+		return nil, nil
+	}
+
+	return mockDraftVersions, nil
+}
+
+// GetProposalDraftVersion implements service.ProposalRepo.
+func (m *MockProposalService) GetProposalDraftVersion(ctx context.Context, draftId int64, version int) (*model.ProposalDraftVersionTbl, error) {
+	if draftId != 1 || version < 1 || version > len(mockDraftVersions) {
+		return nil, nil
+	}
+
+	return &mockDraftVersions[version-1], nil
+}
+
+// SubmitProposalDraft implements service.ProposalRepo.
+func (m *MockProposalService) SubmitProposalDraft(ctx context.Context, id int64, version int, proposer string, submittedAt int64) error {
+	if proposer != strings.ToLower(MockDraftCreator) {
+		return fmt.Errorf("unexpected proposer address %s", proposer)
+	}
+
+	return nil
+}
+
+// LinkProposalDraft implements service.ProposalRepo.
+func (m *MockProposalService) LinkProposalDraft(ctx context.Context, proposal *model.ProposalTbl) (int64, error) {
+	return 0, nil
 }
 
 // GetGitHubNameByCreaters implements service.ProposalRepo.
@@ -44,10 +135,47 @@ func (m *MockProposalService) CreateProposal(ctx context.Context, in *model.Prop
 	panic("unimplemented")
 }
 
+// CreateProposalAudit implements service.ProposalRepo.
+func (m *MockProposalService) CreateProposalAudit(ctx context.Context, in *model.ProposalAuditTbl) error {
+	return nil
+}
+
+// CreateProposalResultHistory implements service.ProposalRepo.
+func (m *MockProposalService) CreateProposalResultHistory(ctx context.Context, in *model.ProposalResultHistoryTbl) error {
+	return nil
+}
+
 // CreateProposalDraft implements service.ProposalRepo.
 func (m *MockProposalService) CreateProposalDraft(ctx context.Context, in *model.ProposalDraftTbl) (int64, error) {
 	fmt.Printf("mock create proposal draft success.  proposal: %v", in)
-	return 1, nil
+	in.ID = 3
+	in.Version = 1
+	return in.ID, nil
+}
+
+// GetProposalAudit implements service.ProposalRepo.
+func (m *MockProposalService) GetProposalAudit(ctx context.Context, req api.ProposalReq) (*model.ProposalAuditTbl, error) {
+	return nil, nil
+}
+
+// GetProposalResultHistory implements service.ProposalRepo.
+func (m *MockProposalService) GetProposalResultHistory(ctx context.Context, req api.ProposalReq) ([]model.ProposalResultHistoryTbl, error) {
+	return []model.ProposalResultHistoryTbl{}, nil
+}
+
+// DeleteProposalAudit implements service.ProposalRepo.
+func (m *MockProposalService) DeleteProposalAudit(ctx context.Context, req api.ProposalReq) error {
+	return nil
+}
+
+// DeleteProposalResultHistory implements service.ProposalRepo.
+func (m *MockProposalService) DeleteProposalResultHistory(ctx context.Context, req api.ProposalReq) error {
+	return nil
+}
+
+// GetProposalVoters implements service.ProposalRepo.
+func (m *MockProposalService) GetProposalVoters(ctx context.Context, req api.ProposalReq) ([]model.VoteTbl, error) {
+	return []model.VoteTbl{}, nil
 }
 
 // GetProposalById implements service.ProposalRepo.
@@ -68,6 +196,16 @@ func (m *MockProposalService) GetProposalDraftByAddress(ctx context.Context, req
 	return nil, errors.New("not found")
 }
 
+// CreateDraftNonce implements service.ProposalRepo.
+func (m *MockProposalService) CreateDraftNonce(ctx context.Context, in *model.DraftNonceTbl) (bool, error) {
+	return in.Nonce != "used-nonce", nil
+}
+
+// DeleteDraftNonces implements service.ProposalRepo.
+func (m *MockProposalService) DeleteDraftNonces(ctx context.Context, before int64) error {
+	return nil
+}
+
 // GetProposalListWithPagination implements service.ProposalRepo.
 func (m *MockProposalService) GetProposalListWithPagination(ctx context.Context, req api.ProposalListReq) ([]model.ProposalWithVoted, int64, error) {
 	return []model.ProposalWithVoted{
@@ -84,6 +222,21 @@ func (m *MockProposalService) GetUncountedProposalList(ctx context.Context, chai
 	panic("unimplemented")
 }
 
+// GetStartedProposalList implements service.ProposalRepo.
+func (m *MockProposalService) GetStartedProposalList(ctx context.Context, chainId int64, timestamp int64) ([]model.ProposalTbl, error) {
+	panic("unimplemented")
+}
+
+// GetAllProposalList implements service.ProposalRepo.
+func (m *MockProposalService) GetAllProposalList(ctx context.Context, chainId int64) ([]model.ProposalTbl, error) {
+	panic("unimplemented")
+}
+
+// UpdateProposalPowerRetry implements service.ProposalRepo.
+func (m *MockProposalService) UpdateProposalPowerRetry(ctx context.Context, in *model.ProposalTbl) error {
+	panic("unimplemented")
+}
+
 // UpdateProposal implements service.ProposalRepo.
 func (m *MockProposalService) UpdateProposal(ctx context.Context, in *model.ProposalTbl) error {
 	panic("unimplemented")
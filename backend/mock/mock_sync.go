@@ -16,7 +16,9 @@ package mock
 
 import (
 	"context"
+	"math/big"
 
+	"powervoting-server/constant"
 	"powervoting-server/model"
 	"powervoting-server/service"
 )
@@ -30,7 +32,7 @@ func (m *MockSyncService) UpdateVoterAndProposalGithubNameByGistInfo(ctx context
 }
 
 // UpdateVoterByMinerIds implements service.ISyncService.
-func (m *MockSyncService) UpdateVoterByMinerIds(ctx context.Context, voterAddress string, minerIds []uint64) error {
+func (m *MockSyncService) UpdateVoterByMinerIds(ctx context.Context, chainId int64, voterAddress string, minerIds []uint64) error {
 	panic("unimplemented")
 }
 
@@ -77,7 +79,7 @@ func (m MockSyncService) AddProposal(ctx context.Context, in *model.ProposalTbl)
 }
 
 // AddVote implements service.ISyncService.
-func (m MockSyncService) AddVote(ctx context.Context, in *model.VoteTbl) error {
+func (m MockSyncService) AddVote(ctx context.Context, in *model.VoteHistoryTbl) error {
 	panic("unimplemented")
 }
 
@@ -92,10 +94,95 @@ func (m MockSyncService) CreateSyncEventInfo(ctx context.Context, in *model.Sync
 }
 
 // GetSyncEventInfo implements service.ISyncService.
-func (m MockSyncService) GetSyncEventInfo(ctx context.Context, addr string) (*model.SyncEventTbl, error) {
+func (m MockSyncService) GetSyncEventInfo(ctx context.Context, chainId int64, addr string) (*model.SyncEventTbl, error) {
 	panic("unimplemented")
 }
 
+// AddSyncBlocks implements service.ISyncService.
+func (m MockSyncService) AddSyncBlocks(ctx context.Context, blocks []model.SyncBlockTbl, pruneBelow int64) error {
+	return nil
+}
+
+// GetSyncBlocks implements service.ISyncService.
+func (m MockSyncService) GetSyncBlocks(ctx context.Context, chainId, maxHeight int64, limit int) ([]model.SyncBlockTbl, error) {
+	return nil, nil
+}
+
+// RollbackSync implements service.ISyncService.
+func (m MockSyncService) RollbackSync(ctx context.Context, addr string, chainId, height int64) error {
+	return nil
+}
+
+// Transaction implements service.ISyncService.
+func (m MockSyncService) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// AddDeadLetter implements service.ISyncService.
+func (m MockSyncService) AddDeadLetter(ctx context.Context, in *model.EventDeadLetterTbl) error {
+	return nil
+}
+
+// GetDueDeadLetters implements service.ISyncService.
+func (m MockSyncService) GetDueDeadLetters(ctx context.Context, chainId, now int64, limit int) ([]model.EventDeadLetterTbl, error) {
+	return nil, nil
+}
+
+// UpdateDeadLetter implements service.ISyncService.
+func (m MockSyncService) UpdateDeadLetter(ctx context.Context, in *model.EventDeadLetterTbl) error {
+	return nil
+}
+
+// GetSyncBackfill implements service.ISyncService.
+func (m MockSyncService) GetSyncBackfill(ctx context.Context, chainId int64) (*model.SyncBackfillTbl, error) {
+	return nil, nil
+}
+
+// SaveSyncBackfill implements service.ISyncService.
+func (m MockSyncService) SaveSyncBackfill(ctx context.Context, in *model.SyncBackfillTbl) error {
+	return nil
+}
+
+// AddEventLog implements service.ISyncService.
+func (m MockSyncService) AddEventLog(ctx context.Context, in *model.EventLogTbl) error {
+	return nil
+}
+
+// GetEventLogs implements service.ISyncService.
+func (m MockSyncService) GetEventLogs(ctx context.Context, chainId int64, offset, limit int) ([]model.EventLogTbl, error) {
+	return nil, nil
+}
+
+// GetEventLog implements service.ISyncService.
+func (m MockSyncService) GetEventLog(ctx context.Context, chainId int64, txHash string, logIndex uint) (*model.EventLogTbl, error) {
+	return nil, nil
+}
+
+// DeleteDerivedEvents implements service.ISyncService.
+func (m MockSyncService) DeleteDerivedEvents(ctx context.Context, chainId int64) error {
+	return nil
+}
+
+// ProposalList implements service.ISyncService.
+func (m MockSyncService) ProposalList(ctx context.Context, chainId int64) ([]model.ProposalTbl, error) {
+	return nil, nil
+}
+
+// VoterList implements service.ISyncService.
+func (m MockSyncService) VoterList(ctx context.Context, chainId int64) ([]model.VoterInfoTbl, error) {
+	return nil, nil
+}
+
+// RestoreVoter implements service.ISyncService.
+func (m MockSyncService) RestoreVoter(ctx context.Context, in *model.VoterInfoTbl) error {
+	return nil
+}
+
+// GetVotedList implements service.ISyncService.
+func (m MockSyncService) GetVotedList(ctx context.Context, chainId int64, proposalId int64) ([]model.VoteTbl, error) {
+	return m.GetUncountedVotedList(ctx, chainId, proposalId)
+}
+
 // GetUncountedVotedList implements service.ISyncService.
 func (m MockSyncService) GetUncountedVotedList(ctx context.Context, chainId int64, proposalId int64) ([]model.VoteTbl, error) {
 	return []model.VoteTbl{
@@ -144,6 +231,41 @@ RVdEZlJQWm9yaS9ITnNqcGNuQmxNcUlob3VKYkErRUJDb243eUkK2rehvaQY2kad
 	}, nil
 }
 
+// AddProposalAudit implements service.ISyncService.
+func (m *MockSyncService) AddProposalAudit(ctx context.Context, in *model.ProposalAuditTbl) error {
+	return nil
+}
+
+// GetProposal implements service.ISyncService.
+func (m *MockSyncService) GetProposal(ctx context.Context, chainId int64, proposalId int64) (*model.ProposalTbl, error) {
+	return &model.ProposalTbl{
+		ProposalId:     proposalId,
+		ChainId:        chainId,
+		Counted:        constant.ProposalCounted,
+		ResultRevision: 1,
+	}, nil
+}
+
+// GetProposalAudit implements service.ISyncService.
+func (m *MockSyncService) GetProposalAudit(ctx context.Context, chainId int64, proposalId int64) (*model.ProposalAuditTbl, error) {
+	return nil, nil
+}
+
+// AddProposalResultHistory implements service.ISyncService.
+func (m *MockSyncService) AddProposalResultHistory(ctx context.Context, in *model.ProposalResultHistoryTbl) error {
+	return nil
+}
+
+// DeleteProposalAudit implements service.ISyncService.
+func (m *MockSyncService) DeleteProposalAudit(ctx context.Context, chainId, proposalId int64) error {
+	return nil
+}
+
+// DeleteProposalResultHistory implements service.ISyncService.
+func (m *MockSyncService) DeleteProposalResultHistory(ctx context.Context, chainId, proposalId int64) error {
+	return nil
+}
+
 // UncountedProposalList implements service.ISyncService.
 func (m *MockSyncService) UncountedProposalList(ctx context.Context, chainId int64, endTime int64) ([]model.ProposalTbl, error) {
 	return []model.ProposalTbl{
@@ -154,14 +276,67 @@ func (m *MockSyncService) UncountedProposalList(ctx context.Context, chainId int
 	}, nil
 }
 
+// GetNetworkPower implements service.ISyncService.
+func (m *MockSyncService) GetNetworkPower(ctx context.Context, chainId, height int64) (model.NetworkPower, error) {
+	return model.NetworkPower{
+		RawBytePower:    big.NewInt(10000),
+		QualityAdjPower: big.NewInt(10000),
+	}, nil
+}
+
 // UpdateProposal implements service.ISyncService.
 func (m *MockSyncService) UpdateProposal(ctx context.Context, in *model.ProposalTbl) error {
 	return nil
 }
 
+// UpdateProposalPowerRetry implements service.ISyncService.
+func (m *MockSyncService) UpdateProposalPowerRetry(ctx context.Context, in *model.ProposalTbl) error {
+	return nil
+}
+
 // UpdateSyncEventInfo implements service.ISyncService.
-func (m *MockSyncService) UpdateSyncEventInfo(ctx context.Context, addr string, height int64) error {
+func (m *MockSyncService) UpdateSyncEventInfo(ctx context.Context, chainId int64, addr string, height int64) error {
 	panic("unimplemented")
 }
 
 var _ service.ISyncService = (*MockSyncService)(nil)
+
+// AddGithubRepo implements service.ISyncService.
+func (m MockSyncService) AddGithubRepo(ctx context.Context, in *model.GithubRepoTbl) error {
+	panic("unimplemented")
+}
+
+// RemoveGithubRepo implements service.ISyncService.
+func (m MockSyncService) RemoveGithubRepo(ctx context.Context, chainId, repoId, blockNumber int64) error {
+	panic("unimplemented")
+}
+
+// AddSnapshotHeight implements service.ISyncService.
+func (m MockSyncService) AddSnapshotHeight(ctx context.Context, in *model.SnapshotHeightTbl) error {
+	panic("unimplemented")
+}
+
+// AddConfChange implements service.ISyncService.
+func (m MockSyncService) AddConfChange(ctx context.Context, in *model.ConfChangeTbl) error {
+	panic("unimplemented")
+}
+
+// StartedProposalList implements service.ISyncService.
+func (m MockSyncService) StartedProposalList(ctx context.Context, chainId, startTime int64) ([]model.ProposalTbl, error) {
+	return nil, nil
+}
+
+// AddWebhookEvent implements service.ISyncService.
+func (m MockSyncService) AddWebhookEvent(ctx context.Context, in *model.WebhookEventTbl) error {
+	return nil
+}
+
+// GetDueWebhookDeliveries implements service.ISyncService.
+func (m MockSyncService) GetDueWebhookDeliveries(ctx context.Context, now int64, limit int) ([]model.WebhookDueDelivery, error) {
+	return nil, nil
+}
+
+// UpdateWebhookDelivery implements service.ISyncService.
+func (m MockSyncService) UpdateWebhookDelivery(ctx context.Context, in *model.WebhookDeliveryTbl) error {
+	return nil
+}
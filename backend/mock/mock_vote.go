@@ -18,6 +18,7 @@ import (
 	"context"
 	"errors"
 
+	"powervoting-server/constant"
 	"powervoting-server/model"
 	"powervoting-server/service"
 )
@@ -26,7 +27,7 @@ type MockVoteService struct {
 }
 
 // GetVoterInfoByAddress implements service.VoteRepo.
-func (m *MockVoteService) GetVoterInfoByAddress(ctx context.Context, address string) (*model.VoterInfoTbl, error) {
+func (m *MockVoteService) GetVoterInfoByAddress(ctx context.Context, chainId int64, address string) (*model.VoterInfoTbl, error) {
 	panic("unimplemented")
 }
 
@@ -52,6 +53,40 @@ func (m *MockVoteService) CreateVote(ctx context.Context, in *model.VoteTbl) (in
 	panic("unimplemented")
 }
 
+// CreateVoteHistory implements service.VoteRepo.
+func (m *MockVoteService) CreateVoteHistory(ctx context.Context, in *model.VoteHistoryTbl) error {
+	return nil
+}
+
+// GetVoteHistory implements service.VoteRepo.
+func (m *MockVoteService) GetVoteHistory(ctx context.Context, chainId int64, proposalId int64, address string) ([]model.VoteHistoryTbl, error) {
+	return []model.VoteHistoryTbl{
+		{ChainId: chainId, ProposalId: proposalId, Address: address, BlockNumber: 100, Valid: true},
+		{ChainId: chainId, ProposalId: proposalId, Address: address, BlockNumber: 200, Valid: true},
+		{ChainId: chainId, ProposalId: proposalId, Address: address, BlockNumber: 300, Valid: false},
+	}, nil
+}
+
+// GetVoterVotes implements service.VoteRepo.
+func (m *MockVoteService) GetVoterVotes(ctx context.Context, chainId int64, address string) ([]model.VoteWithProposal, error) {
+	return []model.VoteWithProposal{
+		{
+			VoteTbl:         model.VoteTbl{ChainId: chainId, ProposalId: 2, Address: address, BlockNumber: 200, Timestamp: 2000},
+			Title:           "In progress",
+			ProposalCounted: constant.ProposalCreate,
+		},
+		{
+			VoteTbl: model.VoteTbl{
+				ChainId: chainId, ProposalId: 1, Address: address, BlockNumber: 100, Timestamp: 1000,
+				VoteResult: constant.VoteApprove, SpPower: "0", ClientPower: "0", TokenHolderPower: "1000", DeveloperPower: "0",
+			},
+			Title:           "Counted",
+			ProposalCounted: constant.ProposalCounted,
+			Outcome:         constant.ProposalOutcomePassed,
+		},
+	}, nil
+}
+
 // GetVoteList implements service.VoteRepo.
 func (m *MockVoteService) GetVoteList(ctx context.Context, chainId int64, proposalId int64, counted bool) ([]model.VoteTbl, error) {
 	if chainId != 1 || proposalId != 1 {
@@ -75,3 +110,8 @@ func (m *MockVoteService) CreateVoterAddress(ctx context.Context, in *model.Vote
 func (m *MockVoteService) GetAllVoterAddresss(ctx context.Context, chainId int64) ([]model.VoterInfoTbl, error) {
 	panic("unimplemented")
 }
+
+// RestoreVoterInfo implements service.VoteRepo.
+func (m *MockVoteService) RestoreVoterInfo(ctx context.Context, in *model.VoterInfoTbl) error {
+	panic("unimplemented")
+}
@@ -49,7 +49,14 @@ type AddressReq struct {
 
 // VerifyGistReq verify that Gist is valid
 type VerifyGistReq struct {
-	GistId string `form:"gistId" validate:"required"` // Gist ID
+	GistId  string `form:"gistId" validate:"required"` // Gist ID
+	ChainId int64  `form:"chainId"`                    // Chain ID of the network the addresses belong to, the primary network if empty
+	AddressReq
+}
+
+// VoterInfoReq represents a request for the gist information of a voter.
+type VoterInfoReq struct {
+	ChainId int64 `form:"chainId"` // Chain ID of the network the address belongs to, the primary network if empty
 	AddressReq
 }
 
@@ -71,8 +78,37 @@ type ProposalReq struct {
 	ChainIdParam       // Embedded chain ID parameter
 }
 
-// AddProposalDraftReq represents a request for creating or updating a proposal draft.
+// VoteHistoryReq represents a request for the votes of a voter on a proposal.
+type VoteHistoryReq struct {
+	ProposalReq
+	Address string `form:"address" validate:"required"` // Voter address, Ethereum or Filecoin format
+}
+
+// VoterHistoryReq represents a request for the votes of a voter on every proposal of a network.
+type VoterHistoryReq struct {
+	ChainIdParam
+	Address string `form:"address" validate:"required"` // Voter address, Ethereum or Filecoin format
+}
+
+// ExportReq represents a request for the bulk export of a dataset of a network.
+type ExportReq struct {
+	ChainIdParam
+	Dataset string `form:"dataset" validate:"required,oneof=proposals votes fip_proposals fip_votes voters"` // Dataset to export
+	Format  string `form:"format" validate:"omitempty,oneof=csv ndjson"`                                     // Export format, csv by default
+	From    int64  `form:"from" validate:"gte=0"`                                                            // Only export the rows created at or after this unix time
+	To      int64  `form:"to" validate:"omitempty,gtefield=From"`                                            // Only export the rows created at or before this unix time, no limit if empty
+}
+
+// ProposalParticipationReq represents a request for the participation of a proposal over time.
+type ProposalParticipationReq struct {
+	ProposalReq
+	Interval int64 `form:"interval" validate:"omitempty,gte=60"` // Seconds between two points of the timeline, one hour by default
+}
+
+// AddProposalDraftReq represents a request for creating a proposal draft or saving a new version of it.
 type AddProposalDraftReq struct {
+	DraftId   int64  `json:"draftId" validate:"gte=0"`                   // Draft to save a new version of, a new draft if empty
+	Name      string `json:"name" validate:"max=64"`                     // Name of the draft, the title if empty
 	Creator   string `json:"creator" validate:"required"`                // Creator address
 	StartTime int64  `json:"startTime" validate:"required"`              // Start time of the proposal
 	EndTime   int64  `json:"endTime" validate:"required"`                // End time of the proposal
@@ -81,11 +117,54 @@ type AddProposalDraftReq struct {
 	Content   string `json:"content" validate:"required,min=1,max=2000"` // Description of the proposal
 	ChainIdParam
 	ProposalPercentage
+	DraftAuth
 }
 
 type DelProposalDraftReq struct {
-    AddressReq
+	AddressReq
+	ChainIdParam
+	DraftIdParam
+	DraftAuth
+}
+
+// SubmitProposalDraftReq represents a request marking the draft version a proposal is created from.
+type SubmitProposalDraftReq struct {
+	Creator string `json:"creator" validate:"required"`      // Creator address
+	Version int    `json:"version" validate:"required,gt=0"` // Version the proposal is created from
 	ChainIdParam
+	DraftIdParam
+	DraftAuth
+}
+
+// DraftIdParam represents the ID of a proposal draft.
+type DraftIdParam struct {
+	DraftId int64 `json:"draftId" form:"draftId" validate:"required,gt=0"` // Draft ID
+}
+
+// ProposalDraftReq represents a request for a proposal draft, the last saved draft of the address if the draft ID is empty.
+type ProposalDraftReq struct {
+	AddressReq
+	DraftId int64 `form:"draftId" validate:"gte=0"` // Draft ID
+}
+
+// ProposalDraftListReq represents a request for the drafts of a creator.
+type ProposalDraftListReq struct {
+	Address string `form:"address" validate:"required"` // Creator address
+	ChainId int64  `form:"chainId" validate:"gte=0"`    // Chain ID of the drafts, every network if empty
+}
+
+// ProposalDraftDiffReq represents a request for the changes between two versions of a proposal draft.
+type ProposalDraftDiffReq struct {
+	DraftIdParam
+	From int `form:"from" validate:"required,gt=0"` // Version the changes are from
+	To   int `form:"to" validate:"required,gt=0"`   // Version the changes are to
+}
+
+// DraftAuth authenticates a draft mutation with a signature of the creator over the message built by the server from the request.
+type DraftAuth struct {
+	Timestamp int64  `json:"timestamp" form:"timestamp" validate:"required,gt=0"` // Unix time the payload was signed at
+	Nonce     string `json:"nonce" form:"nonce" validate:"required,min=8,max=64"` // Random value, accepted once per creator
+	Signature string `json:"signature" form:"signature" validate:"required"`      // EIP-191 signature for a 0x creator, hex Filecoin signature for an f1 or f3 creator
 }
 
 type ProposalPercentage struct {
@@ -105,6 +184,52 @@ type FipEditorListReq struct {
 	ChainId int64 `form:"chainId" validate:"required"`
 }
 
+// DeadLetterListReq represents a request for the event logs that failed to be applied.
+type DeadLetterListReq struct {
+	PageReq
+	ChainId   int64 `form:"chainId" validate:"required"` // Chain ID
+	Exhausted bool  `form:"exhausted"`                   // Only list the event logs that failed every replay
+}
+
+// AddWebhookReq represents a request for subscribing a URL to governance lifecycle events.
+type AddWebhookReq struct {
+	Url         string   `json:"url" validate:"required,url,max=1024"`           // URL the events are posted to
+	Events      []string `json:"events" validate:"required,min=1,dive,required"` // Events to subscribe to
+	ChainId     int64    `json:"chainId"`                                        // Chain ID of the events, 0 for every network
+	Description string   `json:"description" validate:"max=254"`                 // Description of the subscriber
+}
+
+// StreamReq represents a request for the stream of the governance lifecycle events.
+type StreamReq struct {
+	ChainId    int64  `form:"chainId" validate:"required_with=ProposalId"` // Only stream the events of the chain, 0 for every network
+	ProposalId int64  `form:"proposalId"`                                  // Only stream the events of the proposal and its votes
+	Cursor     *int64 `form:"cursor" validate:"omitempty,gte=0"`           // Stream sequence of the last received event, the stream resumes after it
+}
+
+// WebhookReq represents a request for a webhook.
+type WebhookReq struct {
+	Id int64 `form:"id" validate:"required"` // Webhook ID
+}
+
+// WebhookDeliveryListReq represents a request for the delivery log of a webhook.
+type WebhookDeliveryListReq struct {
+	PageReq
+	WebhookReq
+	Status *int `form:"status" validate:"omitempty,oneof=0 1 2 3"` // Only list the deliveries with the status
+}
+
+// GithubRepoListReq represents a request for the GitHub repositories of the PowerVotingConf contract.
+type GithubRepoListReq struct {
+	ChainIdParam
+	IncludeRemoved bool `form:"includeRemoved"` // Also list the removed repositories
+}
+
+// SnapshotHeightListReq represents a request for the snapshot heights set on the PowerVotingConf contract.
+type SnapshotHeightListReq struct {
+	PageReq
+	ChainIdParam
+}
+
 // Offset calculates the offset for pagination based on the current page and page size.
 // It ensures the page and page size are within valid ranges.
 //
@@ -127,13 +252,18 @@ func (p *PageReq) Offset() int {
 	return (p.Page - 1) * p.PageSize
 }
 
-func (a *AddressReq) ToEthAddr() (string, error) {
-	lotusClient := jsonrpc.NewClientWithOpts(config.Client.Network.Rpc, &jsonrpc.RPCClientOpts{})
-
+// ToEthAddr converts the address to an Ethereum address with the RPC of the given network, 0 for the primary network.
+func (a *AddressReq) ToEthAddr(chainId int64) (string, error) {
 	if strings.HasPrefix(a.Address, "0x") {
 		return utils.EthStandardAddressToHex(a.Address), nil
 	}
 
+	network, ok := config.GetNetwork(chainId)
+	if !ok {
+		return "", fmt.Errorf("network %d is not configured", chainId)
+	}
+	lotusClient := jsonrpc.NewClientWithOpts(network.Rpc, &jsonrpc.RPCClientOpts{})
+
 	resp, err := lotusClient.Call(context.Background(), "Filecoin.FilecoinAddressToEthAddress", a.Address)
 	if err != nil {
 		zap.L().Error("FilcoinAddressToEthAddress: lotus rpc error", zap.String("address", a.Address), zap.Error(err))
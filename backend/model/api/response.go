@@ -14,7 +14,11 @@
 
 package api
 
-import "powervoting-server/model"
+import (
+	"encoding/json"
+
+	"powervoting-server/model"
+)
 
 // Response represents the structure of a generic response.
 type Response struct {
@@ -63,6 +67,77 @@ type ProposalRep struct {
 	SnapshotInfo   SnapshotInfo           `json:"snapshotInfo,omitempty"`   // Snapshot information
 	Percentage     ProposalPercentage     `json:"percentage,omitempty"`     // Proposal percentage
 	TotalPower     TotalPower             `json:"totalPower,omitempty"`     // Total power
+	Algorithm      string                 `json:"countingAlgorithm"`        // Counting algorithm, empty for the default
+	Options        []string               `json:"options"`                  // Vote options
+	Outcome        string                 `json:"outcome"`                  // Outcome once counted [passed, rejected, failed-quorum]
+	Quorum         ProposalQuorum         `json:"quorum"`                   // Quorum rules of the proposal
+	ResultRevision int64                  `json:"resultRevision"`           // Revision of the result, increased by every recount
+	AwaitingPower  bool                   `json:"awaitingPower"`            // Whether counting waits for voter power missing from the snapshot
+	MissingPower   []string               `json:"missingPowerVoters"`       // Voters missing from the snapshot, counted as zero once counted
+	Breakdown      map[string]CategoryRep `json:"categoryBreakdown"`        // How each power category voted, indexed by category
+}
+
+// CategoryRep represents how one power category voted on a proposal.
+type CategoryRep struct {
+	Weight     uint16                    `json:"weight"`     // Percentage of the category set on the proposal
+	TotalPower string                    `json:"totalPower"` // Power of all counted votes in the category
+	Options    map[string]OptionPowerRep `json:"options"`    // Power of each vote option in the category
+}
+
+// OptionPowerRep represents the power of one vote option in a power category.
+type OptionPowerRep struct {
+	Power      string  `json:"power"`      // Power of the votes for the option
+	Share      float64 `json:"share"`      // Percentage of the category power, rounded for display
+	ExactShare string  `json:"exactShare"` // Percentage of the category power as an unrounded decimal
+}
+
+// ProposalResultHistoryRep represents a result of a proposal that was replaced by a recount.
+type ProposalResultHistoryRep struct {
+	ResultRevision int64                  `json:"resultRevision"` // Revision of the replaced result
+	Reason         string                 `json:"reason"`         // Why the proposal was recounted
+	RecountedAt    int64                  `json:"recountedAt"`    // Recount time
+	Outcome        string                 `json:"outcome"`        // Outcome of the replaced result
+	VotePercentage ProposalVotePercentage `json:"votePercentage"` // Voting result percentages
+	TotalPower     TotalPower             `json:"totalPower"`     // Total power
+	AuditSha256    string                 `json:"auditSha256"`    // SHA-256 of the replaced audit bundle
+}
+
+// ProposalAuditRep represents the tally audit bundle of a counted proposal.
+type ProposalAuditRep struct {
+	ProposalId int64           `json:"proposalId"` // Proposal ID
+	ChainId    int64           `json:"chainId"`    // Chain ID
+	Sha256     string          `json:"sha256"`     // SHA-256 of the bundle, hex encoded
+	Bundle     json.RawMessage `json:"bundle"`     // Canonical JSON of the audit bundle
+}
+
+// ProposalParticipationRep represents the turnout of a proposal, the vote choices are not included.
+// The power of a voter is its power in the proposal snapshot, whether or not it has been counted.
+type ProposalParticipationRep struct {
+	ProposalId   int64                `json:"proposalId"`   // Proposal ID
+	ChainId      int64                `json:"chainId"`      // Chain ID
+	SnapshotDay  string               `json:"snapshotDay"`  // Snapshot day
+	Voters       int64                `json:"voters"`       // Number of voters
+	MissingPower int64                `json:"missingPower"` // Number of voters without power in the snapshot
+	Power        TotalPower           `json:"power"`        // Snapshot power of the voters
+	Electorate   TotalPower           `json:"electorate"`   // Snapshot power of all addresses
+	Interval     int64                `json:"interval"`     // Seconds between two points of the timeline
+	Timeline     []ParticipationPoint `json:"timeline"`     // Participation at the end of each interval, from the proposal start time
+}
+
+// ParticipationPoint represents the participation of a proposal up to a point in time.
+type ParticipationPoint struct {
+	Time   int64      `json:"time"`   // End of the interval
+	Voters int64      `json:"voters"` // Number of voters up to the time
+	Power  TotalPower `json:"power"`  // Snapshot power of the voters up to the time
+}
+
+// ProposalQuorum represents the quorum rules of a proposal, power shares are in basis points.
+type ProposalQuorum struct {
+	MinVoters             int64  `json:"minVoters"`             // Minimum number of counted voters
+	SpPowerShare          uint16 `json:"spPowerShare"`          // Minimum share of the network power voting
+	ClientPowerShare      uint16 `json:"clientPowerShare"`      // Minimum share of the client power voting
+	TokenHolderPowerShare uint16 `json:"tokenHolderPowerShare"` // Minimum share of the token holder power voting
+	DeveloperPowerShare   uint16 `json:"developerPowerShare"`   // Minimum share of the developer power voting
 }
 
 type SnapshotInfo struct {
@@ -78,13 +153,26 @@ type TotalPower struct {
 }
 
 // ProposalVotePercentage represents the voting percentages for a proposal.
+// The float percentages are rounded half away from zero to 2 decimal places and may not add up to 100,
+// Exact holds the unrounded percentages the outcome is decided on.
 type ProposalVotePercentage struct {
-	Approve float64 `json:"approve"` // Approve percentage
-	Reject  float64 `json:"reject"`  // Reject percentage
+	Approve float64            `json:"approve"`           // Approve percentage
+	Reject  float64            `json:"reject"`            // Reject percentage
+	Abstain float64            `json:"abstain"`           // Abstain percentage
+	Options map[string]float64 `json:"options,omitempty"` // Percentage of each vote option
+	Exact   map[string]string  `json:"exact,omitempty"`   // Unrounded decimal percentage of each vote option
 }
 
 // ProposalDraftRep represents the draft details of a proposal.
 type ProposalDraftRep struct {
+	DraftId               int64  `json:"draftId"`               // Draft ID
+	Name                  string `json:"name"`                  // Draft name
+	Creator               string `json:"creator"`               // Creator address
+	ChainId               int64  `json:"chainId"`               // Chain ID
+	Version               int    `json:"version"`               // Current version
+	SubmittedVersion      int    `json:"submittedVersion"`      // Version a proposal is created from, 0 if not marked
+	ProposalId            int64  `json:"proposalId"`            // On-chain proposal created from the draft, 0 until synced
+	UpdatedAt             int64  `json:"updatedAt"`             // Unix time the draft was last saved
 	Title                 string `json:"title"`                 // Proposal title
 	Content               string `json:"content"`               // Proposal content
 	StartTime             int64  `json:"startTime"`             // Start time
@@ -96,6 +184,43 @@ type ProposalDraftRep struct {
 	ClientPercentage      uint16 `json:"clientPercentage"`      // Client percentage
 }
 
+// ProposalDraftVersionRep represents a saved content of a proposal draft.
+type ProposalDraftVersionRep struct {
+	Version               int    `json:"version"`               // Version, from 1
+	CreatedAt             int64  `json:"createdAt"`             // Unix time the version was saved
+	Title                 string `json:"title"`                 // Proposal title
+	Content               string `json:"content"`               // Proposal content
+	StartTime             int64  `json:"startTime"`             // Start time
+	EndTime               int64  `json:"endTime"`               // End time
+	Timezone              string `json:"timezone"`              // Timezone
+	TokenHolderPercentage uint16 `json:"tokenHolderPercentage"` // Token holder percentage
+	SpPercentage          uint16 `json:"spPercentage"`          // SP percentage
+	DeveloperPercentage   uint16 `json:"developerPercentage"`   // Developer percentage
+	ClientPercentage      uint16 `json:"clientPercentage"`      // Client percentage
+}
+
+// ProposalDraftDiffRep represents the changes between two versions of a proposal draft.
+type ProposalDraftDiffRep struct {
+	DraftId int64              `json:"draftId"` // Draft ID
+	From    int                `json:"from"`    // Version the changes are from
+	To      int                `json:"to"`      // Version the changes are to
+	Fields  []DraftFieldChange `json:"fields"`  // Fields whose value changed, except the content
+	Content []DraftDiffLine    `json:"content"` // Line diff of the content, empty if it did not change
+}
+
+// DraftFieldChange represents a field of a proposal draft whose value changed between two versions.
+type DraftFieldChange struct {
+	Field string `json:"field"` // JSON name of the field
+	From  string `json:"from"`  // Value in the version the changes are from
+	To    string `json:"to"`    // Value in the version the changes are to
+}
+
+// DraftDiffLine represents a line of the content diff of two versions of a proposal draft.
+type DraftDiffLine struct {
+	Op   string `json:"op"`   // equal, insert or delete
+	Text string `json:"text"` // Line, without its line break
+}
+
 // Voted represents the details of a vote cast on a proposal.
 type Voted struct {
 	ProposalId   int64  `json:"proposalId"`   // Proposal ID
@@ -107,6 +232,183 @@ type Voted struct {
 	PowerRep            // Voter power information
 }
 
+// VoteRevision represents one vote of a voter on a proposal, a voter can vote again until the proposal ends.
+type VoteRevision struct {
+	Revision      int    `json:"revision"`      // Position of the vote in the history of the voter, from 1
+	VoteEncrypted string `json:"voteEncrypted"` // Vote encrypted
+	BlockNumber   int64  `json:"blockNumber"`   // Vote block number
+	LogIndex      uint   `json:"logIndex"`      // Index of the vote log in its block
+	TxHash        string `json:"txHash"`        // Vote transaction hash
+	VotedTime     int64  `json:"votedTime"`     // Voted time
+	Valid         bool   `json:"valid"`         // Whether the vote was cast before the proposal end time
+	Current       bool   `json:"current"`       // Whether this is the vote that is counted
+}
+
+// VoterHistoryRep represents the vote of a voter on a proposal, with the outcome of the proposal.
+type VoterHistoryRep struct {
+	ProposalId  int64  `json:"proposalId"`  // Proposal ID
+	ChainId     int64  `json:"chainId"`     // Chain ID
+	Title       string `json:"title"`       // Proposal title
+	BlockNumber int64  `json:"blockNumber"` // Vote block number
+	VotedTime   int64  `json:"votedTime"`   // Voted time
+	VotedResult string `json:"votedResult"` // Decrypted vote option, empty until the proposal is counted
+	Counted     bool   `json:"counted"`     // Whether the proposal has been counted
+	Outcome     string `json:"outcome"`     // Proposal outcome once counted [passed, rejected, failed-quorum]
+	PowerRep           // Power of the voter in each category, set when the proposal is counted
+}
+
+// ExportProposalRow represents a proposal in an export, with its result once counted.
+type ExportProposalRow struct {
+	ChainId               int64    `json:"chainId"`               // Chain ID
+	ProposalId            int64    `json:"proposalId"`            // Proposal ID
+	Creator               string   `json:"creator"`               // Creator address
+	Title                 string   `json:"title"`                 // Proposal title
+	Content               string   `json:"content"`               // Proposal content
+	StartTime             int64    `json:"startTime"`             // Start time
+	EndTime               int64    `json:"endTime"`               // End time
+	Timestamp             int64    `json:"timestamp"`             // Proposal create time
+	BlockNumber           int64    `json:"blockNumber"`           // Proposal created block number
+	SnapshotDay           string   `json:"snapshotDay"`           // Snapshot day
+	SnapshotBlockHeight   int64    `json:"snapshotBlockHeight"`   // Snapshot height
+	CountingAlgorithm     string   `json:"countingAlgorithm"`     // Counting algorithm of the proposal
+	VoteOptions           []string `json:"voteOptions"`           // Options a vote can choose from
+	TokenHolderPercentage uint16   `json:"tokenHolderPercentage"` // Token holder weight
+	SpPercentage          uint16   `json:"spPercentage"`          // SP weight
+	ClientPercentage      uint16   `json:"clientPercentage"`      // Client weight
+	DeveloperPercentage   uint16   `json:"developerPercentage"`   // Developer weight
+	Counted               bool     `json:"counted"`               // Whether the proposal has been counted
+	ResultRevision        int64    `json:"resultRevision"`        // Revision of the result
+	Outcome               string   `json:"outcome"`               // Outcome once counted
+	ApprovePercentage     float64  `json:"approvePercentage"`     // Percentage of votes for the proposal
+	RejectPercentage      float64  `json:"rejectPercentage"`      // Percentage of votes against the proposal
+	AbstainPercentage     float64  `json:"abstainPercentage"`     // Percentage of votes abstaining
+	TotalSpPower          string   `json:"totalSpPower"`          // Total SP power
+	TotalClientPower      string   `json:"totalClientPower"`      // Total client power
+	TotalTokenHolderPower string   `json:"totalTokenHolderPower"` // Total token holder power
+	TotalDeveloperPower   string   `json:"totalDeveloperPower"`   // Total developer power
+}
+
+// ExportVoteRow represents a counted vote in an export, with the power of the voter.
+type ExportVoteRow struct {
+	ChainId          int64  `json:"chainId"`          // Chain ID
+	ProposalId       int64  `json:"proposalId"`       // Proposal ID
+	Address          string `json:"address"`          // Voter address
+	VotedResult      string `json:"votedResult"`      // Decrypted vote option
+	SpPower          string `json:"spPower"`          // SP power
+	ClientPower      string `json:"clientPower"`      // Client power
+	TokenHolderPower string `json:"tokenHolderPower"` // Token holder power
+	DeveloperPower   string `json:"developerPower"`   // Developer power
+	PowerMissing     bool   `json:"powerMissing"`     // Whether the voter was missing from the snapshot and counted as zero
+	BlockNumber      int64  `json:"blockNumber"`      // Vote block number
+	LogIndex         uint   `json:"logIndex"`         // Index of the vote log in its block
+	VotedTime        int64  `json:"votedTime"`        // Voted time
+}
+
+// ExportFipProposalRow represents a FIP editor proposal in an export.
+type ExportFipProposalRow struct {
+	ChainId          int64  `json:"chainId"`          // Chain ID
+	ProposalId       int64  `json:"proposalId"`       // Proposal ID
+	ProposalType     int    `json:"proposalType"`     // Proposal type [0: revoke, 1: approve]
+	Status           int    `json:"status"`           // Proposal status [0: pending, 1: passed]
+	Creator          string `json:"creator"`          // Creator address
+	CandidateAddress string `json:"candidateAddress"` // Candidate address
+	CandidateInfo    string `json:"candidateInfo"`    // Candidate information
+	BlockNumber      int64  `json:"blockNumber"`      // Proposal created block number
+	Timestamp        int64  `json:"timestamp"`        // Proposal create time
+}
+
+// ExportFipVoteRow represents a vote on a FIP editor proposal in an export.
+type ExportFipVoteRow struct {
+	ChainId     int64  `json:"chainId"`     // Chain ID
+	ProposalId  int64  `json:"proposalId"`  // Proposal ID
+	Voter       string `json:"voter"`       // Voter address
+	Removed     bool   `json:"removed"`     // Whether the vote was removed with its proposal
+	BlockNumber int64  `json:"blockNumber"` // Vote block number
+	Timestamp   int64  `json:"timestamp"`   // Voted time
+}
+
+// ExportVoterRow represents the identity bound to a voter address in an export.
+type ExportVoterRow struct {
+	ChainId     int64    `json:"chainId"`     // Chain ID
+	Address     string   `json:"address"`     // Voter address
+	OwnerId     string   `json:"ownerId"`     // Owner ID of the voter
+	MinerIds    []string `json:"minerIds"`    // Miner IDs of the voter
+	GistId      string   `json:"gistId"`      // Github Gist ID
+	GithubName  string   `json:"githubName"`  // Github name
+	BlockNumber int64    `json:"blockNumber"` // Voter update block number
+	Timestamp   int64    `json:"timestamp"`   // Voter update time
+}
+
+// DeadLetterRep represents an event log that failed to be applied.
+type DeadLetterRep struct {
+	Id              int64           `json:"id"`              // Dead letter ID
+	ChainId         int64           `json:"chainId"`         // Chain ID
+	Event           string          `json:"event"`           // Event name
+	ContractAddress string          `json:"contractAddress"` // Address of the contract that emitted the event
+	BlockNumber     int64           `json:"blockNumber"`     // Event block number
+	TxHash          string          `json:"txHash"`          // Event transaction hash
+	LogIndex        uint            `json:"logIndex"`        // Event log index in the block
+	RawLog          json.RawMessage `json:"rawLog"`          // The event log
+	Error           string          `json:"error"`           // Error of the last attempt
+	Attempts        int             `json:"attempts"`        // Number of attempts to apply the event
+	Status          int             `json:"status"`          // 0: pending, 2: exhausted
+	NextAttemptTime int64           `json:"nextAttemptTime"` // Unix time of the next replay
+	FailedTime      int64           `json:"failedTime"`      // Unix time of the first failure
+}
+
+// WebhookRep represents a webhook subscribed to governance lifecycle events.
+type WebhookRep struct {
+	Id          int64    `json:"id"`               // Webhook ID
+	Url         string   `json:"url"`              // URL the events are posted to
+	Events      []string `json:"events"`           // Subscribed events
+	ChainId     int64    `json:"chainId"`          // Chain ID of the events, 0 for every network
+	Description string   `json:"description"`      // Description of the subscriber
+	Secret      string   `json:"secret,omitempty"` // Key of the HMAC signature, only returned when the webhook is added
+	CreatedTime int64    `json:"createdTime"`      // Unix time the webhook was added
+}
+
+// WebhookDeliveryRep represents a delivery of an event to a webhook.
+type WebhookDeliveryRep struct {
+	Id              int64  `json:"id"`              // Delivery ID
+	WebhookId       int64  `json:"webhookId"`       // Webhook ID
+	EventId         int64  `json:"eventId"`         // Event ID
+	Event           string `json:"event"`           // Event name
+	Attempts        int    `json:"attempts"`        // Number of attempts to post the event
+	Status          int    `json:"status"`          // 0: pending, 1: delivered, 2: exhausted, 3: cancelled
+	NextAttemptTime int64  `json:"nextAttemptTime"` // Unix time of the next attempt
+	ResponseStatus  int    `json:"responseStatus"`  // HTTP status of the last attempt
+	Error           string `json:"error"`           // Error of the last attempt
+	DeliveredTime   int64  `json:"deliveredTime"`   // Unix time the webhook accepted the event
+	CreatedTime     int64  `json:"createdTime"`     // Unix time the event was emitted
+}
+
+// GithubRepoRep represents a GitHub repository whose contributors are counted as developers.
+type GithubRepoRep struct {
+	RepoId      int64  `json:"repoId"`      // Repository ID on the contract
+	RepoName    string `json:"repoName"`    // Repository name
+	OrgType     int    `json:"orgType"`     // 0: core Filecoin org, 1: ecosystem org, 2: GitHub user
+	Removed     bool   `json:"removed"`     // Whether the repository has been removed
+	BlockNumber int64  `json:"blockNumber"` // Block number of the addition
+	Timestamp   int64  `json:"timestamp"`   // Time of the addition
+}
+
+// SnapshotHeightRep represents the snapshot height of a day.
+type SnapshotHeightRep struct {
+	Day         string `json:"day"`         // Snapshot day
+	Height      int64  `json:"height"`      // Block height of the snapshot
+	BlockNumber int64  `json:"blockNumber"` // Block number of the event
+	Timestamp   int64  `json:"timestamp"`   // Time of the event
+}
+
+// ConfChangeRep represents a change of a PowerVotingConf contract setting.
+type ConfChangeRep struct {
+	OldValue    string `json:"oldValue"`    // Value before the change
+	NewValue    string `json:"newValue"`    // Value after the change
+	BlockNumber int64  `json:"blockNumber"` // Block number of the event
+	TxHash      string `json:"txHash"`      // Transaction hash of the event
+	Timestamp   int64  `json:"timestamp"`   // Time of the event
+}
+
 type FipProposalRep struct {
 	ProposalId       int64    `json:"proposalId"`       // Proposal ID
 	ChainId          int64    `json:"chainId"`          // Chain ID
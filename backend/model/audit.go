@@ -0,0 +1,97 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// proposal tally audit table
+type ProposalAuditTbl struct {
+	BaseField
+	ProposalId int64  `json:"proposal_id" gorm:"not null;uniqueIndex:idx_audit_proposal_chain"` // Proposal ID
+	ChainId    int64  `json:"chain_id" gorm:"not null;uniqueIndex:idx_audit_proposal_chain"`    // Chain ID
+	Bundle     string `json:"bundle" gorm:"type:longtext;not null"`                             // Canonical JSON of the TallyAudit
+	Sha256     string `json:"sha256" gorm:"not null"`                                           // SHA-256 of the bundle, hex encoded
+}
+
+// DrandBeacon is the drand round and signature that unlocked a time lock encrypted vote.
+type DrandBeacon struct {
+	Round     uint64 `json:"round"`     // Drand round number
+	Signature string `json:"signature"` // Round signature, hex encoded
+}
+
+// TallyAudit holds every input and intermediate value used to count a proposal, so that the result can be recomputed.
+// It is encoded with encoding/json: struct fields keep their order, map keys are sorted and the rows are sorted
+// by address, which makes the encoding of the same count identical byte for byte.
+type TallyAudit struct {
+	Version              int                        `json:"version"`               // Bundle format version
+	ProposalId           int64                      `json:"proposal_id"`           // Proposal ID
+	ChainId              int64                      `json:"chain_id"`              // Chain ID
+	CountingAlgorithm    string                     `json:"counting_algorithm"`    // Counting algorithm used
+	Options              []string                   `json:"options"`               // Vote options of the proposal
+	Percentage           Percentage                 `json:"percentage"`            // Category weights of the proposal
+	Quorum               QuorumRule                 `json:"quorum"`                // Quorum rules of the proposal
+	DrandChainHash       string                     `json:"drand_chain_hash"`      // Drand chain used to decrypt the votes
+	SnapshotDay          string                     `json:"snapshot_day"`          // Snapshot day
+	SnapshotHeight       int64                      `json:"snapshot_height"`       // Snapshot height
+	SnapshotPower        []AuditPower               `json:"snapshot_power"`        // Power of all addresses in the snapshot
+	Votes                []AuditVote                `json:"votes"`                 // Every vote of the proposal
+	OptionSums           map[string]AuditPowerCount `json:"option_sums"`           // Power of the counted votes per option
+	TotalSum             AuditPowerCount            `json:"total_sum"`             // Power of all counted votes
+	Electorate           AuditPowerCount            `json:"electorate"`            // Power that could have voted, used for the quorum
+	AlgorithmPercentages map[string]string          `json:"algorithm_percentages"` // Unrounded percentages from the counting algorithm
+	Percentages          map[string]string          `json:"percentages"`           // Final percentages of every option, unrounded
+	QuorumMet            bool                       `json:"quorum_met"`            // Whether the quorum rules are met
+	Outcome              string                     `json:"outcome"`               // Proposal outcome
+}
+
+// AuditPower is the power of an address in the snapshot.
+type AuditPower struct {
+	Address          string `json:"address"`            // Address
+	SpPower          string `json:"sp_power"`           // SP power
+	ClientPower      string `json:"client_power"`       // Client power
+	TokenHolderPower string `json:"token_holder_power"` // Token holder power
+	DeveloperPower   string `json:"developer_power"`    // Developer power
+	BlockHeight      int64  `json:"block_height"`       // Block height of the power
+}
+
+// AuditVote is a vote with its decryption and the power it was counted with.
+type AuditVote struct {
+	Address          string      `json:"address"`            // Voter address
+	VoteEncrypted    string      `json:"vote_encrypted"`     // Time lock encrypted vote
+	Beacon           DrandBeacon `json:"beacon"`             // Drand beacon that unlocked the vote
+	Choice           string      `json:"choice"`             // Decrypted vote option
+	Counted          bool        `json:"counted"`            // Whether the vote was counted
+	PowerMissing     bool        `json:"power_missing"`      // Whether the voter was missing from the snapshot and counted as zero
+	SpPower          string      `json:"sp_power"`           // SP power
+	ClientPower      string      `json:"client_power"`       // Client power
+	TokenHolderPower string      `json:"token_holder_power"` // Token holder power
+	DeveloperPower   string      `json:"developer_power"`    // Developer power
+}
+
+// AuditPowerCount is a VoterPowerCount with the sums as decimal strings.
+type AuditPowerCount struct {
+	SpPower          string `json:"sp_power"`           // Total SP power
+	ClientPower      string `json:"client_power"`       // Total client power
+	TokenHolderPower string `json:"token_holder_power"` // Total token holder power
+	DeveloperPower   string `json:"developer_power"`    // Total developer power
+}
+
+// NewAuditPowerCount converts a power count to its audit representation.
+func NewAuditPowerCount(count VoterPowerCount) AuditPowerCount {
+	return AuditPowerCount{
+		SpPower:          count.SpPower.String(),
+		ClientPower:      count.ClientPower.String(),
+		TokenHolderPower: count.TokenPower.String(),
+		DeveloperPower:   count.DeveloperPower.String(),
+	}
+}
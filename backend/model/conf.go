@@ -0,0 +1,51 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// GitHub repository added on the PowerVotingConf contract, its contributors are developers in the power snapshot
+type GithubRepoTbl struct {
+	BaseField
+	ChainId            int64  `json:"chain_id" gorm:"not null;uniqueIndex:idx_github_repo"` // Chain ID
+	RepoId             int64  `json:"repo_id" gorm:"not null;uniqueIndex:idx_github_repo"`  // Repository ID on the contract
+	RepoName           string `json:"repo_name" gorm:"not null"`                            // Repository name
+	OrgType            int    `json:"org_type" gorm:"not null"`                             // 0: core Filecoin org, 1: ecosystem org, 2: GitHub user
+	Removed            bool   `json:"removed" gorm:"not null;default:false"`                // Whether the repository has been removed
+	RemovedBlockNumber int64  `json:"removed_block_number" gorm:"not null;default:0"`       // Block number of the removal, 0 while it is not removed
+	BlockNumber        int64  `json:"block_number" gorm:"not null"`                         // Block number of the addition
+	Timestamp          int64  `json:"timestamp" gorm:"not null"`                            // Time of the addition
+}
+
+// Snapshot height of a day set on the PowerVotingConf contract
+type SnapshotHeightTbl struct {
+	BaseField
+	ChainId     int64  `json:"chain_id" gorm:"not null;uniqueIndex:idx_snapshot_height_day"` // Chain ID
+	Day         string `json:"day" gorm:"not null;uniqueIndex:idx_snapshot_height_day"`      // Snapshot day, e.g. 20240101
+	Height      int64  `json:"height" gorm:"not null"`                                       // Block height of the snapshot
+	BlockNumber int64  `json:"block_number" gorm:"not null"`                                 // Block number of the event
+	Timestamp   int64  `json:"timestamp" gorm:"not null"`                                    // Time of the event
+}
+
+// Change of a setting of the PowerVotingConf contract, the snapshot expiration days or the vote counting algorithm
+type ConfChangeTbl struct {
+	BaseField
+	ChainId     int64  `json:"chain_id" gorm:"not null;uniqueIndex:idx_conf_change_log;index:idx_conf_change_setting"` // Chain ID
+	Setting     string `json:"setting" gorm:"not null;index:idx_conf_change_setting"`                                  // Changed setting
+	OldValue    string `json:"old_value" gorm:"not null"`                                                              // Value before the change
+	NewValue    string `json:"new_value" gorm:"not null"`                                                              // Value after the change
+	BlockNumber int64  `json:"block_number" gorm:"not null"`                                                           // Block number of the event
+	TxHash      string `json:"tx_hash" gorm:"not null;uniqueIndex:idx_conf_change_log"`                                // Transaction hash of the event
+	LogIndex    uint   `json:"log_index" gorm:"not null;uniqueIndex:idx_conf_change_log"`                              // Log index of the event in its block
+	Timestamp   int64  `json:"timestamp" gorm:"not null"`                                                              // Time of the event
+}
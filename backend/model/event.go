@@ -17,9 +17,71 @@ package model
 // sync event table
 type SyncEventTbl struct {
 	Id                         int64  `json:"id"`
-	ChainId                    int64  `json:"chain_id" gorm:"not null"`
+	ChainId                    int64  `json:"chain_id" gorm:"not null;uniqueIndex:idx_sync_event_contract"`
 	ChainName                  string `json:"chain_name" gorm:"not null"`
-	PowerVotingContractAddress string `json:"power_voting_contract_address" gorm:"not null;uniqueIndex"`
+	PowerVotingContractAddress string `json:"power_voting_contract_address" gorm:"not null;uniqueIndex:idx_sync_event_contract"`
 	FipProposalContractAddress string `json:"fip_proposal_contract_address" gorm:"not null"`
 	SyncedHeight               int64  `json:"synced_height" gorm:"not null,default:0"`
 }
+
+// Block recorded at a synced height, used to detect chain reorganizations
+type SyncBlockTbl struct {
+	Id         int64  `json:"id"`
+	ChainId    int64  `json:"chain_id" gorm:"not null;uniqueIndex:idx_chain_height"` // Chain ID
+	Height     int64  `json:"height" gorm:"not null;uniqueIndex:idx_chain_height"`   // Block height
+	Hash       string `json:"hash" gorm:"not null"`                                  // Block hash
+	ParentHash string `json:"parent_hash" gorm:"not null,default:''"`                // Parent block hash, empty for blocks recorded from their event logs
+}
+
+// Previous state of the rows updated in place by an event log, restored when its block is rolled back
+type SyncUndoTbl struct {
+	BaseField
+	ChainId     int64  `json:"chain_id" gorm:"not null;index:idx_sync_undo_block"`     // Chain ID
+	BlockNumber int64  `json:"block_number" gorm:"not null;index:idx_sync_undo_block"` // Block of the event log that updated the rows
+	Table       string `json:"table" gorm:"not null"`                                  // Table of the rows
+	Rows        string `json:"rows" gorm:"type:longtext;not null"`                     // JSON of the rows before the update
+}
+
+// Progress of the backfill of the historical events of a chain, saved with every applied chunk
+type SyncBackfillTbl struct {
+	BaseField
+	ChainId       int64 `json:"chain_id" gorm:"not null;uniqueIndex"`   // Chain ID
+	StartHeight   int64 `json:"start_height" gorm:"not null"`           // Synced height when the backfill started
+	TargetHeight  int64 `json:"target_height" gorm:"not null"`          // Height the backfill syncs to
+	AppliedHeight int64 `json:"applied_height" gorm:"not null"`         // Last height of the applied chunks
+	ChunkSize     int64 `json:"chunk_size" gorm:"not null"`             // Blocks per chunk, shrunk when the RPC rejected a range
+	Chunks        int64 `json:"chunks" gorm:"not null;default:0"`       // Number of applied chunks
+	Finished      bool  `json:"finished" gorm:"not null;default:false"` // Whether the target height has been reached
+}
+
+// Event log that failed to be applied, kept to be replayed
+type EventDeadLetterTbl struct {
+	BaseField
+	ChainId         int64  `json:"chain_id" gorm:"not null;uniqueIndex:idx_dead_letter_log;index:idx_dead_letter_due"` // Chain ID
+	ContractAddress string `json:"contract_address" gorm:"not null"`                                                   // Address of the contract that emitted the event
+	Event           string `json:"event" gorm:"not null"`                                                              // Event name, or the signature topic of an unknown event
+	BlockNumber     int64  `json:"block_number" gorm:"not null"`                                                       // Event block number
+	TxHash          string `json:"tx_hash" gorm:"not null;uniqueIndex:idx_dead_letter_log"`                            // Event transaction hash
+	LogIndex        uint   `json:"log_index" gorm:"not null;uniqueIndex:idx_dead_letter_log"`                          // Event log index in the block
+	RawLog          string `json:"raw_log" gorm:"type:text;not null"`                                                  // JSON of the event log
+	Error           string `json:"error" gorm:"type:text"`                                                             // Error of the last attempt
+	Attempts        int    `json:"attempts" gorm:"not null;default:0"`                                                 // Number of attempts to apply the event
+	Status          int    `json:"status" gorm:"not null;default:0;index:idx_dead_letter_due"`                         // 0: pending, 1: resolved, 2: exhausted
+	NextAttemptTime int64  `json:"next_attempt_time" gorm:"not null;default:0;index:idx_dead_letter_due"`              // Unix time of the next replay
+}
+
+// Raw log of the synced contract events, kept to rebuild the tables derived from the events
+type EventLogTbl struct {
+	BaseField
+	ChainId         int64       `json:"chain_id" gorm:"not null;uniqueIndex:idx_event_log;index:idx_event_log_order"`  // Chain ID
+	ContractAddress string      `json:"contract_address" gorm:"not null"`                                              // Address of the contract that emitted the event
+	Event           string      `json:"event" gorm:"not null"`                                                         // Event name
+	BlockNumber     int64       `json:"block_number" gorm:"not null;index:idx_event_log_order"`                        // Event block number
+	BlockHash       string      `json:"block_hash" gorm:"not null"`                                                    // Event block hash
+	BlockTime       int64       `json:"block_time" gorm:"not null"`                                                    // Unix time of the event block
+	TxHash          string      `json:"tx_hash" gorm:"not null;uniqueIndex:idx_event_log"`                             // Event transaction hash
+	TxIndex         uint        `json:"tx_index" gorm:"not null"`                                                      // Index of the transaction in the block
+	LogIndex        uint        `json:"log_index" gorm:"not null;uniqueIndex:idx_event_log;index:idx_event_log_order"` // Event log index in the block
+	Topics          StringSlice `json:"topics" gorm:"type:json"`                                                       // Event topics
+	Data            string      `json:"data" gorm:"type:mediumtext;not null"`                                          // Hex encoded event data
+}
@@ -18,6 +18,7 @@ type FipProposalVoted struct {
 	FipProposalTbl
 	Voters string `json:"voters" gorm:"column:voters"`
 }
+
 // FipProposalTbl is the table for FIP proposals
 type FipProposalTbl struct {
 	BaseField
@@ -32,23 +33,22 @@ type FipProposalTbl struct {
 	Timestamp        int64  `json:"timestamp" gorm:"not null"`
 }
 
-
 // FipProposalVoteTbl is the table for FIP proposal voters
 type FipProposalVoteTbl struct {
 	BaseField
-	ChainId     int64  `json:"chain_id" gorm:"not null"`
-	ProposalId  int64  `json:"proposal_id" gorm:"not null;uniqueIndex:idx_id_voter"`
-	Voter       string `json:"voter" gorm:"not null;uniqueIndex:idx_id_voter"`
+	ChainId     int64  `json:"chain_id" gorm:"not null;uniqueIndex:idx_chain_id_voter"`
+	ProposalId  int64  `json:"proposal_id" gorm:"not null;uniqueIndex:idx_chain_id_voter"`
+	Voter       string `json:"voter" gorm:"not null;uniqueIndex:idx_chain_id_voter"`
 	IsRemove    int    `json:"is_remove" gorm:"not null,default:0;comment:0: not remove, 1: remove"`
 	BlockNumber int64  `json:"block_number" gorm:"not null"`
 	Timestamp   int64  `json:"timestamp" gorm:"not null"`
 }
 
-
 // FipEditorTbl is the table for FIP voters
 type FipEditorTbl struct {
 	BaseField
-	ChainId  int64  `json:"chain_id" gorm:"not null"`
-	Editor   string `json:"editor" gorm:"not null;uniqueIndex"`
-	IsRemove int    `json:"is_remove" gorm:"not null,default:0"`
+	ChainId     int64  `json:"chain_id" gorm:"not null;uniqueIndex:idx_chain_editor"`
+	Editor      string `json:"editor" gorm:"not null;uniqueIndex:idx_chain_editor"`
+	IsRemove    int    `json:"is_remove" gorm:"not null,default:0"`
+	BlockNumber int64  `json:"block_number" gorm:"not null;default:0"` // Block the editor was added in, 0 for the initial editor
 }
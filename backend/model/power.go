@@ -53,6 +53,12 @@ type SnapshotAllPower struct {
 	DevPower  any         `json:"devPower"`
 }
 
+// Total power of the network from the Lotus StateMinerPower TotalPower
+type NetworkPower struct {
+	RawBytePower    *big.Int `json:"rawBytePower"`    // Network raw byte power
+	QualityAdjPower *big.Int `json:"qualityAdjPower"` // Network quality adjusted power
+}
+
 type SnapshotHeight struct {
 	Height int64  `json:"height"`
 	Day    string `json:"day"`
@@ -14,6 +14,14 @@
 
 package model
 
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+
+	"powervoting-server/constant"
+)
+
 type ProposalWithVoted struct {
 	ProposalTbl
 	Voted *bool `json:"voted,omitempty" gorm:"column:voted"` // Virtual field indicating whether to vote
@@ -22,28 +30,138 @@ type ProposalWithVoted struct {
 // proposal table
 type ProposalTbl struct {
 	BaseField
-	ProposalId          int64  `json:"proposal_id" gorm:"not null,default:0;uniqueIndex:idx_proposal_chain_id"` // Proposal ID
-	Creator             string `json:"creator" gorm:"not null"`                                                 // Creator address
-	StartTime           int64  `json:"start_time" gorm:"not null"`                                              // Start time
-	EndTime             int64  `json:"end_time" gorm:"not null"`                                                // Expiry time
-	Timestamp           int64  `json:"timestamp" gorm:"not null"`                                               // Proposal create time
-	Counted             int    `json:"counted" gorm:"not null,default:0;comment:0: not counted, 1: counted"`                                       // Whether the proposal has been counted. [0: false, 1: true] 0 is not counted, 1 is counted
-	ChainId             int64  `json:"chain_id" gorm:"not null;uniqueIndex:idx_proposal_chain_id"`              // Chain ID
-	Title               string `json:"title" gorm:"type:longtext;not null,default:''"`                          // Name
-	Content             string `json:"content" gorm:"type:longtext;not null,default:''"`                        // Descriptions
-	BlockNumber         int64  `json:"block_number" gorm:"not null"`                                            // Proposal created block number
-	SnapshotDay         string `json:"snapshot_day" gorm:"not null"`                                            // Snapshot day
-	SnapshotBlockHeight int64  `json:"snapshot_block_height" gorm:"not null,default:0"`                         // Snapshot height
-	SnapshotTimestamp   int64  `json:"snapshot_timestamp" gorm:"not null,default:0"`
+	ProposalId          int64       `json:"proposal_id" gorm:"not null,default:0;uniqueIndex:idx_proposal_chain_id"` // Proposal ID
+	Creator             string      `json:"creator" gorm:"not null"`                                                 // Creator address
+	StartTime           int64       `json:"start_time" gorm:"not null"`                                              // Start time
+	EndTime             int64       `json:"end_time" gorm:"not null"`                                                // Expiry time
+	Timestamp           int64       `json:"timestamp" gorm:"not null"`                                               // Proposal create time
+	Counted             int         `json:"counted" gorm:"not null,default:0;comment:0: not counted, 1: counted"`    // Whether the proposal has been counted. [0: false, 1: true] 0 is not counted, 1 is counted
+	ChainId             int64       `json:"chain_id" gorm:"not null;uniqueIndex:idx_proposal_chain_id"`              // Chain ID
+	Title               string      `json:"title" gorm:"type:longtext;not null,default:''"`                          // Name
+	Content             string      `json:"content" gorm:"type:longtext;not null,default:''"`                        // Descriptions
+	BlockNumber         int64       `json:"block_number" gorm:"not null"`                                            // Proposal created block number
+	SnapshotDay         string      `json:"snapshot_day" gorm:"not null"`                                            // Snapshot day
+	SnapshotBlockHeight int64       `json:"snapshot_block_height" gorm:"not null,default:0"`                         // Snapshot height
+	SnapshotTimestamp   int64       `json:"snapshot_timestamp" gorm:"not null,default:0"`
+	CountingAlgorithm   string      `json:"counting_algorithm" gorm:"not null,default:''"` // Counting algorithm in force when the proposal was created
+	VoteOptions         StringSlice `json:"vote_options" gorm:"type:json"`                 // Options a vote can choose from
+	ResultRevision      int64       `json:"result_revision" gorm:"not null,default:0"`     // Revision of the result, 0 until counted and increased by every recount
 	ProposalResult
+	PowerRetry
 	Percentage
 	TotalPower
+	QuorumRule
+}
+
+// Options returns the options a vote on the proposal can choose from.
+// Proposals synced before options were recorded only offer approve and reject.
+func (p *ProposalTbl) Options() []string {
+	if len(p.VoteOptions) == 0 {
+		return []string{constant.VoteApprove, constant.VoteReject}
+	}
+
+	return p.VoteOptions
 }
 
-// Percent voting used to count proposals
+// Percent voting used to count proposals.
+// The float percentages are kept for display and rounded with utils.RoundPercentage,
+// the outcome is decided on the exact percentages.
 type ProposalResult struct {
-	ApprovePercentage float64 `json:"approve_percentage" gorm:"not null"` // Percentage of votes for the proposal
-	RejectPercentage  float64 `json:"reject_percentage" gorm:"not null"`  // Percentage of votes against the proposal
+	ApprovePercentage float64           `json:"approve_percentage" gorm:"not null"`           // Percentage of votes for the proposal
+	RejectPercentage  float64           `json:"reject_percentage" gorm:"not null"`            // Percentage of votes against the proposal
+	AbstainPercentage float64           `json:"abstain_percentage" gorm:"not null,default:0"` // Percentage of votes abstaining
+	OptionPercentages Float64Map        `json:"option_percentages" gorm:"type:json"`          // Percentage of votes for each option
+	ExactPercentages  StringMap         `json:"exact_percentages" gorm:"type:json"`           // Percentage of votes for each option as an unrounded decimal
+	Outcome           string            `json:"outcome" gorm:"not null,default:''"`           // Outcome once counted [passed, rejected, failed-quorum]
+	CategoryBreakdown CategoryBreakdown `json:"category_breakdown" gorm:"type:json"`          // How each power category voted
+}
+
+// CategoryBreakdown holds the result of each power category of a proposal, indexed by category name
+type CategoryBreakdown map[string]CategoryResult
+
+// Result of one power category of a proposal
+type CategoryResult struct {
+	Weight     uint16                 `json:"weight"`      // Percentage of the category set on the proposal
+	TotalPower string                 `json:"total_power"` // Power of all counted votes in the category
+	Options    map[string]OptionPower `json:"options"`     // Power of each vote option in the category
+}
+
+// Power of one vote option in a power category
+type OptionPower struct {
+	Power      string  `json:"power"`       // Power of the votes for the option
+	Share      float64 `json:"share"`       // Percentage of the category power, rounded for display
+	ExactShare string  `json:"exact_share"` // Percentage of the category power as an unrounded decimal
+}
+
+func (b *CategoryBreakdown) Scan(src interface{}) error {
+	if src == nil {
+		*b = CategoryBreakdown{}
+		return nil
+	}
+
+	bytes, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupport type: %T", src)
+	}
+
+	return json.Unmarshal(bytes, b)
+}
+
+func (b CategoryBreakdown) Value() (driver.Value, error) {
+	if b == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(b)
+}
+
+// State of a count deferred because voters are missing from the power snapshot
+type PowerRetry struct {
+	AwaitingPower      bool        `json:"awaiting_power" gorm:"not null,default:false"` // Whether the count waits for missing voter power
+	PowerRetries       int         `json:"power_retries" gorm:"not null,default:0"`      // Number of deferred counts
+	NextCountTime      int64       `json:"next_count_time" gorm:"not null,default:0"`    // Earliest time of the next count
+	MissingPowerVoters StringSlice `json:"missing_power_voters" gorm:"type:json"`        // Voters without power, counted as zero once the proposal is counted
+}
+
+// Previous results of a recounted proposal
+type ProposalResultHistoryTbl struct {
+	BaseField
+	ProposalId     int64  `json:"proposal_id" gorm:"not null;uniqueIndex:idx_history_proposal_revision"`     // Proposal ID
+	ChainId        int64  `json:"chain_id" gorm:"not null;uniqueIndex:idx_history_proposal_revision"`        // Chain ID
+	ResultRevision int64  `json:"result_revision" gorm:"not null;uniqueIndex:idx_history_proposal_revision"` // Revision of the replaced result
+	Reason         string `json:"reason" gorm:"type:text;not null"`                                          // Why the result was recounted
+	AuditSha256    string `json:"audit_sha256" gorm:"not null,default:''"`                                   // SHA-256 of the replaced audit bundle
+	AuditBundle    string `json:"audit_bundle" gorm:"type:longtext"`                                         // Replaced audit bundle
+	ProposalResult
+	TotalPower
+}
+
+// NewResultHistory copies the current result of a proposal and its audit bundle into a result history record.
+func NewResultHistory(proposal ProposalTbl, audit *ProposalAuditTbl, reason string) *ProposalResultHistoryTbl {
+	history := &ProposalResultHistoryTbl{
+		ProposalId:     proposal.ProposalId,
+		ChainId:        proposal.ChainId,
+		ResultRevision: proposal.ResultRevision,
+		Reason:         reason,
+		ProposalResult: proposal.ProposalResult,
+		TotalPower:     proposal.TotalPower,
+	}
+
+	if audit != nil {
+		history.AuditSha256 = audit.Sha256
+		history.AuditBundle = audit.Bundle
+	}
+
+	return history
+}
+
+// Quorum rules in force when the proposal was created, power shares are in basis points and 0 disables a rule
+type QuorumRule struct {
+	QuorumMinVoters        int64  `json:"quorum_min_voters" gorm:"not null,default:0"`         // Minimum number of counted voters
+	QuorumSpShare          uint16 `json:"quorum_sp_share" gorm:"not null,default:0"`           // Minimum share of the network power voting
+	QuorumClientShare      uint16 `json:"quorum_client_share" gorm:"not null,default:0"`       // Minimum share of the client power voting
+	QuorumTokenHolderShare uint16 `json:"quorum_token_holder_share" gorm:"not null,default:0"` // Minimum share of the token holder power voting
+	QuorumDeveloperShare   uint16 `json:"quorum_developer_share" gorm:"not null,default:0"`    // Minimum share of the developer power voting
 }
 
 // Percentage represents the percentage of votes for different groups.
@@ -63,14 +181,42 @@ type TotalPower struct {
 }
 
 // Drafts of proposals are used to save proposals published by users
+// A creator can hold many drafts, each saved content is kept as a version in ProposalDraftVersionTbl
 type ProposalDraftTbl struct {
 	BaseField
-	Creator   string `json:"creator" gorm:"not null;unique"`     // Creator address
-	StartTime int64  `json:"start_time" gorm:"not null"`         // Start time
-	EndTime   int64  `json:"end_time" gorm:"not null"`           // Expiry time
-	Timezone  string `json:"timezone" gorm:"not null"`           // Proposal timezone
-	ChainId   int64  `json:"chain_id" gorm:"not null"`           // Network ID
-	Title     string `json:"title" gorm:"not null,default:''"`   // Name
-	Content   string `json:"content" gorm:"not null,default:''"` // Descriptions
+	Creator          string `json:"creator" gorm:"not null;index"`               // Creator address
+	Name             string `json:"name" gorm:"not null;default:''"`             // Name of the draft
+	Version          int    `json:"version" gorm:"not null;default:1"`           // Current version
+	SubmittedVersion int    `json:"submitted_version" gorm:"not null;default:0"` // Version a proposal is created from, 0 if not marked
+	SubmittedAt      int64  `json:"submitted_at" gorm:"not null;default:0"`      // Unix time the version was marked
+	ProposerAddress  string `json:"proposer_address" gorm:"not null;default:''"` // Lowercase 0x address the marked proposal is created by, the masked ID address of an f1 or f3 creator
+	ProposalId       int64  `json:"proposal_id" gorm:"not null;default:0;index"` // On-chain proposal created from the draft, 0 until synced
+	StartTime        int64  `json:"start_time" gorm:"not null"`                  // Start time
+	EndTime          int64  `json:"end_time" gorm:"not null"`                    // Expiry time
+	Timezone         string `json:"timezone" gorm:"not null"`                    // Proposal timezone
+	ChainId          int64  `json:"chain_id" gorm:"not null"`                    // Network ID
+	Title            string `json:"title" gorm:"not null,default:''"`            // Name
+	Content          string `json:"content" gorm:"not null,default:''"`          // Descriptions
 	Percentage
 }
+
+// ProposalDraftVersionTbl is a saved content of a proposal draft.
+type ProposalDraftVersionTbl struct {
+	BaseField
+	DraftId   int64  `json:"draft_id" gorm:"not null;uniqueIndex:idx_draft_version"` // Draft ID
+	Version   int    `json:"version" gorm:"not null;uniqueIndex:idx_draft_version"`  // Version, from 1
+	StartTime int64  `json:"start_time" gorm:"not null"`                             // Start time
+	EndTime   int64  `json:"end_time" gorm:"not null"`                               // Expiry time
+	Timezone  string `json:"timezone" gorm:"not null"`                               // Proposal timezone
+	Title     string `json:"title" gorm:"not null,default:''"`                       // Name
+	Content   string `json:"content" gorm:"not null,default:''"`                     // Descriptions
+	Percentage
+}
+
+// DraftNonceTbl records the nonces of the signed draft mutations, so that a signed payload is accepted once.
+type DraftNonceTbl struct {
+	BaseField
+	Address   string `json:"address" gorm:"not null;size:128;uniqueIndex:idx_draft_nonce"` // Address that signed the payload
+	Nonce     string `json:"nonce" gorm:"not null;size:64;uniqueIndex:idx_draft_nonce"`    // Nonce of the payload
+	Timestamp int64  `json:"timestamp" gorm:"not null;index"`                              // Unix time the payload was signed at
+}
@@ -29,13 +29,48 @@ type VoteTbl struct {
 	ChainId          int64  `json:"chain_id" gorm:"not null;uniqueIndex:idx_proposal_address"`                   // Chain ID
 	Address          string `json:"address" gorm:"not null;uniqueIndex:idx_proposal_address"`                    // Voter address
 	VoteEncrypted    string `json:"vote_encrypted" gorm:"type:longtext;not null"`                                // Vote encrypted
-	VoteResult       string `json:"vote_result" gorm:"not null,default:''"`                                      // Vote result ["approve", "reject"]
+	VoteResult       string `json:"vote_result" gorm:"not null,default:''"`                                      // Vote result, one of the proposal vote options
 	SpPower          string `json:"sp_power" gorm:"not null"`                                                    // SP power
 	ClientPower      string `json:"client_power" gorm:"not null"`                                                // Client power
 	TokenHolderPower string `json:"token_holder_power" gorm:"not null"`                                          // Token holder power
 	DeveloperPower   string `json:"developer_power" gorm:"not null"`                                             // Developer power
 	BlockNumber      int64  `json:"block_number" gorm:"not null"`                                                // Proposal created block number
 	Timestamp        int64  `json:"timestamp" gorm:"not null"`                                                   // Proposal create time
+	DrandRound       uint64 `json:"drand_round" gorm:"not null,default:0"`                                       // Drand round that unlocked the vote
+	DrandSignature   string `json:"drand_signature" gorm:"not null,default:''"`                                  // Drand round signature, hex encoded
+	PowerMissing     bool   `json:"power_missing" gorm:"not null,default:false"`                                 // Whether the voter was missing from the snapshot and counted as zero
+	LogIndex         uint   `json:"log_index" gorm:"not null,default:0"`                                         // Index of the vote log in its block
+}
+
+// After reports whether the vote was cast after another vote, by block number then log index.
+func (v VoteTbl) After(other VoteTbl) bool {
+	if v.BlockNumber != other.BlockNumber {
+		return v.BlockNumber > other.BlockNumber
+	}
+
+	return v.LogIndex > other.LogIndex
+}
+
+// A vote joined with the proposal it was cast on
+type VoteWithProposal struct {
+	VoteTbl
+	Title           string `json:"title" gorm:"column:title"`                       // Proposal title
+	ProposalCounted int    `json:"proposal_counted" gorm:"column:proposal_counted"` // Whether the proposal has been counted
+	Outcome         string `json:"outcome" gorm:"column:outcome"`                   // Proposal outcome once counted
+}
+
+// Every vote event of a proposal. A voter can vote again, the latest valid vote is the one in VoteTbl
+type VoteHistoryTbl struct {
+	BaseField
+	ProposalId    int64  `json:"proposal_id" gorm:"not null;index:idx_vote_history_voter"`                               // Proposal ID
+	ChainId       int64  `json:"chain_id" gorm:"not null;uniqueIndex:idx_vote_history_log;index:idx_vote_history_voter"` // Chain ID
+	Address       string `json:"address" gorm:"not null;index:idx_vote_history_voter"`                                   // Voter address
+	VoteEncrypted string `json:"vote_encrypted" gorm:"type:longtext;not null"`                                           // Vote encrypted
+	BlockNumber   int64  `json:"block_number" gorm:"not null;uniqueIndex:idx_vote_history_log"`                          // Vote block number
+	LogIndex      uint   `json:"log_index" gorm:"not null;uniqueIndex:idx_vote_history_log"`                             // Index of the vote log in its block
+	TxHash        string `json:"tx_hash" gorm:"not null,default:''"`                                                     // Vote transaction hash
+	Timestamp     int64  `json:"timestamp" gorm:"not null"`                                                              // Vote time
+	Valid         bool   `json:"valid" gorm:"not null"`                                                                  // Whether the vote was cast before the proposal end time
 }
 
 type VoterInfoTbl struct {
@@ -124,4 +159,53 @@ func (s StringSlice) Value() (driver.Value, error) {
 	}
 
 	return json.Marshal(s)
-}
\ No newline at end of file
+}
+
+type Float64Map map[string]float64
+
+func (m *Float64Map) Scan(src interface{}) error {
+	if src == nil {
+		*m = map[string]float64{}
+		return nil
+	}
+
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupport type: %T", src)
+	}
+
+	return json.Unmarshal(b, m)
+}
+
+func (m Float64Map) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(m)
+}
+
+// StringMap holds a string value for each key, such as the exact decimal percentage of each vote option
+type StringMap map[string]string
+
+func (m *StringMap) Scan(src interface{}) error {
+	if src == nil {
+		*m = map[string]string{}
+		return nil
+	}
+
+	b, ok := src.([]byte)
+	if !ok {
+		return fmt.Errorf("unsupport type: %T", src)
+	}
+
+	return json.Unmarshal(b, m)
+}
+
+func (m StringMap) Value() (driver.Value, error) {
+	if m == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(m)
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// Subscriber of the governance lifecycle events, the subscribed events are posted to its URL
+type WebhookTbl struct {
+	BaseField
+	Url         string      `json:"url" gorm:"not null"`                    // URL the events are posted to
+	Secret      string      `json:"-" gorm:"not null"`                      // Key of the HMAC signature of the deliveries
+	Events      StringSlice `json:"events" gorm:"type:json"`                // Subscribed events
+	ChainId     int64       `json:"chain_id" gorm:"not null;default:0"`     // Chain ID of the subscribed events, 0 for every network
+	Description string      `json:"description" gorm:"not null;default:''"` // Description given by the subscriber
+}
+
+// Subscribes checks whether the webhook is subscribed to an event of a chain.
+func (w WebhookTbl) Subscribes(chainId int64, event string) bool {
+	return (w.ChainId == 0 || w.ChainId == chainId) && slices.Contains(w.Events, event)
+}
+
+// Governance lifecycle event emitted for the webhooks and the stream, an event is emitted once for its subject and block.
+// The ID orders the events, it is the cursor of the stream
+type WebhookEventTbl struct {
+	BaseField
+	ChainId     int64  `json:"chain_id" gorm:"not null;uniqueIndex:idx_webhook_event"`                      // Chain ID
+	ProposalId  int64  `json:"proposal_id" gorm:"not null;default:0;index"`                                 // Proposal ID of the proposal and vote events, 0 for the other events
+	Event       string `json:"event" gorm:"not null;uniqueIndex:idx_webhook_event"`                         // Event name
+	Subject     string `json:"subject" gorm:"not null;uniqueIndex:idx_webhook_event"`                       // What the event is about, such as proposal:12
+	Timestamp   int64  `json:"timestamp" gorm:"not null"`                                                   // Unix time the event happened
+	BlockHash   string `json:"block_hash" gorm:"size:66;not null;default:'';uniqueIndex:idx_webhook_event"` // Hash of the block of the event log that emitted the event, empty for the counting events
+	BlockNumber int64  `json:"block_number" gorm:"not null;default:0;index"`                                // Block of the event log that emitted the event, the block of the proposal for the counting events
+	Data        string `json:"data" gorm:"type:mediumtext;not null"`                                        // JSON of the event data
+	Seq         *int64 `json:"seq" gorm:"uniqueIndex"`                                                      // Stream sequence, assigned in commit order once the event is committed, nil until then
+}
+
+// NewWebhookEvent creates an event for the webhooks with its data encoded as JSON.
+// The proposal ID is 0 for the events that are not about a proposal.
+func NewWebhookEvent(chainId, proposalId int64, event, subject string, timestamp int64, data any) (*WebhookEventTbl, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s data of %s: %w", event, subject, err)
+	}
+
+	return &WebhookEventTbl{
+		ChainId:    chainId,
+		ProposalId: proposalId,
+		Event:      event,
+		Subject:    subject,
+		Timestamp:  timestamp,
+		Data:       string(raw),
+	}, nil
+}
+
+// Delivery of an event to a webhook, kept as the delivery log
+type WebhookDeliveryTbl struct {
+	BaseField
+	WebhookId       int64  `json:"webhook_id" gorm:"not null;index"`                                           // ID of the webhook
+	EventId         int64  `json:"event_id" gorm:"not null"`                                                   // ID of the delivered event
+	Event           string `json:"event" gorm:"not null"`                                                      // Event name
+	Attempts        int    `json:"attempts" gorm:"not null;default:0"`                                         // Number of attempts to post the event
+	Status          int    `json:"status" gorm:"not null;default:0;index:idx_webhook_delivery_due"`            // 0: pending, 1: delivered, 2: exhausted, 3: cancelled
+	NextAttemptTime int64  `json:"next_attempt_time" gorm:"not null;default:0;index:idx_webhook_delivery_due"` // Unix time of the next attempt
+	ResponseStatus  int    `json:"response_status" gorm:"not null;default:0"`                                  // HTTP status of the last attempt, 0 when there was no answer
+	Error           string `json:"error" gorm:"type:text"`                                                     // Error of the last attempt
+	DeliveredTime   int64  `json:"delivered_time" gorm:"not null;default:0"`                                   // Unix time the webhook accepted the event
+}
+
+// WebhookDueDelivery is a delivery to post, with the URL and secret of its webhook and its event
+type WebhookDueDelivery struct {
+	WebhookDeliveryTbl
+	Url       string // URL of the webhook
+	Secret    string // Key of the HMAC signature
+	ChainId   int64  // Chain ID of the event
+	Subject   string // Subject of the event
+	Timestamp int64  // Unix time the event happened
+	Data      string // JSON of the event data
+}
+
+// WebhookPayload is the JSON body posted to a webhook, and the data of a stream event
+type WebhookPayload struct {
+	Id        int64           `json:"id"`        // ID of the event, the same for every delivery of the event
+	Event     string          `json:"event"`     // Event name
+	ChainId   int64           `json:"chainId"`   // Chain ID
+	Subject   string          `json:"subject"`   // What the event is about
+	Timestamp int64           `json:"timestamp"` // Unix time the event happened
+	Data      json.RawMessage `json:"data"`      // Event data
+}
+
+// StreamEvent is an event sent to a stream.
+type StreamEvent struct {
+	Seq     int64          // Stream sequence of the event, the cursor of the stream
+	Payload WebhookPayload // Event as delivered to the webhooks
+}
+
+// WebhookProposalData is the data of the proposal events
+type WebhookProposalData struct {
+	ProposalId        int64      `json:"proposalId"`                  // Proposal ID
+	Creator           string     `json:"creator"`                     // Creator address
+	Title             string     `json:"title"`                       // Proposal title
+	StartTime         int64      `json:"startTime"`                   // Start time of the voting
+	EndTime           int64      `json:"endTime"`                     // End time of the voting
+	SnapshotDay       string     `json:"snapshotDay"`                 // Day of the power snapshot
+	BlockNumber       int64      `json:"blockNumber"`                 // Block of the proposal creation
+	ResultRevision    int64      `json:"resultRevision,omitempty"`    // Revision of the result, once counted
+	Outcome           string     `json:"outcome,omitempty"`           // Outcome, once counted
+	OptionPercentages Float64Map `json:"optionPercentages,omitempty"` // Percentage of votes for each option, once counted
+}
+
+// NewWebhookProposalData returns the webhook data of a proposal.
+func NewWebhookProposalData(proposal ProposalTbl) WebhookProposalData {
+	return WebhookProposalData{
+		ProposalId:        proposal.ProposalId,
+		Creator:           proposal.Creator,
+		Title:             proposal.Title,
+		StartTime:         proposal.StartTime,
+		EndTime:           proposal.EndTime,
+		SnapshotDay:       proposal.SnapshotDay,
+		BlockNumber:       proposal.BlockNumber,
+		ResultRevision:    proposal.ResultRevision,
+		Outcome:           proposal.Outcome,
+		OptionPercentages: proposal.OptionPercentages,
+	}
+}
+
+// WebhookVoteData is the data of the vote events, the vote stays encrypted until the proposal is counted
+type WebhookVoteData struct {
+	ProposalId    int64  `json:"proposalId"`    // Proposal ID
+	Address       string `json:"address"`       // Voter address
+	VoteEncrypted string `json:"voteEncrypted"` // Encrypted vote
+	BlockNumber   int64  `json:"blockNumber"`   // Block of the vote
+	TxHash        string `json:"txHash"`        // Transaction of the vote
+	LogIndex      uint   `json:"logIndex"`      // Log index of the vote in the block
+}
+
+// WebhookFipProposalData is the data of the FIP editor proposal events
+type WebhookFipProposalData struct {
+	ProposalId       int64  `json:"proposalId"`       // FIP proposal ID
+	ProposalType     int    `json:"proposalType"`     // 0: revoke, 1: approve
+	Creator          string `json:"creator"`          // Creator address
+	CandidateAddress string `json:"candidateAddress"` // Address of the editor approved or revoked
+	CandidateInfo    string `json:"candidateInfo"`    // Information about the candidate
+	BlockNumber      int64  `json:"blockNumber"`      // Block of the proposal creation
+}
+
+// NewWebhookFipProposalData returns the webhook data of a FIP editor proposal.
+func NewWebhookFipProposalData(proposal FipProposalTbl) WebhookFipProposalData {
+	return WebhookFipProposalData{
+		ProposalId:       proposal.ProposalId,
+		ProposalType:     proposal.ProposalType,
+		Creator:          proposal.Creator,
+		CandidateAddress: proposal.CandidateAddress,
+		CandidateInfo:    proposal.CandidateInfo,
+		BlockNumber:      proposal.BlockNumber,
+	}
+}
+
+// WebhookVoterData is the data of the voter identity events
+type WebhookVoterData struct {
+	Address     string `json:"address"`     // Voter address
+	GistId      string `json:"gistId"`      // GitHub gist proving the identity
+	GithubName  string `json:"githubName"`  // GitHub account of the gist
+	BlockNumber int64  `json:"blockNumber"` // Block of the binding
+}
@@ -0,0 +1,226 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+)
+
+// GenerateClient generates the Go source of a typed client of the document, in package pkg.
+// It declares a struct for every component schema, a parameters struct for every operation with query parameters,
+// and a method of Client for every operation answering the JSON envelope. Streams and downloads are left out.
+// The Client type and its do method, which sends a request and decodes the data of the envelope, are written by hand next to it.
+func GenerateClient(doc *Document, pkg string) ([]byte, error) {
+	var code bytes.Buffer
+	names := make([]string, 0, len(doc.Components.Schemas))
+	for name := range doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		writeStruct(&code, name, doc.Components.Schemas[name])
+	}
+
+	for _, op := range doc.operations() {
+		if err := writeOperation(&code, op); err != nil {
+			return nil, err
+		}
+	}
+
+	var b bytes.Buffer
+	b.WriteString("// Code generated by powervoting-server/openapi/gen from the OpenAPI document. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\nimport (\n", pkg)
+	for _, imp := range []struct{ path, use string }{
+		{"context", "context.Context"},
+		{"encoding/json", "json.RawMessage"},
+		{"fmt", "fmt.Sprint"},
+		{"net/url", "url.Values"},
+		{"time", "time.Time"},
+	} {
+		if bytes.Contains(code.Bytes(), []byte(imp.use)) {
+			fmt.Fprintf(&b, "\t%q\n", imp.path)
+		}
+	}
+	b.WriteString(")\n\n")
+	b.Write(code.Bytes())
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated client: %w", err)
+	}
+
+	return src, nil
+}
+
+// writeStruct declares the struct of an object schema, with the fields in the order of the Go struct it was built from.
+func writeStruct(b *bytes.Buffer, name string, s *Schema) {
+	fmt.Fprintf(b, "type %s struct {\n", exportedName(name))
+	for _, prop := range s.order {
+		fmt.Fprintf(b, "\t%s %s `json:%q`\n", exportedName(prop), fieldType(s.Properties[prop]), prop)
+	}
+	b.WriteString("}\n\n")
+}
+
+// writeOperation writes the parameters struct and the client method of an operation.
+func writeOperation(b *bytes.Buffer, op *Operation) error {
+	ok := op.Responses["200"]
+	if ok == nil || ok.Content[jsonContentType] == nil {
+		return nil
+	}
+	envelope := ok.Content[jsonContentType].Schema
+
+	args := []string{"ctx context.Context"}
+	if len(op.Parameters) > 0 {
+		fmt.Fprintf(b, "// %sParams holds the query parameters of %s.\n", op.OperationId, op.OperationId)
+		fmt.Fprintf(b, "type %sParams struct {\n", op.OperationId)
+		for _, param := range op.Parameters {
+			fmt.Fprintf(b, "\t%s %s\n", exportedName(param.Name), fieldType(param.Schema))
+		}
+		b.WriteString("}\n\n")
+		args = append(args, "params "+op.OperationId+"Params")
+	}
+
+	body := "nil"
+	if op.RequestBody != nil {
+		media := op.RequestBody.Content[jsonContentType]
+		if media == nil {
+			return fmt.Errorf("operation %s has no JSON request body", op.OperationId)
+		}
+		args = append(args, "body "+goType(media.Schema))
+		body = "body"
+	}
+
+	data := envelope.Properties["data"]
+	result, zero := "", ""
+	if data != nil {
+		result = goType(data)
+		if data.Ref != "" {
+			result = "*" + result
+		}
+		zero = "nil"
+		if data.Ref == "" {
+			zero = "data"
+		}
+	}
+
+	if op.Summary != "" {
+		fmt.Fprintf(b, "// %s calls %s %s: %s.\n", op.OperationId, op.method, op.path, op.Summary)
+	} else {
+		fmt.Fprintf(b, "// %s calls %s %s.\n", op.OperationId, op.method, op.path)
+	}
+	if data == nil {
+		fmt.Fprintf(b, "func (c *Client) %s(%s) error {\n", op.OperationId, strings.Join(args, ", "))
+	} else {
+		fmt.Fprintf(b, "func (c *Client) %s(%s) (%s, error) {\n", op.OperationId, strings.Join(args, ", "), result)
+	}
+
+	query := "nil"
+	if len(op.Parameters) > 0 {
+		query = "query"
+		b.WriteString("\tquery := url.Values{}\n")
+		for _, param := range op.Parameters {
+			writeQueryParam(b, param)
+		}
+	}
+
+	if data == nil {
+		fmt.Fprintf(b, "\treturn c.do(ctx, %q, %q, %s, %s, nil)\n}\n\n", op.method, op.path, query, body)
+		return nil
+	}
+
+	fmt.Fprintf(b, "\tvar data %s\n", goType(data))
+	fmt.Fprintf(b, "\tif err := c.do(ctx, %q, %q, %s, %s, &data); err != nil {\n\t\treturn %s, err\n\t}\n", op.method, op.path, query, body, zero)
+	if data.Ref != "" {
+		b.WriteString("\treturn &data, nil\n}\n\n")
+	} else {
+		b.WriteString("\treturn data, nil\n}\n\n")
+	}
+
+	return nil
+}
+
+// writeQueryParam sets a query parameter: a required one always, an optional one when it is not its zero value,
+// or when it is set for a nullable parameter, whose zero value is meaningful.
+func writeQueryParam(b *bytes.Buffer, param *Parameter) {
+	field := "params." + exportedName(param.Name)
+	switch {
+	case param.Schema.Nullable:
+		fmt.Fprintf(b, "\tif %s != nil {\n\t\tquery.Set(%q, fmt.Sprint(*%s))\n\t}\n", field, param.Name, field)
+	case param.Required:
+		fmt.Fprintf(b, "\tquery.Set(%q, fmt.Sprint(%s))\n", param.Name, field)
+	default:
+		fmt.Fprintf(b, "\tif %s != %s {\n\t\tquery.Set(%q, fmt.Sprint(%s))\n\t}\n", field, zeroValue(param.Schema), param.Name, field)
+	}
+}
+
+// fieldType returns the Go type of a struct field or a query parameter, a pointer for a nullable one.
+func fieldType(s *Schema) string {
+	if s.Nullable {
+		return "*" + goType(s)
+	}
+
+	return goType(s)
+}
+
+// goType returns the Go type of a schema.
+func goType(s *Schema) string {
+	if s.Ref != "" {
+		return exportedName(strings.TrimPrefix(s.Ref, schemaRefPrefix))
+	}
+
+	switch s.Type {
+	case "boolean":
+		return "bool"
+	case "integer":
+		if s.Format == "int64" {
+			return "int64"
+		}
+		return "int"
+	case "number":
+		return "float64"
+	case "string":
+		switch s.Format {
+		case "byte":
+			return "[]byte"
+		case "date-time":
+			return "time.Time"
+		}
+		return "string"
+	case "array":
+		return "[]" + goType(s.Items)
+	case "object":
+		if s.AdditionalProperties != nil {
+			return "map[string]" + goType(s.AdditionalProperties)
+		}
+	}
+
+	return "json.RawMessage"
+}
+
+// zeroValue returns the Go zero value of a scalar schema.
+func zeroValue(s *Schema) string {
+	switch s.Type {
+	case "boolean":
+		return "false"
+	case "integer", "number":
+		return "0"
+	default:
+		return `""`
+	}
+}
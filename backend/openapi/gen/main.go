@@ -0,0 +1,58 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command gen writes the typed Go client of the REST API, and optionally its OpenAPI document, from the routes of the router.
+//
+//	go run ./openapi/gen -out ../cli/client/client_gen.go -spec openapi.json
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"powervoting-server/openapi"
+	"powervoting-server/router"
+)
+
+func main() {
+	out := flag.String("out", "", "file the generated client is written to")
+	pkg := flag.String("package", "client", "package of the generated client")
+	spec := flag.String("spec", "", "file the OpenAPI document is written to, not written by default")
+	flag.Parse()
+
+	if *out == "" {
+		log.Fatal("-out is required")
+	}
+
+	doc := router.OpenAPI()
+	src, err := openapi.GenerateClient(doc, *pkg)
+	if err != nil {
+		log.Fatalf("generate client: %v", err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatalf("write client: %v", err)
+	}
+
+	if *spec != "" {
+		data, err := json.MarshalIndent(doc, "", "  ")
+		if err != nil {
+			log.Fatalf("marshal OpenAPI document: %v", err)
+		}
+		if err := os.WriteFile(*spec, append(data, '\n'), 0o644); err != nil {
+			log.Fatalf("write OpenAPI document: %v", err)
+		}
+	}
+}
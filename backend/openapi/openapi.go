@@ -0,0 +1,217 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package openapi describes the REST API as an OpenAPI 3 document built from the request and response structs
+// of the handlers, and generates a typed Go client from it.
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// Route describes a route of the REST API.
+type Route struct {
+	Method   string // HTTP method
+	Path     string // Path relative to the API prefix
+	Id       string // Operation ID, also the name of the client method
+	Summary  string // What the route does
+	Tag      string // Group of the route
+	Request  any    // Request struct: query parameters for GET and DELETE, JSON body otherwise; nil when there is none
+	Response any    // Data of a successful response, nil when it has none. The value of an interface field sets its schema
+	Auth     bool   // Whether the route needs the bearer token
+	Produces string // Content type of a response that is not the JSON envelope, such as a stream
+}
+
+// Document is an OpenAPI 3 document.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+type Server struct {
+	Url string `json:"url"`
+}
+
+// PathItem holds the operations of a path, indexed by lower case HTTP method.
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationId string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+
+	method string // HTTP method
+	path   string // Path relative to the server URL
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme"`
+}
+
+// Schema is the subset of the OpenAPI schema object needed by the structs of the API.
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+
+	order []string // Property names in the order of the struct fields
+}
+
+const (
+	schemaRefPrefix = "#/components/schemas/"
+	bearerAuth      = "bearerAuth"
+	jsonContentType = "application/json"
+)
+
+// New builds the OpenAPI document of the routes, served under serverUrl.
+func New(info Info, serverUrl string, routes []Route) *Document {
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Servers: []Server{{Url: serverUrl}},
+		Paths:   make(map[string]*PathItem),
+	}
+	gen := newSchemaGen()
+
+	for _, route := range routes {
+		op := &Operation{
+			OperationId: route.Id,
+			Summary:     route.Summary,
+			Responses:   make(map[string]*Response),
+			method:      route.Method,
+			path:        route.Path,
+		}
+		if route.Tag != "" {
+			op.Tags = []string{route.Tag}
+		}
+
+		if route.Request != nil {
+			if route.Method == http.MethodGet || route.Method == http.MethodDelete {
+				op.Parameters = gen.queryParameters(reflect.TypeOf(route.Request))
+			} else {
+				op.RequestBody = &RequestBody{
+					Required: true,
+					Content:  map[string]*MediaType{jsonContentType: {Schema: gen.schema(reflect.ValueOf(route.Request))}},
+				}
+			}
+		}
+
+		if route.Produces != "" {
+			op.Responses["200"] = &Response{
+				Description: "success",
+				Content:     map[string]*MediaType{route.Produces: {Schema: &Schema{Type: "string"}}},
+			}
+		} else {
+			envelope := &Schema{
+				Type: "object",
+				Properties: map[string]*Schema{
+					"code":    {Type: "integer", Format: "int32"},
+					"message": {Type: "string"},
+				},
+				order: []string{"code", "message"},
+			}
+			if route.Response != nil {
+				envelope.Properties["data"] = gen.schema(reflect.ValueOf(route.Response))
+				envelope.order = append(envelope.order, "data")
+			}
+			op.Responses["200"] = &Response{
+				Description: "success, or an error described by code and message",
+				Content:     map[string]*MediaType{jsonContentType: {Schema: envelope}},
+			}
+		}
+
+		if route.Auth {
+			op.Security = []map[string][]string{{bearerAuth: {}}}
+			doc.Components.SecuritySchemes = map[string]*SecurityScheme{bearerAuth: {Type: "http", Scheme: "bearer"}}
+		}
+
+		item, ok := doc.Paths[route.Path]
+		if !ok {
+			item = &PathItem{}
+			doc.Paths[route.Path] = item
+		}
+		(*item)[strings.ToLower(route.Method)] = op
+	}
+	doc.Components.Schemas = gen.schemas
+
+	return doc
+}
+
+// operations returns the operations of the document sorted by operation ID.
+func (d *Document) operations() []*Operation {
+	var ops []*Operation
+	for _, item := range d.Paths {
+		for _, op := range *item {
+			ops = append(ops, op)
+		}
+	}
+	sortBy(ops, func(op *Operation) string { return op.OperationId })
+
+	return ops
+}
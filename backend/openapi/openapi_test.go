@@ -0,0 +1,119 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"go/parser"
+	"go/token"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testPage struct {
+	Page     int `form:"page" validate:"required,gt=0"`
+	PageSize int `form:"pageSize" validate:"required,lte=100"`
+}
+
+type testListReq struct {
+	testPage
+	ChainId int64  `form:"chainId" validate:"required"`
+	Status  string `form:"status" validate:"omitempty,oneof=open closed"`
+}
+
+type testAddReq struct {
+	Title   string   `json:"title" validate:"required,max=200"`
+	Options []string `json:"options" validate:"omitempty,min=2,dive,required"`
+}
+
+type testItem struct {
+	Id        int64     `json:"id"`
+	CreatedAt time.Time `json:"createdAt"`
+	Note      *string   `json:"note"`
+}
+
+type testList struct {
+	Total int64 `json:"total"`
+	List  any   `json:"list"`
+}
+
+var testRoutes = []Route{
+	{Method: http.MethodGet, Path: "/item/list", Id: "GetItemList", Tag: "item", Request: testListReq{}, Response: testList{List: []testItem{}}},
+	{Method: http.MethodPost, Path: "/item/add", Id: "AddItem", Tag: "item", Request: testAddReq{}, Response: testItem{}, Auth: true},
+	{Method: http.MethodGet, Path: "/item/export", Id: "ExportItems", Tag: "item", Produces: "text/csv"},
+}
+
+func TestNew(t *testing.T) {
+	doc := New(Info{Title: "test", Version: "1"}, "/api", testRoutes)
+
+	list := (*doc.Paths["/item/list"])["get"]
+	params := map[string]*Parameter{}
+	for _, param := range list.Parameters {
+		assert.Equal(t, "query", param.In)
+		params[param.Name] = param
+	}
+	// the fields of the embedded struct are parameters too
+	assert.Len(t, params, 4)
+	assert.True(t, params["page"].Required)
+	assert.Equal(t, 0.0, *params["page"].Schema.Minimum)
+	assert.True(t, params["page"].Schema.ExclusiveMinimum)
+	assert.Equal(t, 100.0, *params["pageSize"].Schema.Maximum)
+	assert.Equal(t, "int64", params["chainId"].Schema.Format)
+	assert.False(t, params["status"].Required)
+	assert.Equal(t, []any{"open", "closed"}, params["status"].Schema.Enum)
+
+	// the interface field of the response is described by the value set in the route
+	data := list.Responses["200"].Content[jsonContentType].Schema.Properties["data"]
+	assert.Equal(t, schemaRefPrefix+"testListOfTestItem", data.Ref)
+	items := doc.Components.Schemas["testListOfTestItem"].Properties["list"]
+	assert.Equal(t, "array", items.Type)
+	assert.Equal(t, schemaRefPrefix+"testItem", items.Items.Ref)
+	item := doc.Components.Schemas["testItem"]
+	assert.Equal(t, "date-time", item.Properties["createdAt"].Format)
+	assert.True(t, item.Properties["note"].Nullable)
+
+	add := (*doc.Paths["/item/add"])["post"]
+	assert.Empty(t, add.Parameters)
+	assert.Equal(t, []map[string][]string{{bearerAuth: {}}}, add.Security)
+	body := doc.Components.Schemas["testAddReq"]
+	assert.Equal(t, []string{"title"}, body.Required)
+	assert.Equal(t, 200, *body.Properties["title"].MaxLength)
+	assert.Equal(t, 2, *body.Properties["options"].MinItems)
+
+	export := (*doc.Paths["/item/export"])["get"]
+	assert.Equal(t, "string", export.Responses["200"].Content["text/csv"].Schema.Type)
+}
+
+func TestGenerateClient(t *testing.T) {
+	src, err := GenerateClient(New(Info{Title: "test", Version: "1"}, "/api", testRoutes), "client")
+	assert.NoError(t, err)
+
+	file, err := parser.ParseFile(token.NewFileSet(), "client_gen.go", src, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "client", file.Name.Name)
+
+	code := string(src)
+	assert.True(t, strings.HasPrefix(code, "// Code generated"))
+	assert.Contains(t, code, "type GetItemListParams struct")
+	assert.Contains(t, code, "func (c *Client) GetItemList(ctx context.Context, params GetItemListParams) (*TestListOfTestItem, error)")
+	assert.Contains(t, code, "CreatedAt time.Time `json:\"createdAt\"`")
+	assert.Contains(t, code, "Note      *string   `json:\"note\"`")
+	assert.Contains(t, code, "func (c *Client) AddItem(ctx context.Context, body TestAddReq) (*TestItem, error)")
+	// operations that do not answer the JSON envelope have no method
+	assert.NotContains(t, code, "ExportItems")
+}
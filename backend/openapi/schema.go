@@ -0,0 +1,345 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	rawMessageType = reflect.TypeOf(json.RawMessage{})
+	marshalerType  = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// schemaGen builds the schemas of Go values, named structs are kept as components.
+type schemaGen struct {
+	schemas map[string]*Schema
+	names   map[string]reflect.Type // type of each component name, to tell apart structs of the same name
+}
+
+func newSchemaGen() *schemaGen {
+	return &schemaGen{
+		schemas: make(map[string]*Schema),
+		names:   make(map[string]reflect.Type),
+	}
+}
+
+// schema returns the schema of a value. The type of the value decides the schema,
+// except for interface fields whose value, when set, decides their schema.
+func (g *schemaGen) schema(v reflect.Value) *Schema {
+	t := v.Type()
+	nullable := false
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+		nullable = true
+		if v.IsValid() && !v.IsNil() {
+			v = v.Elem()
+		} else {
+			v = reflect.Value{}
+		}
+	}
+
+	s := g.typeSchema(t, v)
+	if nullable && s.Ref == "" {
+		s.Nullable = true
+	}
+
+	return s
+}
+
+func (g *schemaGen) typeSchema(t reflect.Type, v reflect.Value) *Schema {
+	switch {
+	case t == timeType:
+		return &Schema{Type: "string", Format: "date-time"}
+	case t == rawMessageType:
+		return &Schema{}
+	case t.Kind() == reflect.Struct && t.Implements(marshalerType):
+		// Values such as decimals are marshaled as strings
+		return &Schema{Type: "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int, reflect.Uint:
+		return &Schema{Type: "integer"}
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		var elem reflect.Value
+		if v.IsValid() && v.Len() > 0 {
+			elem = v.Index(0)
+		}
+		return &Schema{Type: "array", Items: g.schema(valueOrZero(t.Elem(), elem))}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(reflect.Zero(t.Elem()))}
+	case reflect.Interface:
+		if v.IsValid() && !v.IsNil() {
+			return g.schema(v.Elem())
+		}
+		return &Schema{}
+	case reflect.Struct:
+		return g.structSchema(t, v)
+	default:
+		return &Schema{}
+	}
+}
+
+// structSchema returns a reference to the component of a named struct, an anonymous struct is inlined.
+// A struct whose interface fields are set is a component of its own, named after the types of the values,
+// such as CountListRepOfProposalRep for a CountListRep holding a list of ProposalRep.
+func (g *schemaGen) structSchema(t reflect.Type, v reflect.Value) *Schema {
+	if t.Name() == "" {
+		return g.objectSchema(t, v)
+	}
+
+	name := t.Name()
+	if suffix := interfaceTypeNames(t, v); suffix != "" {
+		name += "Of" + suffix
+	}
+	// Structs of the same name in another package are prefixed with their package name
+	if other, ok := g.names[name]; ok && other != t {
+		pkg := t.PkgPath()
+		name = exportedName(pkg[strings.LastIndex(pkg, "/")+1:]) + name
+	}
+
+	if _, ok := g.schemas[name]; !ok {
+		g.names[name] = t
+		// Registered before its fields, so that a recursive struct refers to itself
+		g.schemas[name] = &Schema{}
+		*g.schemas[name] = *g.objectSchema(t, v)
+	}
+
+	return &Schema{Ref: schemaRefPrefix + name}
+}
+
+// objectSchema returns the schema of the JSON object of a struct, the fields of embedded structs are flattened.
+func (g *schemaGen) objectSchema(t reflect.Type, v reflect.Value) *Schema {
+	s := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	g.addFields(s, t, v)
+
+	return s
+}
+
+func (g *schemaGen) addFields(s *Schema, t reflect.Type, v reflect.Value) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, ok := fieldName(field, "json")
+		if !ok {
+			continue
+		}
+
+		var fv reflect.Value
+		if v.IsValid() {
+			fv = v.Field(i)
+		}
+
+		if field.Anonymous && field.Tag.Get("json") == "" && field.Type.Kind() == reflect.Struct {
+			g.addFields(s, field.Type, fv)
+			continue
+		}
+
+		fs := g.schema(valueOrZero(field.Type, fv))
+		required := applyValidation(fs, field)
+		if _, dup := s.Properties[name]; !dup {
+			s.order = append(s.order, name)
+		}
+		s.Properties[name] = fs
+		if required {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// queryParameters returns the query parameters of a request struct, from the fields with a form tag.
+func (g *schemaGen) queryParameters(t reflect.Type) []*Parameter {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	var params []*Parameter
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			params = append(params, g.queryParameters(field.Type)...)
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("form"), ",")
+		if name == "" || name == "-" || !field.IsExported() {
+			continue
+		}
+
+		schema := g.schema(reflect.Zero(field.Type))
+		params = append(params, &Parameter{
+			Name:     name,
+			In:       "query",
+			Required: applyValidation(schema, field),
+			Schema:   schema,
+		})
+	}
+
+	return params
+}
+
+// fieldName returns the name of a struct field in JSON, from its tag or, for request fields
+// that are only bound from the query, its form tag. Unexported and ignored fields have no name.
+func fieldName(field reflect.StructField, tag string) (string, bool) {
+	if !field.IsExported() && !field.Anonymous {
+		return "", false
+	}
+
+	name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		name, _, _ = strings.Cut(field.Tag.Get("form"), ",")
+	}
+	if name == "" || name == "-" {
+		name = field.Name
+	}
+
+	return name, true
+}
+
+// applyValidation adds the constraints of the validate tag of a field to its schema
+// and reports whether the field is required. The rules after dive apply to the elements and are ignored.
+func applyValidation(s *Schema, field reflect.StructField) bool {
+	required := false
+	for _, rule := range strings.Split(field.Tag.Get("validate"), ",") {
+		key, value, _ := strings.Cut(rule, "=")
+		switch key {
+		case "dive":
+			return required
+		case "required":
+			required = true
+		case "oneof":
+			for _, option := range strings.Fields(value) {
+				if n, err := strconv.ParseInt(option, 10, 64); err == nil && s.Type == "integer" {
+					s.Enum = append(s.Enum, n)
+				} else {
+					s.Enum = append(s.Enum, option)
+				}
+			}
+		case "gt", "gte":
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				s.Minimum = &n
+				s.ExclusiveMinimum = key == "gt"
+			}
+		case "lte":
+			if n, err := strconv.ParseFloat(value, 64); err == nil {
+				s.Maximum = &n
+			}
+		case "min", "max":
+			n, err := strconv.Atoi(value)
+			if err != nil {
+				continue
+			}
+			applyBound(s, key == "min", n)
+		}
+	}
+
+	return required
+}
+
+// applyBound sets the minimum or maximum of a field, a length for strings and a count for arrays.
+func applyBound(s *Schema, isMin bool, n int) {
+	switch s.Type {
+	case "string":
+		if isMin {
+			s.MinLength = &n
+		} else {
+			s.MaxLength = &n
+		}
+	case "array":
+		if isMin {
+			s.MinItems = &n
+		} else {
+			s.MaxItems = &n
+		}
+	default:
+		f := float64(n)
+		if isMin {
+			s.Minimum = &f
+		} else {
+			s.Maximum = &f
+		}
+	}
+}
+
+// interfaceTypeNames returns the names of the types of the values set in the interface fields of a struct.
+func interfaceTypeNames(t reflect.Type, v reflect.Value) string {
+	if !v.IsValid() {
+		return ""
+	}
+
+	var names []string
+	for i := 0; i < t.NumField(); i++ {
+		fv := v.Field(i)
+		if t.Field(i).Type.Kind() != reflect.Interface || fv.IsNil() {
+			continue
+		}
+
+		et := fv.Elem().Type()
+		for et.Kind() == reflect.Pointer || et.Kind() == reflect.Slice || et.Kind() == reflect.Array {
+			et = et.Elem()
+		}
+		names = append(names, exportedName(et.Name()))
+	}
+
+	return strings.Join(names, "And")
+}
+
+// valueOrZero returns v, or the zero value of t when v is not set.
+func valueOrZero(t reflect.Type, v reflect.Value) reflect.Value {
+	if v.IsValid() {
+		return v
+	}
+
+	return reflect.Zero(t)
+}
+
+// exportedName converts a JSON or package name to an exported Go name, such as proposalId to ProposalId.
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' || r == '.' }) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+
+	return b.String()
+}
+
+// sortBy sorts a slice by a string key.
+func sortBy[T any](s []T, key func(T) string) {
+	sort.Slice(s, func(i, j int) bool { return key(s[i]) < key(s[j]) })
+}
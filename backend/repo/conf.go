@@ -0,0 +1,218 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"powervoting-server/model"
+	"powervoting-server/model/api"
+	"powervoting-server/service"
+)
+
+type ConfRepoImpl struct {
+	mydb *gorm.DB
+}
+
+var _ service.ConfRepo = (*ConfRepoImpl)(nil)
+
+func NewConfRepo(mydb *gorm.DB) *ConfRepoImpl {
+	return &ConfRepoImpl{mydb: mydb}
+}
+
+// CreateGithubRepo creates a GitHub repository record, or lists again a repository with the same ID that was removed.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values, cancellation signals, and deadlines.
+//   - in: The repository added on the contract.
+//
+// Returns:
+//   - error: An error object if the operation fails.
+func (c *ConfRepoImpl) CreateGithubRepo(ctx context.Context, in *model.GithubRepoTbl) error {
+	if err := conn(ctx, c.mydb).Model(&model.GithubRepoTbl{}).
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "chain_id"}, {Name: "repo_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"repo_name",
+				"org_type",
+				"removed",
+				"removed_block_number",
+				"block_number",
+				"timestamp",
+				"updated_at",
+			}),
+		}).
+		Create(in).Error; err != nil {
+		return fmt.Errorf("create github repo error: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveGithubRepo marks a GitHub repository as removed at a block.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values, cancellation signals, and deadlines.
+//   - chainId: The chain ID of the repository.
+//   - repoId: The ID of the repository on the contract.
+//   - blockNumber: The block number of the removal.
+//
+// Returns:
+//   - error: An error object if the operation fails.
+func (c *ConfRepoImpl) RemoveGithubRepo(ctx context.Context, chainId, repoId, blockNumber int64) error {
+	if err := conn(ctx, c.mydb).Model(&model.GithubRepoTbl{}).
+		WithContext(ctx).
+		Where("chain_id = ? AND repo_id = ?", chainId, repoId).
+		Updates(map[string]any{
+			"removed":              true,
+			"removed_block_number": blockNumber,
+		}).Error; err != nil {
+		return fmt.Errorf("remove github repo error: %w", err)
+	}
+
+	return nil
+}
+
+// CreateSnapshotHeight creates the snapshot height of a day, or replaces the height already set for the day.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values, cancellation signals, and deadlines.
+//   - in: The snapshot height set on the contract.
+//
+// Returns:
+//   - error: An error object if the operation fails.
+func (c *ConfRepoImpl) CreateSnapshotHeight(ctx context.Context, in *model.SnapshotHeightTbl) error {
+	if err := conn(ctx, c.mydb).Model(&model.SnapshotHeightTbl{}).
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "chain_id"}, {Name: "day"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"height",
+				"block_number",
+				"timestamp",
+				"updated_at",
+			}),
+		}).
+		Create(in).Error; err != nil {
+		return fmt.Errorf("create snapshot height error: %w", err)
+	}
+
+	return nil
+}
+
+// CreateConfChange records a change of a contract setting. A change already recorded for the same event log is ignored.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values, cancellation signals, and deadlines.
+//   - in: The setting change.
+//
+// Returns:
+//   - error: An error object if the operation fails.
+func (c *ConfRepoImpl) CreateConfChange(ctx context.Context, in *model.ConfChangeTbl) error {
+	if err := conn(ctx, c.mydb).Model(&model.ConfChangeTbl{}).
+		WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(in).Error; err != nil {
+		return fmt.Errorf("create conf change error: %w", err)
+	}
+
+	return nil
+}
+
+// GetGithubRepoList retrieves the GitHub repositories of a chain ordered by repository ID.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values, cancellation signals, and deadlines.
+//   - req: The chain ID and whether to include the removed repositories.
+//
+// Returns:
+//   - []model.GithubRepoTbl: The repositories.
+//   - error: An error object if the operation fails.
+func (c *ConfRepoImpl) GetGithubRepoList(ctx context.Context, req api.GithubRepoListReq) ([]model.GithubRepoTbl, error) {
+	var list []model.GithubRepoTbl
+	query := conn(ctx, c.mydb).Model(&model.GithubRepoTbl{}).
+		WithContext(ctx).
+		Where("chain_id = ?", req.ChainId)
+	if !req.IncludeRemoved {
+		query = query.Where("removed = ?", false)
+	}
+
+	if err := query.Order("repo_id").Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("get github repo list error: %w", err)
+	}
+
+	return list, nil
+}
+
+// GetSnapshotHeightListWithPagination retrieves a page of the snapshot heights of a chain, the most recent day first.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values, cancellation signals, and deadlines.
+//   - req: The chain ID and the pagination.
+//
+// Returns:
+//   - []model.SnapshotHeightTbl: The page of snapshot heights.
+//   - int64: The total count of snapshot heights of the chain.
+//   - error: An error object if the operation fails.
+func (c *ConfRepoImpl) GetSnapshotHeightListWithPagination(ctx context.Context, req api.SnapshotHeightListReq) ([]model.SnapshotHeightTbl, int64, error) {
+	var (
+		list  []model.SnapshotHeightTbl
+		total int64
+	)
+	query := conn(ctx, c.mydb).Model(&model.SnapshotHeightTbl{}).
+		WithContext(ctx).
+		Where("chain_id = ?", req.ChainId)
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count snapshot heights error: %w", err)
+	}
+
+	offset := req.Offset()
+	if err := query.Order("day desc").
+		Limit(req.PageSize).
+		Offset(offset).
+		Find(&list).Error; err != nil {
+		return nil, 0, fmt.Errorf("get snapshot height list error: %w", err)
+	}
+
+	return list, total, nil
+}
+
+// GetConfChangeList retrieves the changes of a contract setting of a chain in the order they were made.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values, cancellation signals, and deadlines.
+//   - chainId: The chain ID of the changes.
+//   - setting: The changed setting.
+//
+// Returns:
+//   - []model.ConfChangeTbl: The changes, the oldest first.
+//   - error: An error object if the operation fails.
+func (c *ConfRepoImpl) GetConfChangeList(ctx context.Context, chainId int64, setting string) ([]model.ConfChangeTbl, error) {
+	var list []model.ConfChangeTbl
+	if err := conn(ctx, c.mydb).Model(&model.ConfChangeTbl{}).
+		WithContext(ctx).
+		Where("chain_id = ? AND setting = ?", chainId, setting).
+		Order("block_number, log_index").
+		Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("get conf change list error: %w", err)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,108 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+	"powervoting-server/model/api"
+	"powervoting-server/service"
+)
+
+type ExportRepoImpl struct {
+	mydb *gorm.DB
+}
+
+var _ service.ExportRepo = (*ExportRepoImpl)(nil)
+
+func NewExportRepo(mydb *gorm.DB) *ExportRepoImpl {
+	return &ExportRepoImpl{mydb: mydb}
+}
+
+// ExportProposals reads the proposals of a network created in the time range of the export, one batch at a time.
+func (e *ExportRepoImpl) ExportProposals(ctx context.Context, req api.ExportReq, fn func([]model.ProposalTbl) error) error {
+	query := exportQuery(ctx, e.mydb, model.ProposalTbl{}, req)
+	if err := exportInBatches(query, fn); err != nil {
+		return fmt.Errorf("export proposals error: %w", err)
+	}
+
+	return nil
+}
+
+// ExportVotes reads the counted votes of a network cast in the time range of the export, one batch at a time.
+func (e *ExportRepoImpl) ExportVotes(ctx context.Context, req api.ExportReq, fn func([]model.VoteTbl) error) error {
+	query := exportQuery(ctx, e.mydb, model.VoteTbl{}, req).Where("vote_result != ''")
+	if err := exportInBatches(query, fn); err != nil {
+		return fmt.Errorf("export votes error: %w", err)
+	}
+
+	return nil
+}
+
+// ExportFipProposals reads the FIP editor proposals of a network created in the time range of the export, one batch at a time.
+func (e *ExportRepoImpl) ExportFipProposals(ctx context.Context, req api.ExportReq, fn func([]model.FipProposalTbl) error) error {
+	query := exportQuery(ctx, e.mydb, model.FipProposalTbl{}, req)
+	if err := exportInBatches(query, fn); err != nil {
+		return fmt.Errorf("export fip proposals error: %w", err)
+	}
+
+	return nil
+}
+
+// ExportFipVotes reads the votes on the FIP editor proposals of a network cast in the time range of the export, one batch at a time.
+func (e *ExportRepoImpl) ExportFipVotes(ctx context.Context, req api.ExportReq, fn func([]model.FipProposalVoteTbl) error) error {
+	query := exportQuery(ctx, e.mydb, model.FipProposalVoteTbl{}, req)
+	if err := exportInBatches(query, fn); err != nil {
+		return fmt.Errorf("export fip votes error: %w", err)
+	}
+
+	return nil
+}
+
+// ExportVoters reads the voters of a network with a bound identity, updated in the time range of the export, one batch at a time.
+func (e *ExportRepoImpl) ExportVoters(ctx context.Context, req api.ExportReq, fn func([]model.VoterInfoTbl) error) error {
+	query := exportQuery(ctx, e.mydb, model.VoterInfoTbl{}, req).Where("gist_id != ''")
+	if err := exportInBatches(query, fn); err != nil {
+		return fmt.Errorf("export voters error: %w", err)
+	}
+
+	return nil
+}
+
+// exportQuery filters a table by the chain ID and the time range of an export.
+func exportQuery(ctx context.Context, db *gorm.DB, table any, req api.ExportReq) *gorm.DB {
+	query := conn(ctx, db).Model(table).
+		WithContext(ctx).
+		Where("chain_id = ? AND timestamp >= ?", req.ChainId, req.From)
+	if req.To > 0 {
+		query = query.Where("timestamp <= ?", req.To)
+	}
+
+	return query
+}
+
+// exportInBatches passes the rows of a query to fn in batches of ExportBatchSize, in the order of their IDs,
+// so that an export never holds more than one batch in memory.
+func exportInBatches[T any](query *gorm.DB, fn func([]T) error) error {
+	var batch []T
+	return query.FindInBatches(&batch, constant.ExportBatchSize, func(_ *gorm.DB, _ int) error {
+		return fn(batch)
+	}).Error
+}
@@ -55,7 +55,10 @@ func (f *FipRepoImpl) CreateFipProposal(ctx context.Context, in *model.FipPropos
 	// Use the database context to execute the operation with the provided context.
 	// The `Clauses` method is used to specify the behavior on conflict.
 	// In this case, if a record with the same `proposal_id` exists, the specified fields will be updated.
-	if err := f.mydb.Model(&model.FipProposalTbl{}).WithContext(ctx).Clauses(
+	if err := saveUndo[model.FipProposalTbl](ctx, f.mydb, in.ChainId, "proposal_id = ?", in.ProposalId); err != nil {
+		return 0, err
+	}
+	if err := conn(ctx, f.mydb).Model(&model.FipProposalTbl{}).WithContext(ctx).Clauses(
 		clause.OnConflict{
 			Columns: []clause.Column{{Name: "proposal_id"}, {Name: "chain_id"}},
 			DoUpdates: clause.AssignmentColumns([]string{
@@ -90,7 +93,7 @@ func (f *FipRepoImpl) CreateFipProposal(ctx context.Context, in *model.FipPropos
 func (f *FipRepoImpl) GetFipProposalListWithPagination(ctx context.Context, req api.FipProposalListReq) ([]model.FipProposalVoted, int64, error) {
 	var list []model.FipProposalVoted
 
-	baseQuery := f.mydb.Model(&model.FipProposalTbl{}).
+	baseQuery := conn(ctx, f.mydb).Model(&model.FipProposalTbl{}).
 		Where("proposal_type = ?", req.ProposalType). // Filter by `proposal_type`
 		// The code is using a method called `Where` to filter data based on the condition `chain_id = req.ChainId`. This is likely part of a database query or data manipulation operation in a Go program. The `req.ChainId` variable is being used as a parameter to filter the data.
 		Where("chain_id = ?", req.ChainId).
@@ -102,7 +105,7 @@ func (f *FipRepoImpl) GetFipProposalListWithPagination(ctx context.Context, req
 	// Use the database context to execute the query with the provided context.
 	// Filter the records by `proposal_type`, order them by `proposal_id` in descending order,
 	// and apply pagination using `Limit` and `Offset`.
-	subQuery := f.mydb.Model(&model.FipProposalVoteTbl{}).
+	subQuery := conn(ctx, f.mydb).Model(&model.FipProposalVoteTbl{}).
 		WithContext(ctx).
 		Select("COALESCE(JSON_ARRAYAGG(voter), JSON_ARRAY())").
 		Where("proposal_id = ?", gorm.Expr("fip_proposal_tbl.proposal_id")).
@@ -120,7 +123,7 @@ func (f *FipRepoImpl) GetFipProposalListWithPagination(ctx context.Context, req
 	return list, count, nil
 }
 
-// CreateFipProposalVote creates a new FipProposalVoter record in the database. If a record with the same `voter` and `proposal_id`
+// CreateFipProposalVote creates a new FipProposalVoter record in the database. If a record with the same `chain_id`, `voter` and `proposal_id`
 // already exists, the operation does nothing (no update or insertion is performed).
 //
 // Parameters:
@@ -133,12 +136,15 @@ func (f *FipRepoImpl) GetFipProposalListWithPagination(ctx context.Context, req
 func (f *FipRepoImpl) CreateFipProposalVote(ctx context.Context, in *model.FipProposalVoteTbl) (int64, error) {
 	// Use the database context to execute the operation with the provided context.
 	// The `Clauses` method specifies the behavior on conflict. In this case, if a record with the same
-	// `voter` and `proposal_id` already exists, the operation does nothing (`DoNothing: true`).
-	if err := f.mydb.Model(&model.FipProposalVoteTbl{}).
+	// `chain_id`, `voter` and `proposal_id` already exists, the operation does nothing (`DoNothing: true`).
+	if err := saveUndo[model.FipProposalVoteTbl](ctx, f.mydb, in.ChainId, "voter = ? AND proposal_id = ?", in.Voter, in.ProposalId); err != nil {
+		return 0, err
+	}
+	if err := conn(ctx, f.mydb).Model(&model.FipProposalVoteTbl{}).
 		WithContext(ctx).
 		Clauses(
 			clause.OnConflict{
-				Columns: []clause.Column{{Name: "voter"}, {Name: "proposal_id"}}, // Conflict columns
+				Columns: []clause.Column{{Name: "chain_id"}, {Name: "voter"}, {Name: "proposal_id"}}, // Conflict columns
 				DoUpdates: clause.AssignmentColumns([]string{
 					"is_remove",
 					"block_number",
@@ -167,12 +173,16 @@ func (f *FipRepoImpl) CreateFipProposalVote(ctx context.Context, in *model.FipPr
 func (f *FipRepoImpl) CreateFipEditor(ctx context.Context, in *model.FipEditorTbl) (int64, error) {
 	// Use the database context to execute the create operation with the provided context.
 	// The `Create` method inserts the provided `FipEditorTbl` data into the database.
-	if err := f.mydb.Model(&model.FipEditorTbl{}).
+	if err := saveUndo[model.FipEditorTbl](ctx, f.mydb, in.ChainId, "editor = ?", in.Editor); err != nil {
+		return 0, err
+	}
+	if err := conn(ctx, f.mydb).Model(&model.FipEditorTbl{}).
 		WithContext(ctx).
 		Clauses(clause.OnConflict{
-			Columns: []clause.Column{{Name: "editor"}},
+			Columns: []clause.Column{{Name: "chain_id"}, {Name: "editor"}},
 			DoUpdates: clause.AssignmentColumns([]string{
 				"is_remove",
+				"block_number",
 				"updated_at",
 			}),
 		}).
@@ -196,7 +206,7 @@ func (f *FipRepoImpl) CreateFipEditor(ctx context.Context, in *model.FipEditorTb
 //   - error: An error object if the operation fails. Returns `nil` if the operation is successful.
 func (f *FipRepoImpl) GetFipEditorCount(ctx context.Context, chainId int64) (int64, error) {
 	// Build the query to filter FipVoter records by the specified `chain_id`.
-	query := f.mydb.Model(&model.FipEditorTbl{}).
+	query := conn(ctx, f.mydb).Model(&model.FipEditorTbl{}).
 		WithContext(ctx).
 		Where("chain_id = ? and is_remove = ?", chainId, constant.FipEditorValid)
 
@@ -206,7 +216,7 @@ func (f *FipRepoImpl) GetFipEditorCount(ctx context.Context, chainId int64) (int
 
 // GetFipProposalVoteCount implements service.FipRepo.
 func (f *FipRepoImpl) GetFipProposalVoteCount(ctx context.Context, chainId, proposalId int64) (int64, error) {
-	query := f.mydb.Model(&model.FipProposalVoteTbl{}).
+	query := conn(ctx, f.mydb).Model(&model.FipProposalVoteTbl{}).
 		WithContext(ctx).
 		Where("chain_id = ? and proposal_id = ? and is_remove = ?", chainId, proposalId, constant.FipEditorValid)
 
@@ -227,7 +237,11 @@ func (f *FipRepoImpl) GetFipProposalVoteCount(ctx context.Context, chainId, prop
 func (f *FipRepoImpl) UpdateStatusAndGetFipProposal(ctx context.Context, proposalId int64, chainId int64) (*model.FipProposalTbl, error) {
 	var updatedProposal model.FipProposalTbl
 
-	err := f.mydb.Transaction(func(tx *gorm.DB) error {
+	if err := saveUndo[model.FipProposalTbl](ctx, f.mydb, chainId, "proposal_id = ?", proposalId); err != nil {
+		return nil, err
+	}
+
+	err := conn(ctx, f.mydb).Transaction(func(tx *gorm.DB) error {
 		baseQuery := tx.Model(&model.FipProposalTbl{}).
 			WithContext(ctx).
 			Where("proposal_id = ? AND chain_id = ?", proposalId, chainId)
@@ -259,19 +273,24 @@ func (f *FipRepoImpl) UpdateStatusAndGetFipProposal(ctx context.Context, proposa
 
 // UpdateFipProposalVoteByAddress updates the vote status of a specific proposal for a given voter address.
 // It sets the `is_remove` field to a predefined invalid state for the record matching the specified
-// `proposal_id` and voter `address`.
+// `chain_id`, `proposal_id` and voter `address`.
 //
 // Parameters:
 //   - ctx:        The context for managing request-scoped values, cancellation signals, and deadlines.
+//   - chainId:    The chain ID of the proposal.
 //   - proposalId: The unique identifier of the proposal for which the vote status is to be updated.
 //   - address:    The blockchain address of the voter whose voting record should be updated.
 //
 // Returns:
 //   - error:      An error object if the database operation fails. Returns `nil` if the update is successful.
-func (f *FipRepoImpl) UpdateFipProposalVoteByAddress(ctx context.Context, proposalId int64, address string) error {
-	if err := f.mydb.Model(&model.FipProposalVoteTbl{}).
+func (f *FipRepoImpl) UpdateFipProposalVoteByAddress(ctx context.Context, chainId, proposalId int64, address string) error {
+	if err := saveUndo[model.FipProposalVoteTbl](ctx, f.mydb, chainId, "voter = ? AND proposal_id = ?", address, proposalId); err != nil {
+		return err
+	}
+
+	if err := conn(ctx, f.mydb).Model(&model.FipProposalVoteTbl{}).
 		WithContext(ctx).
-		Where("voter = ? AND proposal_id = ?", address, proposalId).
+		Where("voter = ? AND proposal_id = ? AND chain_id = ?", address, proposalId, chainId).
 		UpdateColumns(map[string]any{
 			"is_remove":  constant.FipEditorInvalid,
 			"updated_at": time.Now(),
@@ -284,18 +303,23 @@ func (f *FipRepoImpl) UpdateFipProposalVoteByAddress(ctx context.Context, propos
 }
 
 // UpdateFipEditorByAddress updates the status of a FipEditor in the database. It sets the `is_remove` field
-// to a predefined invalid state for the record matching the specified `editor` address.
+// to a predefined invalid state for the record matching the specified `chain_id` and `editor` address.
 //
 // Parameters:
 //   - ctx:     The context for managing request-scoped values, cancellation signals, and deadlines.
+//   - chainId: The chain ID of the editor.
 //   - address: The blockchain address of the editor whose status is to be updated.
 //
 // Returns:
 //   - error:   An error object if the database operation fails. Returns `nil` if the update is successful.
-func (f *FipRepoImpl) UpdateFipEditorByAddress(ctx context.Context, address string) error {
-	if err := f.mydb.Model(&model.FipEditorTbl{}).
+func (f *FipRepoImpl) UpdateFipEditorByAddress(ctx context.Context, chainId int64, address string) error {
+	if err := saveUndo[model.FipEditorTbl](ctx, f.mydb, chainId, "editor = ?", address); err != nil {
+		return err
+	}
+
+	if err := conn(ctx, f.mydb).Model(&model.FipEditorTbl{}).
 		WithContext(ctx).
-		Where("editor = ?", address).
+		Where("editor = ? AND chain_id = ?", address, chainId).
 		UpdateColumns(map[string]any{
 			"is_remove": constant.FipEditorInvalid,
 		}).
@@ -319,7 +343,7 @@ func (f *FipRepoImpl) UpdateFipEditorByAddress(ctx context.Context, address stri
 //   - error:                  An error object if the database query fails. Returns `nil` if the query is successful.
 func (f *FipRepoImpl) GetUnpassFipProposalList(ctx context.Context, chainId int64) ([]model.FipProposalTbl, error) {
 	var fipProposals []model.FipProposalTbl
-	if err := f.mydb.Model(&model.FipProposalTbl{}).
+	if err := conn(ctx, f.mydb).Model(&model.FipProposalTbl{}).
 		WithContext(ctx).
 		Where("chain_id = ? AND status = ?", chainId, constant.FipProposalUnpass).
 		Find(&fipProposals).Error; err != nil {
@@ -331,7 +355,7 @@ func (f *FipRepoImpl) GetUnpassFipProposalList(ctx context.Context, chainId int6
 
 func (f *FipRepoImpl) GetValidFipEditorList(ctx context.Context, req api.FipEditorListReq) ([]model.FipEditorTbl, error) {
 	var list []model.FipEditorTbl
-	if err := f.mydb.Model(&model.FipEditorTbl{}).Where("is_remove = ? and chain_id = ?", constant.FipEditorValid, req.ChainId).WithContext(ctx).Find(&list).Error; err != nil {
+	if err := conn(ctx, f.mydb).Model(&model.FipEditorTbl{}).Where("is_remove = ? and chain_id = ?", constant.FipEditorValid, req.ChainId).WithContext(ctx).Find(&list).Error; err != nil {
 		return nil, err
 	}
 
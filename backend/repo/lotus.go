@@ -9,21 +9,55 @@ import (
 
 	"powervoting-server/config"
 	"powervoting-server/model"
+	"powervoting-server/utils"
 	"powervoting-server/utils/types"
 )
 
+// lotusNetwork is the Lotus RPC client of a configured network.
+type lotusNetwork struct {
+	client        jsonrpc.RPCClient
+	minerIdPrefix string
+}
+
 type LotusRPCRepo struct {
-	client jsonrpc.RPCClient
+	networks map[int64]lotusNetwork
 }
 
 func NewLotusRPCRepo() *LotusRPCRepo {
+	networks := make(map[int64]lotusNetwork)
+	for _, network := range config.Networks() {
+		networks[network.ChainId] = lotusNetwork{
+			client:        jsonrpc.NewClientWithOpts(network.Rpc, &jsonrpc.RPCClientOpts{}),
+			minerIdPrefix: network.MinerIdPrefix,
+		}
+	}
+
 	return &LotusRPCRepo{
-		client: jsonrpc.NewClientWithOpts(config.Client.Network.Rpc, &jsonrpc.RPCClientOpts{}),
+		networks: networks,
 	}
 }
 
-func (l *LotusRPCRepo) FilecoinAddressToID(ctx context.Context, addr string) (string, error) {
-	resp, err := l.client.Call(ctx, "Filecoin.StateLookupID", addr, types.TipSetKey{})
+// network returns the Lotus RPC client of the network of a chain ID, chain ID 0 is the primary network.
+func (l *LotusRPCRepo) network(chainId int64) (lotusNetwork, error) {
+	if chainId == 0 {
+		chainId = config.Client.Network.ChainId
+	}
+
+	network, ok := l.networks[chainId]
+	if !ok {
+		return lotusNetwork{}, fmt.Errorf("network %d is not configured", chainId)
+	}
+
+	return network, nil
+}
+
+func (l *LotusRPCRepo) FilecoinAddressToID(ctx context.Context, chainId int64, addr string) (string, error) {
+	network, err := l.network(chainId)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := network.client.Call(ctx, "Filecoin.StateLookupID", addr, types.TipSetKey{})
 	if err != nil {
 		return "", err
 	}
@@ -35,12 +69,17 @@ func (l *LotusRPCRepo) FilecoinAddressToID(ctx context.Context, addr string) (st
 	return resp.Result.(string), nil
 }
 
-func (l *LotusRPCRepo) EthAddrToFilcoinAddr(ctx context.Context, addr string) (string, error) {
+func (l *LotusRPCRepo) EthAddrToFilcoinAddr(ctx context.Context, chainId int64, addr string) (string, error) {
 	if !strings.HasPrefix(addr, "0x") {
 		return addr, nil
 	}
 
-	resp, err := l.client.Call(ctx, "Filecoin.EthAddressToFilecoinAddress", addr)
+	network, err := l.network(chainId)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := network.client.Call(ctx, "Filecoin.EthAddressToFilecoinAddress", addr)
 	if err != nil {
 		return "", err
 	}
@@ -52,8 +91,8 @@ func (l *LotusRPCRepo) EthAddrToFilcoinAddr(ctx context.Context, addr string) (s
 	return resp.Result.(string), nil
 }
 
-func (l *LotusRPCRepo) getOwnerByMinerId(ctx context.Context, minerId string) (string, error) {
-	resp, err := l.client.Call(ctx, "Filecoin.StateMinerInfo", minerId, types.TipSetKey{})
+func (l *LotusRPCRepo) getOwnerByMinerId(ctx context.Context, client jsonrpc.RPCClient, minerId string) (string, error) {
+	resp, err := client.Call(ctx, "Filecoin.StateMinerInfo", minerId, types.TipSetKey{})
 	if err != nil {
 		return "", err
 	}
@@ -66,20 +105,25 @@ func (l *LotusRPCRepo) getOwnerByMinerId(ctx context.Context, minerId string) (s
 	return owner.(string), nil
 }
 
-func (l *LotusRPCRepo) GetActorIdByAddress(ctx context.Context, addr string) (string, error) {
-	filcoinAddr, err := l.EthAddrToFilcoinAddr(ctx, addr)
+func (l *LotusRPCRepo) GetActorIdByAddress(ctx context.Context, chainId int64, addr string) (string, error) {
+	filcoinAddr, err := l.EthAddrToFilcoinAddr(ctx, chainId, addr)
 	if err != nil {
 		return "", err
 	}
 
-	return l.FilecoinAddressToID(ctx, filcoinAddr)
+	return l.FilecoinAddressToID(ctx, chainId, filcoinAddr)
 }
 
-func (l *LotusRPCRepo) GetValidMinerIds(ctx context.Context, actorId string, minerIds []uint64) (model.StringSlice, error) {
+func (l *LotusRPCRepo) GetValidMinerIds(ctx context.Context, chainId int64, actorId string, minerIds []uint64) (model.StringSlice, error) {
+	network, err := l.network(chainId)
+	if err != nil {
+		return nil, err
+	}
+
 	var ids model.StringSlice
 	for _, id := range minerIds {
-		minerIdStr := fmt.Sprintf("%s%d", config.Client.Network.MinerIdPrefix, id)
-		owner, err := l.getOwnerByMinerId(ctx, minerIdStr)
+		minerIdStr := fmt.Sprintf("%s%d", network.minerIdPrefix, id)
+		owner, err := l.getOwnerByMinerId(ctx, network.client, minerIdStr)
 		if err != nil {
 			return nil, err
 		}
@@ -92,12 +136,17 @@ func (l *LotusRPCRepo) GetValidMinerIds(ctx context.Context, actorId string, min
 	return ids, nil
 }
 
-func (l *LotusRPCRepo) FilecoinAddrToEthAddr(ctx context.Context, addr string) (string, error) {
+func (l *LotusRPCRepo) FilecoinAddrToEthAddr(ctx context.Context, chainId int64, addr string) (string, error) {
 	if strings.HasPrefix(addr, "0x") {
 		return addr, nil
 	}
 
-	resp, err := l.client.Call(ctx, "Filecoin.FilecoinAddressToEthAddress", addr)
+	network, err := l.network(chainId)
+	if err != nil {
+		return "", err
+	}
+
+	resp, err := network.client.Call(ctx, "Filecoin.FilecoinAddressToEthAddress", addr)
 	if err != nil {
 		return "", err
 	}
@@ -108,3 +157,56 @@ func (l *LotusRPCRepo) FilecoinAddrToEthAddr(ctx context.Context, addr string) (
 
 	return resp.Result.(string), nil
 }
+
+// GetNetworkPower returns the total power of the network at the given height, or at the chain head if height is 0.
+func (l *LotusRPCRepo) GetNetworkPower(ctx context.Context, chainId, height int64) (model.NetworkPower, error) {
+	network, err := l.network(chainId)
+	if err != nil {
+		return model.NetworkPower{}, err
+	}
+
+	tipSetKey := []any{}
+	if height > 0 {
+		resp, err := network.client.Call(ctx, "Filecoin.ChainGetTipSetByHeight", height, types.TipSetKey{})
+		if err != nil {
+			return model.NetworkPower{}, err
+		}
+
+		if resp.Error != nil {
+			return model.NetworkPower{}, resp.Error
+		}
+
+		var tipSet struct {
+			Cids []any `json:"Cids"`
+		}
+		if err := resp.GetObject(&tipSet); err != nil {
+			return model.NetworkPower{}, err
+		}
+		tipSetKey = tipSet.Cids
+	}
+
+	// An empty miner address only loads the network total power
+	resp, err := network.client.Call(ctx, "Filecoin.StateMinerPower", "", tipSetKey)
+	if err != nil {
+		return model.NetworkPower{}, err
+	}
+
+	if resp.Error != nil {
+		return model.NetworkPower{}, resp.Error
+	}
+
+	var minerPower struct {
+		TotalPower struct {
+			RawBytePower    string `json:"RawBytePower"`
+			QualityAdjPower string `json:"QualityAdjPower"`
+		} `json:"TotalPower"`
+	}
+	if err := resp.GetObject(&minerPower); err != nil {
+		return model.NetworkPower{}, err
+	}
+
+	return model.NetworkPower{
+		RawBytePower:    utils.StringConvToBigInt(minerPower.TotalPower.RawBytePower),
+		QualityAdjPower: utils.StringConvToBigInt(minerPower.TotalPower.QualityAdjPower),
+	}, nil
+}
@@ -16,7 +16,7 @@ func TestGetActorIdByAddress(t *testing.T) {
 
 	lotusRepo := repo.NewLotusRPCRepo()
 
-	res, err := lotusRepo.GetActorIdByAddress(context.Background(), "0x763D410594a24048537990dde6ca81c38CfF566a")
+	res, err := lotusRepo.GetActorIdByAddress(context.Background(), 0, "0x763D410594a24048537990dde6ca81c38CfF566a")
 	assert.NoError(t, err)
 	assert.NotEmpty(t, res)
 }
@@ -25,7 +25,7 @@ func TestFilecoinAddressToID(t *testing.T) {
 	config.GetDefaultConfig()
 
 	lotusRepo := repo.NewLotusRPCRepo()
-	res, err := lotusRepo.GetValidMinerIds(context.Background(), "t017386", []uint64{
+	res, err := lotusRepo.GetValidMinerIds(context.Background(), 0, "t017386", []uint64{
 		17387,
 		28064,
 	})
@@ -37,7 +37,7 @@ func TestEthAddrToFilcoinAddr(t *testing.T) {
 	config.GetDefaultConfig()
 
 	lotusRepo := repo.NewLotusRPCRepo()
-	res, err := lotusRepo.EthAddrToFilcoinAddr(context.Background(), "0xfF000000000000000000000000000000000278bc")
+	res, err := lotusRepo.EthAddrToFilcoinAddr(context.Background(), 0, "0xfF000000000000000000000000000000000278bc")
 	assert.NoError(t, err)
 	assert.NotEmpty(t, res)
 
@@ -69,7 +69,7 @@ func TestEthAddrToFilcoinAddr(t *testing.T) {
 func TestFilecoinAddrToEthAddr(t *testing.T) {
 	config.GetDefaultConfig()
 	lotusRepo := repo.NewLotusRPCRepo()
-	res, err := lotusRepo.FilecoinAddrToEthAddr(context.Background(), "t1bh2fekhhi3c4rcynxvah6hqei2s4geylxizvzfa")
+	res, err := lotusRepo.FilecoinAddrToEthAddr(context.Background(), 0, "t1bh2fekhhi3c4rcynxvah6hqei2s4geylxizvzfa")
 	assert.NoError(t, err)
 	assert.NotEmpty(t, res)
 }
@@ -78,7 +78,7 @@ func TestGetValidMinerIds(t *testing.T) {
 	config.GetDefaultConfig()
 
 	lotusRepo := repo.NewLotusRPCRepo()
-	res, err := lotusRepo.GetValidMinerIds(context.Background(), "t0161980", []uint64{
+	res, err := lotusRepo.GetValidMinerIds(context.Background(), 0, "t0161980", []uint64{
 		161842,
 	})
 
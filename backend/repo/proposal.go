@@ -57,8 +57,8 @@ func (p *ProposalRepoImpl) GetProposalListWithPagination(ctx context.Context, re
 		return nil, 0, fmt.Errorf("get proposal list count error: %w", err)
 	}
 
-	ethAddr, _ := req.AddressReq.ToEthAddr()
-	subQuery := p.mydb.Model(&model.VoteTbl{}).
+	ethAddr, _ := req.AddressReq.ToEthAddr(req.ChainId)
+	subQuery := conn(ctx, p.mydb).Model(&model.VoteTbl{}).
 		Select("1").
 		Where("proposal_id = proposal_tbl.proposal_id").
 		Where("chain_id = proposal_tbl.chain_id").
@@ -80,7 +80,7 @@ func (p *ProposalRepoImpl) GetGitHubNameByCreaters(ctx context.Context, creators
 	}
 
 	var voters []model.VoterInfoTbl
-	query := p.mydb.Model(&model.VoterInfoTbl{}).
+	query := conn(ctx, p.mydb).Model(&model.VoterInfoTbl{}).
 		WithContext(ctx).
 		Where("address IN ?", creators).
 		Select("address, github_name").Find(&voters)
@@ -107,7 +107,7 @@ func (p *ProposalRepoImpl) GetProposalById(ctx context.Context, req api.Proposal
 	// The WithContext method ensures that the query is cancellable and can be timed out.
 	// The Where method specifies the conditions for the query.
 	// The First method retrieves the first record that matches the conditions.
-	query := p.mydb.Model(&model.ProposalTbl{}).
+	query := conn(ctx, p.mydb).Model(&model.ProposalTbl{}).
 		Where("proposal_id = ? AND chain_id = ?", req.ProposalId, req.ChainId)
 
 	if err := query.WithContext(ctx).First(&proposal).Error; err != nil {
@@ -121,56 +121,120 @@ func (p *ProposalRepoImpl) GetProposalById(ctx context.Context, req api.Proposal
 	return &proposal, nil
 }
 
-// CreateProposalDraft creates a new proposal draft in the database.
-// If a proposal draft with the same creator already exists, it updates the existing draft.
+// CreateProposalDraft creates a new proposal draft in the database, with its content as the first version.
 func (p *ProposalRepoImpl) CreateProposalDraft(ctx context.Context, in *model.ProposalDraftTbl) (int64, error) {
-	if err := p.mydb.Model(model.ProposalDraftTbl{}).
-		WithContext(ctx).
-		Clauses(clause.OnConflict{
-			Columns: []clause.Column{
-				{Name: "creator"},
-			},
-			DoUpdates: clause.AssignmentColumns([]string{
-				"start_time",
-				"end_time",
-				"title",
-				"timezone",
-				"content",
-				"token_holder_percentage",
-				"sp_percentage",
-				"client_percentage",
-				"developer_percentage",
-			}),
-		}).Create(in).Error; err != nil {
+	in.Version = 1
+	err := transaction(ctx, p.mydb, func(ctx context.Context) error {
+		if err := conn(ctx, p.mydb).Model(model.ProposalDraftTbl{}).
+			WithContext(ctx).
+			Create(in).Error; err != nil {
+			return err
+		}
+
+		return conn(ctx, p.mydb).Model(model.ProposalDraftVersionTbl{}).
+			WithContext(ctx).
+			Create(newProposalDraftVersion(in)).Error
+	})
+	if err != nil {
 		return 0, fmt.Errorf("create proposal draft error: %w", err)
 	}
 
 	return in.ID, nil
 }
 
-func (p *ProposalRepoImpl) DeleteProposalDraft(ctx context.Context, req api.DelProposalDraftReq) error {
-	if err := p.mydb.Model(model.ProposalDraftTbl{}).
-		WithContext(ctx).
-		Where("creator = ? AND chain_id = ?", req.Address, req.ChainId).
-		Delete(&model.ProposalDraftTbl{
-			Creator: req.Address,
-			ChainId:  req.ChainId,
-		}).Error; err != nil {
+// UpdateProposalDraft saves a proposal draft that was read at the previous version.
+// When the version of the draft is not the previous version, its content is saved as a new version.
+// It fails with ErrDraftVersionConflict if the draft has been saved since it was read.
+func (p *ProposalRepoImpl) UpdateProposalDraft(ctx context.Context, in *model.ProposalDraftTbl, previousVersion int) error {
+	err := transaction(ctx, p.mydb, func(ctx context.Context) error {
+		res := conn(ctx, p.mydb).Model(model.ProposalDraftTbl{}).
+			WithContext(ctx).
+			Where("id = ? AND version = ?", in.ID, previousVersion).
+			Select("name", "version", "updated_at", "start_time", "end_time", "timezone", "title", "content",
+				"token_holder_percentage", "sp_percentage", "client_percentage", "developer_percentage").
+			Updates(in)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return constant.ErrDraftVersionConflict
+		}
+
+		if in.Version == previousVersion {
+			return nil
+		}
+
+		return conn(ctx, p.mydb).Model(model.ProposalDraftVersionTbl{}).
+			WithContext(ctx).
+			Create(newProposalDraftVersion(in)).Error
+	})
+	if err != nil {
+		return fmt.Errorf("update proposal draft error: %w", err)
+	}
+
+	return nil
+}
+
+// newProposalDraftVersion copies the current content of a proposal draft into a version.
+func newProposalDraftVersion(draft *model.ProposalDraftTbl) *model.ProposalDraftVersionTbl {
+	return &model.ProposalDraftVersionTbl{
+		DraftId:    draft.ID,
+		Version:    draft.Version,
+		StartTime:  draft.StartTime,
+		EndTime:    draft.EndTime,
+		Timezone:   draft.Timezone,
+		Title:      draft.Title,
+		Content:    draft.Content,
+		Percentage: draft.Percentage,
+	}
+}
+
+// DeleteProposalDraft deletes a proposal draft and its versions from the database.
+func (p *ProposalRepoImpl) DeleteProposalDraft(ctx context.Context, id int64) error {
+	err := transaction(ctx, p.mydb, func(ctx context.Context) error {
+		if err := conn(ctx, p.mydb).Model(model.ProposalDraftVersionTbl{}).
+			WithContext(ctx).
+			Where("draft_id = ?", id).
+			Delete(&model.ProposalDraftVersionTbl{}).Error; err != nil {
+			return err
+		}
+
+		return conn(ctx, p.mydb).Model(model.ProposalDraftTbl{}).
+			WithContext(ctx).
+			Where("id = ?", id).
+			Delete(&model.ProposalDraftTbl{}).Error
+	})
+	if err != nil {
 		return fmt.Errorf("delete proposal draft error: %w", err)
 	}
-	
+
 	return nil
 }
 
-// GetProposalDraftByAddress retrieves a proposal draft from the database based on the creator's address.
+// GetProposalDraft retrieves a proposal draft by its ID, nil if it does not exist.
+func (p *ProposalRepoImpl) GetProposalDraft(ctx context.Context, id int64) (*model.ProposalDraftTbl, error) {
+	var proposalDraft model.ProposalDraftTbl
+	if err := conn(ctx, p.mydb).Model(model.ProposalDraftTbl{}).
+		WithContext(ctx).
+		Where("id = ?", id).
+		First(&proposalDraft).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("get proposal draft error: %w", err)
+	}
+
+	return &proposalDraft, nil
+}
+
+// GetProposalDraftByAddress retrieves the last saved proposal draft of a creator from the database.
 func (p *ProposalRepoImpl) GetProposalDraftByAddress(ctx context.Context, req api.AddressReq) (*model.ProposalDraftTbl, error) {
 	var proposalDraft model.ProposalDraftTbl
-	// Query the database for a proposal draft where the creator matches the provided address.
-	// The query uses GORM's Model method to specify the model type and WithContext to pass the context.
-	// The Where clause filters records based on the creator field, and First retrieves the first matching record.
-	if err := p.mydb.Model(model.ProposalDraftTbl{}).
+	if err := conn(ctx, p.mydb).Model(model.ProposalDraftTbl{}).
 		WithContext(ctx).
 		Where("creator = ?", req.Address).
+		Order("updated_at desc, id desc").
 		First(&proposalDraft).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -182,10 +246,141 @@ func (p *ProposalRepoImpl) GetProposalDraftByAddress(ctx context.Context, req ap
 	return &proposalDraft, nil
 }
 
+// GetProposalDraftList retrieves the proposal drafts of a creator, the last saved first.
+func (p *ProposalRepoImpl) GetProposalDraftList(ctx context.Context, req api.ProposalDraftListReq) ([]model.ProposalDraftTbl, error) {
+	query := conn(ctx, p.mydb).Model(model.ProposalDraftTbl{}).
+		WithContext(ctx).
+		Where("creator = ?", req.Address)
+	if req.ChainId != 0 {
+		query = query.Where("chain_id = ?", req.ChainId)
+	}
+
+	var list []model.ProposalDraftTbl
+	if err := query.Order("updated_at desc, id desc").Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("get proposal draft list error: %w", err)
+	}
+
+	return list, nil
+}
+
+// GetProposalDraftVersions retrieves the versions of a proposal draft, the first one first.
+func (p *ProposalRepoImpl) GetProposalDraftVersions(ctx context.Context, draftId int64) ([]model.ProposalDraftVersionTbl, error) {
+	var list []model.ProposalDraftVersionTbl
+	if err := conn(ctx, p.mydb).Model(model.ProposalDraftVersionTbl{}).
+		WithContext(ctx).
+		Where("draft_id = ?", draftId).
+		Order("version asc").
+		Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("get proposal draft versions error: %w", err)
+	}
+
+	return list, nil
+}
+
+// GetProposalDraftVersion retrieves a version of a proposal draft, nil if it does not exist.
+func (p *ProposalRepoImpl) GetProposalDraftVersion(ctx context.Context, draftId int64, version int) (*model.ProposalDraftVersionTbl, error) {
+	var draftVersion model.ProposalDraftVersionTbl
+	if err := conn(ctx, p.mydb).Model(model.ProposalDraftVersionTbl{}).
+		WithContext(ctx).
+		Where("draft_id = ? AND version = ?", draftId, version).
+		First(&draftVersion).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("get proposal draft version error: %w", err)
+	}
+
+	return &draftVersion, nil
+}
+
+// SubmitProposalDraft marks the version of a proposal draft a proposal is created from.
+func (p *ProposalRepoImpl) SubmitProposalDraft(ctx context.Context, id int64, version int, proposer string, submittedAt int64) error {
+	if err := conn(ctx, p.mydb).Model(model.ProposalDraftTbl{}).
+		WithContext(ctx).
+		Where("id = ? AND proposal_id = 0", id).
+		Updates(map[string]any{
+			"submitted_version": version,
+			"proposer_address":  proposer,
+			"submitted_at":      submittedAt,
+		}).Error; err != nil {
+		return fmt.Errorf("submit proposal draft error: %w", err)
+	}
+
+	return nil
+}
+
+// LinkProposalDraft links a synced proposal to the draft it was created from:
+// the last marked draft of the proposal creator on the chain, not linked yet, whose marked version has the title and content of the proposal.
+// The creator is matched against the proposer address of the drafts, so that the drafts of f1 and f3 creators are found by their masked ID address.
+// A proposal already linked to a draft is not linked again. It returns the ID of the linked draft, 0 if none matches.
+func (p *ProposalRepoImpl) LinkProposalDraft(ctx context.Context, proposal *model.ProposalTbl) (int64, error) {
+	var linked int64
+	if err := conn(ctx, p.mydb).Model(model.ProposalDraftTbl{}).
+		WithContext(ctx).
+		Where("chain_id = ? AND proposal_id = ?", proposal.ChainId, proposal.ProposalId).
+		Count(&linked).Error; err != nil {
+		return 0, fmt.Errorf("count proposal draft error: %w", err)
+	}
+	if linked > 0 {
+		return 0, nil
+	}
+
+	var ids []int64
+	if err := conn(ctx, p.mydb).Model(model.ProposalDraftTbl{}).
+		WithContext(ctx).
+		Joins("JOIN proposal_draft_version_tbl v ON v.draft_id = proposal_draft_tbl.id AND v.version = proposal_draft_tbl.submitted_version").
+		Where("proposal_draft_tbl.chain_id = ? AND proposal_draft_tbl.proposer_address = ? AND proposal_draft_tbl.proposal_id = 0", proposal.ChainId, strings.ToLower(proposal.Creator)).
+		Where("v.title = ? AND v.content = ?", proposal.Title, proposal.Content).
+		Order("proposal_draft_tbl.submitted_at desc").
+		Limit(1).
+		Pluck("proposal_draft_tbl.id", &ids).Error; err != nil {
+		return 0, fmt.Errorf("find submitted proposal draft error: %w", err)
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	if err := conn(ctx, p.mydb).Model(model.ProposalDraftTbl{}).
+		WithContext(ctx).
+		Where("id = ?", ids[0]).
+		Update("proposal_id", proposal.ProposalId).Error; err != nil {
+		return 0, fmt.Errorf("link proposal draft error: %w", err)
+	}
+
+	return ids[0], nil
+}
+
+// CreateDraftNonce records the nonce of a signed draft mutation.
+// A nonce already used by the same address is not saved again, the returned flag tells whether it was saved.
+func (p *ProposalRepoImpl) CreateDraftNonce(ctx context.Context, in *model.DraftNonceTbl) (bool, error) {
+	res := conn(ctx, p.mydb).Model(model.DraftNonceTbl{}).
+		WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(in)
+	if res.Error != nil {
+		return false, fmt.Errorf("create draft nonce error: %w", res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+// DeleteDraftNonces deletes the nonces of the payloads signed before the given unix time.
+func (p *ProposalRepoImpl) DeleteDraftNonces(ctx context.Context, before int64) error {
+	if err := conn(ctx, p.mydb).Model(model.DraftNonceTbl{}).
+		WithContext(ctx).
+		Where("timestamp < ?", before).
+		Delete(&model.DraftNonceTbl{}).Error; err != nil {
+		return fmt.Errorf("delete draft nonces error: %w", err)
+	}
+
+	return nil
+}
+
 // CreateProposal creates a new proposal in the database.
 // If a proposal with the same proposal_id already exists, it updates the existing proposal.
 func (p *ProposalRepoImpl) CreateProposal(ctx context.Context, in *model.ProposalTbl) (int64, error) {
-	if err := p.mydb.Model(model.ProposalTbl{}).
+	if err := conn(ctx, p.mydb).Model(model.ProposalTbl{}).
 		WithContext(ctx).
 		Clauses(clause.OnConflict{
 			// Specify the columns that should trigger the conflict (in this case, proposal_id).
@@ -206,6 +401,13 @@ func (p *ProposalRepoImpl) CreateProposal(ctx context.Context, in *model.Proposa
 				"snapshot_day",
 				"token_holder_percentage",
 				"snapshot_block_height",
+				"counting_algorithm",
+				"vote_options",
+				"quorum_min_voters",
+				"quorum_sp_share",
+				"quorum_client_share",
+				"quorum_token_holder_share",
+				"quorum_developer_share",
 				"sp_percentage",
 				"client_percentage",
 				"developer_percentage",
@@ -217,18 +419,140 @@ func (p *ProposalRepoImpl) CreateProposal(ctx context.Context, in *model.Proposa
 	return in.ID, nil
 }
 
+// CreateProposalAudit creates the tally audit bundle of a proposal in the database.
+// If the proposal already has a bundle, it is replaced.
+func (p *ProposalRepoImpl) CreateProposalAudit(ctx context.Context, in *model.ProposalAuditTbl) error {
+	if err := conn(ctx, p.mydb).Model(model.ProposalAuditTbl{}).
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "proposal_id"},
+				{Name: "chain_id"},
+			},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"bundle",
+				"sha256",
+				"updated_at",
+			}),
+		}).Create(in).Error; err != nil {
+		return fmt.Errorf("create proposal audit error: %w", err)
+	}
+
+	return nil
+}
+
+// GetProposalAudit retrieves the tally audit bundle of a proposal from the database.
+func (p *ProposalRepoImpl) GetProposalAudit(ctx context.Context, req api.ProposalReq) (*model.ProposalAuditTbl, error) {
+	var audit model.ProposalAuditTbl
+	if err := conn(ctx, p.mydb).Model(model.ProposalAuditTbl{}).
+		WithContext(ctx).
+		Where("proposal_id = ? AND chain_id = ?", req.ProposalId, req.ChainId).
+		First(&audit).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("get proposal audit error: %w", err)
+	}
+
+	return &audit, nil
+}
+
+// CreateProposalResultHistory stores a replaced result of a recounted proposal in the database.
+// Storing the same revision again updates its reason, so that a recount interrupted after this step can be retried.
+func (p *ProposalRepoImpl) CreateProposalResultHistory(ctx context.Context, in *model.ProposalResultHistoryTbl) error {
+	if err := conn(ctx, p.mydb).Model(model.ProposalResultHistoryTbl{}).
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{
+				{Name: "proposal_id"},
+				{Name: "chain_id"},
+				{Name: "result_revision"},
+			},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"reason",
+				"updated_at",
+			}),
+		}).Create(in).Error; err != nil {
+		return fmt.Errorf("create proposal result history error: %w", err)
+	}
+
+	return nil
+}
+
+// GetProposalResultHistory retrieves the replaced results of a proposal from the database, the latest first.
+func (p *ProposalRepoImpl) GetProposalResultHistory(ctx context.Context, req api.ProposalReq) ([]model.ProposalResultHistoryTbl, error) {
+	var history []model.ProposalResultHistoryTbl
+	if err := conn(ctx, p.mydb).Model(model.ProposalResultHistoryTbl{}).
+		WithContext(ctx).
+		Where("proposal_id = ? AND chain_id = ?", req.ProposalId, req.ChainId).
+		Order("result_revision desc").
+		Find(&history).Error; err != nil {
+		return nil, fmt.Errorf("get proposal result history error: %w", err)
+	}
+
+	return history, nil
+}
+
+// DeleteProposalAudit deletes the tally audit bundle of a proposal from the database.
+func (p *ProposalRepoImpl) DeleteProposalAudit(ctx context.Context, req api.ProposalReq) error {
+	if err := conn(ctx, p.mydb).Model(model.ProposalAuditTbl{}).
+		WithContext(ctx).
+		Where("proposal_id = ? AND chain_id = ?", req.ProposalId, req.ChainId).
+		Delete(&model.ProposalAuditTbl{}).Error; err != nil {
+		return fmt.Errorf("delete proposal audit error: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteProposalResultHistory deletes the replaced results of a proposal from the database.
+func (p *ProposalRepoImpl) DeleteProposalResultHistory(ctx context.Context, req api.ProposalReq) error {
+	if err := conn(ctx, p.mydb).Model(model.ProposalResultHistoryTbl{}).
+		WithContext(ctx).
+		Where("proposal_id = ? AND chain_id = ?", req.ProposalId, req.ChainId).
+		Delete(&model.ProposalResultHistoryTbl{}).Error; err != nil {
+		return fmt.Errorf("delete proposal result history error: %w", err)
+	}
+
+	return nil
+}
+
+// GetProposalVoters retrieves the address and vote time of every vote of a proposal, ordered by vote time.
+func (p *ProposalRepoImpl) GetProposalVoters(ctx context.Context, req api.ProposalReq) ([]model.VoteTbl, error) {
+	var voters []model.VoteTbl
+	if err := conn(ctx, p.mydb).Model(model.VoteTbl{}).
+		WithContext(ctx).
+		Select("address", "timestamp").
+		Where("proposal_id = ? AND chain_id = ?", req.ProposalId, req.ChainId).
+		Order("timestamp asc, id asc").
+		Find(&voters).Error; err != nil {
+		return nil, fmt.Errorf("get proposal voters error: %w", err)
+	}
+
+	return voters, nil
+}
+
 // UpdateProposal updates the specified proposal in the database.
 func (p *ProposalRepoImpl) UpdateProposal(ctx context.Context, in *model.ProposalTbl) error {
 	// Start a new database transaction and set the context for it.
-	err := p.mydb.Model(model.ProposalTbl{}).
+	err := conn(ctx, p.mydb).Model(model.ProposalTbl{}).
 		WithContext(ctx).
-		// Specify the condition to find the proposal by its ID.
-		Where("proposal_id = ?", in.ProposalId).
+		// Specify the condition to find the proposal by its ID and chain ID.
+		Where("proposal_id = ? AND chain_id = ?", in.ProposalId, in.ChainId).
 		// Update the specified columns with new values from the input proposal.
 		UpdateColumns(map[string]any{
 			"counted":                  in.Counted,
 			"approve_percentage":       in.ProposalResult.ApprovePercentage,
 			"reject_percentage":        in.ProposalResult.RejectPercentage,
+			"abstain_percentage":       in.ProposalResult.AbstainPercentage,
+			"option_percentages":       in.ProposalResult.OptionPercentages,
+			"exact_percentages":        in.ProposalResult.ExactPercentages,
+			"category_breakdown":       in.ProposalResult.CategoryBreakdown,
+			"outcome":                  in.ProposalResult.Outcome,
+			"result_revision":          in.ResultRevision,
+			"awaiting_power":           in.AwaitingPower,
+			"missing_power_voters":     in.MissingPowerVoters,
 			"total_sp_power":           in.TotalSpPower,
 			"total_token_holder_power": in.TotalTokenHolderPower,
 			"total_client_power":       in.TotalClientPower,
@@ -239,6 +563,24 @@ func (p *ProposalRepoImpl) UpdateProposal(ctx context.Context, in *model.Proposa
 	return err
 }
 
+// UpdateProposalPowerRetry updates the deferred counting state of the specified proposal in the database.
+func (p *ProposalRepoImpl) UpdateProposalPowerRetry(ctx context.Context, in *model.ProposalTbl) error {
+	if err := conn(ctx, p.mydb).Model(model.ProposalTbl{}).
+		WithContext(ctx).
+		Where("proposal_id = ? AND chain_id = ?", in.ProposalId, in.ChainId).
+		UpdateColumns(map[string]any{
+			"awaiting_power":       in.AwaitingPower,
+			"power_retries":        in.PowerRetries,
+			"next_count_time":      in.NextCountTime,
+			"missing_power_voters": in.MissingPowerVoters,
+			"updated_at":           time.Now(),
+		}).Error; err != nil {
+		return fmt.Errorf("update proposal power retry error: %w", err)
+	}
+
+	return nil
+}
+
 // GetUnCountedProposalList retrieves a list of proposals based on the provided network ID and timestamp.
 // It queries the database for proposals with the following conditions:
 // 1. Matching network ID.
@@ -247,7 +589,7 @@ func (p *ProposalRepoImpl) UpdateProposal(ctx context.Context, in *model.Proposa
 // It returns the list of proposals and any error encountered during the database query.
 func (p *ProposalRepoImpl) GetUncountedProposalList(ctx context.Context, chainId int64, timestamp int64) ([]model.ProposalTbl, error) {
 	var proposalList []model.ProposalTbl
-	tx := p.mydb.Model(model.ProposalTbl{}).
+	tx := conn(ctx, p.mydb).Model(model.ProposalTbl{}).
 		WithContext(ctx).
 		Where("chain_id = ?", chainId).
 		Where("end_time <= ?", timestamp).
@@ -257,6 +599,34 @@ func (p *ProposalRepoImpl) GetUncountedProposalList(ctx context.Context, chainId
 	return proposalList, tx.Error
 }
 
+// GetStartedProposalList retrieves the uncounted proposals of a chain whose voting started at or before the timestamp.
+func (p *ProposalRepoImpl) GetStartedProposalList(ctx context.Context, chainId int64, timestamp int64) ([]model.ProposalTbl, error) {
+	var proposalList []model.ProposalTbl
+	if err := conn(ctx, p.mydb).Model(model.ProposalTbl{}).
+		WithContext(ctx).
+		Where("chain_id = ? AND start_time <= ? AND counted = ?", chainId, timestamp, constant.ProposalCreate).
+		Order("proposal_id").
+		Find(&proposalList).Error; err != nil {
+		return nil, fmt.Errorf("get started proposal list error: %w", err)
+	}
+
+	return proposalList, nil
+}
+
+// GetAllProposalList retrieves every proposal of a chain.
+func (p *ProposalRepoImpl) GetAllProposalList(ctx context.Context, chainId int64) ([]model.ProposalTbl, error) {
+	var proposalList []model.ProposalTbl
+	if err := conn(ctx, p.mydb).Model(model.ProposalTbl{}).
+		WithContext(ctx).
+		Where("chain_id = ?", chainId).
+		Order("proposal_id").
+		Find(&proposalList).Error; err != nil {
+		return nil, fmt.Errorf("get proposal list error: %w", err)
+	}
+
+	return proposalList, nil
+}
+
 // buildProposalBaseQuery constructs the base query for counting and listing proposals based on the given request.
 func (p *ProposalRepoImpl) buildProposalBaseQuery(req api.ProposalListReq) (queryCount *gorm.DB, queryList *gorm.DB) {
 	// Define a function to apply common conditions to the query.
@@ -0,0 +1,37 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+
+	"powervoting-server/api/rpc"
+	"powervoting-server/model"
+	"powervoting-server/service"
+)
+
+var _ service.SnapshotRepo = (*SnapshotRPCRepo)(nil)
+
+// SnapshotRPCRepo reads the voting power snapshot from the power snapshot service.
+type SnapshotRPCRepo struct{}
+
+func NewSnapshotRPCRepo() *SnapshotRPCRepo {
+	return &SnapshotRPCRepo{}
+}
+
+// GetAllAddressPowerByDay retrieves the power of all addresses in the snapshot of a day.
+func (s *SnapshotRPCRepo) GetAllAddressPowerByDay(ctx context.Context, chainId int64, day string) (model.SnapshotAllPower, error) {
+	return rpc.GetAllAddressPowerByDay(chainId, day)
+}
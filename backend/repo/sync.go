@@ -16,16 +16,22 @@ package repo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"powervoting-server/constant"
 	"powervoting-server/data"
 	"powervoting-server/model"
+	"powervoting-server/model/api"
 	"powervoting-server/service"
 )
 
 var _ service.SyncRepo = (*SyncRepoImpl)(nil)
+var _ service.EventRepo = (*SyncRepoImpl)(nil)
 
 type SyncRepoImpl struct {
 	mydb *gorm.DB
@@ -35,11 +41,16 @@ func NewSyncRepo(mydb *gorm.DB) *SyncRepoImpl {
 	return &SyncRepoImpl{mydb: mydb}
 }
 
+// Transaction runs fn in a transaction that the repositories called with its context take part in.
+func (s *SyncRepoImpl) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return transaction(ctx, s.mydb, fn)
+}
+
 // CreateSyncEventInfo creates a new synchronization event in the database.
 // It takes a context and a pointer to a SyncEventTbl struct as input.
 // It returns the ID of the created event and an error if any occurred.
 func (s *SyncRepoImpl) CreateSyncEventInfo(ctx context.Context, in *model.SyncEventTbl) (int64, error) {
-	if err := s.mydb.Model(model.SyncEventTbl{}).
+	if err := conn(ctx, s.mydb).Model(model.SyncEventTbl{}).
 		WithContext(ctx).
 		Create(in).Error; err != nil {
 		// Check if the error is due to a duplicate entry.
@@ -54,23 +65,23 @@ func (s *SyncRepoImpl) CreateSyncEventInfo(ctx context.Context, in *model.SyncEv
 	return in.Id, nil
 }
 
-// GetSyncEventInfo retrieves the synchronization event information for a given contract address.
+// GetSyncEventInfo retrieves the synchronization event information for a given chain and contract address.
 // It returns a pointer to the SyncEventTbl struct containing the event information and an error if any occurred.
-func (s *SyncRepoImpl) GetSyncEventInfo(ctx context.Context, addr string) (*model.SyncEventTbl, error) {
+func (s *SyncRepoImpl) GetSyncEventInfo(ctx context.Context, chainId int64, addr string) (*model.SyncEventTbl, error) {
 	var syncEvent model.SyncEventTbl
-	tx := s.mydb.Model(model.SyncEventTbl{}).
+	tx := conn(ctx, s.mydb).Model(model.SyncEventTbl{}).
 		WithContext(ctx).
-		Where("power_voting_contract_address = ?", addr).
+		Where("chain_id = ? AND power_voting_contract_address = ?", chainId, addr).
 		Take(&syncEvent)
 
 	return &syncEvent, tx.Error
 }
 
 // UpdateSyncEventInfo updates the synced height of a sync event in the database.
-func (s *SyncRepoImpl) UpdateSyncEventInfo(ctx context.Context, addr string, height int64) error {
-	err := s.mydb.Model(model.SyncEventTbl{}).
+func (s *SyncRepoImpl) UpdateSyncEventInfo(ctx context.Context, chainId int64, addr string, height int64) error {
+	err := conn(ctx, s.mydb).Model(model.SyncEventTbl{}).
 		WithContext(ctx).
-		Where("power_voting_contract_address = ?", addr).
+		Where("chain_id = ? AND power_voting_contract_address = ?", chainId, addr).
 		Updates(model.SyncEventTbl{SyncedHeight: height}).Error
 	if err != nil {
 		return fmt.Errorf("update sync event error: %w", err)
@@ -78,3 +89,370 @@ func (s *SyncRepoImpl) UpdateSyncEventInfo(ctx context.Context, addr string, hei
 
 	return nil
 }
+
+// GetSyncBackfill retrieves the backfill progress of a chain, nil when no backfill has run on the chain.
+func (s *SyncRepoImpl) GetSyncBackfill(ctx context.Context, chainId int64) (*model.SyncBackfillTbl, error) {
+	var backfill model.SyncBackfillTbl
+	if err := conn(ctx, s.mydb).Model(model.SyncBackfillTbl{}).
+		WithContext(ctx).
+		Where("chain_id = ?", chainId).
+		Take(&backfill).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get sync backfill error: %w", err)
+	}
+
+	return &backfill, nil
+}
+
+// SaveSyncBackfill creates or replaces the backfill progress of a chain.
+func (s *SyncRepoImpl) SaveSyncBackfill(ctx context.Context, in *model.SyncBackfillTbl) error {
+	if err := conn(ctx, s.mydb).Model(model.SyncBackfillTbl{}).
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "chain_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"start_height",
+				"target_height",
+				"applied_height",
+				"chunk_size",
+				"chunks",
+				"finished",
+				"updated_at",
+			}),
+		}).
+		Create(in).Error; err != nil {
+		return fmt.Errorf("save sync backfill error: %w", err)
+	}
+
+	return nil
+}
+
+// CreateSyncBlocks records the blocks of synced heights and removes the recorded blocks below pruneBelow.
+func (s *SyncRepoImpl) CreateSyncBlocks(ctx context.Context, blocks []model.SyncBlockTbl, pruneBelow int64) error {
+	if len(blocks) == 0 {
+		return nil
+	}
+
+	return conn(ctx, s.mydb).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(model.SyncBlockTbl{}).
+			Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "chain_id"}, {Name: "height"}},
+				DoUpdates: clause.AssignmentColumns([]string{"hash", "parent_hash"}),
+			}).
+			Create(&blocks).Error; err != nil {
+			return fmt.Errorf("create sync blocks error: %w", err)
+		}
+
+		if err := tx.Where("chain_id = ? AND height < ?", blocks[0].ChainId, pruneBelow).
+			Delete(&model.SyncBlockTbl{}).Error; err != nil {
+			return fmt.Errorf("prune sync blocks error: %w", err)
+		}
+
+		// Blocks below the recorded ones can no longer be rolled back
+		if err := tx.Where("chain_id = ? AND block_number < ?", blocks[0].ChainId, pruneBelow).
+			Delete(&model.SyncUndoTbl{}).Error; err != nil {
+			return fmt.Errorf("prune sync undo rows error: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetSyncBlocks retrieves the recorded blocks of a chain at or below a height, the highest first.
+func (s *SyncRepoImpl) GetSyncBlocks(ctx context.Context, chainId, maxHeight int64, limit int) ([]model.SyncBlockTbl, error) {
+	var blocks []model.SyncBlockTbl
+	if err := conn(ctx, s.mydb).Model(model.SyncBlockTbl{}).
+		WithContext(ctx).
+		Where("chain_id = ? AND height <= ?", chainId, maxHeight).
+		Order("height desc").
+		Limit(limit).
+		Find(&blocks).Error; err != nil {
+		return nil, fmt.Errorf("get sync blocks error: %w", err)
+	}
+
+	return blocks, nil
+}
+
+// RollbackSync deletes the rows synced from the blocks above a height and sets the synced height back to it,
+// in a single transaction.
+func (s *SyncRepoImpl) RollbackSync(ctx context.Context, addr string, chainId, height int64) error {
+	return conn(ctx, s.mydb).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// The voters whose current vote is rolled back get back their previous vote
+		var replaced []model.VoteTbl
+		if err := tx.Model(model.VoteTbl{}).
+			Select("proposal_id", "address").
+			Where("chain_id = ? AND block_number > ?", chainId, height).
+			Find(&replaced).Error; err != nil {
+			return fmt.Errorf("get rolled back votes error: %w", err)
+		}
+
+		// The drafts linked to the proposals created above the height are linked again when the proposals are synced again
+		if err := tx.Model(model.ProposalDraftTbl{}).
+			Where("chain_id = ? AND proposal_id IN (?)", chainId, tx.Model(model.ProposalTbl{}).
+				Select("proposal_id").
+				Where("chain_id = ? AND block_number > ?", chainId, height)).
+			Update("proposal_id", 0).Error; err != nil {
+			return fmt.Errorf("unlink rolled back proposal drafts error: %w", err)
+		}
+
+		// The rows updated in place above the height get back their recorded state, the latest update is undone first
+		var undos []model.SyncUndoTbl
+		if err := tx.Where("chain_id = ? AND block_number > ?", chainId, height).
+			Order("id desc").
+			Find(&undos).Error; err != nil {
+			return fmt.Errorf("get undo rows error: %w", err)
+		}
+
+		for _, undo := range undos {
+			if err := restoreUndo(tx, undo); err != nil {
+				return err
+			}
+		}
+
+		for _, table := range []any{
+			&model.SyncUndoTbl{},
+			&model.VoteTbl{},
+			&model.VoteHistoryTbl{},
+			&model.ProposalTbl{},
+			&model.FipProposalTbl{},
+			&model.FipProposalVoteTbl{},
+			&model.FipEditorTbl{},
+			&model.VoterInfoTbl{},
+			&model.EventDeadLetterTbl{},
+			&model.EventLogTbl{},
+			&model.GithubRepoTbl{},
+			&model.SnapshotHeightTbl{},
+			&model.ConfChangeTbl{},
+		} {
+			if err := tx.Where("chain_id = ? AND block_number > ?", chainId, height).
+				Delete(table).Error; err != nil {
+				return fmt.Errorf("roll back %T error: %w", table, err)
+			}
+		}
+
+		// Repositories removed above the height are listed again
+		if err := tx.Model(model.GithubRepoTbl{}).
+			Where("chain_id = ? AND removed_block_number > ?", chainId, height).
+			Updates(map[string]any{"removed": false, "removed_block_number": 0}).Error; err != nil {
+			return fmt.Errorf("roll back removed github repos error: %w", err)
+		}
+
+		for _, vote := range replaced {
+			var previous model.VoteHistoryTbl
+			err := tx.Model(model.VoteHistoryTbl{}).
+				Where("chain_id = ? AND proposal_id = ? AND address = ? AND valid = ?", chainId, vote.ProposalId, vote.Address, true).
+				Order("block_number desc, log_index desc").
+				First(&previous).Error
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("get previous vote error: %w", err)
+			}
+
+			if err := tx.Create(&model.VoteTbl{
+				ProposalId:    previous.ProposalId,
+				ChainId:       previous.ChainId,
+				Address:       previous.Address,
+				VoteEncrypted: previous.VoteEncrypted,
+				BlockNumber:   previous.BlockNumber,
+				LogIndex:      previous.LogIndex,
+				Timestamp:     previous.Timestamp,
+				BaseField: model.BaseField{
+					CreatedAt: previous.CreatedAt,
+				},
+			}).Error; err != nil {
+				return fmt.Errorf("restore previous vote error: %w", err)
+			}
+		}
+
+		// The webhook events of the blocks above the height are withdrawn, the new chain emits its own events
+		orphaned := tx.Model(model.WebhookEventTbl{}).
+			Select("id").
+			Where("chain_id = ? AND block_number > ?", chainId, height)
+		if err := tx.Model(model.WebhookDeliveryTbl{}).
+			Where("event_id IN (?) AND status = ?", orphaned, constant.WebhookDeliveryStatusPending).
+			Update("status", constant.WebhookDeliveryStatusCancelled).Error; err != nil {
+			return fmt.Errorf("cancel rolled back webhook deliveries error: %w", err)
+		}
+
+		if err := tx.Where("chain_id = ? AND block_number > ?", chainId, height).
+			Delete(&model.WebhookEventTbl{}).Error; err != nil {
+			return fmt.Errorf("roll back webhook events error: %w", err)
+		}
+
+		if err := tx.Where("chain_id = ? AND height > ?", chainId, height).
+			Delete(&model.SyncBlockTbl{}).Error; err != nil {
+			return fmt.Errorf("roll back sync blocks error: %w", err)
+		}
+
+		if err := tx.Model(model.SyncEventTbl{}).
+			Where("chain_id = ? AND power_voting_contract_address = ?", chainId, addr).
+			Update("synced_height", height).Error; err != nil {
+			return fmt.Errorf("roll back synced height error: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// CreateDeadLetter saves an event log that failed to be applied.
+// A log that is already saved, because it was synced again after a chain reorganization, is reset with the new failure.
+func (s *SyncRepoImpl) CreateDeadLetter(ctx context.Context, in *model.EventDeadLetterTbl) error {
+	if err := conn(ctx, s.mydb).Model(model.EventDeadLetterTbl{}).
+		WithContext(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "chain_id"}, {Name: "tx_hash"}, {Name: "log_index"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"raw_log", "error", "attempts", "status", "next_attempt_time", "updated_at",
+			}),
+		}).
+		Create(in).Error; err != nil {
+		return fmt.Errorf("create dead letter error: %w", err)
+	}
+
+	return nil
+}
+
+// GetDueDeadLetters retrieves the pending dead letters of a chain whose next replay time has passed, the oldest event first.
+func (s *SyncRepoImpl) GetDueDeadLetters(ctx context.Context, chainId, now int64, limit int) ([]model.EventDeadLetterTbl, error) {
+	var list []model.EventDeadLetterTbl
+	if err := conn(ctx, s.mydb).Model(model.EventDeadLetterTbl{}).
+		WithContext(ctx).
+		Where("chain_id = ? AND status = ? AND next_attempt_time <= ?", chainId, constant.DeadLetterStatusPending, now).
+		Order("block_number, log_index").
+		Limit(limit).
+		Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("get due dead letters error: %w", err)
+	}
+
+	return list, nil
+}
+
+// UpdateDeadLetter saves the result of a replay of a dead letter.
+func (s *SyncRepoImpl) UpdateDeadLetter(ctx context.Context, in *model.EventDeadLetterTbl) error {
+	if err := conn(ctx, s.mydb).Model(model.EventDeadLetterTbl{}).
+		WithContext(ctx).
+		Where("id = ?", in.ID).
+		UpdateColumns(map[string]any{
+			"error":             in.Error,
+			"attempts":          in.Attempts,
+			"status":            in.Status,
+			"next_attempt_time": in.NextAttemptTime,
+			"updated_at":        time.Now(),
+		}).Error; err != nil {
+		return fmt.Errorf("update dead letter error: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeadLetterListWithPagination retrieves the unresolved dead letters of a chain, the most recent event first.
+func (s *SyncRepoImpl) GetDeadLetterListWithPagination(ctx context.Context, req api.DeadLetterListReq) ([]model.EventDeadLetterTbl, int64, error) {
+	query := s.mydb.Model(model.EventDeadLetterTbl{}).
+		WithContext(ctx).
+		Where("chain_id = ?", req.ChainId)
+	if req.Exhausted {
+		query = query.Where("status = ?", constant.DeadLetterStatusExhausted)
+	} else {
+		query = query.Where("status <> ?", constant.DeadLetterStatusResolved)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count dead letters error: %w", err)
+	}
+
+	offset := req.Offset()
+	var list []model.EventDeadLetterTbl
+	if err := query.Order("block_number desc, log_index desc").
+		Limit(req.PageSize).
+		Offset(offset).
+		Find(&list).Error; err != nil {
+		return nil, 0, fmt.Errorf("get dead letters error: %w", err)
+	}
+
+	return list, total, nil
+}
+
+// CreateEventLog appends an event log to the journal, a log that is already in the journal is ignored.
+func (s *SyncRepoImpl) CreateEventLog(ctx context.Context, in *model.EventLogTbl) error {
+	if err := conn(ctx, s.mydb).Model(model.EventLogTbl{}).
+		WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(in).Error; err != nil {
+		return fmt.Errorf("create event log error: %w", err)
+	}
+
+	return nil
+}
+
+// GetEventLogs retrieves a page of the event log journal of a chain, in the order the events were emitted.
+func (s *SyncRepoImpl) GetEventLogs(ctx context.Context, chainId int64, offset, limit int) ([]model.EventLogTbl, error) {
+	var list []model.EventLogTbl
+	if err := conn(ctx, s.mydb).Model(model.EventLogTbl{}).
+		WithContext(ctx).
+		Where("chain_id = ?", chainId).
+		Order("block_number, log_index, id").
+		Offset(offset).
+		Limit(limit).
+		Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("get event logs error: %w", err)
+	}
+
+	return list, nil
+}
+
+// GetEventLog retrieves the journaled event log of a chain emitted at the given transaction and log index, nil when it is not in the journal.
+func (s *SyncRepoImpl) GetEventLog(ctx context.Context, chainId int64, txHash string, logIndex uint) (*model.EventLogTbl, error) {
+	var eventLog model.EventLogTbl
+	if err := conn(ctx, s.mydb).Model(model.EventLogTbl{}).
+		WithContext(ctx).
+		Where("chain_id = ? AND tx_hash = ? AND log_index = ?", chainId, txHash, logIndex).
+		Take(&eventLog).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("get event log error: %w", err)
+	}
+
+	return &eventLog, nil
+}
+
+// DeleteDerivedEvents deletes the rows of a chain that are derived from its event logs.
+func (s *SyncRepoImpl) DeleteDerivedEvents(ctx context.Context, chainId int64) error {
+	return transaction(ctx, s.mydb, func(ctx context.Context) error {
+		for _, table := range []any{
+			&model.ProposalTbl{},
+			&model.VoteTbl{},
+			&model.VoteHistoryTbl{},
+			&model.FipProposalTbl{},
+			&model.FipProposalVoteTbl{},
+			&model.FipEditorTbl{},
+			&model.VoterInfoTbl{},
+			&model.EventDeadLetterTbl{},
+			&model.GithubRepoTbl{},
+			&model.SnapshotHeightTbl{},
+			&model.ConfChangeTbl{},
+			&model.SyncUndoTbl{},
+		} {
+			if err := conn(ctx, s.mydb).WithContext(ctx).
+				Where("chain_id = ?", chainId).
+				Delete(table).Error; err != nil {
+				return fmt.Errorf("delete %T error: %w", table, err)
+			}
+		}
+
+		// The drafts are kept, they are linked again to the rebuilt proposals
+		if err := conn(ctx, s.mydb).WithContext(ctx).
+			Model(model.ProposalDraftTbl{}).
+			Where("chain_id = ? AND proposal_id <> 0", chainId).
+			Update("proposal_id", 0).Error; err != nil {
+			return fmt.Errorf("unlink proposal drafts error: %w", err)
+		}
+
+		return nil
+	})
+}
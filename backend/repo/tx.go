@@ -0,0 +1,42 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// txKey is the context key of the transaction started by Transaction.
+type txKey struct{}
+
+// conn returns the transaction carried by the context, or the database when the context has none,
+// so that the repositories called inside Transaction share its transaction.
+func conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx
+	}
+
+	return db
+}
+
+// transaction runs fn in a transaction carried by the context passed to it.
+// Inside another transaction it runs in a savepoint, so that its changes can be rolled back alone.
+func transaction(ctx context.Context, db *gorm.DB, fn func(ctx context.Context) error) error {
+	return conn(ctx, db).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
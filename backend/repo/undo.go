@@ -0,0 +1,104 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"powervoting-server/model"
+	"powervoting-server/service"
+)
+
+// saveUndo records the rows of a chain matching a query before they are updated in place by the event log
+// of the block carried by the context, so that RollbackSync can restore them. Nothing is recorded outside of the sync.
+func saveUndo[T any](ctx context.Context, db *gorm.DB, chainId int64, query string, args ...any) error {
+	blockNumber, _, ok := service.SyncBlock(ctx)
+	if !ok {
+		return nil
+	}
+
+	var rows []T
+	res := conn(ctx, db).WithContext(ctx).
+		Where("chain_id = ?", chainId).
+		Where(query, args...).
+		Find(&rows)
+	if res.Error != nil {
+		return fmt.Errorf("get %T undo rows error: %w", rows, res.Error)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	raw, err := json.Marshal(rows)
+	if err != nil {
+		return fmt.Errorf("encode %T undo rows error: %w", rows, err)
+	}
+
+	if err := conn(ctx, db).WithContext(ctx).Create(&model.SyncUndoTbl{
+		ChainId:     chainId,
+		BlockNumber: blockNumber,
+		Table:       res.Statement.Table,
+		Rows:        string(raw),
+	}).Error; err != nil {
+		return fmt.Errorf("save %T undo rows error: %w", rows, err)
+	}
+
+	return nil
+}
+
+// restoreUndo writes back the rows recorded by saveUndo, replacing their current state.
+func restoreUndo(tx *gorm.DB, undo model.SyncUndoTbl) error {
+	switch undo.Table {
+	case tableName(tx, &model.FipProposalTbl{}):
+		return restoreRows[model.FipProposalTbl](tx, undo.Rows)
+	case tableName(tx, &model.FipProposalVoteTbl{}):
+		return restoreRows[model.FipProposalVoteTbl](tx, undo.Rows)
+	case tableName(tx, &model.FipEditorTbl{}):
+		return restoreRows[model.FipEditorTbl](tx, undo.Rows)
+	case tableName(tx, &model.VoterInfoTbl{}):
+		return restoreRows[model.VoterInfoTbl](tx, undo.Rows)
+	default:
+		return fmt.Errorf("unknown undo table %s", undo.Table)
+	}
+}
+
+// restoreRows decodes the recorded rows of a table and saves them over the current rows.
+func restoreRows[T any](tx *gorm.DB, raw string) error {
+	var rows []T
+	if err := json.Unmarshal([]byte(raw), &rows); err != nil {
+		return fmt.Errorf("decode %T undo rows error: %w", rows, err)
+	}
+
+	if err := tx.Clauses(clause.OnConflict{UpdateAll: true}).Create(&rows).Error; err != nil {
+		return fmt.Errorf("restore %T rows error: %w", rows, err)
+	}
+
+	return nil
+}
+
+// tableName returns the table of a model under the naming strategy of the database.
+func tableName(db *gorm.DB, value any) string {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(value); err != nil {
+		return ""
+	}
+
+	return stmt.Schema.Table
+}
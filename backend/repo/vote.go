@@ -45,51 +45,67 @@ func (v *VoteRepoImpl) BatchUpdateVotes(ctx context.Context, votes []model.VoteT
 		return nil
 	}
 
-	tx := v.mydb.Begin().WithContext(ctx)
-	if tx.Error != nil {
-		return fmt.Errorf("begin tx error: %w", tx.Error)
-	}
-
-	for _, vote := range votes {
-		if err := tx.Model(model.VoteTbl{}).
-			WithContext(ctx).
-			Where("proposal_id = ? and address = ?", vote.ProposalId, vote.Address).
-			UpdateColumns(map[string]any{
-				"vote_result":        vote.VoteResult,
-				"sp_power":           vote.SpPower,
-				"client_power":       vote.ClientPower,
-				"developer_power":    vote.DeveloperPower,
-				"token_holder_power": vote.TokenHolderPower,
-				"updated_at":         time.Now(),
-			}).Error; err != nil {
-			tx.Rollback()
-			return fmt.Errorf("update vote error: %w", err)
+	return transaction(ctx, v.mydb, func(ctx context.Context) error {
+		for _, vote := range votes {
+			if err := conn(ctx, v.mydb).Model(model.VoteTbl{}).
+				WithContext(ctx).
+				Where("chain_id = ? and proposal_id = ? and address = ?", vote.ChainId, vote.ProposalId, vote.Address).
+				UpdateColumns(map[string]any{
+					"vote_result":        vote.VoteResult,
+					"sp_power":           vote.SpPower,
+					"client_power":       vote.ClientPower,
+					"developer_power":    vote.DeveloperPower,
+					"token_holder_power": vote.TokenHolderPower,
+					"drand_round":        vote.DrandRound,
+					"drand_signature":    vote.DrandSignature,
+					"power_missing":      vote.PowerMissing,
+					"updated_at":         time.Now(),
+				}).Error; err != nil {
+				return fmt.Errorf("update vote error: %w", err)
+			}
 		}
-	}
 
-	return tx.Commit().Error
+		return nil
+	})
 }
 
-// CreateVote creates a new vote record in the database.
+// CreateVote creates the vote of a voter on a proposal in the database.
+// If the voter already voted, the vote is replaced only when the new vote was cast after it,
+// so that replaying older vote events never overrides a later vote.
+// A replaced vote that was already counted has its count cleared.
 func (v VoteRepoImpl) CreateVote(ctx context.Context, in *model.VoteTbl) (int64, error) {
-	// Use OnConflict to implement the existence of the OnConflict implementation,
-	// update if it does not exist, insert it if it does not exist.
-	err := v.mydb.Model(model.VoteTbl{}).
-		WithContext(ctx).
-		Clauses(clause.OnConflict{
-			Columns: []clause.Column{
-				{Name: "proposal_id"},
-				{Name: "address"},
-				{Name: "chain_id"},
-			},
-			DoUpdates: clause.AssignmentColumns([]string{
-				"vote_encrypted",
-				"timestamp",
-				"block_number",
-				"updated_at",
-			}),
-		}).
-		Create(in).Error
+	err := conn(ctx, v.mydb).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current model.VoteTbl
+		err := tx.Model(model.VoteTbl{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("proposal_id = ? AND chain_id = ? AND address = ?", in.ProposalId, in.ChainId, in.Address).
+			First(&current).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return tx.Create(in).Error
+		}
+		if err != nil {
+			return err
+		}
+
+		in.ID = current.ID
+		if !in.After(current) {
+			return nil
+		}
+
+		return tx.Model(model.VoteTbl{}).
+			Where("id = ?", current.ID).
+			UpdateColumns(map[string]any{
+				"vote_encrypted":  in.VoteEncrypted,
+				"timestamp":       in.Timestamp,
+				"block_number":    in.BlockNumber,
+				"log_index":       in.LogIndex,
+				"vote_result":     "",
+				"drand_round":     0,
+				"drand_signature": "",
+				"power_missing":   false,
+				"updated_at":      time.Now(),
+			}).Error
+	})
 	if err != nil {
 		return 0, fmt.Errorf("create or update vote error: %w", err)
 	}
@@ -97,6 +113,48 @@ func (v VoteRepoImpl) CreateVote(ctx context.Context, in *model.VoteTbl) (int64,
 	return in.BaseField.ID, nil
 }
 
+// CreateVoteHistory stores a vote event in the vote history, a vote event already stored is ignored.
+func (v *VoteRepoImpl) CreateVoteHistory(ctx context.Context, in *model.VoteHistoryTbl) error {
+	if err := conn(ctx, v.mydb).Model(model.VoteHistoryTbl{}).
+		WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(in).Error; err != nil {
+		return fmt.Errorf("create vote history error: %w", err)
+	}
+
+	return nil
+}
+
+// GetVoteHistory retrieves every vote of a voter on a proposal, in the order they were cast.
+func (v *VoteRepoImpl) GetVoteHistory(ctx context.Context, chainId, proposalId int64, address string) ([]model.VoteHistoryTbl, error) {
+	var history []model.VoteHistoryTbl
+	if err := conn(ctx, v.mydb).Model(model.VoteHistoryTbl{}).
+		WithContext(ctx).
+		Where("chain_id = ? AND proposal_id = ? AND address = ?", chainId, proposalId, address).
+		Order("block_number asc, log_index asc").
+		Find(&history).Error; err != nil {
+		return nil, fmt.Errorf("get vote history error: %w", err)
+	}
+
+	return history, nil
+}
+
+// GetVoterVotes retrieves the current vote of a voter on every proposal of a network, with the proposal it was cast on.
+func (v *VoteRepoImpl) GetVoterVotes(ctx context.Context, chainId int64, address string) ([]model.VoteWithProposal, error) {
+	var votes []model.VoteWithProposal
+	if err := conn(ctx, v.mydb).Model(model.VoteTbl{}).
+		WithContext(ctx).
+		Select("vote_tbl.*, proposal_tbl.title, proposal_tbl.counted AS proposal_counted, proposal_tbl.outcome").
+		Joins("JOIN proposal_tbl ON proposal_tbl.proposal_id = vote_tbl.proposal_id AND proposal_tbl.chain_id = vote_tbl.chain_id").
+		Where("vote_tbl.chain_id = ? AND vote_tbl.address = ?", chainId, address).
+		Order("vote_tbl.block_number desc, vote_tbl.log_index desc").
+		Find(&votes).Error; err != nil {
+		return nil, fmt.Errorf("get voter votes error: %w", err)
+	}
+
+	return votes, nil
+}
+
 // GetVoteList retrieves a list of votes based on the provided network ID and proposal ID.
 // It queries the database for votes with the following conditions:
 // 1. Matching network ID.
@@ -104,7 +162,7 @@ func (v VoteRepoImpl) CreateVote(ctx context.Context, in *model.VoteTbl) (int64,
 // It returns the list of votes and any error encountered during the database query.
 func (v *VoteRepoImpl) GetVoteList(ctx context.Context, chainId, proposalId int64, counted bool) ([]model.VoteTbl, error) {
 	var proposalList []model.VoteTbl
-	tx := v.mydb.Model(model.VoteTbl{}).
+	tx := conn(ctx, v.mydb).Model(model.VoteTbl{}).
 		WithContext(ctx).
 		Where("chain_id = ? and proposal_id = ?", chainId, proposalId)
 	if counted {
@@ -130,7 +188,7 @@ func (v *VoteRepoImpl) GetVoteList(ctx context.Context, chainId, proposalId int6
 //   - error: An error object if the operation fails.
 func (v *VoteRepoImpl) CreateVoterAddress(ctx context.Context, in *model.VoterInfoTbl) (int64, error) {
 	// Use the `OnConflict` clause to handle duplicate addresses
-	err := v.mydb.Model(model.VoterInfoTbl{}).
+	err := conn(ctx, v.mydb).Model(model.VoterInfoTbl{}).
 		WithContext(ctx).
 		Clauses(clause.OnConflict{
 			Columns: []clause.Column{
@@ -167,7 +225,7 @@ func (v *VoteRepoImpl) GetAllVoterAddresss(ctx context.Context, chainId int64) (
 	var proposalList []model.VoterInfoTbl
 
 	// Query the database for voter addresses created after the specified height
-	if err := v.mydb.Model(model.VoterInfoTbl{}).
+	if err := conn(ctx, v.mydb).Model(model.VoterInfoTbl{}).
 		WithContext(ctx).
 		Where("chain_id = ?", chainId).
 		Find(&proposalList).Error; err != nil {
@@ -177,9 +235,26 @@ func (v *VoteRepoImpl) GetAllVoterAddresss(ctx context.Context, chainId int64) (
 	return proposalList, nil
 }
 
+// RestoreVoterInfo implements service.VoteRepo.
+// Unlike CreateVoterAddress, the power of the voter is not synced.
+func (v *VoteRepoImpl) RestoreVoterInfo(ctx context.Context, in *model.VoterInfoTbl) error {
+	if err := conn(ctx, v.mydb).Model(model.VoterInfoTbl{}).
+		WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(in).Error; err != nil {
+		return fmt.Errorf("restore voter info error: %w", err)
+	}
+
+	return nil
+}
+
 // UpdateVoterByGistInfo implements service.VoteRepo.
 func (v *VoteRepoImpl) UpdateVoterByGistInfo(ctx context.Context, in *model.VoterInfoTbl) error {
-	if err := v.mydb.Model(model.VoterInfoTbl{}).
+	if err := saveUndo[model.VoterInfoTbl](ctx, v.mydb, in.ChainId, "address = ? OR (github_name = ? AND github_name <> '')", in.Address, in.GithubName); err != nil {
+		return err
+	}
+
+	if err := conn(ctx, v.mydb).Model(model.VoterInfoTbl{}).
 		WithContext(ctx).
 		Clauses(clause.OnConflict{
 			Columns: []clause.Column{
@@ -198,9 +273,9 @@ func (v *VoteRepoImpl) UpdateVoterByGistInfo(ctx context.Context, in *model.Vote
 		return err
 	}
 
-	if err := v.mydb.Model(model.VoterInfoTbl{}).
+	if err := conn(ctx, v.mydb).Model(model.VoterInfoTbl{}).
 		WithContext(ctx).
-		Where("address <> ? and github_name = ?", in.Address, in.GithubName).
+		Where("address <> ? and github_name = ? and chain_id = ?", in.Address, in.GithubName, in.ChainId).
 		UpdateColumns(map[string]any{
 			"gist_id":     "",
 			"github_name": "",
@@ -215,9 +290,13 @@ func (v *VoteRepoImpl) UpdateVoterByGistInfo(ctx context.Context, in *model.Vote
 
 // UpdateVoterByMinerInfo implements service.VoteRepo.
 func (v *VoteRepoImpl) UpdateVoterByMinerInfo(ctx context.Context, in *model.VoterInfoTbl) error {
-	if err := v.mydb.Model(model.VoterInfoTbl{}).
+	if err := saveUndo[model.VoterInfoTbl](ctx, v.mydb, in.ChainId, "address = ?", in.Address); err != nil {
+		return err
+	}
+
+	if err := conn(ctx, v.mydb).Model(model.VoterInfoTbl{}).
 		WithContext(ctx).
-		Where("address = ?", in.Address).UpdateColumns(map[string]any{
+		Where("address = ? AND chain_id = ?", in.Address, in.ChainId).UpdateColumns(map[string]any{
 		"miner_ids":  in.MinerIds,
 		"owner_id":   in.OwnerId,
 		"updated_at": time.Now(),
@@ -229,11 +308,11 @@ func (v *VoteRepoImpl) UpdateVoterByMinerInfo(ctx context.Context, in *model.Vot
 }
 
 // GetVoterInfoByAddress implements service.VoteRepo.
-func (v *VoteRepoImpl) GetVoterInfoByAddress(ctx context.Context, address string) (*model.VoterInfoTbl, error) {
+func (v *VoteRepoImpl) GetVoterInfoByAddress(ctx context.Context, chainId int64, address string) (*model.VoterInfoTbl, error) {
 	var voterInfo model.VoterInfoTbl
-	if err := v.mydb.Model(model.VoterInfoTbl{}).
+	if err := conn(ctx, v.mydb).Model(model.VoterInfoTbl{}).
 		WithContext(ctx).
-		Where("address = ?", address).
+		Where("address = ? AND chain_id = ?", address, chainId).
 		First(&voterInfo).Error; err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
 			return nil, fmt.Errorf("voter address not found: %s", address)
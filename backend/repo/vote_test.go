@@ -0,0 +1,90 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo_test
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+
+	"powervoting-server/model"
+	"powervoting-server/repo"
+)
+
+// execRecorder is a connection that records the statements executed on it instead of sending them to a database.
+type execRecorder struct {
+	updates [][]any
+}
+
+func (r *execRecorder) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return nil, sql.ErrConnDone
+}
+
+func (r *execRecorder) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if strings.HasPrefix(query, "UPDATE") {
+		r.updates = append(r.updates, append([]any{query}, args...))
+	}
+
+	return driver.RowsAffected(1), nil
+}
+
+func (r *execRecorder) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return nil, sql.ErrConnDone
+}
+
+func (r *execRecorder) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return nil
+}
+
+func (r *execRecorder) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	return r, nil
+}
+
+func (r *execRecorder) Commit() error {
+	return nil
+}
+
+func (r *execRecorder) Rollback() error {
+	return nil
+}
+
+func TestBatchUpdateVotesByChain(t *testing.T) {
+	recorder := &execRecorder{}
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: recorder, SkipInitializeWithVersion: true}), &gorm.Config{
+		NamingStrategy: schema.NamingStrategy{SingularTable: true},
+	})
+	assert.NoError(t, err)
+
+	// the same proposal ID and voter on two chains
+	err = repo.NewVoteRepo(db).BatchUpdateVotes(context.Background(), []model.VoteTbl{
+		{ChainId: 314, ProposalId: 1, Address: "0xa", VoteResult: "approve"},
+		{ChainId: 314159, ProposalId: 1, Address: "0xa", VoteResult: "reject"},
+	})
+	assert.NoError(t, err)
+
+	assert.Len(t, recorder.updates, 2)
+	for i, chainId := range []int64{314, 314159} {
+		update := recorder.updates[i]
+		assert.Contains(t, update[0], "WHERE chain_id = ? and proposal_id = ? and address = ?")
+		assert.Equal(t, []any{chainId, int64(1), "0xa"}, update[len(update)-3:])
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+	"powervoting-server/model/api"
+	"powervoting-server/service"
+)
+
+type WebhookRepoImpl struct {
+	mydb *gorm.DB
+}
+
+var _ service.WebhookRepo = (*WebhookRepoImpl)(nil)
+
+func NewWebhookRepo(mydb *gorm.DB) *WebhookRepoImpl {
+	return &WebhookRepoImpl{mydb: mydb}
+}
+
+// CreateWebhook saves a new webhook.
+func (w *WebhookRepoImpl) CreateWebhook(ctx context.Context, in *model.WebhookTbl) error {
+	if err := conn(ctx, w.mydb).Model(model.WebhookTbl{}).
+		WithContext(ctx).
+		Create(in).Error; err != nil {
+		return fmt.Errorf("create webhook error: %w", err)
+	}
+
+	return nil
+}
+
+// GetWebhookList retrieves every webhook, the oldest first.
+func (w *WebhookRepoImpl) GetWebhookList(ctx context.Context) ([]model.WebhookTbl, error) {
+	var list []model.WebhookTbl
+	if err := conn(ctx, w.mydb).Model(model.WebhookTbl{}).
+		WithContext(ctx).
+		Order("id").
+		Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("get webhook list error: %w", err)
+	}
+
+	return list, nil
+}
+
+// DeleteWebhook deletes a webhook and cancels its pending deliveries, the delivery log is kept.
+func (w *WebhookRepoImpl) DeleteWebhook(ctx context.Context, id int64) error {
+	return transaction(ctx, w.mydb, func(ctx context.Context) error {
+		res := conn(ctx, w.mydb).Where("id = ?", id).Delete(&model.WebhookTbl{})
+		if res.Error != nil {
+			return fmt.Errorf("delete webhook error: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return constant.ErrWebhookNotFound
+		}
+
+		if err := conn(ctx, w.mydb).Model(model.WebhookDeliveryTbl{}).
+			Where("webhook_id = ? AND status = ?", id, constant.WebhookDeliveryStatusPending).
+			UpdateColumns(map[string]any{
+				"status":     constant.WebhookDeliveryStatusCancelled,
+				"updated_at": time.Now(),
+			}).Error; err != nil {
+			return fmt.Errorf("cancel webhook deliveries error: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// CreateWebhookEvent saves an event for the webhooks.
+// An event already emitted for the same chain, subject and block hash is ignored, the returned flag tells whether it was saved.
+// A log synced again from another block after a chain reorganization emits its event again.
+func (w *WebhookRepoImpl) CreateWebhookEvent(ctx context.Context, in *model.WebhookEventTbl) (bool, error) {
+	res := conn(ctx, w.mydb).Model(model.WebhookEventTbl{}).
+		WithContext(ctx).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(in)
+	if res.Error != nil {
+		return false, fmt.Errorf("create webhook event error: %w", res.Error)
+	}
+
+	return res.RowsAffected > 0, nil
+}
+
+// CreateWebhookDeliveries saves the deliveries of an event.
+func (w *WebhookRepoImpl) CreateWebhookDeliveries(ctx context.Context, list []model.WebhookDeliveryTbl) error {
+	if len(list) == 0 {
+		return nil
+	}
+
+	if err := conn(ctx, w.mydb).Model(model.WebhookDeliveryTbl{}).
+		WithContext(ctx).
+		Create(&list).Error; err != nil {
+		return fmt.Errorf("create webhook deliveries error: %w", err)
+	}
+
+	return nil
+}
+
+// GetDueWebhookDeliveries retrieves the pending deliveries whose next attempt time has passed, the oldest first.
+func (w *WebhookRepoImpl) GetDueWebhookDeliveries(ctx context.Context, now int64, limit int) ([]model.WebhookDueDelivery, error) {
+	var list []model.WebhookDueDelivery
+	if err := conn(ctx, w.mydb).Table("webhook_delivery_tbl AS d").
+		WithContext(ctx).
+		Select("d.*, w.url, w.secret, e.chain_id, e.subject, e.timestamp, e.data").
+		Joins("JOIN webhook_tbl AS w ON w.id = d.webhook_id").
+		Joins("JOIN webhook_event_tbl AS e ON e.id = d.event_id").
+		Where("d.status = ? AND d.next_attempt_time <= ?", constant.WebhookDeliveryStatusPending, now).
+		Order("d.id").
+		Limit(limit).
+		Scan(&list).Error; err != nil {
+		return nil, fmt.Errorf("get due webhook deliveries error: %w", err)
+	}
+
+	return list, nil
+}
+
+// UpdateWebhookDelivery saves the result of an attempt of a delivery.
+func (w *WebhookRepoImpl) UpdateWebhookDelivery(ctx context.Context, in *model.WebhookDeliveryTbl) error {
+	if err := conn(ctx, w.mydb).Model(model.WebhookDeliveryTbl{}).
+		WithContext(ctx).
+		Where("id = ?", in.ID).
+		UpdateColumns(map[string]any{
+			"attempts":          in.Attempts,
+			"status":            in.Status,
+			"next_attempt_time": in.NextAttemptTime,
+			"response_status":   in.ResponseStatus,
+			"error":             in.Error,
+			"delivered_time":    in.DeliveredTime,
+			"updated_at":        time.Now(),
+		}).Error; err != nil {
+		return fmt.Errorf("update webhook delivery error: %w", err)
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveryListWithPagination retrieves the delivery log of a webhook, the most recent delivery first.
+func (w *WebhookRepoImpl) GetWebhookDeliveryListWithPagination(ctx context.Context, req api.WebhookDeliveryListReq) ([]model.WebhookDeliveryTbl, int64, error) {
+	query := w.mydb.Model(model.WebhookDeliveryTbl{}).
+		WithContext(ctx).
+		Where("webhook_id = ?", req.Id)
+	if req.Status != nil {
+		query = query.Where("status = ?", *req.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("count webhook deliveries error: %w", err)
+	}
+
+	var list []model.WebhookDeliveryTbl
+	if err := query.Order("id desc").
+		Limit(req.PageSize).
+		Offset(req.Offset()).
+		Find(&list).Error; err != nil {
+		return nil, 0, fmt.Errorf("get webhook deliveries error: %w", err)
+	}
+
+	return list, total, nil
+}
+
+// PublishWebhookEvents assigns the next stream sequences to the committed events that have none yet, in the order of their IDs.
+// The events of a transaction that is still running are not read, they are published once committed with a higher sequence,
+// so a stream reading by sequence never passes an event that is not committed yet.
+func (w *WebhookRepoImpl) PublishWebhookEvents(ctx context.Context, limit int) error {
+	return transaction(ctx, w.mydb, func(ctx context.Context) error {
+		db := conn(ctx, w.mydb).WithContext(ctx)
+
+		// The locking read serializes the publishers, a sequence is never assigned twice
+		var last int64
+		if err := db.Raw("SELECT COALESCE(MAX(seq), 0) FROM webhook_event_tbl FOR UPDATE").
+			Scan(&last).Error; err != nil {
+			return fmt.Errorf("get last webhook event sequence error: %w", err)
+		}
+
+		var ids []int64
+		if err := db.Model(model.WebhookEventTbl{}).
+			Where("seq IS NULL").
+			Order("id").
+			Limit(limit).
+			Pluck("id", &ids).Error; err != nil {
+			return fmt.Errorf("get unpublished webhook events error: %w", err)
+		}
+
+		for i, id := range ids {
+			if err := db.Model(model.WebhookEventTbl{}).
+				Where("id = ?", id).
+				UpdateColumn("seq", last+int64(i)+1).Error; err != nil {
+				return fmt.Errorf("publish webhook event error: %w", err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// GetWebhookEventsAfter retrieves the published events after the stream sequence of the cursor that match the chain and proposal of a stream,
+// in the order of their sequence.
+func (w *WebhookRepoImpl) GetWebhookEventsAfter(ctx context.Context, req api.StreamReq, cursor int64, limit int) ([]model.WebhookEventTbl, error) {
+	query := w.mydb.Model(model.WebhookEventTbl{}).
+		WithContext(ctx).
+		Where("seq > ?", cursor)
+	if req.ChainId != 0 {
+		query = query.Where("chain_id = ?", req.ChainId)
+	}
+	if req.ProposalId != 0 {
+		query = query.Where("proposal_id = ?", req.ProposalId)
+	}
+
+	var list []model.WebhookEventTbl
+	if err := query.Order("seq").
+		Limit(limit).
+		Find(&list).Error; err != nil {
+		return nil, fmt.Errorf("get webhook events error: %w", err)
+	}
+
+	return list, nil
+}
+
+// GetLastWebhookEventSeq retrieves the stream sequence of the last published event, 0 when no event has been published.
+func (w *WebhookRepoImpl) GetLastWebhookEventSeq(ctx context.Context) (int64, error) {
+	var seq int64
+	if err := w.mydb.Model(model.WebhookEventTbl{}).
+		WithContext(ctx).
+		Select("COALESCE(MAX(seq), 0)").
+		Scan(&seq).Error; err != nil {
+		return 0, fmt.Errorf("get last webhook event sequence error: %w", err)
+	}
+
+	return seq, nil
+}
@@ -0,0 +1,89 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package router
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+	"powervoting-server/model/api"
+	"powervoting-server/openapi"
+)
+
+//go:generate go run ../openapi/gen -out ../../cli/client/client_gen.go
+
+// apiRoutes describes the routes registered by InitRouters for the OpenAPI document, with their request and response structs.
+// A route added to InitRouters must be described here too, TestOpenAPIRoutes fails otherwise.
+var apiRoutes = []openapi.Route{
+	{Method: http.MethodGet, Path: "/health_check", Id: "HealthCheck", Summary: "Check that the server is up", Tag: "health"},
+
+	{Method: http.MethodGet, Path: "/proposal/votes", Id: "GetCountedVotesInfo", Summary: "Get counted votes for a proposal", Tag: "proposal", Request: api.ProposalReq{}, Response: []api.Voted{}},
+	{Method: http.MethodGet, Path: "/proposal/votes/history", Id: "GetVoteHistory", Summary: "Get every vote of a voter on a proposal", Tag: "proposal", Request: api.VoteHistoryReq{}, Response: []api.VoteRevision{}},
+	{Method: http.MethodGet, Path: "/voter/history", Id: "GetVoterHistory", Summary: "Get the vote of a voter on every proposal", Tag: "voter", Request: api.VoterHistoryReq{}, Response: []api.VoterHistoryRep{}},
+	{Method: http.MethodGet, Path: "/proposal/list", Id: "GetProposalList", Summary: "Get a list of proposals", Tag: "proposal", Request: api.ProposalListReq{}, Response: api.CountListRep{List: []api.ProposalRep{}}},
+	{Method: http.MethodGet, Path: "/proposal/details", Id: "GetProposalDetail", Summary: "Get details of a specific proposal", Tag: "proposal", Request: api.ProposalReq{}, Response: api.ProposalRep{}},
+	{Method: http.MethodGet, Path: "/proposal/audit", Id: "GetProposalAudit", Summary: "Get the tally audit bundle of a counted proposal", Tag: "proposal", Request: api.ProposalReq{}, Response: api.ProposalAuditRep{}},
+	{Method: http.MethodGet, Path: "/proposal/result/history", Id: "GetProposalResultHistory", Summary: "Get the results of a proposal replaced by recounts", Tag: "proposal", Request: api.ProposalReq{}, Response: []api.ProposalResultHistoryRep{}},
+	{Method: http.MethodGet, Path: "/proposal/participation", Id: "GetProposalParticipation", Summary: "Get the voters and committed power of a proposal over time", Tag: "proposal", Request: api.ProposalParticipationReq{}, Response: api.ProposalParticipationRep{}},
+	{Method: http.MethodPost, Path: "/proposal/draft/add", Id: "PostDraft", Summary: "Add a new proposal draft, or a new version of a draft", Tag: "draft", Request: api.AddProposalDraftReq{}, Response: api.ProposalDraftRep{}},
+	{Method: http.MethodDelete, Path: "/proposal/draft/delete", Id: "DeleteDraft", Summary: "Delete a specific proposal draft", Tag: "draft", Request: api.DelProposalDraftReq{}},
+	{Method: http.MethodGet, Path: "/proposal/draft/get", Id: "GetDraft", Summary: "Get a specific proposal draft", Tag: "draft", Request: api.ProposalDraftReq{}, Response: api.ProposalDraftRep{}},
+	{Method: http.MethodGet, Path: "/proposal/draft/list", Id: "GetDraftList", Summary: "Get the proposal drafts of a creator", Tag: "draft", Request: api.ProposalDraftListReq{}, Response: []api.ProposalDraftRep{}},
+	{Method: http.MethodGet, Path: "/proposal/draft/versions", Id: "GetDraftVersions", Summary: "Get the version history of a proposal draft", Tag: "draft", Request: api.DraftIdParam{}, Response: []api.ProposalDraftVersionRep{}},
+	{Method: http.MethodGet, Path: "/proposal/draft/diff", Id: "GetDraftDiff", Summary: "Compare two versions of a proposal draft", Tag: "draft", Request: api.ProposalDraftDiffReq{}, Response: api.ProposalDraftDiffRep{}},
+	{Method: http.MethodPost, Path: "/proposal/draft/submit", Id: "SubmitDraft", Summary: "Mark the draft version a proposal is created from", Tag: "draft", Request: api.SubmitProposalDraftReq{}},
+
+	{Method: http.MethodGet, Path: "/power/getPower", Id: "GetAddressPower", Summary: "Get power distribution for a specific address", Tag: "power", Request: api.GetPowerReq{}, Response: api.PowerRep{}},
+
+	{Method: http.MethodGet, Path: "/fipProposal/list", Id: "GetFipProposalList", Summary: "Get a list of FIP editor proposals", Tag: "fip", Request: api.FipProposalListReq{}, Response: api.CountListRep{List: []api.FipProposalRep{}}},
+	{Method: http.MethodGet, Path: "/fipEditor/list", Id: "GetFipEditorList", Summary: "Get a list of FIP editors", Tag: "fip", Request: api.FipEditorListReq{}, Response: []api.FipEditorRep{}},
+	{Method: http.MethodGet, Path: "/voter/info", Id: "GetFipEditorGistInfo", Summary: "Get the gist info of a voter", Tag: "voter", Request: api.VoterInfoReq{}, Response: api.FipEditorGistInfoRep{}},
+	{Method: http.MethodGet, Path: "/fipEditor/checkGist", Id: "VerifyGistValid", Summary: "Check that a gist binds a GitHub account to the addresses", Tag: "fip", Request: api.VerifyGistReq{}, Response: model.SigObject{}},
+
+	{Method: http.MethodGet, Path: "/event/deadLetter/list", Id: "GetDeadLetterList", Summary: "Get the event logs that failed to be applied", Tag: "event", Request: api.DeadLetterListReq{}, Response: api.CountListRep{List: []api.DeadLetterRep{}}},
+
+	{Method: http.MethodGet, Path: "/conf/githubRepo/list", Id: "GetGithubRepoList", Summary: "Get the GitHub repositories of the developer power", Tag: "conf", Request: api.GithubRepoListReq{}, Response: []api.GithubRepoRep{}},
+	{Method: http.MethodGet, Path: "/conf/snapshotHeight/list", Id: "GetSnapshotHeightList", Summary: "Get the snapshot heights of the days", Tag: "conf", Request: api.SnapshotHeightListReq{}, Response: api.CountListRep{List: []api.SnapshotHeightRep{}}},
+	{Method: http.MethodGet, Path: "/conf/snapshotExpiration/history", Id: "GetSnapshotExpirationHistory", Summary: "Get the changes of the snapshot expiration days", Tag: "conf", Request: api.ChainIdParam{}, Response: []api.ConfChangeRep{}},
+	{Method: http.MethodGet, Path: "/conf/countingAlgorithm/history", Id: "GetCountingAlgorithmHistory", Summary: "Get the changes of the vote counting algorithm", Tag: "conf", Request: api.ChainIdParam{}, Response: []api.ConfChangeRep{}},
+
+	{Method: http.MethodPost, Path: "/webhook/add", Id: "AddWebhook", Summary: "Subscribe a URL to governance lifecycle events", Tag: "webhook", Request: api.AddWebhookReq{}, Response: api.WebhookRep{}, Auth: true},
+	{Method: http.MethodGet, Path: "/webhook/list", Id: "GetWebhookList", Summary: "Get the webhooks", Tag: "webhook", Response: []api.WebhookRep{}, Auth: true},
+	{Method: http.MethodDelete, Path: "/webhook/delete", Id: "DeleteWebhook", Summary: "Delete a webhook", Tag: "webhook", Request: api.WebhookReq{}, Auth: true},
+	{Method: http.MethodGet, Path: "/webhook/delivery/list", Id: "GetWebhookDeliveryList", Summary: "Get the delivery log of a webhook", Tag: "webhook", Request: api.WebhookDeliveryListReq{}, Response: api.CountListRep{List: []api.WebhookDeliveryRep{}}, Auth: true},
+
+	{Method: http.MethodGet, Path: "/stream", Id: "Stream", Summary: "Stream the governance lifecycle events as server-sent events", Tag: "stream", Request: api.StreamReq{}, Produces: "text/event-stream"},
+	{Method: http.MethodGet, Path: "/export", Id: "Export", Summary: "Stream a dataset as CSV or JSON Lines", Tag: "export", Request: api.ExportReq{}, Produces: "text/csv"},
+}
+
+// OpenAPI returns the OpenAPI document of the REST API.
+func OpenAPI() *openapi.Document {
+	return openapi.New(openapi.Info{
+		Title:       "PowerVoting API",
+		Description: "Proposals, votes and voter power of the PowerVoting contracts. Every JSON response is an envelope whose code is 0 on success.",
+		Version:     "1.0.0",
+	}, constant.PowerVotingApiPrefix, apiRoutes)
+}
+
+// openAPIRouter serves the OpenAPI document of the REST API.
+func openAPIRouter(rg *gin.RouterGroup) {
+	doc := OpenAPI()
+	rg.GET("/openapi.json", func(c *gin.Context) {
+		c.JSON(http.StatusOK, doc)
+	})
+}
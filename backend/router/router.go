@@ -25,16 +25,34 @@ import (
 	"powervoting-server/service"
 )
 
+// Services are the services behind the power voting API endpoints.
+// A service left nil is not used, which lets a test set only the services of the routes it calls.
+type Services struct {
+	Proposal service.IProposalService // proposals, drafts and their results
+	Vote     service.IVoteService     // votes and voter history
+	Fip      service.IFipService      // FIP proposals and editors
+	Event    service.IEventService    // dead letters of the event sync
+	Conf     service.IConfService     // PowerVotingConf settings
+	Webhook  service.IWebhookService  // webhook subscriptions and deliveries
+	Stream   service.IStreamService   // real-time event stream
+	Export   service.IExportService   // bulk dataset export
+}
+
 // InitRouters initializes the routers for the power voting API endpoints.
 // It defines routes for health check, proposal result, and proposal history.
 // The health check route returns a success response.
 // The proposal result route is mapped to the VoteResult handler function.
 // The proposal history route is mapped to the VoteHistory handler function.
-func InitRouters(r *gin.Engine, proposalService service.IProposalService, voteService service.IVoteService, fipService service.IFipService) {
+func InitRouters(r *gin.Engine, services Services) {
 
-	proposalHandler := api.NewProposalHandler(proposalService)
-	voteHandler := api.NewVoteHandler(voteService)
-	fipHandler := api.NewFipHandle(fipService)
+	proposalHandler := api.NewProposalHandler(services.Proposal)
+	voteHandler := api.NewVoteHandler(services.Vote)
+	fipHandler := api.NewFipHandle(services.Fip)
+	eventHandler := api.NewEventHandler(services.Event)
+	confHandler := api.NewConfHandler(services.Conf)
+	webhookHandler := api.NewWebhookHandler(services.Webhook)
+	streamHandler := api.NewStreamHandler(services.Stream)
+	exportHandler := api.NewExportHandler(services.Export)
 	powerVotingRouter := r.Group(constant.PowerVotingApiPrefix)
 	r.GET(constant.PowerVotingApiPrefix+"/health_check", func(c *gin.Context) {
 		api.Success(c)
@@ -43,17 +61,32 @@ func InitRouters(r *gin.Engine, proposalService service.IProposalService, voteSe
 	proposalRouter(powerVotingRouter, proposalHandler, voteHandler)
 	powerRouter(powerVotingRouter)
 	fipEditor(powerVotingRouter, fipHandler, voteHandler)
+	eventRouter(powerVotingRouter, eventHandler)
+	confRouter(powerVotingRouter, confHandler)
+	webhookRouter(powerVotingRouter, webhookHandler)
+	streamRouter(powerVotingRouter, streamHandler)
+	exportRouter(powerVotingRouter, exportHandler)
+	openAPIRouter(powerVotingRouter)
 }
 
 // proposalRouter defines routes related to proposal management.
 func proposalRouter(rg *gin.RouterGroup, ph *api.ProposalHandler, vh *api.VoteHandler) {
-	rg.GET("/proposal/votes", wrap(vh.GetCountedVotesInfo)) // Get counted votes for a proposal
+	rg.GET("/proposal/votes", wrap(vh.GetCountedVotesInfo))    // Get counted votes for a proposal
+	rg.GET("/proposal/votes/history", wrap(vh.GetVoteHistory)) // Get every vote of a voter on a proposal
+	rg.GET("/voter/history", wrap(vh.GetVoterHistory))         // Get the vote of a voter on every proposal
 
-	rg.GET("/proposal/list", wrap(ph.GetProposalList))        // Get a list of proposals
-	rg.GET("/proposal/details", wrap(ph.GetProposalDetail))   // Get details of a specific proposal
-	rg.POST("/proposal/draft/add", wrap(ph.PostDraft))        // Add a new proposal draft
-	rg.DELETE("/proposal/draft/delete", wrap(ph.DeleteDraft)) // Delete a specific proposal draft
-	rg.GET("/proposal/draft/get", wrap(ph.GetDraft))          // Get a specific proposal draft
+	rg.GET("/proposal/list", wrap(ph.GetProposalList))                    // Get a list of proposals
+	rg.GET("/proposal/details", wrap(ph.GetProposalDetail))               // Get details of a specific proposal
+	rg.GET("/proposal/audit", wrap(ph.GetProposalAudit))                  // Get the tally audit bundle of a counted proposal
+	rg.GET("/proposal/result/history", wrap(ph.GetProposalResultHistory)) // Get the results of a proposal replaced by recounts
+	rg.GET("/proposal/participation", wrap(ph.GetProposalParticipation))  // Get the voters and committed power of a proposal over time
+	rg.POST("/proposal/draft/add", wrap(ph.PostDraft))                    // Add a new proposal draft
+	rg.DELETE("/proposal/draft/delete", wrap(ph.DeleteDraft))             // Delete a specific proposal draft
+	rg.GET("/proposal/draft/get", wrap(ph.GetDraft))                      // Get a specific proposal draft
+	rg.GET("/proposal/draft/list", wrap(ph.GetDraftList))                 // Get the proposal drafts of a creator
+	rg.GET("/proposal/draft/versions", wrap(ph.GetDraftVersions))         // Get the version history of a proposal draft
+	rg.GET("/proposal/draft/diff", wrap(ph.GetDraftDiff))                 // Compare two versions of a proposal draft
+	rg.POST("/proposal/draft/submit", wrap(ph.SubmitDraft))               // Mark the draft version a proposal is created from
 }
 
 // powerRouter defines routes related to power distribution and management.
@@ -70,6 +103,38 @@ func fipEditor(rg *gin.RouterGroup, fh *api.FipHandle, vh *api.VoteHandler) {
 	// The wrap function is used to handle the request and response, passing the fh.GetFipEditorList handler.
 }
 
+// eventRouter defines routes related to the synchronization of contract events.
+func eventRouter(rg *gin.RouterGroup, eh *api.EventHandler) {
+	rg.GET("/event/deadLetter/list", wrap(eh.GetDeadLetterList)) // Get the event logs that failed to be applied
+}
+
+// confRouter defines routes related to the settings of the PowerVotingConf contract.
+func confRouter(rg *gin.RouterGroup, ch *api.ConfHandler) {
+	rg.GET("/conf/githubRepo/list", wrap(ch.GetGithubRepoList))                       // Get the GitHub repositories of the developer power
+	rg.GET("/conf/snapshotHeight/list", wrap(ch.GetSnapshotHeightList))               // Get the snapshot heights of the days
+	rg.GET("/conf/snapshotExpiration/history", wrap(ch.GetSnapshotExpirationHistory)) // Get the changes of the snapshot expiration days
+	rg.GET("/conf/countingAlgorithm/history", wrap(ch.GetCountingAlgorithmHistory))   // Get the changes of the vote counting algorithm
+}
+
+// webhookRouter defines routes related to the management of the webhooks, they need the webhook token.
+func webhookRouter(rg *gin.RouterGroup, wh *api.WebhookHandler) {
+	webhook := rg.Group("/webhook", api.WebhookAuth())
+	webhook.POST("/add", wrap(wh.AddWebhook))                      // Subscribe a URL to governance lifecycle events
+	webhook.GET("/list", wrap(wh.GetWebhookList))                  // Get the webhooks
+	webhook.DELETE("/delete", wrap(wh.DeleteWebhook))              // Delete a webhook
+	webhook.GET("/delivery/list", wrap(wh.GetWebhookDeliveryList)) // Get the delivery log of a webhook
+}
+
+// streamRouter defines routes related to the real-time updates of the proposals and votes.
+func streamRouter(rg *gin.RouterGroup, sh *api.StreamHandler) {
+	rg.GET("/stream", wrap(sh.Stream)) // Stream the governance lifecycle events as server-sent events
+}
+
+// exportRouter defines routes related to the bulk export of the datasets.
+func exportRouter(rg *gin.RouterGroup, eh *api.ExportHandler) {
+	rg.GET("/export", wrap(eh.Export)) // Stream a dataset as CSV or JSON Lines
+}
+
 // wrap is a utility function to wrap handlers with additional context and validation.
 func wrap(h func(c *constant.Context)) gin.HandlerFunc {
 	validate := validator.New()
@@ -2,10 +2,15 @@ package router
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -15,6 +20,7 @@ import (
 	"powervoting-server/constant"
 	"powervoting-server/model"
 	"powervoting-server/model/api"
+	"powervoting-server/openapi"
 	"powervoting-server/service"
 	"powervoting-server/utils"
 )
@@ -22,6 +28,7 @@ import (
 var _ service.IProposalService = (*MockProposalService)(nil)
 var _ service.IFipService = (*MockFipService)(nil)
 var _ service.IVoteService = (*MockVoteService)(nil)
+var _ service.IEventService = (*MockEventService)(nil)
 
 type MockProposalService struct {
 	mock.Mock
@@ -46,25 +53,169 @@ func (m *MockFipService) GetFipProposalList(ctx context.Context, req api.FipProp
 	panic("unimplemented")
 }
 
-// AddDraft implements service.IProposalService.
-func (m *MockProposalService) AddDraft(ctx context.Context, req *api.AddProposalDraftReq) error {
+type MockEventService struct {
+	mock.Mock
+}
+
+// GetDeadLetterList implements service.IEventService.
+func (m *MockEventService) GetDeadLetterList(ctx context.Context, req api.DeadLetterListReq) (*api.CountListRep, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*api.CountListRep), args.Error(1)
+}
+
+type MockConfService struct {
+	mock.Mock
+}
+
+var _ service.IConfService = (*MockConfService)(nil)
+
+// GetGithubRepoList implements service.IConfService.
+func (m *MockConfService) GetGithubRepoList(ctx context.Context, req api.GithubRepoListReq) ([]api.GithubRepoRep, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).([]api.GithubRepoRep), args.Error(1)
+}
+
+// GetSnapshotHeightList implements service.IConfService.
+func (m *MockConfService) GetSnapshotHeightList(ctx context.Context, req api.SnapshotHeightListReq) (*api.CountListRep, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*api.CountListRep), args.Error(1)
+}
+
+// GetSnapshotExpirationHistory implements service.IConfService.
+func (m *MockConfService) GetSnapshotExpirationHistory(ctx context.Context, chainId int64) ([]api.ConfChangeRep, error) {
+	args := m.Called(ctx, chainId)
+	return args.Get(0).([]api.ConfChangeRep), args.Error(1)
+}
+
+// GetCountingAlgorithmHistory implements service.IConfService.
+func (m *MockConfService) GetCountingAlgorithmHistory(ctx context.Context, chainId int64) ([]api.ConfChangeRep, error) {
+	args := m.Called(ctx, chainId)
+	return args.Get(0).([]api.ConfChangeRep), args.Error(1)
+}
+
+type MockWebhookService struct {
+	mock.Mock
+}
+
+var _ service.IWebhookService = (*MockWebhookService)(nil)
+
+// AddWebhook implements service.IWebhookService.
+func (m *MockWebhookService) AddWebhook(ctx context.Context, req api.AddWebhookReq) (*api.WebhookRep, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*api.WebhookRep), args.Error(1)
+}
+
+// GetWebhookList implements service.IWebhookService.
+func (m *MockWebhookService) GetWebhookList(ctx context.Context) ([]api.WebhookRep, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]api.WebhookRep), args.Error(1)
+}
+
+// DeleteWebhook implements service.IWebhookService.
+func (m *MockWebhookService) DeleteWebhook(ctx context.Context, req api.WebhookReq) error {
 	args := m.Called(ctx, req)
 	return args.Error(0)
 }
 
+// GetWebhookDeliveryList implements service.IWebhookService.
+func (m *MockWebhookService) GetWebhookDeliveryList(ctx context.Context, req api.WebhookDeliveryListReq) (*api.CountListRep, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*api.CountListRep), args.Error(1)
+}
+
+type MockStreamService struct {
+	mock.Mock
+}
+
+var _ service.IStreamService = (*MockStreamService)(nil)
+
+// GetStreamCursor implements service.IStreamService.
+func (m *MockStreamService) GetStreamCursor(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// GetStreamEvents implements service.IStreamService.
+func (m *MockStreamService) GetStreamEvents(ctx context.Context, req api.StreamReq, cursor int64) ([]model.StreamEvent, error) {
+	args := m.Called(ctx, req, cursor)
+	return args.Get(0).([]model.StreamEvent), args.Error(1)
+}
+
+type MockExportService struct {
+	mock.Mock
+}
+
+var _ service.IExportService = (*MockExportService)(nil)
+
+// Export implements service.IExportService.
+func (m *MockExportService) Export(ctx context.Context, req api.ExportReq, w io.Writer) error {
+	args := m.Called(ctx, req, w)
+	if rows, ok := args.Get(0).(string); ok && rows != "" {
+		_, _ = io.WriteString(w, rows)
+	}
+	return args.Error(1)
+}
+
+// AddDraft implements service.IProposalService.
+func (m *MockProposalService) AddDraft(ctx context.Context, req *api.AddProposalDraftReq) (*api.ProposalDraftRep, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*api.ProposalDraftRep), args.Error(1)
+}
+
 // GetDraft implements service.IProposalService.
-func (m *MockProposalService) GetDraft(ctx context.Context, req api.AddressReq) (*api.ProposalDraftRep, error) {
+func (m *MockProposalService) GetDraft(ctx context.Context, req api.ProposalDraftReq) (*api.ProposalDraftRep, error) {
 	m.Called(ctx, req)
 	return nil, nil
 }
 
+// GetDraftList implements service.IProposalService.
+func (m *MockProposalService) GetDraftList(ctx context.Context, req api.ProposalDraftListReq) ([]api.ProposalDraftRep, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).([]api.ProposalDraftRep), args.Error(1)
+}
+
+// GetDraftVersions implements service.IProposalService.
+func (m *MockProposalService) GetDraftVersions(ctx context.Context, req api.DraftIdParam) ([]api.ProposalDraftVersionRep, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).([]api.ProposalDraftVersionRep), args.Error(1)
+}
+
+// GetDraftDiff implements service.IProposalService.
+func (m *MockProposalService) GetDraftDiff(ctx context.Context, req api.ProposalDraftDiffReq) (*api.ProposalDraftDiffRep, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*api.ProposalDraftDiffRep), args.Error(1)
+}
+
+// SubmitDraft implements service.IProposalService.
+func (m *MockProposalService) SubmitDraft(ctx context.Context, req api.SubmitProposalDraftReq) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
 // ProposalDetail implements service.IProposalService.
 func (m *MockProposalService) ProposalDetail(ctx context.Context, req api.ProposalReq) (*api.ProposalRep, error) {
 	m.Called(ctx, req)
 	return nil, nil
 }
 
+// ProposalAudit implements service.IProposalService.
+func (m *MockProposalService) ProposalAudit(ctx context.Context, req api.ProposalReq) (*api.ProposalAuditRep, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*api.ProposalAuditRep), args.Error(1)
+}
+
+// ProposalResultHistory implements service.IProposalService.
+func (m *MockProposalService) ProposalResultHistory(ctx context.Context, req api.ProposalReq) ([]api.ProposalResultHistoryRep, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).([]api.ProposalResultHistoryRep), args.Error(1)
+}
+
 // ProposalList implements service.IProposalService.
+func (m *MockProposalService) ProposalParticipation(ctx context.Context, req api.ProposalParticipationReq) (*api.ProposalParticipationRep, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).(*api.ProposalParticipationRep), args.Error(1)
+}
+
 func (m *MockProposalService) ProposalList(ctx context.Context, req api.ProposalListReq) (*api.CountListRep, error) {
 	args := m.Called(ctx, req)
 	return args.Get(0).(*api.CountListRep), args.Error(1)
@@ -79,8 +230,20 @@ func (m *MockVoteService) VerifyGist(ctx context.Context, req api.VerifyGistReq)
 	panic("unimplemented")
 }
 
+// GetVoteHistory implements service.IVoteService.
+func (m *MockVoteService) GetVoteHistory(ctx context.Context, req api.VoteHistoryReq) ([]api.VoteRevision, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).([]api.VoteRevision), args.Error(1)
+}
+
+// GetVoterHistory implements service.IVoteService.
+func (m *MockVoteService) GetVoterHistory(ctx context.Context, req api.VoterHistoryReq) ([]api.VoterHistoryRep, error) {
+	args := m.Called(ctx, req)
+	return args.Get(0).([]api.VoterHistoryRep), args.Error(1)
+}
+
 // GetFipEditorGistInfo implements service.IVoteService.
-func (m *MockVoteService) GetFipEditorGistInfo(ctx context.Context, req api.AddressReq) (*api.FipEditorGistInfoRep, error) {
+func (m *MockVoteService) GetFipEditorGistInfo(ctx context.Context, req api.VoterInfoReq) (*api.FipEditorGistInfoRep, error) {
 	panic("unimplemented")
 }
 
@@ -95,7 +258,7 @@ func setupRouter(p *MockProposalService, v *MockVoteService, f *MockFipService)
 	gin.SetMode(gin.TestMode)
 
 	r := gin.New()
-	InitRouters(r, p, v, f)
+	InitRouters(r, Services{Proposal: p, Vote: v, Fip: f})
 	return r
 }
 
@@ -182,6 +345,128 @@ func TestGetProposalDetail_InvalidChainId(t *testing.T) {
 	assert.Contains(t, resp.Body.String(), constant.CodeParamErrorStr)
 }
 
+func TestGetProposalAudit_Success(t *testing.T) {
+	proposalService := new(MockProposalService)
+	voteService := new(MockVoteService)
+	fipService := new(MockFipService)
+	router := setupRouter(proposalService, voteService, fipService)
+
+	bundle := `{"version":1,"proposal_id":123,"chain_id":1}`
+	proposalService.On("ProposalAudit",
+		mock.Anything,
+		api.ProposalReq{ProposalId: 123, ChainIdParam: api.ChainIdParam{ChainId: 1}},
+	).Return(&api.ProposalAuditRep{
+		ProposalId: 123,
+		ChainId:    1,
+		Sha256:     "hash",
+		Bundle:     json.RawMessage(bundle),
+	}, nil)
+
+	req, _ := http.NewRequest("GET", constant.PowerVotingApiPrefix+"/proposal/audit?proposalId=123&chainId=1", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Contains(t, resp.Body.String(), `"code":0`)
+	// the bundle is served as is, so its hash can be checked
+	assert.Contains(t, resp.Body.String(), `"bundle":`+bundle)
+}
+
+func TestGetProposalParticipation_InvalidInterval(t *testing.T) {
+	proposalService := new(MockProposalService)
+	voteService := new(MockVoteService)
+	fipService := new(MockFipService)
+	router := setupRouter(proposalService, voteService, fipService)
+
+	req, _ := http.NewRequest("GET", constant.PowerVotingApiPrefix+"/proposal/participation?proposalId=123&chainId=1&interval=10", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Contains(t, resp.Body.String(), constant.CodeParamErrorStr)
+	proposalService.AssertNotCalled(t, "ProposalParticipation", mock.Anything, mock.Anything)
+}
+
+func TestGetProposalParticipation_Success(t *testing.T) {
+	proposalService := new(MockProposalService)
+	voteService := new(MockVoteService)
+	fipService := new(MockFipService)
+	router := setupRouter(proposalService, voteService, fipService)
+
+	proposalService.On("ProposalParticipation",
+		mock.Anything,
+		api.ProposalParticipationReq{
+			ProposalReq: api.ProposalReq{ProposalId: 123, ChainIdParam: api.ChainIdParam{ChainId: 1}},
+			Interval:    600,
+		},
+	).Return(&api.ProposalParticipationRep{
+		ProposalId: 123,
+		ChainId:    1,
+		Voters:     2,
+		Interval:   600,
+	}, nil)
+
+	req, _ := http.NewRequest("GET", constant.PowerVotingApiPrefix+"/proposal/participation?proposalId=123&chainId=1&interval=600", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Contains(t, resp.Body.String(), `"code":0`)
+	assert.Contains(t, resp.Body.String(), `"voters":2`)
+}
+
+func TestGetVoteHistory_MissingAddress(t *testing.T) {
+	proposalService := new(MockProposalService)
+	voteService := new(MockVoteService)
+	fipService := new(MockFipService)
+	router := setupRouter(proposalService, voteService, fipService)
+
+	req, _ := http.NewRequest("GET", constant.PowerVotingApiPrefix+"/proposal/votes/history?proposalId=123&chainId=1", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Contains(t, resp.Body.String(), constant.CodeParamErrorStr)
+	voteService.AssertNotCalled(t, "GetVoteHistory", mock.Anything, mock.Anything)
+}
+
+func TestGetVoterHistory(t *testing.T) {
+	proposalService := new(MockProposalService)
+	voteService := new(MockVoteService)
+	fipService := new(MockFipService)
+	router := setupRouter(proposalService, voteService, fipService)
+
+	voteService.On("GetVoterHistory", mock.Anything, api.VoterHistoryReq{
+		ChainIdParam: api.ChainIdParam{ChainId: 314159},
+		Address:      "f410fa",
+	}).Return([]api.VoterHistoryRep{{ProposalId: 1, ChainId: 314159, Counted: true, VotedResult: "approve", Outcome: "passed"}}, nil)
+
+	req, _ := http.NewRequest("GET", constant.PowerVotingApiPrefix+"/voter/history?chainId=314159&address=f410fa", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Contains(t, resp.Body.String(), `"code":0`)
+	assert.Contains(t, resp.Body.String(), `"votedResult":"approve"`)
+	assert.Contains(t, resp.Body.String(), `"outcome":"passed"`)
+	voteService.AssertExpectations(t)
+}
+
+func TestGetVoterHistory_MissingChainId(t *testing.T) {
+	proposalService := new(MockProposalService)
+	voteService := new(MockVoteService)
+	fipService := new(MockFipService)
+	router := setupRouter(proposalService, voteService, fipService)
+
+	req, _ := http.NewRequest("GET", constant.PowerVotingApiPrefix+"/voter/history?address=0x1234567890123456789012345678901234567890", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Contains(t, resp.Body.String(), constant.CodeParamErrorStr)
+	voteService.AssertNotCalled(t, "GetVoterHistory", mock.Anything, mock.Anything)
+}
+
 func TestGetPower_InvalidAddress(t *testing.T) {
 	config.GetDefaultConfig()
 	proposalService := new(MockProposalService)
@@ -204,7 +489,7 @@ func TestPostDraft_Success(t *testing.T) {
 	voteService := new(MockVoteService)
 	fipService := new(MockFipService)
 	router := setupRouter(proposalService, voteService, fipService)
-	body := `{"title": "Test Proposal","creator": "0x1234567890123456789012345678901234567890","startTime": 1,"endTime": 2,"chainId": 3,"content": "test content","tokenHolderPercentage":1,"spPercentage":1,"clientPercentage":1,"developerPercentage":1,"timezone": "Asia/Shanghai"}`
+	body := `{"title": "Test Proposal","creator": "0x1234567890123456789012345678901234567890","startTime": 1,"endTime": 2,"chainId": 3,"content": "test content","tokenHolderPercentage":1,"spPercentage":1,"clientPercentage":1,"developerPercentage":1,"timezone": "Asia/Shanghai","timestamp": 1735689600,"nonce": "nonce-0001","signature": "0xabcd"}`
 
 	proposalService.On("AddDraft",
 		mock.Anything,
@@ -221,8 +506,9 @@ func TestPostDraft_Success(t *testing.T) {
 				ClientPercentage:      1,
 				DeveloperPercentage:   1,
 			},
-			Timezone: "Asia/Shanghai",
-		}).Return(nil)
+			Timezone:  "Asia/Shanghai",
+			DraftAuth: api.DraftAuth{Timestamp: 1735689600, Nonce: "nonce-0001", Signature: "0xabcd"},
+		}).Return(&api.ProposalDraftRep{DraftId: 1, Version: 1}, nil)
 	req, _ := http.NewRequest("POST", constant.PowerVotingApiPrefix+"/proposal/draft/add", strings.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
 	resp := httptest.NewRecorder()
@@ -231,6 +517,19 @@ func TestPostDraft_Success(t *testing.T) {
 
 	assert.Contains(t, resp.Body.String(), `"code":0`)
 	assert.Contains(t, resp.Body.String(), constant.CodeOKStr)
+	assert.Contains(t, resp.Body.String(), `"draftId":1`)
+}
+
+func TestPostDraft_MissingSignature(t *testing.T) {
+	router := setupRouter(new(MockProposalService), nil, nil)
+	body := `{"title": "Test Proposal","creator": "0x1234567890123456789012345678901234567890","startTime": 1,"endTime": 2,"chainId": 3,"content": "test content","timezone": "Asia/Shanghai"}`
+	req, _ := http.NewRequest("POST", constant.PowerVotingApiPrefix+"/proposal/draft/add", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Contains(t, resp.Body.String(), constant.CodeParamErrorStr)
 }
 
 func TestToEthAddr(t *testing.T) {
@@ -239,7 +538,268 @@ func TestToEthAddr(t *testing.T) {
 		Address: "t3qamo6mesb5ppiqqbmsytvadjlbld7k6xji2wkn55hb4w2c5pa4gyyehtxjl6gtxbzs7kcul3z744frid4oqq",
 	}
 
-	res, err := addr.ToEthAddr()
+	res, err := addr.ToEthAddr(0)
 	assert.Nil(t, err)
 	assert.NotEqual(t, addr.Address, res)
 }
+
+func TestGetDeadLetterList(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	eventService := new(MockEventService)
+	router := gin.New()
+	InitRouters(router, Services{Event: eventService})
+
+	// the chain ID is required
+	req, _ := http.NewRequest("GET", constant.PowerVotingApiPrefix+"/event/deadLetter/list", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Contains(t, resp.Body.String(), constant.CodeParamErrorStr)
+	eventService.AssertNotCalled(t, "GetDeadLetterList", mock.Anything, mock.Anything)
+
+	list := &api.CountListRep{
+		Total: 1,
+		List: []api.DeadLetterRep{{
+			Id:       1,
+			ChainId:  314159,
+			Event:    constant.VoteEvt,
+			Error:    "handle vote error: connection refused",
+			Attempts: 10,
+			Status:   constant.DeadLetterStatusExhausted,
+		}},
+	}
+	eventService.On("GetDeadLetterList", mock.Anything, api.DeadLetterListReq{ChainId: 314159, Exhausted: true}).Return(list, nil)
+
+	req, _ = http.NewRequest("GET", constant.PowerVotingApiPrefix+"/event/deadLetter/list?chainId=314159&exhausted=true", nil)
+	resp = httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Contains(t, resp.Body.String(), `"attempts":10`)
+	assert.Contains(t, resp.Body.String(), `"event":"Vote"`)
+	eventService.AssertExpectations(t)
+}
+
+func TestConfRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	confService := new(MockConfService)
+	router := gin.New()
+	InitRouters(router, Services{Conf: confService})
+
+	// the chain ID is required
+	req, _ := http.NewRequest("GET", constant.PowerVotingApiPrefix+"/conf/githubRepo/list", nil)
+	resp := httptest.NewRecorder()
+	router.ServeHTTP(resp, req)
+	assert.Contains(t, resp.Body.String(), constant.CodeParamErrorStr)
+	confService.AssertNotCalled(t, "GetGithubRepoList", mock.Anything, mock.Anything)
+
+	repoReq := api.GithubRepoListReq{ChainIdParam: api.ChainIdParam{ChainId: 314159}, IncludeRemoved: true}
+	confService.On("GetGithubRepoList", mock.Anything, repoReq).
+		Return([]api.GithubRepoRep{{RepoId: 7, RepoName: "filecoin-project/lotus", Removed: true}}, nil)
+	confService.On("GetSnapshotHeightList", mock.Anything, api.SnapshotHeightListReq{ChainIdParam: api.ChainIdParam{ChainId: 314159}}).
+		Return(&api.CountListRep{Total: 1, List: []api.SnapshotHeightRep{{Day: "20240101", Height: 3500000}}}, nil)
+	confService.On("GetSnapshotExpirationHistory", mock.Anything, int64(314159)).
+		Return([]api.ConfChangeRep{{OldValue: "60", NewValue: "90"}}, nil)
+	confService.On("GetCountingAlgorithmHistory", mock.Anything, int64(314159)).
+		Return([]api.ConfChangeRep{{OldValue: constant.CountingAlgorithmWeightedShare, NewValue: "quadratic"}}, nil)
+
+	for path, expected := range map[string]string{
+		"/conf/githubRepo/list?chainId=314159&includeRemoved=true": `"repoName":"filecoin-project/lotus"`,
+		"/conf/snapshotHeight/list?chainId=314159":                 `"height":3500000`,
+		"/conf/snapshotExpiration/history?chainId=314159":          `"newValue":"90"`,
+		"/conf/countingAlgorithm/history?chainId=314159":           `"newValue":"quadratic"`,
+	} {
+		req, _ := http.NewRequest("GET", constant.PowerVotingApiPrefix+path, nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+		assert.Contains(t, resp.Body.String(), expected)
+	}
+	confService.AssertExpectations(t)
+}
+
+func TestWebhookRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	config.GetDefaultConfig()
+	defer func() { config.Client.Webhook.Token = "" }()
+	webhookService := new(MockWebhookService)
+	router := gin.New()
+	InitRouters(router, Services{Webhook: webhookService})
+
+	request := func(method, path, token, body string) string {
+		req, _ := http.NewRequest(method, constant.PowerVotingApiPrefix+path, strings.NewReader(body))
+		if body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp.Body.String()
+	}
+
+	// the management API is disabled without a token
+	assert.Contains(t, request("GET", "/webhook/list", "", ""), constant.CodeUnauthorizedStr)
+
+	config.Client.Webhook.Token = "admin-token"
+	assert.Contains(t, request("GET", "/webhook/list", "wrong-token", ""), constant.CodeUnauthorizedStr)
+	webhookService.AssertNotCalled(t, "GetWebhookList", mock.Anything)
+
+	webhookService.On("GetWebhookList", mock.Anything).
+		Return([]api.WebhookRep{{Id: 1, Url: "https://example.com/hook", Events: []string{constant.WebhookProposalCounted}}}, nil)
+	assert.Contains(t, request("GET", "/webhook/list", "admin-token", ""), `"url":"https://example.com/hook"`)
+
+	// the URL must be valid
+	assert.Contains(t, request("POST", "/webhook/add", "admin-token", `{"url":"example","events":["proposal.counted"]}`), constant.CodeParamErrorStr)
+	webhookService.AssertNotCalled(t, "AddWebhook", mock.Anything, mock.Anything)
+
+	addReq := api.AddWebhookReq{Url: "https://example.com/hook", Events: []string{constant.WebhookProposalCounted}}
+	webhookService.On("AddWebhook", mock.Anything, addReq).
+		Return(&api.WebhookRep{Id: 2, Url: addReq.Url, Events: addReq.Events, Secret: "abcd"}, nil)
+	assert.Contains(t, request("POST", "/webhook/add", "admin-token", `{"url":"https://example.com/hook","events":["proposal.counted"]}`), `"secret":"abcd"`)
+
+	webhookService.On("DeleteWebhook", mock.Anything, api.WebhookReq{Id: 3}).Return(constant.ErrWebhookNotFound)
+	assert.Contains(t, request("DELETE", "/webhook/delete?id=3", "admin-token", ""), constant.ErrWebhookNotFound.Error())
+
+	status := constant.WebhookDeliveryStatusExhausted
+	deliveryReq := api.WebhookDeliveryListReq{WebhookReq: api.WebhookReq{Id: 2}, Status: &status}
+	webhookService.On("GetWebhookDeliveryList", mock.Anything, deliveryReq).
+		Return(&api.CountListRep{Total: 1, List: []api.WebhookDeliveryRep{{Id: 5, WebhookId: 2, Attempts: 8, Status: status}}}, nil)
+	assert.Contains(t, request("GET", "/webhook/delivery/list?id=2&status=2", "admin-token", ""), `"attempts":8`)
+	webhookService.AssertExpectations(t)
+}
+
+func TestStream(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	streamService := new(MockStreamService)
+	router := gin.New()
+	InitRouters(router, Services{Stream: streamService})
+
+	stream := func(path, lastEventId string) *httptest.ResponseRecorder {
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		req, _ := http.NewRequestWithContext(ctx, "GET", constant.PowerVotingApiPrefix+path, nil)
+		if lastEventId != "" {
+			req.Header.Set("Last-Event-ID", lastEventId)
+		}
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	// a proposal can only be streamed with its chain
+	assert.Contains(t, stream("/stream?proposalId=1", "").Body.String(), constant.CodeParamErrorStr)
+
+	// without a cursor the stream starts after the last emitted event
+	req := api.StreamReq{ChainId: 314159, ProposalId: 1}
+	streamService.On("GetStreamCursor", mock.Anything).Return(int64(4), nil).Once()
+	// the event ID is 5, it was committed after the event with the ID 6 and is streamed after it
+	streamService.On("GetStreamEvents", mock.Anything, req, int64(4)).Return([]model.StreamEvent{{
+		Seq: 6,
+		Payload: model.WebhookPayload{
+			Id:      5,
+			Event:   constant.WebhookVoteCast,
+			ChainId: 314159,
+			Subject: "vote:0xabc:1",
+			Data:    json.RawMessage(`{"proposalId":1}`),
+		},
+	}}, nil).Once()
+	// the next read waits for the poll interval, after the sequence of the event
+	streamService.On("GetStreamEvents", mock.Anything, req, int64(6)).Return([]model.StreamEvent{}, nil).Maybe()
+
+	resp := stream("/stream?chainId=314159&proposalId=1", "")
+	assert.Equal(t, "text/event-stream", resp.Header().Get("Content-Type"))
+	assert.Contains(t, resp.Body.String(), "id: 6\nevent: vote.cast\ndata: {\"id\":5,\"event\":\"vote.cast\",\"chainId\":314159,\"subject\":\"vote:0xabc:1\",\"timestamp\":0,\"data\":{\"proposalId\":1}}\n\n")
+	streamService.AssertExpectations(t)
+
+	// a client resumes after the last event it received
+	streamService.On("GetStreamEvents", mock.Anything, mock.Anything, int64(9)).Return([]model.StreamEvent{}, nil).Once()
+	stream("/stream?cursor=2", "9")
+	streamService.On("GetStreamEvents", mock.Anything, mock.Anything, int64(2)).Return([]model.StreamEvent{}, nil).Once()
+	stream("/stream?cursor=2", "")
+	streamService.AssertExpectations(t)
+}
+
+func TestExport(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	exportService := new(MockExportService)
+	router := gin.New()
+	InitRouters(router, Services{Export: exportService})
+
+	export := func(path string) *httptest.ResponseRecorder {
+		req, _ := http.NewRequest("GET", constant.PowerVotingApiPrefix+path, nil)
+		resp := httptest.NewRecorder()
+		router.ServeHTTP(resp, req)
+		return resp
+	}
+
+	assert.Contains(t, export("/export?chainId=314159&dataset=ballots").Body.String(), constant.CodeParamErrorStr)
+	assert.Contains(t, export("/export?chainId=314159&dataset=votes&from=20&to=10").Body.String(), constant.CodeParamErrorStr)
+
+	// csv is the default format
+	req := api.ExportReq{ChainIdParam: api.ChainIdParam{ChainId: 314159}, Dataset: constant.ExportVotes, Format: constant.ExportFormatCsv, From: 10}
+	exportService.On("Export", mock.Anything, req, mock.Anything).Return("chainId,proposalId\n314159,1\n", nil).Once()
+	resp := export("/export?chainId=314159&dataset=votes&from=10")
+	assert.Equal(t, "text/csv; charset=utf-8", resp.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename="votes-314159.csv"`, resp.Header().Get("Content-Disposition"))
+	assert.Equal(t, "chainId,proposalId\n314159,1\n", resp.Body.String())
+
+	// an export that fails before any row was written answers an error
+	req = api.ExportReq{ChainIdParam: api.ChainIdParam{ChainId: 314159}, Dataset: constant.ExportProposals, Format: constant.ExportFormatNdjson}
+	exportService.On("Export", mock.Anything, req, mock.Anything).Return("", errors.New("database is down")).Once()
+	resp = export("/export?chainId=314159&dataset=proposals&format=ndjson")
+	assert.Contains(t, resp.Body.String(), constant.CodeSystemErrorStr)
+	assert.Empty(t, resp.Header().Get("Content-Disposition"))
+	exportService.AssertExpectations(t)
+}
+
+func TestOpenAPIRoutes(t *testing.T) {
+	router := setupRouter(nil, nil, nil)
+
+	registered := map[string]bool{}
+	for _, route := range router.Routes() {
+		if route.Path == constant.PowerVotingApiPrefix+"/openapi.json" {
+			continue
+		}
+		registered[route.Method+" "+strings.TrimPrefix(route.Path, constant.PowerVotingApiPrefix)] = true
+	}
+
+	described := map[string]bool{}
+	for _, route := range apiRoutes {
+		described[route.Method+" "+route.Path] = true
+	}
+	assert.Equal(t, registered, described)
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	router := setupRouter(nil, nil, nil)
+	req, _ := http.NewRequest("GET", constant.PowerVotingApiPrefix+"/openapi.json", nil)
+	resp := httptest.NewRecorder()
+
+	router.ServeHTTP(resp, req)
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	var doc struct {
+		OpenAPI string                                `json:"openapi"`
+		Servers []struct{ Url string }                `json:"servers"`
+		Paths   map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	assert.NoError(t, json.Unmarshal(resp.Body.Bytes(), &doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	assert.Equal(t, constant.PowerVotingApiPrefix, doc.Servers[0].Url)
+	assert.Contains(t, doc.Paths["/proposal/details"], "get")
+	assert.Contains(t, doc.Paths["/proposal/draft/add"], "post")
+}
+
+func TestGeneratedClientUpToDate(t *testing.T) {
+	src, err := openapi.GenerateClient(OpenAPI(), "client")
+	assert.NoError(t, err)
+
+	generated, err := os.ReadFile("../../cli/client/client_gen.go")
+	if os.IsNotExist(err) {
+		t.Skip("the cli is not checked out next to the backend")
+	}
+	assert.NoError(t, err)
+	assert.Equal(t, string(generated), string(src), "run go generate ./router")
+}
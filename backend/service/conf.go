@@ -0,0 +1,166 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+	"powervoting-server/model/api"
+)
+
+// ConfRepo defines the interface for the settings synced from the PowerVotingConf contract.
+type ConfRepo interface {
+	CreateGithubRepo(ctx context.Context, in *model.GithubRepoTbl) error
+	RemoveGithubRepo(ctx context.Context, chainId, repoId, blockNumber int64) error
+	CreateSnapshotHeight(ctx context.Context, in *model.SnapshotHeightTbl) error
+	CreateConfChange(ctx context.Context, in *model.ConfChangeTbl) error
+	GetGithubRepoList(ctx context.Context, req api.GithubRepoListReq) ([]model.GithubRepoTbl, error)
+	GetSnapshotHeightListWithPagination(ctx context.Context, req api.SnapshotHeightListReq) ([]model.SnapshotHeightTbl, int64, error)
+	GetConfChangeList(ctx context.Context, chainId int64, setting string) ([]model.ConfChangeTbl, error)
+}
+
+type IConfService interface {
+	// GetGithubRepoList retrieves the GitHub repositories whose contributors are counted as developers.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - req: The chain ID and whether to include the removed repositories.
+	//
+	// Returns:
+	//   - []api.GithubRepoRep: The repositories ordered by repository ID.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetGithubRepoList(ctx context.Context, req api.GithubRepoListReq) ([]api.GithubRepoRep, error)
+
+	// GetSnapshotHeightList retrieves the snapshot heights set on the contract.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - req: The chain ID and the pagination.
+	//
+	// Returns:
+	//   - *api.CountListRep: The total count and the page of snapshot heights, the most recent day first.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetSnapshotHeightList(ctx context.Context, req api.SnapshotHeightListReq) (*api.CountListRep, error)
+
+	// GetSnapshotExpirationHistory retrieves the changes of the snapshot expiration days.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - chainId: The chain ID of the contract.
+	//
+	// Returns:
+	//   - []api.ConfChangeRep: The changes, the oldest first.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetSnapshotExpirationHistory(ctx context.Context, chainId int64) ([]api.ConfChangeRep, error)
+
+	// GetCountingAlgorithmHistory retrieves the changes of the vote counting algorithm.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - chainId: The chain ID of the contract.
+	//
+	// Returns:
+	//   - []api.ConfChangeRep: The changes, the oldest first.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetCountingAlgorithmHistory(ctx context.Context, chainId int64) ([]api.ConfChangeRep, error)
+}
+
+type ConfService struct {
+	repo ConfRepo
+}
+
+func NewConfService(repo ConfRepo) *ConfService {
+	return &ConfService{
+		repo: repo,
+	}
+}
+
+func (c *ConfService) GetGithubRepoList(ctx context.Context, req api.GithubRepoListReq) ([]api.GithubRepoRep, error) {
+	list, err := c.repo.GetGithubRepoList(ctx, req)
+	if err != nil {
+		zap.L().Error("GetGithubRepoList error", zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]api.GithubRepoRep, 0, len(list))
+	for _, repo := range list {
+		res = append(res, api.GithubRepoRep{
+			RepoId:      repo.RepoId,
+			RepoName:    repo.RepoName,
+			OrgType:     repo.OrgType,
+			Removed:     repo.Removed,
+			BlockNumber: repo.BlockNumber,
+			Timestamp:   repo.Timestamp,
+		})
+	}
+
+	return res, nil
+}
+
+func (c *ConfService) GetSnapshotHeightList(ctx context.Context, req api.SnapshotHeightListReq) (*api.CountListRep, error) {
+	list, total, err := c.repo.GetSnapshotHeightListWithPagination(ctx, req)
+	if err != nil {
+		zap.L().Error("GetSnapshotHeightListWithPagination error", zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]api.SnapshotHeightRep, 0, len(list))
+	for _, height := range list {
+		res = append(res, api.SnapshotHeightRep{
+			Day:         height.Day,
+			Height:      height.Height,
+			BlockNumber: height.BlockNumber,
+			Timestamp:   height.Timestamp,
+		})
+	}
+
+	return &api.CountListRep{
+		Total: total,
+		List:  res,
+	}, nil
+}
+
+func (c *ConfService) GetSnapshotExpirationHistory(ctx context.Context, chainId int64) ([]api.ConfChangeRep, error) {
+	return c.getConfChangeList(ctx, chainId, constant.ConfSettingSnapshotExpirationDays)
+}
+
+func (c *ConfService) GetCountingAlgorithmHistory(ctx context.Context, chainId int64) ([]api.ConfChangeRep, error) {
+	return c.getConfChangeList(ctx, chainId, constant.ConfSettingCountingAlgorithm)
+}
+
+func (c *ConfService) getConfChangeList(ctx context.Context, chainId int64, setting string) ([]api.ConfChangeRep, error) {
+	list, err := c.repo.GetConfChangeList(ctx, chainId, setting)
+	if err != nil {
+		zap.L().Error("GetConfChangeList error", zap.String("setting", setting), zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]api.ConfChangeRep, 0, len(list))
+	for _, change := range list {
+		res = append(res, api.ConfChangeRep{
+			OldValue:    change.OldValue,
+			NewValue:    change.NewValue,
+			BlockNumber: change.BlockNumber,
+			TxHash:      change.TxHash,
+			Timestamp:   change.Timestamp,
+		})
+	}
+
+	return res, nil
+}
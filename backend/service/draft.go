@@ -0,0 +1,461 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"go.uber.org/zap"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+	"powervoting-server/model/api"
+	"powervoting-server/utils"
+)
+
+// draftPayload is the content of a saved draft covered by the signature of its creator.
+// It is hashed as JSON with the fields in this order and without HTML escaping.
+type draftPayload struct {
+	Name                  string `json:"name"`
+	Title                 string `json:"title"`
+	Content               string `json:"content"`
+	StartTime             int64  `json:"startTime"`
+	EndTime               int64  `json:"endTime"`
+	Timezone              string `json:"timezone"`
+	TokenHolderPercentage uint16 `json:"tokenHolderPercentage"`
+	SpPercentage          uint16 `json:"spPercentage"`
+	ClientPercentage      uint16 `json:"clientPercentage"`
+	DeveloperPercentage   uint16 `json:"developerPercentage"`
+}
+
+// DraftSaveMessage returns the message the creator of a draft signs to save it.
+// The draft line is the hex SHA-256 of the draft content, so that the signature can not be used to save another content.
+// The draft ID line is only in the message saving a new version of an existing draft.
+func DraftSaveMessage(req *api.AddProposalDraftReq) string {
+	var payload bytes.Buffer
+	encoder := json.NewEncoder(&payload)
+	encoder.SetEscapeHTML(false)
+	// A struct of strings and integers always encodes
+	_ = encoder.Encode(draftPayload{
+		Name:                  req.Name,
+		Title:                 req.Title,
+		Content:               req.Content,
+		StartTime:             req.StartTime,
+		EndTime:               req.EndTime,
+		Timezone:              req.Timezone,
+		TokenHolderPercentage: req.TokenHolderPercentage,
+		SpPercentage:          req.SpPercentage,
+		ClientPercentage:      req.ClientPercentage,
+		DeveloperPercentage:   req.DeveloperPercentage,
+	})
+	hash := sha256.Sum256(bytes.TrimSuffix(payload.Bytes(), []byte("\n")))
+
+	var fields []string
+	if req.DraftId != 0 {
+		fields = append(fields, fmt.Sprintf("Draft ID: %d", req.DraftId))
+	}
+	fields = append(fields, "Draft: "+hex.EncodeToString(hash[:]))
+
+	return draftMessage(constant.DraftActionSave, req.Creator, req.ChainId, req.DraftAuth, fields...)
+}
+
+// DraftDeleteMessage returns the message the creator of a draft signs to delete it.
+func DraftDeleteMessage(req api.DelProposalDraftReq) string {
+	return draftMessage(constant.DraftActionDelete, req.Address, req.ChainId, req.DraftAuth,
+		fmt.Sprintf("Draft ID: %d", req.DraftId))
+}
+
+// DraftSubmitMessage returns the message the creator of a draft signs to mark the version a proposal is created from.
+func DraftSubmitMessage(req api.SubmitProposalDraftReq) string {
+	return draftMessage(constant.DraftActionSubmit, req.Creator, req.ChainId, req.DraftAuth,
+		fmt.Sprintf("Draft ID: %d", req.DraftId), fmt.Sprintf("Version: %d", req.Version))
+}
+
+// draftMessage builds the signed message of a draft mutation, one field per line.
+// The fields of the action are written between the chain ID and the timestamp.
+func draftMessage(action, creator string, chainId int64, auth api.DraftAuth, fields ...string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "PowerVoting draft %s\n", action)
+	fmt.Fprintf(&b, "Creator: %s\n", creator)
+	fmt.Fprintf(&b, "Chain ID: %d\n", chainId)
+	for _, field := range fields {
+		b.WriteString(field + "\n")
+	}
+	fmt.Fprintf(&b, "Timestamp: %d\n", auth.Timestamp)
+	fmt.Fprintf(&b, "Nonce: %s", auth.Nonce)
+
+	return b.String()
+}
+
+// draftSignerSupported checks whether the drafts of an address can be signed:
+// an Ethereum address signs with EIP-191, an f1 or f3 Filecoin address with its wallet key.
+func draftSignerSupported(address string) bool {
+	if strings.HasPrefix(address, "0x") {
+		return common.IsHexAddress(address)
+	}
+	if len(address) < 3 || (address[0] != 'f' && address[0] != 't') {
+		return false
+	}
+
+	return address[1] == '1' || address[1] == '3'
+}
+
+// verifyDraftAuth checks that a draft mutation is signed by the creator, within the signature window of now,
+// with a nonce the creator has not used before. The nonce is only recorded once the signature is verified,
+// so that a request without a valid signature can not use up the nonce of the creator.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - creator: The address of the creator of the draft.
+//   - message: The message the creator signed.
+//   - auth: The timestamp, nonce and signature of the request.
+//
+// Returns:
+//   - error: ErrDraftSignatureExpired, ErrDraftSignatureInvalid or ErrDraftNonceUsed if the mutation is not authenticated; otherwise, nil.
+func (p *ProposalService) verifyDraftAuth(ctx context.Context, creator, message string, auth api.DraftAuth) error {
+	now := time.Now().Unix()
+	if auth.Timestamp < now-constant.DraftSignatureWindow || auth.Timestamp > now+constant.DraftSignatureWindow {
+		return constant.ErrDraftSignatureExpired
+	}
+
+	if !draftSignerSupported(creator) {
+		return constant.ErrDraftSignatureInvalid
+	}
+
+	valid, err := utils.VerifySignature(creator, auth.Signature, []byte(message))
+	if err != nil || !valid {
+		zap.L().Warn("VerifyDraftSignatureFailed", zap.String("creator", creator), zap.Error(err))
+		return constant.ErrDraftSignatureInvalid
+	}
+
+	saved, err := p.repo.CreateDraftNonce(ctx, &model.DraftNonceTbl{
+		Address:   creator,
+		Nonce:     auth.Nonce,
+		Timestamp: auth.Timestamp,
+	})
+	if err != nil {
+		zap.L().Error("CreateDraftNonceFailed", zap.Error(err))
+		return err
+	}
+	if !saved {
+		return constant.ErrDraftNonceUsed
+	}
+
+	// The payloads signed before the window are rejected by their timestamp, their nonces are no longer needed
+	if err := p.repo.DeleteDraftNonces(ctx, now-2*constant.DraftSignatureWindow); err != nil {
+		zap.L().Warn("DeleteDraftNoncesFailed", zap.Error(err))
+	}
+
+	return nil
+}
+
+// creatorDraft retrieves a draft of a creator on a chain, ErrDraftNotFound if the draft does not exist or is not a draft of the creator.
+func (p *ProposalService) creatorDraft(ctx context.Context, id int64, creator string, chainId int64) (*model.ProposalDraftTbl, error) {
+	draft, err := p.repo.GetProposalDraft(ctx, id)
+	if err != nil {
+		zap.L().Error("GetProposalDraft error", zap.Error(err))
+		return nil, errors.New("fail to get proposal draft")
+	}
+
+	if draft == nil || !strings.EqualFold(draft.Creator, creator) || draft.ChainId != chainId {
+		return nil, constant.ErrDraftNotFound
+	}
+
+	return draft, nil
+}
+
+// GetDraftList retrieves the proposal drafts of a creator, the last saved first.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - req: Contains the creator's address and the chain ID of the drafts.
+//
+// Returns:
+//   - []api.ProposalDraftRep: The proposal drafts.
+//   - error: An error if the query operation fails; otherwise, nil.
+func (p *ProposalService) GetDraftList(ctx context.Context, req api.ProposalDraftListReq) ([]api.ProposalDraftRep, error) {
+	list, err := p.repo.GetProposalDraftList(ctx, req)
+	if err != nil {
+		zap.L().Error("GetProposalDraftList error", zap.Error(err))
+		return nil, errors.New("fail to get proposal draft list")
+	}
+
+	res := make([]api.ProposalDraftRep, 0, len(list))
+	for i := range list {
+		res = append(res, *draftRep(&list[i]))
+	}
+
+	return res, nil
+}
+
+// GetDraftVersions retrieves the version history of a proposal draft, the first version first.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - req: Contains the ID of the draft.
+//
+// Returns:
+//   - []api.ProposalDraftVersionRep: The versions of the draft.
+//   - error: An error if the query operation fails or if no draft is found; otherwise, nil.
+func (p *ProposalService) GetDraftVersions(ctx context.Context, req api.DraftIdParam) ([]api.ProposalDraftVersionRep, error) {
+	list, err := p.repo.GetProposalDraftVersions(ctx, req.DraftId)
+	if err != nil {
+		zap.L().Error("GetProposalDraftVersions error", zap.Error(err))
+		return nil, errors.New("fail to get proposal draft versions")
+	}
+
+	if len(list) == 0 {
+		return nil, constant.ErrDraftNotFound
+	}
+
+	res := make([]api.ProposalDraftVersionRep, 0, len(list))
+	for _, version := range list {
+		res = append(res, api.ProposalDraftVersionRep{
+			Version:               version.Version,
+			CreatedAt:             version.CreatedAt.Unix(),
+			Title:                 version.Title,
+			Content:               version.Content,
+			StartTime:             version.StartTime,
+			EndTime:               version.EndTime,
+			Timezone:              version.Timezone,
+			TokenHolderPercentage: version.TokenHolderPercentage,
+			SpPercentage:          version.SpPercentage,
+			DeveloperPercentage:   version.DeveloperPercentage,
+			ClientPercentage:      version.ClientPercentage,
+		})
+	}
+
+	return res, nil
+}
+
+// GetDraftDiff compares two versions of a proposal draft.
+// The changed fields are listed with their values, and the content is compared line by line.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - req: Contains the ID of the draft and the versions to compare.
+//
+// Returns:
+//   - *api.ProposalDraftDiffRep: The changes from the first version to the second one.
+//   - error: An error if the query operation fails or if a version is not found; otherwise, nil.
+func (p *ProposalService) GetDraftDiff(ctx context.Context, req api.ProposalDraftDiffReq) (*api.ProposalDraftDiffRep, error) {
+	var versions [2]*model.ProposalDraftVersionTbl
+	for i, number := range []int{req.From, req.To} {
+		version, err := p.repo.GetProposalDraftVersion(ctx, req.DraftId, number)
+		if err != nil {
+			zap.L().Error("GetProposalDraftVersion error", zap.Error(err))
+			return nil, errors.New("fail to get proposal draft version")
+		}
+		if version == nil {
+			return nil, fmt.Errorf("no version %d of the draft found", number)
+		}
+		versions[i] = version
+	}
+
+	res := &api.ProposalDraftDiffRep{
+		DraftId: req.DraftId,
+		From:    req.From,
+		To:      req.To,
+		Fields:  draftFieldChanges(versions[0], versions[1]),
+		Content: []api.DraftDiffLine{},
+	}
+	if versions[0].Content != versions[1].Content {
+		res.Content = diffLines(versions[0].Content, versions[1].Content)
+	}
+
+	return res, nil
+}
+
+// SubmitDraft marks the version of a draft a proposal is created from, so that the proposal is linked to the draft once it is synced.
+// The request must be signed by the creator, see DraftSubmitMessage for the signed message.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - req: Contains the ID and version of the draft and the signature of the creator.
+//
+// Returns:
+//   - error: An error if the request is not authenticated, the version is not found or the draft is already linked to a proposal; otherwise, nil.
+func (p *ProposalService) SubmitDraft(ctx context.Context, req api.SubmitProposalDraftReq) error {
+	if err := p.verifyDraftAuth(ctx, req.Creator, DraftSubmitMessage(req), req.DraftAuth); err != nil {
+		return err
+	}
+
+	draft, err := p.creatorDraft(ctx, req.DraftId, req.Creator, req.ChainId)
+	if err != nil {
+		return err
+	}
+	if draft.ProposalId != 0 {
+		return fmt.Errorf("draft is already linked to proposal %d", draft.ProposalId)
+	}
+
+	version, err := p.repo.GetProposalDraftVersion(ctx, req.DraftId, req.Version)
+	if err != nil {
+		zap.L().Error("GetProposalDraftVersion error", zap.Error(err))
+		return errors.New("fail to get proposal draft version")
+	}
+	if version == nil {
+		return fmt.Errorf("no version %d of the draft found", req.Version)
+	}
+
+	proposer, err := p.proposerAddress(ctx, req.Creator, req.ChainId)
+	if err != nil {
+		zap.L().Error("ResolveProposerAddress error", zap.String("creator", req.Creator), zap.Error(err))
+		return errors.New("fail to resolve the creator address")
+	}
+
+	if err := p.repo.SubmitProposalDraft(ctx, req.DraftId, req.Version, proposer, time.Now().Unix()); err != nil {
+		zap.L().Error("SubmitProposalDraft error", zap.Error(err))
+		return errors.New("fail to submit proposal draft")
+	}
+
+	return nil
+}
+
+// proposerAddress returns the lowercase 0x address the proposal of a draft creator is created by on the chain.
+// An f1 or f3 creator sends the proposal from the masked 0x address of its actor ID.
+func (p *ProposalService) proposerAddress(ctx context.Context, creator string, chainId int64) (string, error) {
+	if strings.HasPrefix(creator, "0x") {
+		return strings.ToLower(creator), nil
+	}
+
+	idAddress, err := p.lotusRepo.FilecoinAddressToID(ctx, chainId, creator)
+	if err != nil {
+		return "", fmt.Errorf("look up actor ID of %s: %w", creator, err)
+	}
+
+	ethAddress, err := p.lotusRepo.FilecoinAddrToEthAddr(ctx, chainId, idAddress)
+	if err != nil {
+		return "", fmt.Errorf("convert %s to an eth address: %w", idAddress, err)
+	}
+
+	return strings.ToLower(ethAddress), nil
+}
+
+// draftRep transforms a proposal draft into the response format.
+func draftRep(draft *model.ProposalDraftTbl) *api.ProposalDraftRep {
+	return &api.ProposalDraftRep{
+		DraftId:               draft.ID,
+		Name:                  draft.Name,
+		Creator:               draft.Creator,
+		ChainId:               draft.ChainId,
+		Version:               draft.Version,
+		SubmittedVersion:      draft.SubmittedVersion,
+		ProposalId:            draft.ProposalId,
+		UpdatedAt:             draft.UpdatedAt.Unix(),
+		Content:               draft.Content,
+		StartTime:             draft.StartTime,
+		EndTime:               draft.EndTime,
+		Timezone:              draft.Timezone,
+		Title:                 draft.Title,
+		SpPercentage:          draft.SpPercentage,
+		TokenHolderPercentage: draft.TokenHolderPercentage,
+		ClientPercentage:      draft.ClientPercentage,
+		DeveloperPercentage:   draft.DeveloperPercentage,
+	}
+}
+
+// draftContentChanged checks whether a saved draft has another content than the current one, the versioned fields.
+func draftContentChanged(current, saved *model.ProposalDraftTbl) bool {
+	return current.Title != saved.Title ||
+		current.Content != saved.Content ||
+		current.StartTime != saved.StartTime ||
+		current.EndTime != saved.EndTime ||
+		current.Timezone != saved.Timezone ||
+		current.Percentage != saved.Percentage
+}
+
+// draftFieldChanges lists the fields other than the content whose value differs between two versions of a draft.
+func draftFieldChanges(from, to *model.ProposalDraftVersionTbl) []api.DraftFieldChange {
+	fields := []struct {
+		name     string
+		from, to any
+	}{
+		{"title", from.Title, to.Title},
+		{"startTime", from.StartTime, to.StartTime},
+		{"endTime", from.EndTime, to.EndTime},
+		{"timezone", from.Timezone, to.Timezone},
+		{"tokenHolderPercentage", from.TokenHolderPercentage, to.TokenHolderPercentage},
+		{"spPercentage", from.SpPercentage, to.SpPercentage},
+		{"clientPercentage", from.ClientPercentage, to.ClientPercentage},
+		{"developerPercentage", from.DeveloperPercentage, to.DeveloperPercentage},
+	}
+
+	changes := []api.DraftFieldChange{}
+	for _, field := range fields {
+		if field.from != field.to {
+			changes = append(changes, api.DraftFieldChange{
+				Field: field.name,
+				From:  fmt.Sprint(field.from),
+				To:    fmt.Sprint(field.to),
+			})
+		}
+	}
+
+	return changes
+}
+
+// diffLines returns the line diff of two texts, built from the longest common subsequence of their lines.
+func diffLines(from, to string) []api.DraftDiffLine {
+	a, b := strings.Split(from, "\n"), strings.Split(to, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var diff []api.DraftDiffLine
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			diff = append(diff, api.DraftDiffLine{Op: constant.DraftDiffEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			diff = append(diff, api.DraftDiffLine{Op: constant.DraftDiffDelete, Text: a[i]})
+			i++
+		default:
+			diff = append(diff, api.DraftDiffLine{Op: constant.DraftDiffInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, api.DraftDiffLine{Op: constant.DraftDiffDelete, Text: a[i]})
+	}
+	for ; j < len(b); j++ {
+		diff = append(diff, api.DraftDiffLine{Op: constant.DraftDiffInsert, Text: b[j]})
+	}
+
+	return diff
+}
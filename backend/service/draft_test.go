@@ -0,0 +1,274 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/constant"
+	"powervoting-server/mock"
+	"powervoting-server/model/api"
+	"powervoting-server/service"
+)
+
+// signDraft signs a draft message with EIP-191, as a wallet does with personal_sign.
+func signDraft(t *testing.T, key *ecdsa.PrivateKey, message string) string {
+	sig, err := crypto.Sign(accounts.TextHash([]byte(message)), key)
+	assert.NoError(t, err)
+
+	return hexutil.Encode(sig)
+}
+
+func mockDraftReq(creator, nonce string) *api.AddProposalDraftReq {
+	return &api.AddProposalDraftReq{
+		Creator:      creator,
+		StartTime:    1735689600,
+		EndTime:      1736294400,
+		Timezone:     "UTC",
+		Title:        "Draft <title>",
+		Content:      "content & more",
+		ChainIdParam: api.ChainIdParam{ChainId: 314159},
+		DraftAuth:    api.DraftAuth{Timestamp: time.Now().Unix(), Nonce: nonce},
+	}
+}
+
+func assertAddDraftError(t *testing.T, proposalService *service.ProposalService, req *api.AddProposalDraftReq, expected error) {
+	_, err := proposalService.AddDraft(context.Background(), req)
+	assert.ErrorIs(t, err, expected)
+}
+
+func TestDraftSaveMessage(t *testing.T) {
+	req := mockDraftReq("0x1234567890123456789012345678901234567890", "nonce-0001")
+	req.Timestamp = 1735689600
+
+	// the draft is hashed as compact JSON without HTML escaping
+	assert.Equal(t, "PowerVoting draft save\n"+
+		"Creator: 0x1234567890123456789012345678901234567890\n"+
+		"Chain ID: 314159\n"+
+		"Draft: 9d82efd173ec684c2a9b2b3e65dbd4fbca90d93cbaf8a446a1674f84a1066656\n"+
+		"Timestamp: 1735689600\n"+
+		"Nonce: nonce-0001", service.DraftSaveMessage(req))
+}
+
+func TestAddDraftSignature(t *testing.T) {
+	proposalService := service.NewProposalService(&mock.MockProposalService{}, nil, nil)
+	ctx := context.Background()
+	key, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	creator := crypto.PubkeyToAddress(key.PublicKey).Hex()
+
+	req := mockDraftReq(creator, "nonce-0001")
+	req.Signature = signDraft(t, key, service.DraftSaveMessage(req))
+	draft, err := proposalService.AddDraft(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), draft.DraftId)
+	assert.Equal(t, 1, draft.Version)
+	// an unnamed draft is named after its title
+	assert.Equal(t, "Draft <title>", draft.Name)
+
+	// the signature does not cover another content
+	changed := *req
+	changed.Content = "other content"
+	assertAddDraftError(t, proposalService, &changed, constant.ErrDraftSignatureInvalid)
+
+	// nor another creator
+	other, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	forged := mockDraftReq(creator, "nonce-0002")
+	forged.Signature = signDraft(t, other, service.DraftSaveMessage(forged))
+	assertAddDraftError(t, proposalService, forged, constant.ErrDraftSignatureInvalid)
+
+	// a payload signed too long ago is rejected
+	expired := mockDraftReq(creator, "nonce-0003")
+	expired.Timestamp -= constant.DraftSignatureWindow + 1
+	expired.Signature = signDraft(t, key, service.DraftSaveMessage(expired))
+	assertAddDraftError(t, proposalService, expired, constant.ErrDraftSignatureExpired)
+
+	// a nonce is accepted once
+	replayed := mockDraftReq(creator, "used-nonce")
+	replayed.Signature = signDraft(t, key, service.DraftSaveMessage(replayed))
+	assertAddDraftError(t, proposalService, replayed, constant.ErrDraftNonceUsed)
+
+	// only 0x, f1 and f3 creators can sign
+	unsupported := mockDraftReq("f01234", "nonce-0004")
+	unsupported.Signature = req.Signature
+	assertAddDraftError(t, proposalService, unsupported, constant.ErrDraftSignatureInvalid)
+}
+
+func TestDeleteDraftSignature(t *testing.T) {
+	proposalService := service.NewProposalService(&mock.MockProposalService{}, nil, nil)
+	key := mockDraftCreatorKey(t)
+
+	req := api.DelProposalDraftReq{
+		AddressReq:   api.AddressReq{Address: mock.MockDraftCreator},
+		ChainIdParam: api.ChainIdParam{ChainId: 314159},
+		DraftIdParam: api.DraftIdParam{DraftId: 1},
+		DraftAuth:    api.DraftAuth{Timestamp: time.Now().Unix(), Nonce: "nonce-0001"},
+	}
+	// a signature of another action is not accepted
+	req.Signature = signDraft(t, key, service.DraftSaveMessage(mockDraftReq(req.Address, req.Nonce)))
+	assert.ErrorIs(t, proposalService.DeleteDraft(context.Background(), req), constant.ErrDraftSignatureInvalid)
+
+	req.Signature = signDraft(t, key, service.DraftDeleteMessage(req))
+	assert.NoError(t, proposalService.DeleteDraft(context.Background(), req))
+
+	// only a draft of the creator can be deleted
+	req.DraftId = 4
+	req.Nonce = "nonce-0002"
+	req.Signature = signDraft(t, key, service.DraftDeleteMessage(req))
+	assert.ErrorIs(t, proposalService.DeleteDraft(context.Background(), req), constant.ErrDraftNotFound)
+}
+
+// mockDraftCreatorKey returns the key of the creator of the mock drafts.
+func mockDraftCreatorKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := crypto.HexToECDSA("ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	assert.NoError(t, err)
+	assert.Equal(t, mock.MockDraftCreator, crypto.PubkeyToAddress(key.PublicKey).Hex())
+
+	return key
+}
+
+func TestAddDraftVersion(t *testing.T) {
+	proposalService := service.NewProposalService(&mock.MockProposalService{}, nil, nil)
+	ctx := context.Background()
+	key := mockDraftCreatorKey(t)
+
+	// the mock draft 1 is at version 2, saving another content makes version 3
+	req := mockDraftReq(mock.MockDraftCreator, "nonce-0001")
+	req.DraftId = 1
+	req.Signature = signDraft(t, key, service.DraftSaveMessage(req))
+	draft, err := proposalService.AddDraft(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), draft.DraftId)
+	assert.Equal(t, 3, draft.Version)
+	assert.Equal(t, "draft", draft.Name)
+
+	// renaming a draft keeps its version
+	current, err := proposalService.GetDraft(ctx, api.ProposalDraftReq{DraftId: 1})
+	assert.NoError(t, err)
+	req = mockDraftReq(mock.MockDraftCreator, "nonce-0002")
+	req.DraftId = 1
+	req.Name = "renamed"
+	req.Title = current.Title
+	req.Content = current.Content
+	req.TokenHolderPercentage = current.TokenHolderPercentage
+	req.SpPercentage = current.SpPercentage
+	req.ClientPercentage = current.ClientPercentage
+	req.DeveloperPercentage = current.DeveloperPercentage
+	req.Signature = signDraft(t, key, service.DraftSaveMessage(req))
+	draft, err = proposalService.AddDraft(ctx, req)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, draft.Version)
+	assert.Equal(t, "renamed", draft.Name)
+
+	// a draft of another creator can not be saved
+	other, err := crypto.GenerateKey()
+	assert.NoError(t, err)
+	req = mockDraftReq(crypto.PubkeyToAddress(other.PublicKey).Hex(), "nonce-0003")
+	req.DraftId = 1
+	req.Signature = signDraft(t, other, service.DraftSaveMessage(req))
+	assertAddDraftError(t, proposalService, req, constant.ErrDraftNotFound)
+}
+
+func TestGetDraft(t *testing.T) {
+	proposalService := service.NewProposalService(&mock.MockProposalService{}, nil, nil)
+	ctx := context.Background()
+
+	draft, err := proposalService.GetDraft(ctx, api.ProposalDraftReq{AddressReq: api.AddressReq{Address: mock.MockDraftCreator}, DraftId: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), draft.ProposalId)
+	assert.Equal(t, 2, draft.SubmittedVersion)
+
+	// a draft is not found for another creator
+	_, err = proposalService.GetDraft(ctx, api.ProposalDraftReq{AddressReq: api.AddressReq{Address: "0x1234567890123456789012345678901234567890"}, DraftId: 2})
+	assert.ErrorIs(t, err, constant.ErrDraftNotFound)
+
+	list, err := proposalService.GetDraftList(ctx, api.ProposalDraftListReq{Address: mock.MockDraftCreator})
+	assert.NoError(t, err)
+	assert.Len(t, list, 2)
+
+	versions, err := proposalService.GetDraftVersions(ctx, api.DraftIdParam{DraftId: 1})
+	assert.NoError(t, err)
+	assert.Len(t, versions, 2)
+	assert.Equal(t, "Title", versions[0].Title)
+	_, err = proposalService.GetDraftVersions(ctx, api.DraftIdParam{DraftId: 4})
+	assert.ErrorIs(t, err, constant.ErrDraftNotFound)
+}
+
+func TestGetDraftDiff(t *testing.T) {
+	proposalService := service.NewProposalService(&mock.MockProposalService{}, nil, nil)
+
+	diff, err := proposalService.GetDraftDiff(context.Background(), api.ProposalDraftDiffReq{DraftIdParam: api.DraftIdParam{DraftId: 1}, From: 1, To: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, []api.DraftFieldChange{
+		{Field: "title", From: "Title", To: "New title"},
+		{Field: "tokenHolderPercentage", From: "25", To: "40"},
+		{Field: "spPercentage", From: "25", To: "20"},
+		{Field: "clientPercentage", From: "25", To: "20"},
+		{Field: "developerPercentage", From: "25", To: "20"},
+	}, diff.Fields)
+	assert.Equal(t, []api.DraftDiffLine{
+		{Op: constant.DraftDiffEqual, Text: "line 1"},
+		{Op: constant.DraftDiffDelete, Text: "line 2"},
+		{Op: constant.DraftDiffEqual, Text: "line 3"},
+		{Op: constant.DraftDiffInsert, Text: "line 4"},
+	}, diff.Content)
+
+	// a version compared to itself has no changes
+	diff, err = proposalService.GetDraftDiff(context.Background(), api.ProposalDraftDiffReq{DraftIdParam: api.DraftIdParam{DraftId: 1}, From: 2, To: 2})
+	assert.NoError(t, err)
+	assert.Empty(t, diff.Fields)
+	assert.Empty(t, diff.Content)
+
+	_, err = proposalService.GetDraftDiff(context.Background(), api.ProposalDraftDiffReq{DraftIdParam: api.DraftIdParam{DraftId: 1}, From: 1, To: 5})
+	assert.Error(t, err)
+}
+
+func TestSubmitDraft(t *testing.T) {
+	proposalService := service.NewProposalService(&mock.MockProposalService{}, nil, nil)
+	ctx := context.Background()
+	key := mockDraftCreatorKey(t)
+
+	req := api.SubmitProposalDraftReq{
+		Creator:      mock.MockDraftCreator,
+		Version:      2,
+		ChainIdParam: api.ChainIdParam{ChainId: 314159},
+		DraftIdParam: api.DraftIdParam{DraftId: 1},
+		DraftAuth:    api.DraftAuth{Timestamp: time.Now().Unix(), Nonce: "nonce-0001"},
+	}
+	req.Signature = signDraft(t, key, service.DraftSubmitMessage(req))
+	assert.NoError(t, proposalService.SubmitDraft(ctx, req))
+
+	// the version must exist
+	req.Version = 5
+	req.Nonce = "nonce-0002"
+	req.Signature = signDraft(t, key, service.DraftSubmitMessage(req))
+	assert.Error(t, proposalService.SubmitDraft(ctx, req))
+
+	// a draft linked to a proposal can not be marked again
+	req.DraftId = 2
+	req.Version = 2
+	req.Nonce = "nonce-0003"
+	req.Signature = signDraft(t, key, service.DraftSubmitMessage(req))
+	assert.ErrorContains(t, proposalService.SubmitDraft(ctx, req), "linked to proposal 7")
+}
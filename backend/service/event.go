@@ -0,0 +1,84 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+
+	"go.uber.org/zap"
+
+	"powervoting-server/model"
+	"powervoting-server/model/api"
+)
+
+type EventRepo interface {
+	GetDeadLetterListWithPagination(ctx context.Context, req api.DeadLetterListReq) ([]model.EventDeadLetterTbl, int64, error)
+}
+
+type IEventService interface {
+	// GetDeadLetterList retrieves the event logs that failed to be applied and have not been replayed successfully.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - req: The chain ID, the pagination and whether to only list the logs that failed every replay.
+	//
+	// Returns:
+	//   - *api.CountListRep: The total count and the page of dead letters, the most recent event first.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetDeadLetterList(ctx context.Context, req api.DeadLetterListReq) (*api.CountListRep, error)
+}
+
+type EventService struct {
+	repo EventRepo
+}
+
+func NewEventService(repo EventRepo) *EventService {
+	return &EventService{
+		repo: repo,
+	}
+}
+
+func (e *EventService) GetDeadLetterList(ctx context.Context, req api.DeadLetterListReq) (*api.CountListRep, error) {
+	list, total, err := e.repo.GetDeadLetterListWithPagination(ctx, req)
+	if err != nil {
+		zap.L().Error("GetDeadLetterListWithPagination error", zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]api.DeadLetterRep, 0, len(list))
+	for _, letter := range list {
+		res = append(res, api.DeadLetterRep{
+			Id:              letter.ID,
+			ChainId:         letter.ChainId,
+			Event:           letter.Event,
+			ContractAddress: letter.ContractAddress,
+			BlockNumber:     letter.BlockNumber,
+			TxHash:          letter.TxHash,
+			LogIndex:        letter.LogIndex,
+			RawLog:          json.RawMessage(letter.RawLog),
+			Error:           letter.Error,
+			Attempts:        letter.Attempts,
+			Status:          letter.Status,
+			NextAttemptTime: letter.NextAttemptTime,
+			FailedTime:      letter.CreatedAt.Unix(),
+		})
+	}
+
+	return &api.CountListRep{
+		Total: total,
+		List:  res,
+	}, nil
+}
@@ -0,0 +1,342 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+	"powervoting-server/model/api"
+)
+
+// ExportRepo defines the interface for reading the datasets of a bulk export.
+// Each method passes the rows of a network in the time range of the export to fn, one batch at a time.
+type ExportRepo interface {
+	ExportProposals(ctx context.Context, req api.ExportReq, fn func([]model.ProposalTbl) error) error
+	ExportVotes(ctx context.Context, req api.ExportReq, fn func([]model.VoteTbl) error) error
+	ExportFipProposals(ctx context.Context, req api.ExportReq, fn func([]model.FipProposalTbl) error) error
+	ExportFipVotes(ctx context.Context, req api.ExportReq, fn func([]model.FipProposalVoteTbl) error) error
+	ExportVoters(ctx context.Context, req api.ExportReq, fn func([]model.VoterInfoTbl) error) error
+}
+
+type IExportService interface {
+	// Export writes a dataset of a network to w as CSV or JSON Lines, one batch of rows at a time.
+	// w is flushed after every batch when it can be, so that a large export is streamed.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - req: The dataset, format, chain ID and time range of the export.
+	//   - w: The writer the export is written to.
+	//
+	// Returns:
+	//   - error: An error if the dataset or format is not supported, or the rows can not be read or written; otherwise, nil.
+	Export(ctx context.Context, req api.ExportReq, w io.Writer) error
+}
+
+type ExportService struct {
+	repo ExportRepo
+}
+
+func NewExportService(repo ExportRepo) *ExportService {
+	return &ExportService{
+		repo: repo,
+	}
+}
+
+// Export writes a dataset of a network to w as CSV or JSON Lines.
+func (e *ExportService) Export(ctx context.Context, req api.ExportReq, w io.Writer) error {
+	var err error
+	switch req.Dataset {
+	case constant.ExportProposals:
+		err = exportRows(ctx, req, w, e.repo.ExportProposals, newExportProposalRow)
+	case constant.ExportVotes:
+		err = exportRows(ctx, req, w, e.repo.ExportVotes, newExportVoteRow)
+	case constant.ExportFipProposals:
+		err = exportRows(ctx, req, w, e.repo.ExportFipProposals, newExportFipProposalRow)
+	case constant.ExportFipVotes:
+		err = exportRows(ctx, req, w, e.repo.ExportFipVotes, newExportFipVoteRow)
+	case constant.ExportVoters:
+		err = exportRows(ctx, req, w, e.repo.ExportVoters, newExportVoterRow)
+	default:
+		return fmt.Errorf("unsupported export dataset %q", req.Dataset)
+	}
+
+	if err != nil {
+		zap.L().Error("Export failed", zap.String("dataset", req.Dataset), zap.Int64("chainId", req.ChainId), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// exportRows reads the rows of a dataset with read, converts every row with toRow and writes it with the encoder of the export format.
+func exportRows[M, R any](
+	ctx context.Context,
+	req api.ExportReq,
+	w io.Writer,
+	read func(context.Context, api.ExportReq, func([]M) error) error,
+	toRow func(M) R,
+) error {
+	var row R
+	enc, err := newExportEncoder(req.Format, reflect.TypeOf(row), w)
+	if err != nil {
+		return err
+	}
+
+	if err := read(ctx, req, func(batch []M) error {
+		for _, m := range batch {
+			if err := enc.encode(toRow(m)); err != nil {
+				return err
+			}
+		}
+
+		return enc.flush()
+	}); err != nil {
+		return err
+	}
+
+	return enc.close()
+}
+
+// exportEncoder writes the rows of an export in its format.
+type exportEncoder interface {
+	// encode writes one row.
+	encode(row any) error
+	// flush writes the buffered rows to the writer of the export and flushes it when it can be.
+	flush() error
+	// close ends the export, an empty CSV export still gets its header.
+	close() error
+}
+
+// newExportEncoder returns the encoder of an export format, CSV when the format is empty.
+func newExportEncoder(format string, rowType reflect.Type, w io.Writer) (exportEncoder, error) {
+	switch format {
+	case "", constant.ExportFormatCsv:
+		return &csvExportEncoder{w: w, csv: csv.NewWriter(w), header: csvHeader(rowType)}, nil
+	case constant.ExportFormatNdjson:
+		return &ndjsonExportEncoder{w: w, json: json.NewEncoder(w)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
+
+// flushWriter flushes the writer of an export when it can be, such as an HTTP response.
+func flushWriter(w io.Writer) {
+	if f, ok := w.(interface{ Flush() }); ok {
+		f.Flush()
+	}
+}
+
+// csvExportEncoder writes every row as a CSV record, the header row holds the JSON names of the fields.
+type csvExportEncoder struct {
+	w           io.Writer
+	csv         *csv.Writer
+	header      []string
+	wroteHeader bool
+}
+
+func (c *csvExportEncoder) encode(row any) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	return c.csv.Write(csvRecord(reflect.ValueOf(row)))
+}
+
+func (c *csvExportEncoder) flush() error {
+	c.csv.Flush()
+	if err := c.csv.Error(); err != nil {
+		return err
+	}
+	flushWriter(c.w)
+
+	return nil
+}
+
+func (c *csvExportEncoder) close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	return c.flush()
+}
+
+// writeHeader writes the header row before the first record.
+func (c *csvExportEncoder) writeHeader() error {
+	if c.wroteHeader {
+		return nil
+	}
+	c.wroteHeader = true
+
+	return c.csv.Write(c.header)
+}
+
+// csvHeader returns the JSON names of the fields of a row type.
+func csvHeader(rowType reflect.Type) []string {
+	header := make([]string, 0, rowType.NumField())
+	for i := 0; i < rowType.NumField(); i++ {
+		name, _, _ := strings.Cut(rowType.Field(i).Tag.Get("json"), ",")
+		header = append(header, name)
+	}
+
+	return header
+}
+
+// csvRecord formats the fields of a row, the values of a list are separated by semicolons.
+func csvRecord(row reflect.Value) []string {
+	record := make([]string, 0, row.NumField())
+	for i := 0; i < row.NumField(); i++ {
+		field := row.Field(i)
+		switch field.Kind() {
+		case reflect.Slice:
+			values := make([]string, 0, field.Len())
+			for j := 0; j < field.Len(); j++ {
+				values = append(values, fmt.Sprint(field.Index(j).Interface()))
+			}
+			record = append(record, strings.Join(values, ";"))
+		case reflect.Float64:
+			record = append(record, strconv.FormatFloat(field.Float(), 'f', -1, 64))
+		default:
+			record = append(record, fmt.Sprint(field.Interface()))
+		}
+	}
+
+	return record
+}
+
+// ndjsonExportEncoder writes every row as a JSON object on its own line.
+type ndjsonExportEncoder struct {
+	w    io.Writer
+	json *json.Encoder
+}
+
+func (n *ndjsonExportEncoder) encode(row any) error {
+	return n.json.Encode(row)
+}
+
+func (n *ndjsonExportEncoder) flush() error {
+	flushWriter(n.w)
+	return nil
+}
+
+func (n *ndjsonExportEncoder) close() error {
+	return n.flush()
+}
+
+// newExportProposalRow converts a proposal to an export row, the result is empty until the proposal is counted.
+func newExportProposalRow(proposal model.ProposalTbl) api.ExportProposalRow {
+	row := api.ExportProposalRow{
+		ChainId:               proposal.ChainId,
+		ProposalId:            proposal.ProposalId,
+		Creator:               proposal.Creator,
+		Title:                 proposal.Title,
+		Content:               proposal.Content,
+		StartTime:             proposal.StartTime,
+		EndTime:               proposal.EndTime,
+		Timestamp:             proposal.Timestamp,
+		BlockNumber:           proposal.BlockNumber,
+		SnapshotDay:           proposal.SnapshotDay,
+		SnapshotBlockHeight:   proposal.SnapshotBlockHeight,
+		CountingAlgorithm:     proposal.CountingAlgorithm,
+		VoteOptions:           proposal.Options(),
+		TokenHolderPercentage: proposal.TokenHolderPercentage,
+		SpPercentage:          proposal.SpPercentage,
+		ClientPercentage:      proposal.ClientPercentage,
+		DeveloperPercentage:   proposal.DeveloperPercentage,
+		Counted:               proposal.Counted == constant.ProposalCounted,
+	}
+	if row.Counted {
+		row.ResultRevision = max(proposal.ResultRevision, 1)
+		row.Outcome = proposal.Outcome
+		row.ApprovePercentage = proposal.ApprovePercentage
+		row.RejectPercentage = proposal.RejectPercentage
+		row.AbstainPercentage = proposal.AbstainPercentage
+		row.TotalSpPower = proposal.TotalSpPower
+		row.TotalClientPower = proposal.TotalClientPower
+		row.TotalTokenHolderPower = proposal.TotalTokenHolderPower
+		row.TotalDeveloperPower = proposal.TotalDeveloperPower
+	}
+
+	return row
+}
+
+// newExportVoteRow converts a counted vote to an export row.
+func newExportVoteRow(vote model.VoteTbl) api.ExportVoteRow {
+	return api.ExportVoteRow{
+		ChainId:          vote.ChainId,
+		ProposalId:       vote.ProposalId,
+		Address:          vote.Address,
+		VotedResult:      vote.VoteResult,
+		SpPower:          vote.SpPower,
+		ClientPower:      vote.ClientPower,
+		TokenHolderPower: vote.TokenHolderPower,
+		DeveloperPower:   vote.DeveloperPower,
+		PowerMissing:     vote.PowerMissing,
+		BlockNumber:      vote.BlockNumber,
+		LogIndex:         vote.LogIndex,
+		VotedTime:        vote.Timestamp,
+	}
+}
+
+// newExportFipProposalRow converts a FIP editor proposal to an export row.
+func newExportFipProposalRow(proposal model.FipProposalTbl) api.ExportFipProposalRow {
+	return api.ExportFipProposalRow{
+		ChainId:          proposal.ChainId,
+		ProposalId:       proposal.ProposalId,
+		ProposalType:     proposal.ProposalType,
+		Status:           proposal.Status,
+		Creator:          proposal.Creator,
+		CandidateAddress: proposal.CandidateAddress,
+		CandidateInfo:    proposal.CandidateInfo,
+		BlockNumber:      proposal.BlockNumber,
+		Timestamp:        proposal.Timestamp,
+	}
+}
+
+// newExportFipVoteRow converts a vote on a FIP editor proposal to an export row.
+func newExportFipVoteRow(vote model.FipProposalVoteTbl) api.ExportFipVoteRow {
+	return api.ExportFipVoteRow{
+		ChainId:     vote.ChainId,
+		ProposalId:  vote.ProposalId,
+		Voter:       vote.Voter,
+		Removed:     vote.IsRemove == constant.FipEditorInvalid,
+		BlockNumber: vote.BlockNumber,
+		Timestamp:   vote.Timestamp,
+	}
+}
+
+// newExportVoterRow converts the identity of a voter to an export row.
+func newExportVoterRow(voter model.VoterInfoTbl) api.ExportVoterRow {
+	return api.ExportVoterRow{
+		ChainId:     voter.ChainId,
+		Address:     voter.Address,
+		OwnerId:     voter.OwnerId,
+		MinerIds:    voter.MinerIds,
+		GistId:      voter.GistId,
+		GithubName:  voter.GithubName,
+		BlockNumber: voter.BlockNumber,
+		Timestamp:   voter.Timestamp,
+	}
+}
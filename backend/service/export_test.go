@@ -0,0 +1,111 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+	"powervoting-server/model/api"
+)
+
+// fakeExportRepo passes the rows of each dataset to the export in batches of one row.
+type fakeExportRepo struct {
+	ExportRepo
+	proposals []model.ProposalTbl
+	votes     []model.VoteTbl
+}
+
+func (f *fakeExportRepo) ExportProposals(ctx context.Context, req api.ExportReq, fn func([]model.ProposalTbl) error) error {
+	for _, proposal := range f.proposals {
+		if err := fn([]model.ProposalTbl{proposal}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *fakeExportRepo) ExportVotes(ctx context.Context, req api.ExportReq, fn func([]model.VoteTbl) error) error {
+	for _, vote := range f.votes {
+		if err := fn([]model.VoteTbl{vote}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestExportVotesCsv(t *testing.T) {
+	exportService := NewExportService(&fakeExportRepo{votes: []model.VoteTbl{{
+		ChainId:          314159,
+		ProposalId:       1,
+		Address:          "0x1234567890123456789012345678901234567890",
+		VoteResult:       constant.VoteApprove,
+		SpPower:          "0",
+		ClientPower:      "0",
+		TokenHolderPower: "1000",
+		DeveloperPower:   "0",
+		BlockNumber:      100,
+		LogIndex:         2,
+		Timestamp:        1000,
+	}}})
+
+	var out bytes.Buffer
+	req := api.ExportReq{ChainIdParam: api.ChainIdParam{ChainId: 314159}, Dataset: constant.ExportVotes}
+	assert.NoError(t, exportService.Export(context.Background(), req, &out))
+	assert.Equal(t, "chainId,proposalId,address,votedResult,spPower,clientPower,tokenHolderPower,developerPower,powerMissing,blockNumber,logIndex,votedTime\n"+
+		"314159,1,0x1234567890123456789012345678901234567890,approve,0,0,1000,0,false,100,2,1000\n", out.String())
+
+	// an empty export still has its header
+	out.Reset()
+	exportService = NewExportService(&fakeExportRepo{})
+	assert.NoError(t, exportService.Export(context.Background(), req, &out))
+	assert.Equal(t, "chainId,proposalId,address,votedResult,spPower,clientPower,tokenHolderPower,developerPower,powerMissing,blockNumber,logIndex,votedTime\n", out.String())
+}
+
+func TestExportProposalsNdjson(t *testing.T) {
+	exportService := NewExportService(&fakeExportRepo{proposals: []model.ProposalTbl{
+		{ChainId: 314159, ProposalId: 1, Title: "Counted", Counted: constant.ProposalCounted, ProposalResult: model.ProposalResult{ApprovePercentage: 62.5, Outcome: constant.ProposalOutcomePassed}},
+		{ChainId: 314159, ProposalId: 2, Title: "In progress", ProposalResult: model.ProposalResult{Outcome: "stale"}},
+	}})
+
+	var out bytes.Buffer
+	req := api.ExportReq{ChainIdParam: api.ChainIdParam{ChainId: 314159}, Dataset: constant.ExportProposals, Format: constant.ExportFormatNdjson}
+	assert.NoError(t, exportService.Export(context.Background(), req, &out))
+
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	assert.Len(t, lines, 2)
+	assert.Contains(t, string(lines[0]), `"approvePercentage":62.5`)
+	assert.Contains(t, string(lines[0]), `"outcome":"passed"`)
+	assert.Contains(t, string(lines[0]), `"resultRevision":1`)
+	assert.Contains(t, string(lines[0]), `"voteOptions":["approve","reject"]`)
+	// the result of a proposal that is not counted is left empty
+	assert.Contains(t, string(lines[1]), `"counted":false`)
+	assert.Contains(t, string(lines[1]), `"outcome":""`)
+}
+
+func TestExportUnsupported(t *testing.T) {
+	exportService := NewExportService(&fakeExportRepo{})
+
+	req := api.ExportReq{ChainIdParam: api.ChainIdParam{ChainId: 314159}, Dataset: "ballots"}
+	assert.Error(t, exportService.Export(context.Background(), req, &bytes.Buffer{}))
+
+	req = api.ExportReq{ChainIdParam: api.ChainIdParam{ChainId: 314159}, Dataset: constant.ExportVotes, Format: "xml"}
+	assert.Error(t, exportService.Export(context.Background(), req, &bytes.Buffer{}))
+}
@@ -29,8 +29,8 @@ type FipRepo interface {
 	CreateFipProposal(ctx context.Context, in *model.FipProposalTbl) (int64, error)
 	GetFipProposalListWithPagination(ctx context.Context, req api.FipProposalListReq) ([]model.FipProposalVoted, int64, error)
 	GetUnpassFipProposalList(ctx context.Context, chainId int64) ([]model.FipProposalTbl, error)
-	UpdateFipProposalVoteByAddress(ctx context.Context, proposalId int64, address string) error
-	UpdateFipEditorByAddress(ctx context.Context, address string) error
+	UpdateFipProposalVoteByAddress(ctx context.Context, chainId, proposalId int64, address string) error
+	UpdateFipEditorByAddress(ctx context.Context, chainId int64, address string) error
 	UpdateStatusAndGetFipProposal(ctx context.Context, proposalId, chainId int64) (*model.FipProposalTbl, error)
 	CreateFipProposalVote(ctx context.Context, in *model.FipProposalVoteTbl) (int64, error)
 	CreateFipEditor(ctx context.Context, in *model.FipEditorTbl) (int64, error)
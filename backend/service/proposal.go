@@ -16,8 +16,11 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -54,8 +57,8 @@ type ProposalRepo interface {
 	//   - error: An error if the query operation fails; otherwise, nil.
 	GetProposalById(ctx context.Context, req api.ProposalReq) (*model.ProposalTbl, error)
 
-	// CreateProposalDraft creates a new proposal draft.
-	// A creator can only have one draft proposal at a time; creating a new draft overwrites the existing one.
+	// CreateProposalDraft creates a new proposal draft, with its content as the first version.
+	// A creator can hold many drafts.
 	//
 	// Parameters:
 	//   - ctx: Context for request cancellation and timeout.
@@ -66,8 +69,40 @@ type ProposalRepo interface {
 	//   - error: An error if the creation operation fails; otherwise, nil.
 	CreateProposalDraft(ctx context.Context, in *model.ProposalDraftTbl) (int64, error)
 
-	DeleteProposalDraft(ctx context.Context, req api.DelProposalDraftReq) error
-	// GetProposalDraftByAddress retrieves a proposal draft by the creator's address.
+	// UpdateProposalDraft saves a proposal draft that was read at the previous version.
+	// When the version of the draft is not the previous version, its content is saved as a new version.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - in: The proposal draft with its new name, content and version.
+	//   - previousVersion: The version the draft was read at.
+	//
+	// Returns:
+	//   - error: ErrDraftVersionConflict if the draft has been saved since it was read, or an error if the update operation fails; otherwise, nil.
+	UpdateProposalDraft(ctx context.Context, in *model.ProposalDraftTbl, previousVersion int) error
+
+	// DeleteProposalDraft deletes a proposal draft and its versions.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - id: The ID of the draft.
+	//
+	// Returns:
+	//   - error: An error if the deletion operation fails; otherwise, nil.
+	DeleteProposalDraft(ctx context.Context, id int64) error
+
+	// GetProposalDraft retrieves a proposal draft by its ID.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - id: The ID of the draft.
+	//
+	// Returns:
+	//   - *model.ProposalDraftTbl: The proposal draft, nil if it does not exist.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetProposalDraft(ctx context.Context, id int64) (*model.ProposalDraftTbl, error)
+
+	// GetProposalDraftByAddress retrieves the last saved proposal draft of a creator.
 	//
 	// Parameters:
 	//   - ctx: Context for request cancellation and timeout.
@@ -78,6 +113,86 @@ type ProposalRepo interface {
 	//   - error: An error if the query operation fails; otherwise, nil.
 	GetProposalDraftByAddress(ctx context.Context, req api.AddressReq) (*model.ProposalDraftTbl, error)
 
+	// GetProposalDraftList retrieves the proposal drafts of a creator, the last saved first.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - req: Contains the creator's address and the chain ID of the drafts.
+	//
+	// Returns:
+	//   - []model.ProposalDraftTbl: The proposal drafts.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetProposalDraftList(ctx context.Context, req api.ProposalDraftListReq) ([]model.ProposalDraftTbl, error)
+
+	// GetProposalDraftVersions retrieves the versions of a proposal draft, the first one first.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - draftId: The ID of the draft.
+	//
+	// Returns:
+	//   - []model.ProposalDraftVersionTbl: The versions of the draft.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetProposalDraftVersions(ctx context.Context, draftId int64) ([]model.ProposalDraftVersionTbl, error)
+
+	// GetProposalDraftVersion retrieves a version of a proposal draft.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - draftId: The ID of the draft.
+	//   - version: The version.
+	//
+	// Returns:
+	//   - *model.ProposalDraftVersionTbl: The version, nil if it does not exist.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetProposalDraftVersion(ctx context.Context, draftId int64, version int) (*model.ProposalDraftVersionTbl, error)
+
+	// SubmitProposalDraft marks the version of a proposal draft a proposal is created from.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - id: The ID of the draft.
+	//   - version: The version the proposal is created from.
+	//   - proposer: The lowercase 0x address the proposal is created by.
+	//   - submittedAt: The unix time the version is marked at.
+	//
+	// Returns:
+	//   - error: An error if the update operation fails; otherwise, nil.
+	SubmitProposalDraft(ctx context.Context, id int64, version int, proposer string, submittedAt int64) error
+
+	// LinkProposalDraft links a synced proposal to the marked draft of its proposer address whose marked version has its title and content.
+	// This is called when a ProposalCreate event is synced.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - proposal: The synced proposal.
+	//
+	// Returns:
+	//   - int64: The ID of the linked draft, 0 if no draft matches.
+	//   - error: An error if the link operation fails; otherwise, nil.
+	LinkProposalDraft(ctx context.Context, proposal *model.ProposalTbl) (int64, error)
+
+	// CreateDraftNonce records the nonce of a signed draft mutation, so that the signed payload is accepted once.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - in: The address and nonce of the signed payload.
+	//
+	// Returns:
+	//   - bool: False if the address has already used the nonce.
+	//   - error: An error if the creation operation fails; otherwise, nil.
+	CreateDraftNonce(ctx context.Context, in *model.DraftNonceTbl) (bool, error)
+
+	// DeleteDraftNonces deletes the nonces of the payloads signed before a time, they can no longer be replayed.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - before: The unix time before which the payloads were signed.
+	//
+	// Returns:
+	//   - error: An error if the deletion operation fails; otherwise, nil.
+	DeleteDraftNonces(ctx context.Context, before int64) error
+
 	// CreateProposal creates a new proposal record in the repository.
 	// This is typically called when a ProposalCreate event is parsed during the execution of a synchronous contract event.
 	//
@@ -101,6 +216,17 @@ type ProposalRepo interface {
 	//   - error: An error if the update operation fails; otherwise, nil.
 	UpdateProposal(ctx context.Context, in *model.ProposalTbl) error
 
+	// UpdateProposalPowerRetry updates the deferred counting state of a proposal in the repository.
+	// This is called when a proposal can not be counted yet because voter power is missing from its snapshot.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - in: The proposal with its deferred counting state.
+	//
+	// Returns:
+	//   - error: An error if the update operation fails; otherwise, nil.
+	UpdateProposalPowerRetry(ctx context.Context, in *model.ProposalTbl) error
+
 	// GetUncountedProposalList retrieves a list of proposals that have not been counted yet, filtered by chain ID and timestamp.
 	//
 	// Parameters:
@@ -113,6 +239,29 @@ type ProposalRepo interface {
 	//   - error: An error if the query operation fails; otherwise, nil.
 	GetUncountedProposalList(ctx context.Context, chainId int64, timestamp int64) ([]model.ProposalTbl, error)
 
+	// GetStartedProposalList retrieves the proposals of a chain that have not been counted and whose voting has started.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - chainId: The chain ID to filter proposals.
+	//   - timestamp: The time the voting must have started at.
+	//
+	// Returns:
+	//   - []model.ProposalTbl: The started proposals, ordered by proposal ID.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetStartedProposalList(ctx context.Context, chainId int64, timestamp int64) ([]model.ProposalTbl, error)
+
+	// GetAllProposalList retrieves every proposal of a chain.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - chainId: The chain ID to filter proposals.
+	//
+	// Returns:
+	//   - []model.ProposalTbl: The proposals with their results, ordered by proposal ID.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetAllProposalList(ctx context.Context, chainId int64) ([]model.ProposalTbl, error)
+
 	// GetGitHubNameByCreaters retrieves a list of GitHub usernames associated with the given creators.
 	//
 	// Parameters:
@@ -123,20 +272,124 @@ type ProposalRepo interface {
 	//   - map[string]string: A map where the key is the creator address and the value is the corresponding GitHub username.
 	//   - error: An error if the query operation fails; otherwise, nil.
 	GetGitHubNameByCreaters(ctx context.Context, creators []string) (map[string]model.GiuthubInfo, error)
+
+	// CreateProposalAudit stores the tally audit bundle of a counted proposal.
+	// If the proposal already has a bundle, it is replaced.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - in: The audit bundle to store.
+	//
+	// Returns:
+	//   - error: An error if the creation operation fails; otherwise, nil.
+	CreateProposalAudit(ctx context.Context, in *model.ProposalAuditTbl) error
+
+	// GetProposalAudit retrieves the tally audit bundle of a proposal.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - req: Contains the proposal ID and chain ID to query.
+	//
+	// Returns:
+	//   - *model.ProposalAuditTbl: The audit bundle if found, nil otherwise.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetProposalAudit(ctx context.Context, req api.ProposalReq) (*model.ProposalAuditTbl, error)
+
+	// CreateProposalResultHistory stores a result of a proposal that is replaced by a recount.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - in: The replaced result, with the reason of the recount.
+	//
+	// Returns:
+	//   - error: An error if the creation operation fails; otherwise, nil.
+	CreateProposalResultHistory(ctx context.Context, in *model.ProposalResultHistoryTbl) error
+
+	// GetProposalResultHistory retrieves the results of a proposal replaced by recounts, the latest first.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - req: Contains the proposal ID and chain ID to query.
+	//
+	// Returns:
+	//   - []model.ProposalResultHistoryTbl: The replaced results, empty if the proposal was never recounted.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetProposalResultHistory(ctx context.Context, req api.ProposalReq) ([]model.ProposalResultHistoryTbl, error)
+
+	// DeleteProposalAudit deletes the tally audit bundle of a proposal.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - req: Contains the proposal ID and chain ID of the bundle.
+	//
+	// Returns:
+	//   - error: An error if the deletion fails; otherwise, nil.
+	DeleteProposalAudit(ctx context.Context, req api.ProposalReq) error
+
+	// DeleteProposalResultHistory deletes the results of a proposal replaced by recounts.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - req: Contains the proposal ID and chain ID of the results.
+	//
+	// Returns:
+	//   - error: An error if the deletion fails; otherwise, nil.
+	DeleteProposalResultHistory(ctx context.Context, req api.ProposalReq) error
+
+	// GetProposalVoters retrieves the voters of a proposal with their vote time, ordered by vote time.
+	// The vote choices are not loaded.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - req: Contains the proposal ID and chain ID to query.
+	//
+	// Returns:
+	//   - []model.VoteTbl: The votes of the proposal with only the address and timestamp set.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetProposalVoters(ctx context.Context, req api.ProposalReq) ([]model.VoteTbl, error)
+}
+
+// SnapshotRepo defines the interface for reading the voting power snapshot.
+type SnapshotRepo interface {
+	// GetAllAddressPowerByDay retrieves the power of all addresses in the snapshot of a day.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - chainId: The chain ID of the snapshot.
+	//   - day: The snapshot day, formatted as 20060102.
+	//
+	// Returns:
+	//   - model.SnapshotAllPower: The power of every address in the snapshot.
+	//   - error: An error if the snapshot can not be fetched; otherwise, nil.
+	GetAllAddressPowerByDay(ctx context.Context, chainId int64, day string) (model.SnapshotAllPower, error)
 }
 
 // IProposalService defines the interface for managing proposal-related operations.
 // It provides methods for creating, retrieving, and listing proposals and proposal drafts.
 type IProposalService interface {
-	AddDraft(ctx context.Context, req *api.AddProposalDraftReq) error
+	AddDraft(ctx context.Context, req *api.AddProposalDraftReq) (*api.ProposalDraftRep, error)
 
 	DeleteDraft(ctx context.Context, req api.DelProposalDraftReq) error
 
-	GetDraft(ctx context.Context, req api.AddressReq) (*api.ProposalDraftRep, error)
+	GetDraft(ctx context.Context, req api.ProposalDraftReq) (*api.ProposalDraftRep, error)
+
+	GetDraftList(ctx context.Context, req api.ProposalDraftListReq) ([]api.ProposalDraftRep, error)
+
+	GetDraftVersions(ctx context.Context, req api.DraftIdParam) ([]api.ProposalDraftVersionRep, error)
+
+	GetDraftDiff(ctx context.Context, req api.ProposalDraftDiffReq) (*api.ProposalDraftDiffRep, error)
+
+	SubmitDraft(ctx context.Context, req api.SubmitProposalDraftReq) error
 
 	ProposalDetail(ctx context.Context, req api.ProposalReq) (*api.ProposalRep, error)
 
 	ProposalList(ctx context.Context, req api.ProposalListReq) (*api.CountListRep, error)
+
+	ProposalAudit(ctx context.Context, req api.ProposalReq) (*api.ProposalAuditRep, error)
+
+	ProposalResultHistory(ctx context.Context, req api.ProposalReq) ([]api.ProposalResultHistoryRep, error)
+
+	ProposalParticipation(ctx context.Context, req api.ProposalParticipationReq) (*api.ProposalParticipationRep, error)
 }
 
 var _ IProposalService = (*ProposalService)(nil)
@@ -145,12 +398,16 @@ var _ IProposalService = (*ProposalService)(nil)
 // It encapsulates the dependencies required for interacting with proposal data,
 // such as creating, retrieving, and updating proposals and proposal drafts.
 type ProposalService struct {
-	repo ProposalRepo // repo provides access to the underlying proposal repository
+	repo         ProposalRepo // repo provides access to the underlying proposal repository
+	snapshotRepo SnapshotRepo // snapshotRepo provides the voter power at the proposal snapshot
+	lotusRepo    LotusRepo    // lotusRepo resolves the Filecoin addresses of the draft creators
 }
 
-func NewProposalService(repo ProposalRepo) *ProposalService {
+func NewProposalService(repo ProposalRepo, snapshotRepo SnapshotRepo, lotusRepo LotusRepo) *ProposalService {
 	return &ProposalService{
-		repo: repo,
+		repo:         repo,
+		snapshotRepo: snapshotRepo,
+		lotusRepo:    lotusRepo,
 	}
 }
 
@@ -198,23 +455,22 @@ func (p *ProposalService) ProposalList(ctx context.Context, req api.ProposalList
 			githubInfo = model.GiuthubInfo{}
 		}
 		temp := api.ProposalRep{
-			ProposalId:   proposal.ProposalId,
-			ChainId:      proposal.ChainId,
-			Content:      proposal.Content,
-			StartTime:    proposal.StartTime,
-			EndTime:      proposal.EndTime,
-			Voted:        proposal.Voted != nil,
-			Creator:      proposal.Creator,
-			Title:        proposal.Title,
-			Status:       constant.ProposalStatusPending,
-			CreatedAt:    proposal.CreatedAt.Unix(),
-			UpdatedAt:    proposal.UpdatedAt.Unix(),
-			GithubName:   githubInfo.GithubName,
-			GithubAvatar: githubInfo.GithubAvatar,
-			VotePercentage: api.ProposalVotePercentage{
-				Reject:  proposal.RejectPercentage,
-				Approve: proposal.ApprovePercentage,
-			},
+			ProposalId:     proposal.ProposalId,
+			ChainId:        proposal.ChainId,
+			Content:        proposal.Content,
+			StartTime:      proposal.StartTime,
+			EndTime:        proposal.EndTime,
+			Voted:          proposal.Voted != nil,
+			Creator:        proposal.Creator,
+			Title:          proposal.Title,
+			Status:         constant.ProposalStatusPending,
+			CreatedAt:      proposal.CreatedAt.Unix(),
+			UpdatedAt:      proposal.UpdatedAt.Unix(),
+			GithubName:     githubInfo.GithubName,
+			GithubAvatar:   githubInfo.GithubAvatar,
+			VotePercentage: votePercentageRep(proposal.ProposalResult),
+			Options:        proposal.Options(),
+			Outcome:        proposal.Outcome,
 		}
 
 		// Calculate the proposal status based on the current time and counted flag
@@ -238,6 +494,106 @@ func (p *ProposalService) ProposalList(ctx context.Context, req api.ProposalList
 	}, nil
 }
 
+// ProposalAudit retrieves the tally audit bundle of a counted proposal.
+// The bundle is returned as stored, so that its SHA-256 can be checked and the count recomputed from it.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - req: Contains the proposal ID and chain ID to query.
+//
+// Returns:
+//   - *api.ProposalAuditRep: The audit bundle and its hash.
+//   - error: An error if the query operation fails or the proposal has no bundle; otherwise, nil.
+func (p *ProposalService) ProposalAudit(ctx context.Context, req api.ProposalReq) (*api.ProposalAuditRep, error) {
+	audit, err := p.repo.GetProposalAudit(ctx, req)
+	if err != nil {
+		zap.L().Error("GetProposalAudit error", zap.Error(err))
+		return nil, errors.New("fail to get proposal audit")
+	}
+
+	if audit == nil {
+		return nil, fmt.Errorf("no audit found for proposal: %d", req.ProposalId)
+	}
+
+	return &api.ProposalAuditRep{
+		ProposalId: audit.ProposalId,
+		ChainId:    audit.ChainId,
+		Sha256:     audit.Sha256,
+		Bundle:     json.RawMessage(audit.Bundle),
+	}, nil
+}
+
+// votePercentageRep converts the stored result of a proposal to its voting percentages.
+func votePercentageRep(result model.ProposalResult) api.ProposalVotePercentage {
+	return api.ProposalVotePercentage{
+		Approve: result.ApprovePercentage,
+		Reject:  result.RejectPercentage,
+		Abstain: result.AbstainPercentage,
+		Options: result.OptionPercentages,
+		Exact:   result.ExactPercentages,
+	}
+}
+
+// categoryBreakdownRep converts the stored category breakdown of a proposal to its response format.
+func categoryBreakdownRep(breakdown model.CategoryBreakdown) map[string]api.CategoryRep {
+	res := make(map[string]api.CategoryRep, len(breakdown))
+	for name, category := range breakdown {
+		options := make(map[string]api.OptionPowerRep, len(category.Options))
+		for option, power := range category.Options {
+			options[option] = api.OptionPowerRep{
+				Power:      power.Power,
+				Share:      power.Share,
+				ExactShare: power.ExactShare,
+			}
+		}
+
+		res[name] = api.CategoryRep{
+			Weight:     category.Weight,
+			TotalPower: category.TotalPower,
+			Options:    options,
+		}
+	}
+
+	return res
+}
+
+// ProposalResultHistory retrieves the results of a proposal that were replaced by recounts, the latest first.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - req: Contains the proposal ID and chain ID to query.
+//
+// Returns:
+//   - []api.ProposalResultHistoryRep: The replaced results with the reason of each recount.
+//   - error: An error if the query operation fails; otherwise, nil.
+func (p *ProposalService) ProposalResultHistory(ctx context.Context, req api.ProposalReq) ([]api.ProposalResultHistoryRep, error) {
+	history, err := p.repo.GetProposalResultHistory(ctx, req)
+	if err != nil {
+		zap.L().Error("GetProposalResultHistory error", zap.Error(err))
+		return nil, errors.New("fail to get proposal result history")
+	}
+
+	res := make([]api.ProposalResultHistoryRep, 0, len(history))
+	for _, h := range history {
+		res = append(res, api.ProposalResultHistoryRep{
+			ResultRevision: h.ResultRevision,
+			Reason:         h.Reason,
+			RecountedAt:    h.CreatedAt.Unix(),
+			Outcome:        h.Outcome,
+			VotePercentage: votePercentageRep(h.ProposalResult),
+			TotalPower: api.TotalPower{
+				SpPower:          h.TotalSpPower,
+				TokenHolderPower: h.TotalTokenHolderPower,
+				ClientPower:      h.TotalClientPower,
+				DeveloperPower:   h.TotalDeveloperPower,
+			},
+			AuditSha256: h.AuditSha256,
+		})
+	}
+
+	return res, nil
+}
+
 // ProposalDetail retrieves the details of a specific proposal by its ID.
 // It queries the underlying proposal repository for the data and logs any errors encountered.
 //
@@ -289,22 +645,20 @@ func (p *ProposalService) ProposalDetail(ctx context.Context, req api.ProposalRe
 
 	// Transform the proposal data into the response format
 	return &api.ProposalRep{
-		ProposalId:   proposal.ProposalId,
-		GithubName:   githubInfo.GithubName,
-		GithubAvatar: githubInfo.GithubAvatar,
-		ChainId:      proposal.ChainId,
-		Content:      proposal.Content,
-		StartTime:    proposal.StartTime,
-		EndTime:      proposal.EndTime,
-		Creator:      proposal.Creator,
-		Title:        proposal.Title,
-		Status:       status,
-		CreatedAt:    proposal.CreatedAt.Unix(),
-		UpdatedAt:    proposal.UpdatedAt.Unix(),
-		VotePercentage: api.ProposalVotePercentage{
-			Approve: proposal.ApprovePercentage,
-			Reject:  proposal.RejectPercentage,
-		},
+		ProposalId:     proposal.ProposalId,
+		GithubName:     githubInfo.GithubName,
+		GithubAvatar:   githubInfo.GithubAvatar,
+		ChainId:        proposal.ChainId,
+		Content:        proposal.Content,
+		StartTime:      proposal.StartTime,
+		EndTime:        proposal.EndTime,
+		Creator:        proposal.Creator,
+		Title:          proposal.Title,
+		Status:         status,
+		CreatedAt:      proposal.CreatedAt.Unix(),
+		UpdatedAt:      proposal.UpdatedAt.Unix(),
+		VotePercentage: votePercentageRep(proposal.ProposalResult),
+		Options:        proposal.Options(),
 		Percentage: api.ProposalPercentage{
 			SpPercentage:          proposal.SpPercentage,
 			TokenHolderPercentage: proposal.TokenHolderPercentage,
@@ -321,22 +675,41 @@ func (p *ProposalService) ProposalDetail(ctx context.Context, req api.ProposalRe
 			SnapshotHeight: proposal.SnapshotBlockHeight,
 			SnapshotDay:    proposal.SnapshotDay,
 		},
+		Algorithm:      proposal.CountingAlgorithm,
+		Outcome:        proposal.Outcome,
+		ResultRevision: proposal.ResultRevision,
+		AwaitingPower:  proposal.AwaitingPower,
+		MissingPower:   proposal.MissingPowerVoters,
+		Breakdown:      categoryBreakdownRep(proposal.CategoryBreakdown),
+		Quorum: api.ProposalQuorum{
+			MinVoters:             proposal.QuorumMinVoters,
+			SpPowerShare:          proposal.QuorumSpShare,
+			ClientPowerShare:      proposal.QuorumClientShare,
+			TokenHolderPowerShare: proposal.QuorumTokenHolderShare,
+			DeveloperPowerShare:   proposal.QuorumDeveloperShare,
+		},
 	}, nil
 }
 
-// AddDraft creates a new proposal draft and persists it to the repository.
-// It transforms the request data into the draft model and logs any errors encountered.
+// AddDraft creates a new proposal draft, or saves a new version of a draft of the creator, and persists it to the repository.
+// A draft whose content did not change keeps its version, only its name is saved.
+// The request must be signed by the creator, see DraftSaveMessage for the signed message.
 //
 // Parameters:
 //   - ctx: Context for request cancellation and timeout.
-//   - req: Contains the draft proposal data to be created.
+//   - req: Contains the draft proposal data to be saved and the signature of the creator.
 //
 // Returns:
-//   - error: An error if the creation operation fails; otherwise, nil.
-func (p *ProposalService) AddDraft(ctx context.Context, req *api.AddProposalDraftReq) error {
-	// Create a new proposal draft in the repository
-	_, err := p.repo.CreateProposalDraft(ctx, &model.ProposalDraftTbl{
+//   - *api.ProposalDraftRep: The saved proposal draft.
+//   - error: An error if the request is not authenticated, the draft is not a draft of the creator or the save operation fails; otherwise, nil.
+func (p *ProposalService) AddDraft(ctx context.Context, req *api.AddProposalDraftReq) (*api.ProposalDraftRep, error) {
+	if err := p.verifyDraftAuth(ctx, req.Creator, DraftSaveMessage(req), req.DraftAuth); err != nil {
+		return nil, err
+	}
+
+	draft := &model.ProposalDraftTbl{
 		Creator:   req.Creator,
+		Name:      req.Name,
 		StartTime: req.StartTime,
 		EndTime:   req.EndTime,
 		Timezone:  req.Timezone,
@@ -349,60 +722,249 @@ func (p *ProposalService) AddDraft(ctx context.Context, req *api.AddProposalDraf
 			ClientPercentage:      req.ClientPercentage,
 			DeveloperPercentage:   req.DeveloperPercentage,
 		},
-	})
+	}
+
+	if req.DraftId == 0 {
+		if draft.Name == "" {
+			draft.Name = draft.Title
+		}
 
-	// Handle errors during draft creation
+		// Create a new proposal draft in the repository
+		if _, err := p.repo.CreateProposalDraft(ctx, draft); err != nil {
+			zap.L().Error("CreateProposalDraft error", zap.Error(err))
+			return nil, errors.New("fail to create proposal draft")
+		}
+
+		return draftRep(draft), nil
+	}
+
+	current, err := p.creatorDraft(ctx, req.DraftId, req.Creator, req.ChainId)
 	if err != nil {
-		zap.L().Error("CreateProposalDraft error", zap.Error(err))
-		return errors.New("fail to create proposal draft")
+		return nil, err
 	}
 
-	return nil
+	if draft.Name == "" {
+		draft.Name = current.Name
+	}
+	draft.BaseField = current.BaseField
+	draft.Creator = current.Creator
+	draft.SubmittedVersion = current.SubmittedVersion
+	draft.SubmittedAt = current.SubmittedAt
+	draft.ProposalId = current.ProposalId
+	draft.Version = current.Version
+	if draftContentChanged(current, draft) {
+		draft.Version++
+	}
+
+	if err := p.repo.UpdateProposalDraft(ctx, draft, current.Version); err != nil {
+		if errors.Is(err, constant.ErrDraftVersionConflict) {
+			return nil, constant.ErrDraftVersionConflict
+		}
+
+		zap.L().Error("UpdateProposalDraft error", zap.Error(err))
+		return nil, errors.New("fail to save proposal draft")
+	}
+
+	return draftRep(draft), nil
 }
 
-// GetDraft retrieves a proposal draft by the creator's address.
+// GetDraft retrieves a proposal draft by its ID, or the last saved draft of the creator when the ID is empty.
 // It queries the underlying repository for the draft data and logs any errors encountered.
 //
 // Parameters:
 //   - ctx: Context for request cancellation and timeout.
-//   - req: Contains the creator's address to query.
+//   - req: Contains the draft ID or the creator's address to query.
 //
 // Returns:
 //   - *api.ProposalDraftRep: The proposal draft details if found.
 //   - error: An error if the query operation fails or if no draft is found; otherwise, nil.
-func (p *ProposalService) GetDraft(ctx context.Context, req api.AddressReq) (*api.ProposalDraftRep, error) {
+func (p *ProposalService) GetDraft(ctx context.Context, req api.ProposalDraftReq) (*api.ProposalDraftRep, error) {
+	var (
+		res *model.ProposalDraftTbl
+		err error
+	)
 	// Fetch the proposal draft from the repository
-	res, err := p.repo.GetProposalDraftByAddress(ctx, req)
+	if req.DraftId != 0 {
+		res, err = p.repo.GetProposalDraft(ctx, req.DraftId)
+		if res != nil && req.Address != "" && !strings.EqualFold(res.Creator, req.Address) {
+			res = nil
+		}
+	} else {
+		res, err = p.repo.GetProposalDraftByAddress(ctx, req.AddressReq)
+	}
 	if err != nil {
-		zap.L().Error("GetProposalByCreatorAddress error", zap.Error(err))
+		zap.L().Error("GetProposalDraft error", zap.Error(err))
 		return nil, errors.New("fail to get proposal draft")
 	}
 
 	// Check if the draft exists
 	if res == nil {
-		return nil, errors.New("no draft found")
-	}
-
-	// Transform the draft data into the response format
-	return &api.ProposalDraftRep{
-		Content:               res.Content,
-		StartTime:             res.StartTime,
-		EndTime:               res.EndTime,
-		Timezone:              res.Timezone,
-		Title:                 res.Title,
-		SpPercentage:          res.SpPercentage,
-		TokenHolderPercentage: res.TokenHolderPercentage,
-		ClientPercentage:      res.ClientPercentage,
-		DeveloperPercentage:   res.DeveloperPercentage,
-	}, nil
+		return nil, constant.ErrDraftNotFound
+	}
+
+	return draftRep(res), nil
 }
 
+// DeleteDraft deletes a proposal draft of a creator and its versions.
+// The request must be signed by the creator, see DraftDeleteMessage for the signed message.
 func (p *ProposalService) DeleteDraft(ctx context.Context, req api.DelProposalDraftReq) error {
-	err := p.repo.DeleteProposalDraft(ctx, req)
-	if err != nil {
+	if err := p.verifyDraftAuth(ctx, req.Address, DraftDeleteMessage(req), req.DraftAuth); err != nil {
+		return err
+	}
+
+	if _, err := p.creatorDraft(ctx, req.DraftId, req.Address, req.ChainId); err != nil {
+		return err
+	}
+
+	if err := p.repo.DeleteProposalDraft(ctx, req.DraftId); err != nil {
 		zap.L().Error("DeleteProposalDraft error", zap.Error(err))
 		return errors.New("fail to delete proposal draft")
 	}
 
 	return nil
 }
+
+// ProposalParticipation counts the voters of a proposal and the snapshot power they committed, over time.
+// Votes are encrypted until the proposal ends, only the voter addresses and vote times are used,
+// so the turnout can be followed while the proposal is in progress without revealing any choice.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - req: Contains the proposal ID, chain ID and the interval of the timeline.
+//
+// Returns:
+//   - *api.ProposalParticipationRep: The participation of the proposal and its timeline.
+//   - error: An error if the proposal, its votes or its snapshot can not be read; otherwise, nil.
+func (p *ProposalService) ProposalParticipation(ctx context.Context, req api.ProposalParticipationReq) (*api.ProposalParticipationRep, error) {
+	proposal, err := p.repo.GetProposalById(ctx, req.ProposalReq)
+	if err != nil {
+		zap.L().Error("GetProposalById error", zap.Error(err))
+		return nil, errors.New("fail to get proposal")
+	}
+
+	if proposal == nil {
+		return nil, fmt.Errorf("proposal not found: %d", req.ProposalId)
+	}
+
+	voters, err := p.repo.GetProposalVoters(ctx, req.ProposalReq)
+	if err != nil {
+		zap.L().Error("GetProposalVoters error", zap.Error(err))
+		return nil, errors.New("fail to get proposal voters")
+	}
+
+	allPowers, err := p.snapshotRepo.GetAllAddressPowerByDay(ctx, proposal.ChainId, proposal.SnapshotDay)
+	if err != nil {
+		zap.L().Error("GetAllAddressPowerByDay error",
+			zap.Int64("proposalId", proposal.ProposalId),
+			zap.String("snapshotDay", proposal.SnapshotDay),
+			zap.Error(err))
+		return nil, errors.New("fail to get snapshot power")
+	}
+
+	return participation(*proposal, voters, allPowers.AddrPower, req.Interval, time.Now().Unix()), nil
+}
+
+const (
+	defaultParticipationInterval = 60 * 60 // one hour
+	maxParticipationPoints       = 1000
+)
+
+// participation builds the participation of a proposal from its voters and the power of all addresses in its snapshot.
+// The timeline has a cumulative point at the end of every interval from the proposal start time
+// until now or the proposal end time, whichever is first. The interval is widened if the timeline would
+// have more than maxParticipationPoints points.
+func participation(proposal model.ProposalTbl, voters []model.VoteTbl, powers []model.AddrPower, interval, now int64) *api.ProposalParticipationRep {
+	powersMap := make(map[string]model.AddrPower, len(powers))
+	electorate := newPowerSum()
+	for _, power := range powers {
+		powersMap[power.Address] = power
+		electorate.add(power)
+	}
+
+	res := &api.ProposalParticipationRep{
+		ProposalId:  proposal.ProposalId,
+		ChainId:     proposal.ChainId,
+		SnapshotDay: proposal.SnapshotDay,
+		Electorate:  electorate.totalPower(),
+		Timeline:    []api.ParticipationPoint{},
+	}
+
+	end := min(now, proposal.EndTime)
+	if interval <= 0 {
+		interval = defaultParticipationInterval
+	}
+	if span := end - proposal.StartTime; span > interval*maxParticipationPoints {
+		interval = (span + maxParticipationPoints - 1) / maxParticipationPoints
+	}
+	res.Interval = interval
+
+	committed := newPowerSum()
+	addVoter := func(vote model.VoteTbl) {
+		res.Voters++
+		power, ok := powersMap[vote.Address]
+		if !ok {
+			res.MissingPower++
+			return
+		}
+		committed.add(power)
+	}
+
+	next := 0
+	for t := proposal.StartTime + interval; t < end+interval; t += interval {
+		t = min(t, end)
+		for ; next < len(voters) && voters[next].Timestamp <= t; next++ {
+			addVoter(voters[next])
+		}
+
+		res.Timeline = append(res.Timeline, api.ParticipationPoint{
+			Time:   t,
+			Voters: res.Voters,
+			Power:  committed.totalPower(),
+		})
+	}
+
+	// Votes after the last point are in the totals
+	for ; next < len(voters); next++ {
+		addVoter(voters[next])
+	}
+	res.Power = committed.totalPower()
+
+	return res
+}
+
+// powerSum sums the snapshot power of addresses in each power category.
+type powerSum struct {
+	sp, client, tokenHolder, developer *big.Int
+}
+
+func newPowerSum() *powerSum {
+	return &powerSum{
+		sp:          new(big.Int),
+		client:      new(big.Int),
+		tokenHolder: new(big.Int),
+		developer:   new(big.Int),
+	}
+}
+
+// add adds the power of an address, a category missing from the snapshot counts as zero.
+func (s *powerSum) add(power model.AddrPower) {
+	for _, category := range []struct{ sum, power *big.Int }{
+		{s.sp, power.SpPower},
+		{s.client, power.ClientPower},
+		{s.tokenHolder, power.TokenHolderPower},
+		{s.developer, power.DeveloperPower},
+	} {
+		if category.power != nil {
+			category.sum.Add(category.sum, category.power)
+		}
+	}
+}
+
+func (s *powerSum) totalPower() api.TotalPower {
+	return api.TotalPower{
+		SpPower:          s.sp.String(),
+		ClientPower:      s.client.String(),
+		TokenHolderPower: s.tokenHolder.String(),
+		DeveloperPower:   s.developer.String(),
+	}
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package service
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/model"
+	"powervoting-server/model/api"
+)
+
+func mockAddrPower(address string, token int64) model.AddrPower {
+	return model.AddrPower{
+		Address:          address,
+		SpPower:          big.NewInt(0),
+		ClientPower:      big.NewInt(0),
+		TokenHolderPower: big.NewInt(token),
+		DeveloperPower:   big.NewInt(0),
+	}
+}
+
+func TestParticipation(t *testing.T) {
+	proposal := model.ProposalTbl{ProposalId: 1, ChainId: 314159, SnapshotDay: "20250105", StartTime: 1000, EndTime: 5000}
+	powers := []model.AddrPower{mockAddrPower("0xa", 100), mockAddrPower("0xb", 200), mockAddrPower("0xc", 300)}
+	voters := []model.VoteTbl{
+		{Address: "0xa", Timestamp: 1100},
+		{Address: "0xb", Timestamp: 2000},
+		{Address: "0xd", Timestamp: 2500},
+	}
+
+	res := participation(proposal, voters, powers, 1000, 3500)
+	assert.Equal(t, int64(3), res.Voters)
+	assert.Equal(t, int64(1), res.MissingPower)
+	assert.Equal(t, "300", res.Power.TokenHolderPower)
+	assert.Equal(t, "600", res.Electorate.TokenHolderPower)
+	// the last point is now, the proposal is still in progress
+	assert.Equal(t, []api.ParticipationPoint{
+		{Time: 2000, Voters: 2, Power: api.TotalPower{SpPower: "0", ClientPower: "0", TokenHolderPower: "300", DeveloperPower: "0"}},
+		{Time: 3000, Voters: 3, Power: api.TotalPower{SpPower: "0", ClientPower: "0", TokenHolderPower: "300", DeveloperPower: "0"}},
+		{Time: 3500, Voters: 3, Power: api.TotalPower{SpPower: "0", ClientPower: "0", TokenHolderPower: "300", DeveloperPower: "0"}},
+	}, res.Timeline)
+}
+
+func TestParticipationInterval(t *testing.T) {
+	proposal := model.ProposalTbl{StartTime: 0, EndTime: 10 * 24 * 60 * 60}
+
+	// one hour by default
+	res := participation(proposal, nil, nil, 0, 60*60*24)
+	assert.Equal(t, int64(defaultParticipationInterval), res.Interval)
+	assert.Len(t, res.Timeline, 24)
+
+	// widened to keep the timeline short
+	res = participation(proposal, nil, nil, 60, proposal.EndTime)
+	assert.Len(t, res.Timeline, maxParticipationPoints)
+
+	// not started yet
+	res = participation(proposal, nil, nil, 0, -1)
+	assert.Empty(t, res.Timeline)
+	assert.Equal(t, "0", res.Power.SpPower)
+}
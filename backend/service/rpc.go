@@ -73,17 +73,21 @@ func (r *RpcService) GetAllVoterAddresss(ctx context.Context, chainId int64) ([]
 	return addresss, nil
 }
 
+// GetVoterInfoByAddress returns the voter info of an address on the primary network,
+// with its miner IDs and gist info refreshed when they changed.
 func (r *RpcService) GetVoterInfoByAddress(ctx context.Context, address string) (*model.VoterInfoTbl, error) {
-	voterInfo, err := r.voteRepo.GetVoterInfoByAddress(ctx, address)
+	voterInfo, err := r.voteRepo.GetVoterInfoByAddress(ctx, config.Client.Network.ChainId, address)
 	if err != nil {
 		r.logger.Error("GetVoterInfoByAddress error", zap.Error(err))
 		return nil, err
 	}
 
 	if len(voterInfo.MinerIds) != 0 {
+		// The miner IDs are checked on the network of the voter
+		network, _ := config.GetNetwork(voterInfo.ChainId)
 		minerIds := make([]uint64, 0, len(voterInfo.MinerIds))
 		for _, minerId := range voterInfo.MinerIds {
-			cutId := strings.ReplaceAll(minerId, config.Client.Network.MinerIdPrefix, "")
+			cutId := strings.ReplaceAll(minerId, network.MinerIdPrefix, "")
 			id, err := strconv.ParseInt(cutId, 10, 64)
 			if err != nil {
 				r.logger.Error("ParseInt error", zap.Error(err), zap.Any("minerId", minerId))
@@ -92,7 +96,7 @@ func (r *RpcService) GetVoterInfoByAddress(ctx context.Context, address string)
 			minerIds = append(minerIds, uint64(id))
 		}
 
-		validMinerIds, err := r.lotusRepo.GetValidMinerIds(ctx, voterInfo.OwnerId, minerIds)
+		validMinerIds, err := r.lotusRepo.GetValidMinerIds(ctx, voterInfo.ChainId, voterInfo.OwnerId, minerIds)
 		if err != nil {
 			r.logger.Error("verfy minerIds error", zap.Error(err))
 		} else {
@@ -130,7 +134,7 @@ func (r *RpcService) GetVoterInfoByAddress(ctx context.Context, address string)
 				return true
 			}
 
-			gistAddrActorId, err := r.lotusRepo.GetActorIdByAddress(ctx, gistAddr)
+			gistAddrActorId, err := r.lotusRepo.GetActorIdByAddress(ctx, voterInfo.ChainId, gistAddr)
 			if err != nil {
 				zap.L().Error("GetActorIdByAddress failed by VerifyGist", zap.String("address", gistAddr), zap.Error(err))
 				return false
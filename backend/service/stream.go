@@ -0,0 +1,127 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+	"powervoting-server/model/api"
+)
+
+// StreamRepo defines the interface for the governance lifecycle events read by the stream.
+type StreamRepo interface {
+	PublishWebhookEvents(ctx context.Context, limit int) error
+	GetWebhookEventsAfter(ctx context.Context, req api.StreamReq, cursor int64, limit int) ([]model.WebhookEventTbl, error)
+	GetLastWebhookEventSeq(ctx context.Context) (int64, error)
+}
+
+type IStreamService interface {
+	// GetStreamCursor retrieves the stream sequence of the last committed event, a stream without a cursor starts after it.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//
+	// Returns:
+	//   - int64: The stream sequence of the last event, 0 when no event has been emitted.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetStreamCursor(ctx context.Context) (int64, error)
+
+	// GetStreamEvents retrieves the events after the cursor that match the chain and proposal of a stream.
+	// The events are read in the order their transactions were committed, by their stream sequence,
+	// so an event committed after the cursor is never behind it.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - req: The chain ID and proposal ID of the stream.
+	//   - cursor: The stream sequence of the last event sent to the stream.
+	//
+	// Returns:
+	//   - []model.StreamEvent: At most StreamBatchSize events, in the order of their sequence.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetStreamEvents(ctx context.Context, req api.StreamReq, cursor int64) ([]model.StreamEvent, error)
+}
+
+type StreamService struct {
+	repo      StreamRepo
+	publishMu sync.Mutex // Lets one stream at a time publish the committed events
+}
+
+func NewStreamService(repo StreamRepo) *StreamService {
+	return &StreamService{
+		repo: repo,
+	}
+}
+
+// publish assigns their stream sequence to the events committed since the last read.
+func (s *StreamService) publish(ctx context.Context) error {
+	s.publishMu.Lock()
+	defer s.publishMu.Unlock()
+
+	if err := s.repo.PublishWebhookEvents(ctx, constant.StreamPublishBatchSize); err != nil {
+		zap.L().Error("PublishWebhookEvents error", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (s *StreamService) GetStreamCursor(ctx context.Context) (int64, error) {
+	if err := s.publish(ctx); err != nil {
+		return 0, err
+	}
+
+	seq, err := s.repo.GetLastWebhookEventSeq(ctx)
+	if err != nil {
+		zap.L().Error("GetLastWebhookEventSeq error", zap.Error(err))
+		return 0, err
+	}
+
+	return seq, nil
+}
+
+func (s *StreamService) GetStreamEvents(ctx context.Context, req api.StreamReq, cursor int64) ([]model.StreamEvent, error) {
+	if err := s.publish(ctx); err != nil {
+		return nil, err
+	}
+
+	list, err := s.repo.GetWebhookEventsAfter(ctx, req, cursor, constant.StreamBatchSize)
+	if err != nil {
+		zap.L().Error("GetWebhookEventsAfter error", zap.Int64("cursor", cursor), zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]model.StreamEvent, 0, len(list))
+	for _, event := range list {
+		res = append(res, model.StreamEvent{
+			Seq: *event.Seq,
+			Payload: model.WebhookPayload{
+				Id:        event.ID,
+				Event:     event.Event,
+				ChainId:   event.ChainId,
+				Subject:   event.Subject,
+				Timestamp: event.Timestamp,
+				Data:      json.RawMessage(event.Data),
+			},
+		})
+	}
+
+	return res, nil
+}
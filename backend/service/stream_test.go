@@ -0,0 +1,103 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/model"
+	"powervoting-server/model/api"
+)
+
+// fakeStreamRepo keeps the events in memory, an event that is not committed can not be read or published.
+type fakeStreamRepo struct {
+	events    []model.WebhookEventTbl
+	committed map[int64]bool
+}
+
+func (f *fakeStreamRepo) PublishWebhookEvents(ctx context.Context, limit int) error {
+	var last int64
+	for _, event := range f.events {
+		if event.Seq != nil {
+			last = max(last, *event.Seq)
+		}
+	}
+
+	for i := range f.events {
+		if f.events[i].Seq == nil && f.committed[f.events[i].ID] {
+			last++
+			seq := last
+			f.events[i].Seq = &seq
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeStreamRepo) GetWebhookEventsAfter(ctx context.Context, req api.StreamReq, cursor int64, limit int) ([]model.WebhookEventTbl, error) {
+	var list []model.WebhookEventTbl
+	for _, event := range f.events {
+		if event.Seq != nil && *event.Seq > cursor {
+			list = append(list, event)
+		}
+	}
+
+	return list, nil
+}
+
+func (f *fakeStreamRepo) GetLastWebhookEventSeq(ctx context.Context) (int64, error) {
+	var last int64
+	for _, event := range f.events {
+		if event.Seq != nil {
+			last = max(last, *event.Seq)
+		}
+	}
+
+	return last, nil
+}
+
+func TestGetStreamEventsInCommitOrder(t *testing.T) {
+	repo := &fakeStreamRepo{
+		events: []model.WebhookEventTbl{
+			{BaseField: model.BaseField{ID: 1}, Subject: "proposal:1"},
+			{BaseField: model.BaseField{ID: 2}, Subject: "proposal:2"},
+			{BaseField: model.BaseField{ID: 3}, Subject: "proposal:3"},
+		},
+		// the event 2 is emitted by a sync transaction that is still running
+		committed: map[int64]bool{1: true, 3: true},
+	}
+	streamService := NewStreamService(repo)
+
+	cursor, err := streamService.GetStreamCursor(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), cursor)
+
+	events, err := streamService.GetStreamEvents(context.Background(), api.StreamReq{}, 0)
+	assert.NoError(t, err)
+	assert.Len(t, events, 2)
+	assert.Equal(t, int64(1), events[0].Payload.Id)
+	assert.Equal(t, int64(3), events[1].Payload.Id)
+
+	// the event committed later is streamed after the cursor that already passed its ID
+	repo.committed[2] = true
+	events, err = streamService.GetStreamEvents(context.Background(), api.StreamReq{}, events[1].Seq)
+	assert.NoError(t, err)
+	assert.Len(t, events, 1)
+	assert.Equal(t, int64(2), events[0].Payload.Id)
+	assert.Equal(t, int64(3), events[0].Seq)
+}
@@ -17,11 +17,13 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"go.uber.org/zap"
 
 	"powervoting-server/constant"
 	"powervoting-server/model"
+	"powervoting-server/model/api"
 	"powervoting-server/utils"
 )
 
@@ -40,48 +42,236 @@ type SyncRepo interface {
 	//   - error: An error if the creation operation fails; otherwise, nil.
 	CreateSyncEventInfo(ctx context.Context, in *model.SyncEventTbl) (int64, error)
 
-	// UpdateSyncEventInfo updates the synchronization event information for a given address and block height.
+	// UpdateSyncEventInfo updates the synchronization event information for a given chain, address and block height.
 	// This is typically called after a timed task completes the synchronization of contract events.
 	//
 	// Parameters:
 	//   - ctx: Context for request cancellation and timeout.
+	//   - chainId: The chain ID of the sync event.
 	//   - addr: The address associated with the sync event.
 	//   - height: The block height to update.
 	//
 	// Returns:
 	//   - error: An error if the update operation fails; otherwise, nil.
-	UpdateSyncEventInfo(ctx context.Context, addr string, height int64) error
+	UpdateSyncEventInfo(ctx context.Context, chainId int64, addr string, height int64) error
 
-	// GetSyncEventInfo retrieves synchronization event information for a given address.
+	// GetSyncEventInfo retrieves synchronization event information for a given chain and address.
 	//
 	// Parameters:
 	//   - ctx: Context for request cancellation and timeout.
+	//   - chainId: The chain ID of the sync event.
 	//   - addr: The address associated with the sync event.
 	//
 	// Returns:
 	//   - *model.SyncEventTbl: The synchronization event data if found.
 	//   - error: An error if the query operation fails; otherwise, nil.
-	GetSyncEventInfo(ctx context.Context, addr string) (*model.SyncEventTbl, error)
+	GetSyncEventInfo(ctx context.Context, chainId int64, addr string) (*model.SyncEventTbl, error)
+
+	// GetSyncBackfill retrieves the progress of the backfill of the historical events of a chain.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - chainId: The chain ID of the backfill.
+	//
+	// Returns:
+	//   - *model.SyncBackfillTbl: The backfill progress, nil when no backfill has run on the chain.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetSyncBackfill(ctx context.Context, chainId int64) (*model.SyncBackfillTbl, error)
+
+	// SaveSyncBackfill creates or replaces the progress of the backfill of the historical events of a chain.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - in: The backfill progress.
+	//
+	// Returns:
+	//   - error: An error if the save operation fails; otherwise, nil.
+	SaveSyncBackfill(ctx context.Context, in *model.SyncBackfillTbl) error
+
+	// CreateSyncBlocks records the blocks of synced heights, replacing the blocks already recorded at the same heights.
+	// Recorded blocks below pruneBelow are removed.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - blocks: The blocks to record.
+	//   - pruneBelow: The lowest height to keep.
+	//
+	// Returns:
+	//   - error: An error if the creation operation fails; otherwise, nil.
+	CreateSyncBlocks(ctx context.Context, blocks []model.SyncBlockTbl, pruneBelow int64) error
+
+	// GetSyncBlocks retrieves the recorded blocks of a chain at or below a height, the highest first.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - chainId: The chain ID of the blocks.
+	//   - maxHeight: The highest height to return.
+	//   - limit: The maximum number of blocks to return.
+	//
+	// Returns:
+	//   - []model.SyncBlockTbl: The recorded blocks.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetSyncBlocks(ctx context.Context, chainId, maxHeight int64, limit int) ([]model.SyncBlockTbl, error)
+
+	// RollbackSync removes everything synced from the blocks above a height after a chain reorganization,
+	// so that the events of these blocks are synced again from the new chain.
+	// The votes, vote history, proposals, FIP proposals, FIP votes, FIP editors, voter info, dead letters, journaled event logs,
+	// PowerVotingConf settings and recorded blocks above the height are deleted, the GitHub repositories removed above the height are listed again,
+	// the voters whose vote is deleted get back their latest valid vote at or below the height, and the synced height is set to the height.
+	// The rows updated in place above the height, such as passed FIP proposals, removed FIP editors and oracle updates
+	// of the voters, get back the state recorded before the update, and the webhook events emitted from the blocks above
+	// the height are deleted with their undelivered deliveries cancelled. The drafts linked to the deleted proposals are unlinked.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - addr: The address associated with the sync event.
+	//   - chainId: The chain ID of the synced events.
+	//   - height: The last height that is still on the chain.
+	//
+	// Returns:
+	//   - error: An error if the rollback fails; otherwise, nil.
+	RollbackSync(ctx context.Context, addr string, chainId, height int64) error
+
+	// Transaction runs fn in a database transaction. The repository calls made with the context passed to fn
+	// take part in the transaction, and a Transaction called with that context runs in a savepoint.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - fn: The function to run, its changes are rolled back when it returns an error.
+	//
+	// Returns:
+	//   - error: The error returned by fn, or an error if the transaction can not be committed; otherwise, nil.
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+
+	// CreateDeadLetter saves an event log that failed to be applied, to be replayed later.
+	// A dead letter already saved for the same log is replaced.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - in: The dead letter to save.
+	//
+	// Returns:
+	//   - error: An error if the creation operation fails; otherwise, nil.
+	CreateDeadLetter(ctx context.Context, in *model.EventDeadLetterTbl) error
+
+	// GetDueDeadLetters retrieves the pending dead letters of a chain whose next replay time has passed, the oldest event first.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - chainId: The chain ID of the dead letters.
+	//   - now: The current unix time.
+	//   - limit: The maximum number of dead letters to return.
+	//
+	// Returns:
+	//   - []model.EventDeadLetterTbl: The dead letters to replay.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetDueDeadLetters(ctx context.Context, chainId, now int64, limit int) ([]model.EventDeadLetterTbl, error)
+
+	// UpdateDeadLetter saves the error, attempts, status and next replay time of a dead letter.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - in: The replayed dead letter.
+	//
+	// Returns:
+	//   - error: An error if the update operation fails; otherwise, nil.
+	UpdateDeadLetter(ctx context.Context, in *model.EventDeadLetterTbl) error
+
+	// CreateEventLog appends a raw event log to the event journal. A log already in the journal is ignored.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - in: The event log with its block.
+	//
+	// Returns:
+	//   - error: An error if the creation operation fails; otherwise, nil.
+	CreateEventLog(ctx context.Context, in *model.EventLogTbl) error
+
+	// GetEventLogs retrieves a page of the event journal of a chain, in the order the events were emitted.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - chainId: The chain ID of the event logs.
+	//   - offset: The number of event logs to skip.
+	//   - limit: The maximum number of event logs to return.
+	//
+	// Returns:
+	//   - []model.EventLogTbl: The event logs.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetEventLogs(ctx context.Context, chainId int64, offset, limit int) ([]model.EventLogTbl, error)
+
+	// GetEventLog retrieves the journaled event log of a chain emitted at the given transaction and log index.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - chainId: The chain ID of the event log.
+	//   - txHash: The hash of the transaction that emitted the event log.
+	//   - logIndex: The index of the event log in its block.
+	//
+	// Returns:
+	//   - *model.EventLogTbl: The event log, nil when it is not in the journal.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetEventLog(ctx context.Context, chainId int64, txHash string, logIndex uint) (*model.EventLogTbl, error)
+
+	// DeleteDerivedEvents deletes the proposals, votes, vote history, FIP proposals, FIP votes, FIP editors,
+	// voter info, dead letters, PowerVotingConf settings and recorded undo states of a chain, the rows that are derived from its event logs.
+	// The drafts of the chain are kept and unlinked from their proposals.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - chainId: The chain ID of the rows to delete.
+	//
+	// Returns:
+	//   - error: An error if the delete operation fails; otherwise, nil.
+	DeleteDerivedEvents(ctx context.Context, chainId int64) error
 }
 
+// LotusRepo queries the Lotus node of the network of a chain ID, chain ID 0 is the primary network.
 type LotusRepo interface {
-	GetActorIdByAddress(ctx context.Context, addr string) (string, error)
-	GetValidMinerIds(ctx context.Context, minerId string, minerIds []uint64) (model.StringSlice, error)
-	EthAddrToFilcoinAddr(ctx context.Context, addr string) (string, error)
-	FilecoinAddressToID(ctx context.Context, addr string) (string, error)
-	FilecoinAddrToEthAddr(ctx context.Context, addr string) (string, error) 
+	GetActorIdByAddress(ctx context.Context, chainId int64, addr string) (string, error)
+	GetValidMinerIds(ctx context.Context, chainId int64, minerId string, minerIds []uint64) (model.StringSlice, error)
+	EthAddrToFilcoinAddr(ctx context.Context, chainId int64, addr string) (string, error)
+	FilecoinAddressToID(ctx context.Context, chainId int64, addr string) (string, error)
+	FilecoinAddrToEthAddr(ctx context.Context, chainId int64, addr string) (string, error) 
+	GetNetworkPower(ctx context.Context, chainId, height int64) (model.NetworkPower, error)
 }
 type ISyncService interface {
-	UpdateSyncEventInfo(ctx context.Context, addr string, height int64) error
-	GetSyncEventInfo(ctx context.Context, addr string) (*model.SyncEventTbl, error)
+	UpdateSyncEventInfo(ctx context.Context, chainId int64, addr string, height int64) error
+	GetSyncEventInfo(ctx context.Context, chainId int64, addr string) (*model.SyncEventTbl, error)
+	GetSyncBackfill(ctx context.Context, chainId int64) (*model.SyncBackfillTbl, error)
+	SaveSyncBackfill(ctx context.Context, in *model.SyncBackfillTbl) error
 	CreateSyncEventInfo(ctx context.Context, in *model.SyncEventTbl) error
+	AddSyncBlocks(ctx context.Context, blocks []model.SyncBlockTbl, pruneBelow int64) error
+	GetSyncBlocks(ctx context.Context, chainId, maxHeight int64, limit int) ([]model.SyncBlockTbl, error)
+	RollbackSync(ctx context.Context, addr string, chainId, height int64) error
+	Transaction(ctx context.Context, fn func(ctx context.Context) error) error
+	AddDeadLetter(ctx context.Context, in *model.EventDeadLetterTbl) error
+	GetDueDeadLetters(ctx context.Context, chainId, now int64, limit int) ([]model.EventDeadLetterTbl, error)
+	UpdateDeadLetter(ctx context.Context, in *model.EventDeadLetterTbl) error
+	AddEventLog(ctx context.Context, in *model.EventLogTbl) error
+	GetEventLogs(ctx context.Context, chainId int64, offset, limit int) ([]model.EventLogTbl, error)
+	GetEventLog(ctx context.Context, chainId int64, txHash string, logIndex uint) (*model.EventLogTbl, error)
+	DeleteDerivedEvents(ctx context.Context, chainId int64) error
 	AddProposal(ctx context.Context, in *model.ProposalTbl) error
 	UpdateProposal(ctx context.Context, in *model.ProposalTbl) error
+	UpdateProposalPowerRetry(ctx context.Context, in *model.ProposalTbl) error
 	UncountedProposalList(ctx context.Context, chainId, endTime int64) ([]model.ProposalTbl, error)
+	StartedProposalList(ctx context.Context, chainId, startTime int64) ([]model.ProposalTbl, error)
+	ProposalList(ctx context.Context, chainId int64) ([]model.ProposalTbl, error)
+	GetProposal(ctx context.Context, chainId, proposalId int64) (*model.ProposalTbl, error)
+	AddProposalAudit(ctx context.Context, in *model.ProposalAuditTbl) error
+	GetProposalAudit(ctx context.Context, chainId, proposalId int64) (*model.ProposalAuditTbl, error)
+	AddProposalResultHistory(ctx context.Context, in *model.ProposalResultHistoryTbl) error
+	DeleteProposalAudit(ctx context.Context, chainId, proposalId int64) error
+	DeleteProposalResultHistory(ctx context.Context, chainId, proposalId int64) error
+	GetNetworkPower(ctx context.Context, chainId, height int64) (model.NetworkPower, error)
 	BatchUpdateVotes(ctx context.Context, votes []model.VoteTbl) error
-	AddVote(ctx context.Context, in *model.VoteTbl) error
+	AddVote(ctx context.Context, in *model.VoteHistoryTbl) error
 	GetUncountedVotedList(ctx context.Context, chainId, proposalId int64) ([]model.VoteTbl, error)
+	GetVotedList(ctx context.Context, chainId, proposalId int64) ([]model.VoteTbl, error)
 	AddVoterAddress(ctx context.Context, in *model.VoterInfoTbl) error
+	VoterList(ctx context.Context, chainId int64) ([]model.VoterInfoTbl, error)
+	RestoreVoter(ctx context.Context, in *model.VoterInfoTbl) error
 	CreateFipProposal(ctx context.Context, in *model.FipProposalTbl) error
 
 	UpdateStatusAndGetFipProposal(ctx context.Context, proposalId, chainId int64) (*model.FipProposalTbl, error)
@@ -90,7 +280,16 @@ type ISyncService interface {
 	RevokeFipProposal(ctx context.Context, chainId int64, cadidateAddresss string) error
 
 	UpdateVoterAndProposalGithubNameByGistInfo(ctx context.Context, voterInfo *model.VoterInfoTbl) error
-	UpdateVoterByMinerIds(ctx context.Context, voterAddress string, minerIds []uint64) error
+	UpdateVoterByMinerIds(ctx context.Context, chainId int64, voterAddress string, minerIds []uint64) error
+
+	AddGithubRepo(ctx context.Context, in *model.GithubRepoTbl) error
+	RemoveGithubRepo(ctx context.Context, chainId, repoId, blockNumber int64) error
+	AddSnapshotHeight(ctx context.Context, in *model.SnapshotHeightTbl) error
+	AddConfChange(ctx context.Context, in *model.ConfChangeTbl) error
+
+	AddWebhookEvent(ctx context.Context, in *model.WebhookEventTbl) error
+	GetDueWebhookDeliveries(ctx context.Context, now int64, limit int) ([]model.WebhookDueDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, in *model.WebhookDeliveryTbl) error
 }
 
 // SyncService provides functionality for synchronizing data across repositories.
@@ -102,118 +301,569 @@ type SyncService struct {
 	proposalRepo ProposalRepo // proposalRepo handles proposal-related data
 	fipRepo      FipRepo      // fipRepo handles fip-related data
 	lotusRepo    LotusRepo    // lotusRepo handles lotus-related data
+	confRepo     ConfRepo     // confRepo handles the settings of the PowerVotingConf contract
+	webhookRepo  WebhookRepo  // webhookRepo handles the webhook events and their deliveries
 }
 
-func NewSyncService(repo SyncRepo, voteRepo VoteRepo, proposalRepo ProposalRepo, fipRepo FipRepo, lotusrepo LotusRepo) *SyncService {
+func NewSyncService(repo SyncRepo, voteRepo VoteRepo, proposalRepo ProposalRepo, fipRepo FipRepo, lotusrepo LotusRepo, confRepo ConfRepo, webhookRepo WebhookRepo) *SyncService {
 	return &SyncService{
 		repo:         repo,
 		voteRepo:     voteRepo,
 		proposalRepo: proposalRepo,
 		fipRepo:      fipRepo,
 		lotusRepo:    lotusrepo,
+		confRepo:     confRepo,
+		webhookRepo:  webhookRepo,
 	}
 }
 
-// UpdateSyncEventInfo updates the synchronization event information for a given address and block height.
+// syncBlockKey is the context key of the block whose event log is being applied.
+type syncBlockKey struct{}
+
+// syncBlock is the block of the event log being applied.
+type syncBlock struct {
+	number int64  // block number
+	hash   string // block hash
+}
+
+// WithSyncBlock returns a context that carries the block of the event log being applied.
+// The repositories record the previous state of the rows they update in place for this block,
+// so that RollbackSync can restore it when the block leaves the chain.
+func WithSyncBlock(ctx context.Context, blockNumber int64, blockHash string) context.Context {
+	return context.WithValue(ctx, syncBlockKey{}, syncBlock{number: blockNumber, hash: blockHash})
+}
+
+// SyncBlock returns the number and hash of the block carried by a context of WithSyncBlock,
+// false when the context does not apply an event log.
+func SyncBlock(ctx context.Context) (int64, string, bool) {
+	block, ok := ctx.Value(syncBlockKey{}).(syncBlock)
+	return block.number, block.hash, ok
+}
+
+// UpdateSyncEventInfo updates the synchronization event information for a given chain, address and block height.
 // It delegates the update operation to the underlying repository and logs any errors encountered.
 //
 // Parameters:
 //   - ctx: Context for request cancellation and timeout.
+//   - chainId: The chain ID of the sync event.
 //   - addr: The address associated with the sync event.
 //   - height: Block height that has been synchronized.
 //
 // Returns:
-//   - error: An error if the update operation fails; otherwise, nil.
-func (s *SyncService) UpdateSyncEventInfo(ctx context.Context, addr string, height int64) error {
-	if err := s.repo.UpdateSyncEventInfo(ctx, addr, height); err != nil {
-		zap.L().Error("UpdateSyncEventInfo failed", zap.Error(err))
+//   - error: An error if the update operation fails; otherwise, nil.
+func (s *SyncService) UpdateSyncEventInfo(ctx context.Context, chainId int64, addr string, height int64) error {
+	if err := s.repo.UpdateSyncEventInfo(ctx, chainId, addr, height); err != nil {
+		zap.L().Error("UpdateSyncEventInfo failed", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// GetSyncEventInfo retrieves synchronization event information for a given chain and address.
+// It queries the underlying repository for the data and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - chainId: The chain ID of the sync event.
+//   - addr: The address is the power voting contract address.
+//
+// Returns:
+//   - *model.SyncEventTbl: The synchronization event data if found.
+//   - error: An error if the query operation fails; otherwise, nil.
+func (s *SyncService) GetSyncEventInfo(ctx context.Context, chainId int64, addr string) (*model.SyncEventTbl, error) {
+	res, err := s.repo.GetSyncEventInfo(ctx, chainId, addr)
+	if err != nil {
+		zap.L().Error("GetSyncEventInfo failed", zap.Error(err))
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetSyncBackfill retrieves the progress of the backfill of the historical events of a chain.
+// It queries the underlying repository for the data and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - chainId: The chain ID of the backfill.
+//
+// Returns:
+//   - *model.SyncBackfillTbl: The backfill progress, nil when no backfill has run on the chain.
+//   - error: An error if the query operation fails; otherwise, nil.
+func (s *SyncService) GetSyncBackfill(ctx context.Context, chainId int64) (*model.SyncBackfillTbl, error) {
+	res, err := s.repo.GetSyncBackfill(ctx, chainId)
+	if err != nil {
+		zap.L().Error("GetSyncBackfill failed", zap.Int64("chain id", chainId), zap.Error(err))
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// SaveSyncBackfill creates or replaces the progress of the backfill of the historical events of a chain.
+// It delegates the save operation to the underlying repository and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - in: The backfill progress.
+//
+// Returns:
+//   - error: An error if the save operation fails; otherwise, nil.
+func (s *SyncService) SaveSyncBackfill(ctx context.Context, in *model.SyncBackfillTbl) error {
+	if err := s.repo.SaveSyncBackfill(ctx, in); err != nil {
+		zap.L().Error("SaveSyncBackfill failed", zap.Int64("chain id", in.ChainId), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// CreateSyncEventInfo creates a new synchronization event record in the repository.
+// It delegates the creation operation to the underlying repository and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - in: The synchronization event data to be created.
+//
+// Returns:
+//   - error: An error if the creation operation fails; otherwise, nil.
+func (s *SyncService) CreateSyncEventInfo(ctx context.Context, in *model.SyncEventTbl) error {
+	if in == nil {
+		return errors.New("sync event is nil")
+	}
+
+	_, err := s.repo.CreateSyncEventInfo(ctx, in)
+	if err != nil {
+		zap.L().Error("CreateSyncEventInfo failed", zap.Error(err))
+	}
+
+	return nil
+}
+
+// AddSyncBlocks records the blocks of synced heights, used to detect chain reorganizations on the next sync.
+// It delegates the creation operation to the underlying repository and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - blocks: The blocks to record.
+//   - pruneBelow: Recorded blocks below this height are removed.
+//
+// Returns:
+//   - error: An error if the creation operation fails; otherwise, nil.
+func (s *SyncService) AddSyncBlocks(ctx context.Context, blocks []model.SyncBlockTbl, pruneBelow int64) error {
+	if err := s.repo.CreateSyncBlocks(ctx, blocks, pruneBelow); err != nil {
+		zap.L().Error("CreateSyncBlocks failed", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// GetSyncBlocks retrieves the recorded blocks of a chain at or below a height, the highest first.
+// It queries the underlying repository for the data and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - chainId: The chain ID of the blocks.
+//   - maxHeight: The highest height to return.
+//   - limit: The maximum number of blocks to return.
+//
+// Returns:
+//   - []model.SyncBlockTbl: The recorded blocks.
+//   - error: An error if the query operation fails; otherwise, nil.
+func (s *SyncService) GetSyncBlocks(ctx context.Context, chainId, maxHeight int64, limit int) ([]model.SyncBlockTbl, error) {
+	res, err := s.repo.GetSyncBlocks(ctx, chainId, maxHeight, limit)
+	if err != nil {
+		zap.L().Error("GetSyncBlocks failed", zap.Error(err))
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// RollbackSync removes everything synced above a height after a chain reorganization and sets the synced height back to it.
+// It delegates the rollback to the underlying repository and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - addr: The address is the power voting contract address.
+//   - chainId: The chain ID of the synced events.
+//   - height: The last height that is still on the chain.
+//
+// Returns:
+//   - error: An error if the rollback fails; otherwise, nil.
+func (s *SyncService) RollbackSync(ctx context.Context, addr string, chainId, height int64) error {
+	if err := s.repo.RollbackSync(ctx, addr, chainId, height); err != nil {
+		zap.L().Error("RollbackSync failed", zap.Int64("height", height), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// Transaction runs fn in a database transaction, the services called with the context passed to fn take part in it.
+// Called with the context of another transaction, fn runs in a savepoint that is rolled back alone when fn fails.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - fn: The function to run in the transaction.
+//
+// Returns:
+//   - error: The error returned by fn, or an error if the transaction can not be committed; otherwise, nil.
+func (s *SyncService) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return s.repo.Transaction(ctx, fn)
+}
+
+// AddDeadLetter saves an event log that failed to be applied, so that it is replayed later.
+// It delegates the creation operation to the underlying repository and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - in: The dead letter with the raw event log and the error.
+//
+// Returns:
+//   - error: An error if the creation operation fails; otherwise, nil.
+func (s *SyncService) AddDeadLetter(ctx context.Context, in *model.EventDeadLetterTbl) error {
+	if err := s.repo.CreateDeadLetter(ctx, in); err != nil {
+		zap.L().Error("CreateDeadLetter failed", zap.String("tx hash", in.TxHash), zap.Uint("log index", in.LogIndex), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// GetDueDeadLetters retrieves the pending dead letters of a chain whose next replay time has passed.
+// It queries the underlying repository for the data and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - chainId: The chain ID of the dead letters.
+//   - now: The current unix time.
+//   - limit: The maximum number of dead letters to return.
+//
+// Returns:
+//   - []model.EventDeadLetterTbl: The dead letters to replay, the oldest event first.
+//   - error: An error if the query operation fails; otherwise, nil.
+func (s *SyncService) GetDueDeadLetters(ctx context.Context, chainId, now int64, limit int) ([]model.EventDeadLetterTbl, error) {
+	res, err := s.repo.GetDueDeadLetters(ctx, chainId, now, limit)
+	if err != nil {
+		zap.L().Error("GetDueDeadLetters failed", zap.Error(err))
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// UpdateDeadLetter saves the result of a replay of a dead letter.
+// It delegates the update operation to the underlying repository and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - in: The replayed dead letter.
+//
+// Returns:
+//   - error: An error if the update operation fails; otherwise, nil.
+func (s *SyncService) UpdateDeadLetter(ctx context.Context, in *model.EventDeadLetterTbl) error {
+	if err := s.repo.UpdateDeadLetter(ctx, in); err != nil {
+		zap.L().Error("UpdateDeadLetter failed", zap.Int64("id", in.ID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// AddEventLog appends a raw event log to the event journal, the source the derived tables can be rebuilt from.
+// It delegates the creation operation to the underlying repository and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - in: The event log with its block.
+//
+// Returns:
+//   - error: An error if the creation operation fails; otherwise, nil.
+func (s *SyncService) AddEventLog(ctx context.Context, in *model.EventLogTbl) error {
+	if err := s.repo.CreateEventLog(ctx, in); err != nil {
+		zap.L().Error("CreateEventLog failed", zap.String("tx hash", in.TxHash), zap.Uint("log index", in.LogIndex), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// GetEventLogs retrieves a page of the event journal of a chain, in the order the events were emitted.
+// It queries the underlying repository for the data and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - chainId: The chain ID of the event logs.
+//   - offset: The number of event logs to skip.
+//   - limit: The maximum number of event logs to return.
+//
+// Returns:
+//   - []model.EventLogTbl: The event logs.
+//   - error: An error if the query operation fails; otherwise, nil.
+func (s *SyncService) GetEventLogs(ctx context.Context, chainId int64, offset, limit int) ([]model.EventLogTbl, error) {
+	res, err := s.repo.GetEventLogs(ctx, chainId, offset, limit)
+	if err != nil {
+		zap.L().Error("GetEventLogs failed", zap.Error(err))
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// GetEventLog retrieves the journaled event log of a chain emitted at the given transaction and log index.
+// It queries the underlying repository for the data and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - chainId: The chain ID of the event log.
+//   - txHash: The hash of the transaction that emitted the event log.
+//   - logIndex: The index of the event log in its block.
+//
+// Returns:
+//   - *model.EventLogTbl: The event log, nil when it is not in the journal.
+//   - error: An error if the query operation fails; otherwise, nil.
+func (s *SyncService) GetEventLog(ctx context.Context, chainId int64, txHash string, logIndex uint) (*model.EventLogTbl, error) {
+	res, err := s.repo.GetEventLog(ctx, chainId, txHash, logIndex)
+	if err != nil {
+		zap.L().Error("GetEventLog failed", zap.String("tx hash", txHash), zap.Uint("log index", logIndex), zap.Error(err))
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// DeleteDerivedEvents deletes the rows of a chain that are derived from its event logs, before they are rebuilt from the journal.
+// It delegates the delete operation to the underlying repository and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - chainId: The chain ID of the rows to delete.
+//
+// Returns:
+//   - error: An error if the delete operation fails; otherwise, nil.
+func (s *SyncService) DeleteDerivedEvents(ctx context.Context, chainId int64) error {
+	if err := s.repo.DeleteDerivedEvents(ctx, chainId); err != nil {
+		zap.L().Error("DeleteDerivedEvents failed", zap.Int64("chain id", chainId), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// AddProposal adds a new proposal record to the repository.
+// It delegates the creation operation to the underlying proposal repository and logs any errors encountered.
+// The proposal is then linked to the draft it was created from, if its creator marked one.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - in: The proposal data to be added.
+//
+// Returns:
+//   - error: An error if the creation or the link to the draft fails; otherwise, nil.
+func (s *SyncService) AddProposal(ctx context.Context, in *model.ProposalTbl) error {
+	if in == nil {
+		return errors.New("proposal is nil")
+	}
+
+	_, err := s.proposalRepo.CreateProposal(ctx, in)
+	if err != nil {
+		zap.L().Error("AddProposal failed", zap.Error(err))
+		return err
+	}
+
+	draftId, err := s.proposalRepo.LinkProposalDraft(ctx, in)
+	if err != nil {
+		zap.L().Error("LinkProposalDraft failed", zap.Int64("proposal id", in.ProposalId), zap.Error(err))
+		return err
+	}
+	if draftId != 0 {
+		zap.L().Info("Proposal linked to its draft", zap.Int64("proposal id", in.ProposalId), zap.Int64("draft id", draftId))
+	}
+
+	return nil
+}
+
+// AddProposalAudit stores the tally audit bundle of a counted proposal.
+// It delegates the creation operation to the underlying proposal repository and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - in: The audit bundle to be stored.
+//
+// Returns:
+//   - error: An error if the creation operation fails; otherwise, nil.
+func (s *SyncService) AddProposalAudit(ctx context.Context, in *model.ProposalAuditTbl) error {
+	if in == nil {
+		return errors.New("proposal audit is nil")
+	}
+
+	if err := s.proposalRepo.CreateProposalAudit(ctx, in); err != nil {
+		zap.L().Error("AddProposalAudit failed", zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// GetProposal retrieves a proposal by its chain ID and proposal ID.
+// It queries the underlying proposal repository for the data and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - chainId: The chain ID of the proposal.
+//   - proposalId: The proposal ID.
+//
+// Returns:
+//   - *model.ProposalTbl: The proposal if found.
+//   - error: An error if the query operation fails or the proposal does not exist; otherwise, nil.
+func (s *SyncService) GetProposal(ctx context.Context, chainId, proposalId int64) (*model.ProposalTbl, error) {
+	proposal, err := s.proposalRepo.GetProposalById(ctx, api.ProposalReq{
+		ProposalId:   proposalId,
+		ChainIdParam: api.ChainIdParam{ChainId: chainId},
+	})
+	if err != nil {
+		zap.L().Error("GetProposalById error", zap.Error(err))
+		return nil, err
+	}
+
+	if proposal == nil {
+		return nil, fmt.Errorf("proposal %d not found on chain %d", proposalId, chainId)
+	}
+
+	return proposal, nil
+}
+
+// GetProposalAudit retrieves the tally audit bundle of a proposal.
+// It queries the underlying proposal repository for the data and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - chainId: The chain ID of the proposal.
+//   - proposalId: The proposal ID.
+//
+// Returns:
+//   - *model.ProposalAuditTbl: The audit bundle, nil if the proposal has none.
+//   - error: An error if the query operation fails; otherwise, nil.
+func (s *SyncService) GetProposalAudit(ctx context.Context, chainId, proposalId int64) (*model.ProposalAuditTbl, error) {
+	audit, err := s.proposalRepo.GetProposalAudit(ctx, api.ProposalReq{
+		ProposalId:   proposalId,
+		ChainIdParam: api.ChainIdParam{ChainId: chainId},
+	})
+	if err != nil {
+		zap.L().Error("GetProposalAudit error", zap.Error(err))
+		return nil, err
+	}
+
+	return audit, nil
+}
+
+// AddProposalResultHistory stores a result of a proposal that is replaced by a recount.
+// It delegates the creation operation to the underlying proposal repository and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - in: The replaced result to be stored.
+//
+// Returns:
+//   - error: An error if the creation operation fails; otherwise, nil.
+func (s *SyncService) AddProposalResultHistory(ctx context.Context, in *model.ProposalResultHistoryTbl) error {
+	if in == nil {
+		return errors.New("proposal result history is nil")
+	}
+
+	if err := s.proposalRepo.CreateProposalResultHistory(ctx, in); err != nil {
+		zap.L().Error("AddProposalResultHistory failed", zap.Error(err))
 		return err
 	}
 
 	return nil
 }
 
-// GetSyncEventInfo retrieves synchronization event information for a given address.
-// It queries the underlying repository for the data and logs any errors encountered.
+// DeleteProposalAudit deletes the tally audit bundle of a proposal.
+// It delegates the deletion to the underlying proposal repository and logs any errors encountered.
 //
 // Parameters:
 //   - ctx: Context for request cancellation and timeout.
-//   - addr: The address is the power voting contract address.
+//   - chainId: The chain ID of the proposal.
+//   - proposalId: The proposal ID.
 //
 // Returns:
-//   - *model.SyncEventTbl: The synchronization event data if found.
-//   - error: An error if the query operation fails; otherwise, nil.
-func (s *SyncService) GetSyncEventInfo(ctx context.Context, addr string) (*model.SyncEventTbl, error) {
-	res, err := s.repo.GetSyncEventInfo(ctx, addr)
-	if err != nil {
-		zap.L().Error("GetSyncEventInfo failed", zap.Error(err))
-		return nil, err
+//   - error: An error if the deletion fails; otherwise, nil.
+func (s *SyncService) DeleteProposalAudit(ctx context.Context, chainId, proposalId int64) error {
+	if err := s.proposalRepo.DeleteProposalAudit(ctx, api.ProposalReq{
+		ProposalId:   proposalId,
+		ChainIdParam: api.ChainIdParam{ChainId: chainId},
+	}); err != nil {
+		zap.L().Error("DeleteProposalAudit failed", zap.Int64("proposal id", proposalId), zap.Error(err))
+		return err
 	}
 
-	return res, nil
+	return nil
 }
 
-// CreateSyncEventInfo creates a new synchronization event record in the repository.
-// It delegates the creation operation to the underlying repository and logs any errors encountered.
+// DeleteProposalResultHistory deletes the results of a proposal replaced by recounts.
+// It delegates the deletion to the underlying proposal repository and logs any errors encountered.
 //
 // Parameters:
 //   - ctx: Context for request cancellation and timeout.
-//   - in: The synchronization event data to be created.
+//   - chainId: The chain ID of the proposal.
+//   - proposalId: The proposal ID.
 //
 // Returns:
-//   - error: An error if the creation operation fails; otherwise, nil.
-func (s *SyncService) CreateSyncEventInfo(ctx context.Context, in *model.SyncEventTbl) error {
-	if in == nil {
-		return errors.New("sync event is nil")
-	}
-
-	_, err := s.repo.CreateSyncEventInfo(ctx, in)
-	if err != nil {
-		zap.L().Error("CreateSyncEventInfo failed", zap.Error(err))
+//   - error: An error if the deletion fails; otherwise, nil.
+func (s *SyncService) DeleteProposalResultHistory(ctx context.Context, chainId, proposalId int64) error {
+	if err := s.proposalRepo.DeleteProposalResultHistory(ctx, api.ProposalReq{
+		ProposalId:   proposalId,
+		ChainIdParam: api.ChainIdParam{ChainId: chainId},
+	}); err != nil {
+		zap.L().Error("DeleteProposalResultHistory failed", zap.Int64("proposal id", proposalId), zap.Error(err))
+		return err
 	}
 
 	return nil
 }
 
-// AddProposal adds a new proposal record to the repository.
-// It delegates the creation operation to the underlying proposal repository and logs any errors encountered.
+// UpdateProposal updates an existing proposal record in the repository.
+// It delegates the update operation to the underlying proposal repository and logs any errors encountered.
 //
 // Parameters:
 //   - ctx: Context for request cancellation and timeout.
-//   - in: The proposal data to be added.
+//   - in: The proposal data to be updated.
 //
 // Returns:
-//   - error: An error if the creation operation fails; otherwise, nil.
-func (s *SyncService) AddProposal(ctx context.Context, in *model.ProposalTbl) error {
+//   - error: An error if the update operation fails; otherwise, nil.
+func (s *SyncService) UpdateProposal(ctx context.Context, in *model.ProposalTbl) error {
 	if in == nil {
 		return errors.New("proposal is nil")
 	}
 
-	_, err := s.proposalRepo.CreateProposal(ctx, in)
-	if err != nil {
-		zap.L().Error("AddProposal failed", zap.Error(err))
+	if err := s.proposalRepo.UpdateProposal(ctx, in); err != nil {
+		zap.L().Error("UpdateProposal failed", zap.Error(err))
 		return err
 	}
 
 	return nil
 }
 
-// UpdateProposal updates an existing proposal record in the repository.
+// UpdateProposalPowerRetry updates the deferred counting state of a proposal waiting for missing voter power.
 // It delegates the update operation to the underlying proposal repository and logs any errors encountered.
 //
 // Parameters:
 //   - ctx: Context for request cancellation and timeout.
-//   - in: The proposal data to be updated.
+//   - in: The proposal with its deferred counting state.
 //
 // Returns:
 //   - error: An error if the update operation fails; otherwise, nil.
-func (s *SyncService) UpdateProposal(ctx context.Context, in *model.ProposalTbl) error {
+func (s *SyncService) UpdateProposalPowerRetry(ctx context.Context, in *model.ProposalTbl) error {
 	if in == nil {
 		return errors.New("proposal is nil")
 	}
 
-	if err := s.proposalRepo.UpdateProposal(ctx, in); err != nil {
-		zap.L().Error("UpdateProposal failed", zap.Error(err))
+	if err := s.proposalRepo.UpdateProposalPowerRetry(ctx, in); err != nil {
+		zap.L().Error("UpdateProposalPowerRetry failed", zap.Error(err))
 		return err
 	}
 
@@ -241,6 +891,67 @@ func (s *SyncService) UncountedProposalList(ctx context.Context, chainId, endTim
 	return proposals, nil
 }
 
+// StartedProposalList retrieves the proposals that have not been counted and whose voting has started, filtered by chain ID.
+// It queries the underlying proposal repository for the data and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - chainId: The chain ID to filter proposals.
+//   - startTime: The time the voting must have started at.
+//
+// Returns:
+//   - []model.ProposalTbl: The started proposals, ordered by proposal ID.
+//   - error: An error if the query operation fails; otherwise, nil.
+func (s *SyncService) StartedProposalList(ctx context.Context, chainId, startTime int64) ([]model.ProposalTbl, error) {
+	proposals, err := s.proposalRepo.GetStartedProposalList(ctx, chainId, startTime)
+	if err != nil {
+		zap.L().Error("GetStartedProposalList error", zap.Error(err))
+		return nil, err
+	}
+
+	return proposals, nil
+}
+
+// GetNetworkPower retrieves the total power of the network of a chain at the given height from Lotus.
+// It is used to check the power quorum of proposals and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - chainId: The chain ID of the network.
+//   - height: The block height to query, 0 for the chain head.
+//
+// Returns:
+//   - model.NetworkPower: The raw byte and quality adjusted power of the network.
+//   - error: An error if the query operation fails; otherwise, nil.
+func (s *SyncService) GetNetworkPower(ctx context.Context, chainId, height int64) (model.NetworkPower, error) {
+	power, err := s.lotusRepo.GetNetworkPower(ctx, chainId, height)
+	if err != nil {
+		zap.L().Error("GetNetworkPower error", zap.Error(err))
+		return model.NetworkPower{}, err
+	}
+
+	return power, nil
+}
+
+// ProposalList retrieves every proposal of a chain, with their results.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - chainId: The chain ID to filter proposals.
+//
+// Returns:
+//   - []model.ProposalTbl: The proposals, ordered by proposal ID.
+//   - error: An error if the query operation fails; otherwise, nil.
+func (s *SyncService) ProposalList(ctx context.Context, chainId int64) ([]model.ProposalTbl, error) {
+	proposals, err := s.proposalRepo.GetAllProposalList(ctx, chainId)
+	if err != nil {
+		zap.L().Error("GetAllProposalList error", zap.Error(err))
+		return nil, err
+	}
+
+	return proposals, nil
+}
+
 // BatchUpdateVotes updates multiple vote records in the repository in a single operation.
 // It delegates the batch update operation to the underlying vote repository and logs any errors encountered.
 //
@@ -259,21 +970,65 @@ func (s *SyncService) BatchUpdateVotes(ctx context.Context, votes []model.VoteTb
 	return nil
 }
 
-// AddVote adds a new vote record to the repository.
-// It delegates the creation operation to the underlying vote repository and logs any errors encountered.
+// AddVote adds a vote event to the vote history and makes it the vote of the voter on the proposal.
+// A voter can vote again while the proposal is in progress, the latest vote cast before the proposal end time is counted.
+// A vote cast after the end time is kept in the history as invalid, a vote older than the current vote of the voter
+// only updates the history. A vote on a proposal that is not recorded yet is rejected with constant.ErrProposalNotFound,
+// so that its event log is dead-lettered and replayed once the proposal is synced.
 //
 // Parameters:
 //   - ctx: Context for request cancellation and timeout.
-//   - in: The vote data to be added.
+//   - in: The vote event to be added.
 //
 // Returns:
 //   - error: An error if the creation operation fails; otherwise, nil.
-func (s *SyncService) AddVote(ctx context.Context, in *model.VoteTbl) error {
+func (s *SyncService) AddVote(ctx context.Context, in *model.VoteHistoryTbl) error {
 	if in == nil {
 		return errors.New("vote data is nil")
 	}
 
-	_, err := s.voteRepo.CreateVote(ctx, in)
+	proposal, err := s.proposalRepo.GetProposalById(ctx, api.ProposalReq{
+		ProposalId:   in.ProposalId,
+		ChainIdParam: api.ChainIdParam{ChainId: in.ChainId},
+	})
+	if err != nil {
+		zap.L().Error("GetProposalById failed", zap.Error(err))
+		return err
+	}
+	if proposal == nil {
+		zap.L().Warn("Vote on a proposal that is not recorded",
+			zap.Int64("chain id", in.ChainId),
+			zap.Int64("proposal id", in.ProposalId),
+			zap.String("voter", in.Address))
+		return constant.ErrProposalNotFound
+	}
+
+	in.Valid = in.Timestamp <= proposal.EndTime
+	if err := s.voteRepo.CreateVoteHistory(ctx, in); err != nil {
+		zap.L().Error("CreateVoteHistory failed", zap.Error(err))
+		return err
+	}
+
+	if !in.Valid {
+		zap.L().Warn("Vote cast after the proposal end time is not counted",
+			zap.Int64("proposal id", in.ProposalId),
+			zap.String("voter", in.Address),
+			zap.Int64("block number", in.BlockNumber))
+		return nil
+	}
+
+	_, err = s.voteRepo.CreateVote(ctx, &model.VoteTbl{
+		ProposalId:    in.ProposalId,
+		ChainId:       in.ChainId,
+		Address:       in.Address,
+		VoteEncrypted: in.VoteEncrypted,
+		BlockNumber:   in.BlockNumber,
+		LogIndex:      in.LogIndex,
+		Timestamp:     in.Timestamp,
+		BaseField: model.BaseField{
+			CreatedAt: in.CreatedAt,
+		},
+	})
 	if err != nil {
 		zap.L().Error("CreateVote failed", zap.Error(err))
 		return err
@@ -303,6 +1058,33 @@ func (s *SyncService) GetUncountedVotedList(ctx context.Context, chainId, propos
 	return res, nil
 }
 
+// GetVotedList retrieves all votes for a specific chain and proposal, counted or not.
+// It queries the underlying vote repository for the data and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - chainId: The chain ID to filter votes.
+//   - proposalId: The proposal ID to filter votes.
+//
+// Returns:
+//   - []model.VoteTbl: A list of all votes if the query is successful.
+//   - error: An error if the query operation fails; otherwise, nil.
+func (s *SyncService) GetVotedList(ctx context.Context, chainId, proposalId int64) ([]model.VoteTbl, error) {
+	counted, err := s.voteRepo.GetVoteList(ctx, chainId, proposalId, true)
+	if err != nil {
+		zap.L().Error("GetVoteList failed", zap.Error(err))
+		return nil, err
+	}
+
+	uncounted, err := s.voteRepo.GetVoteList(ctx, chainId, proposalId, false)
+	if err != nil {
+		zap.L().Error("GetVoteList failed", zap.Error(err))
+		return nil, err
+	}
+
+	return append(counted, uncounted...), nil
+}
+
 // AddVoterAddress adds a new voter address record to the database or updates an existing one.
 // It delegates the operation to the `CreateVoterAddress` method of the `voteRepo` and handles any errors that may occur.
 //
@@ -317,7 +1099,7 @@ func (s *SyncService) AddVoterAddress(ctx context.Context, in *model.VoterInfoTb
 		return errors.New("voter address is nil")
 	}
 
-	actorId, err := s.lotusRepo.GetActorIdByAddress(ctx, in.Address)
+	actorId, err := s.lotusRepo.GetActorIdByAddress(ctx, in.ChainId, in.Address)
 	if err != nil {
 		zap.L().Error("GetActorIdByAddress failed", zap.String("address", in.Address), zap.Error(err))
 		return err
@@ -333,6 +1115,47 @@ func (s *SyncService) AddVoterAddress(ctx context.Context, in *model.VoterInfoTb
 	return nil
 }
 
+// VoterList retrieves the voters of a chain.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - chainId: The chain ID of the voters.
+//
+// Returns:
+//   - []model.VoterInfoTbl: The voters with their miner IDs and GitHub identity.
+//   - error: An error if the query operation fails; otherwise, nil.
+func (s *SyncService) VoterList(ctx context.Context, chainId int64) ([]model.VoterInfoTbl, error) {
+	voters, err := s.voteRepo.GetAllVoterAddresss(ctx, chainId)
+	if err != nil {
+		zap.L().Error("GetAllVoterAddresss failed", zap.Int64("chain id", chainId), zap.Error(err))
+		return nil, err
+	}
+
+	return voters, nil
+}
+
+// RestoreVoter saves a recorded voter as it is, without resolving its actor ID or syncing its power.
+// A voter already saved is kept.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - in: The recorded voter.
+//
+// Returns:
+//   - error: An error if the creation operation fails; otherwise, nil.
+func (s *SyncService) RestoreVoter(ctx context.Context, in *model.VoterInfoTbl) error {
+	if in == nil {
+		return errors.New("voter is nil")
+	}
+
+	if err := s.voteRepo.RestoreVoterInfo(ctx, in); err != nil {
+		zap.L().Error("RestoreVoterInfo failed", zap.String("address", in.Address), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
 // CreateFipProposal creates a new FipProposal record in the database by delegating the operation
 // to the underlying repository (`fipRepo`). It performs basic validation to ensure the input is not nil.
 //
@@ -438,7 +1261,7 @@ func (s *SyncService) UpdateStatusAndGetFipProposal(ctx context.Context, proposa
 }
 
 func (s *SyncService) RevokeFipProposal(ctx context.Context, chainId int64, cadidateAddresss string) error {
-	if err := s.fipRepo.UpdateFipEditorByAddress(ctx, cadidateAddresss); err != nil {
+	if err := s.fipRepo.UpdateFipEditorByAddress(ctx, chainId, cadidateAddresss); err != nil {
 		zap.L().Error(
 			"UpdateFipEditorByAddress failed",
 			zap.String("cadidateAddresss", cadidateAddresss),
@@ -458,7 +1281,7 @@ func (s *SyncService) RevokeFipProposal(ctx context.Context, chainId int64, cadi
 
 	for _, proposal := range unpassFipProposals {
 		zap.L().Info("unpassFipProposals", zap.Any("fip proposal", proposal))
-		if err := s.fipRepo.UpdateFipProposalVoteByAddress(ctx, proposal.ProposalId, cadidateAddresss); err != nil {
+		if err := s.fipRepo.UpdateFipProposalVoteByAddress(ctx, chainId, proposal.ProposalId, cadidateAddresss); err != nil {
 			zap.L().Error(
 				"UpdateFipProposalVoteByAddress failed",
 				zap.String("cadidateAddresss", cadidateAddresss),
@@ -473,7 +1296,7 @@ func (s *SyncService) RevokeFipProposal(ctx context.Context, chainId int64, cadi
 }
 
 func (s *SyncService) UpdateVoterAndProposalGithubNameByGistInfo(ctx context.Context, voterInfo *model.VoterInfoTbl) error {
-	actorId, err := s.lotusRepo.GetActorIdByAddress(ctx, voterInfo.Address)
+	actorId, err := s.lotusRepo.GetActorIdByAddress(ctx, voterInfo.ChainId, voterInfo.Address)
 	if err != nil {
 		zap.L().Error("FilecoinAddressToID failed", zap.Error(err))
 		return err
@@ -511,13 +1334,13 @@ func (s *SyncService) UpdateVoterAndProposalGithubNameByGistInfo(ctx context.Con
 			return true
 		}
 
-		reqAddrActorId, err := s.lotusRepo.GetActorIdByAddress(ctx, voterInfo.Address)
+		reqAddrActorId, err := s.lotusRepo.GetActorIdByAddress(ctx, voterInfo.ChainId, voterInfo.Address)
 		if err != nil {
 			zap.L().Error("GetActorIdByAddress failed by VerifyGist", zap.String("address", voterInfo.Address), zap.Error(err))
 			return false
 		}
 
-		gistAddrActorId, err := s.lotusRepo.GetActorIdByAddress(ctx, gistAddr)
+		gistAddrActorId, err := s.lotusRepo.GetActorIdByAddress(ctx, voterInfo.ChainId, gistAddr)
 		if err != nil {
 			zap.L().Error("GetActorIdByAddress failed by VerifyGist", zap.String("address", gistAddr), zap.Error(err))
 			return false
@@ -540,17 +1363,18 @@ func (s *SyncService) UpdateVoterAndProposalGithubNameByGistInfo(ctx context.Con
 	return nil
 }
 
-func (s *SyncService) UpdateVoterByMinerIds(ctx context.Context, voterAddress string, minerIds []uint64) error {
-	actorId, err := s.lotusRepo.GetActorIdByAddress(ctx, voterAddress)
+func (s *SyncService) UpdateVoterByMinerIds(ctx context.Context, chainId int64, voterAddress string, minerIds []uint64) error {
+	actorId, err := s.lotusRepo.GetActorIdByAddress(ctx, chainId, voterAddress)
 	if err != nil {
 		zap.L().Error("FilecoinAddressToID failed", zap.String("Func", "UpdateVoterByMinerIds"), zap.String("voterAddress", voterAddress), zap.Error(err))
 		return err
 	}
 
-	validMinerIds, err := s.lotusRepo.GetValidMinerIds(ctx, actorId, minerIds)
+	validMinerIds, err := s.lotusRepo.GetValidMinerIds(ctx, chainId, actorId, minerIds)
 
 	if err := s.voteRepo.UpdateVoterByMinerInfo(ctx, &model.VoterInfoTbl{
 		Address:  voterAddress,
+		ChainId:  chainId,
 		MinerIds: validMinerIds,
 		OwnerId:  actorId,
 	}); err != nil {
@@ -562,3 +1386,167 @@ func (s *SyncService) UpdateVoterByMinerIds(ctx context.Context, voterAddress st
 
 	return nil
 }
+
+// AddGithubRepo saves a GitHub repository added on the PowerVotingConf contract.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values, cancellation signals, and deadlines.
+//   - in: The repository to save.
+//
+// Returns:
+//   - error: An error object if the operation fails. Returns `nil` if the operation is successful.
+func (s *SyncService) AddGithubRepo(ctx context.Context, in *model.GithubRepoTbl) error {
+	if in == nil {
+		return errors.New("github repo is nil")
+	}
+
+	if err := s.confRepo.CreateGithubRepo(ctx, in); err != nil {
+		zap.L().Error("AddGithubRepo failed", zap.Int64("repo id", in.RepoId), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// RemoveGithubRepo marks a GitHub repository removed from the PowerVotingConf contract.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values, cancellation signals, and deadlines.
+//   - chainId: The chain ID of the repository.
+//   - repoId: The ID of the repository on the contract.
+//   - blockNumber: The block number of the removal.
+//
+// Returns:
+//   - error: An error object if the operation fails. Returns `nil` if the operation is successful.
+func (s *SyncService) RemoveGithubRepo(ctx context.Context, chainId, repoId, blockNumber int64) error {
+	if err := s.confRepo.RemoveGithubRepo(ctx, chainId, repoId, blockNumber); err != nil {
+		zap.L().Error("RemoveGithubRepo failed", zap.Int64("repo id", repoId), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// AddSnapshotHeight saves the snapshot height of a day set on the PowerVotingConf contract.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values, cancellation signals, and deadlines.
+//   - in: The snapshot height to save.
+//
+// Returns:
+//   - error: An error object if the operation fails. Returns `nil` if the operation is successful.
+func (s *SyncService) AddSnapshotHeight(ctx context.Context, in *model.SnapshotHeightTbl) error {
+	if in == nil {
+		return errors.New("snapshot height is nil")
+	}
+
+	if err := s.confRepo.CreateSnapshotHeight(ctx, in); err != nil {
+		zap.L().Error("AddSnapshotHeight failed", zap.String("day", in.Day), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// AddConfChange saves a change of a PowerVotingConf contract setting.
+//
+// Parameters:
+//   - ctx: The context for managing request-scoped values, cancellation signals, and deadlines.
+//   - in: The setting change to save.
+//
+// Returns:
+//   - error: An error object if the operation fails. Returns `nil` if the operation is successful.
+func (s *SyncService) AddConfChange(ctx context.Context, in *model.ConfChangeTbl) error {
+	if in == nil {
+		return errors.New("conf change is nil")
+	}
+
+	if err := s.confRepo.CreateConfChange(ctx, in); err != nil {
+		zap.L().Error("AddConfChange failed", zap.String("setting", in.Setting), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// AddWebhookEvent emits a governance lifecycle event for the webhooks and creates a delivery for every subscribed webhook.
+// An event is emitted once for its chain and subject, so that a synced again or replayed event is not delivered twice.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - in: The event with its JSON data.
+//
+// Returns:
+//   - error: An error if the event or its deliveries can not be saved; otherwise, nil.
+func (s *SyncService) AddWebhookEvent(ctx context.Context, in *model.WebhookEventTbl) error {
+	err := s.Transaction(ctx, func(ctx context.Context) error {
+		created, err := s.webhookRepo.CreateWebhookEvent(ctx, in)
+		if err != nil || !created {
+			return err
+		}
+
+		webhooks, err := s.webhookRepo.GetWebhookList(ctx)
+		if err != nil {
+			return err
+		}
+
+		var deliveries []model.WebhookDeliveryTbl
+		for _, webhook := range webhooks {
+			if webhook.Subscribes(in.ChainId, in.Event) {
+				deliveries = append(deliveries, model.WebhookDeliveryTbl{
+					WebhookId: webhook.ID,
+					EventId:   in.ID,
+					Event:     in.Event,
+					Status:    constant.WebhookDeliveryStatusPending,
+				})
+			}
+		}
+
+		return s.webhookRepo.CreateWebhookDeliveries(ctx, deliveries)
+	})
+	if err != nil {
+		zap.L().Error("AddWebhookEvent failed", zap.String("event", in.Event), zap.String("subject", in.Subject), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+// GetDueWebhookDeliveries retrieves the pending webhook deliveries whose next attempt time has passed.
+// It queries the underlying repository for the data and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - now: The current unix time.
+//   - limit: The maximum number of deliveries to return.
+//
+// Returns:
+//   - []model.WebhookDueDelivery: The deliveries to post with their webhook and event, the oldest first.
+//   - error: An error if the query operation fails; otherwise, nil.
+func (s *SyncService) GetDueWebhookDeliveries(ctx context.Context, now int64, limit int) ([]model.WebhookDueDelivery, error) {
+	res, err := s.webhookRepo.GetDueWebhookDeliveries(ctx, now, limit)
+	if err != nil {
+		zap.L().Error("GetDueWebhookDeliveries failed", zap.Error(err))
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// UpdateWebhookDelivery saves the result of an attempt of a webhook delivery.
+// It delegates the update operation to the underlying repository and logs any errors encountered.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - in: The attempted delivery.
+//
+// Returns:
+//   - error: An error if the update operation fails; otherwise, nil.
+func (s *SyncService) UpdateWebhookDelivery(ctx context.Context, in *model.WebhookDeliveryTbl) error {
+	if err := s.webhookRepo.UpdateWebhookDelivery(ctx, in); err != nil {
+		zap.L().Error("UpdateWebhookDelivery failed", zap.Int64("id", in.ID), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
@@ -21,6 +21,8 @@ import (
 
 	"go.uber.org/zap"
 
+	"powervoting-server/config"
+	"powervoting-server/constant"
 	"powervoting-server/model"
 	"powervoting-server/model/api"
 	"powervoting-server/utils"
@@ -31,6 +33,7 @@ import (
 type VoteRepo interface {
 	// CreateVote creates a new vote record in the repository.
 	// This is typically called when a Vote event is parsed during the execution of a synchronous contract event.
+	// An existing vote of the voter is only replaced by a vote cast after it.
 	//
 	// Parameters:
 	//   - ctx: Context for request cancellation and timeout.
@@ -41,6 +44,41 @@ type VoteRepo interface {
 	//   - error: An error if the creation operation fails; otherwise, nil.
 	CreateVote(ctx context.Context, in *model.VoteTbl) (int64, error)
 
+	// CreateVoteHistory stores a vote event in the vote history, a vote event already stored is ignored.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - in: The vote event to store.
+	//
+	// Returns:
+	//   - error: An error if the creation operation fails; otherwise, nil.
+	CreateVoteHistory(ctx context.Context, in *model.VoteHistoryTbl) error
+
+	// GetVoteHistory retrieves every vote of a voter on a proposal, in the order they were cast.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - chainId: The chain ID associated with the proposal.
+	//   - proposalId: The proposal ID to filter votes.
+	//   - address: The voter address.
+	//
+	// Returns:
+	//   - []model.VoteHistoryTbl: The votes of the voter, the oldest first.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetVoteHistory(ctx context.Context, chainId, proposalId int64, address string) ([]model.VoteHistoryTbl, error)
+
+	// GetVoterVotes retrieves the current vote of a voter on every proposal of a network, with the proposal it was cast on.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - chainId: The chain ID of the network.
+	//   - address: The voter address.
+	//
+	// Returns:
+	//   - []model.VoteWithProposal: The votes of the voter, the latest first.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetVoterVotes(ctx context.Context, chainId int64, address string) ([]model.VoteWithProposal, error)
+
 	// GetVoteList retrieves a list of votes for a specific proposal, optionally filtered by counted status.
 	//
 	// Parameters:
@@ -57,6 +95,7 @@ type VoteRepo interface {
 	// BatchUpdateVotes updates multiple vote records in the repository in a single operation.
 	// This is typically used during the vote counting process, where the weight of each vote
 	// is calculated and the results are updated in the database.
+	// Each vote is matched by its chain ID, proposal ID and address, proposal IDs are only unique on a chain.
 	//
 	// Parameters:
 	//   - ctx: Context for request cancellation and timeout.
@@ -93,16 +132,28 @@ type VoteRepo interface {
 	//   - error: An error object if the query fails.
 	GetAllVoterAddresss(ctx context.Context, chainId int64) ([]model.VoterInfoTbl, error)
 
+	// RestoreVoterInfo saves a recorded voter as it is, a voter already saved is kept.
+	//
+	// Parameters:
+	//   - ctx: The context for managing request-scoped values, cancellation signals, and deadlines.
+	//   - in: The recorded voter.
+	//
+	// Returns:
+	//   - error: An error object if the operation fails.
+	RestoreVoterInfo(ctx context.Context, in *model.VoterInfoTbl) error
+
 	UpdateVoterByMinerInfo(ctx context.Context, in *model.VoterInfoTbl) error
 
 	UpdateVoterByGistInfo(ctx context.Context, in *model.VoterInfoTbl) error
 
-	GetVoterInfoByAddress(ctx context.Context, address string) (*model.VoterInfoTbl, error)
+	GetVoterInfoByAddress(ctx context.Context, chainId int64, address string) (*model.VoterInfoTbl, error)
 }
 
 type IVoteService interface {
 	GetCountedVotedList(ctx context.Context, chainId, proposalId int64) ([]api.Voted, error)
-	GetFipEditorGistInfo(ctx context.Context, req api.AddressReq) (*api.FipEditorGistInfoRep, error)
+	GetVoteHistory(ctx context.Context, req api.VoteHistoryReq) ([]api.VoteRevision, error)
+	GetVoterHistory(ctx context.Context, req api.VoterHistoryReq) ([]api.VoterHistoryRep, error)
+	GetFipEditorGistInfo(ctx context.Context, req api.VoterInfoReq) (*api.FipEditorGistInfoRep, error)
 	VerifyGist(ctx context.Context, req api.VerifyGistReq) (*model.SigObject, error)
 }
 
@@ -160,14 +211,117 @@ func (v *VoteService) GetCountedVotedList(ctx context.Context, chainId, proposal
 	return votes, nil
 }
 
-func (f *VoteService) GetFipEditorGistInfo(ctx context.Context, req api.AddressReq) (*api.FipEditorGistInfoRep, error) {
-	ethAddr, err := req.ToEthAddr()
+// GetVoteHistory retrieves every vote of a voter on a proposal, in the order they were cast.
+// The current vote is the latest valid one, it is the vote that is counted.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - req: Contains the proposal ID, chain ID and the voter address.
+//
+// Returns:
+//   - []api.VoteRevision: The votes of the voter, the oldest first.
+//   - error: An error if the address is invalid or the query operation fails; otherwise, nil.
+func (v *VoteService) GetVoteHistory(ctx context.Context, req api.VoteHistoryReq) ([]api.VoteRevision, error) {
+	addressReq := api.AddressReq{Address: req.Address}
+	ethAddr, err := addressReq.ToEthAddr(req.ChainId)
+	if err != nil {
+		zap.L().Error("req.ToEthAddr failed", zap.String("address", req.Address), zap.Error(err))
+		return nil, err
+	}
+
+	history, err := v.repo.GetVoteHistory(ctx, req.ChainId, req.ProposalId, ethAddr)
+	if err != nil {
+		zap.L().Error("GetVoteHistory failed", zap.Int64("proposalId", req.ProposalId), zap.Error(err))
+		return nil, errors.New("fail to get vote history")
+	}
+
+	revisions := make([]api.VoteRevision, 0, len(history))
+	current := -1
+	for i, vote := range history {
+		revisions = append(revisions, api.VoteRevision{
+			Revision:      i + 1,
+			VoteEncrypted: vote.VoteEncrypted,
+			BlockNumber:   vote.BlockNumber,
+			LogIndex:      vote.LogIndex,
+			TxHash:        vote.TxHash,
+			VotedTime:     vote.Timestamp,
+			Valid:         vote.Valid,
+		})
+		if vote.Valid {
+			current = i
+		}
+	}
+
+	if current >= 0 {
+		revisions[current].Current = true
+	}
+
+	return revisions, nil
+}
+
+// GetVoterHistory retrieves the vote of a voter on every proposal of a network, the latest first.
+// The vote option and the power used are only returned once the proposal is counted, before that the vote is still encrypted.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - req: Contains the chain ID and the voter address.
+//
+// Returns:
+//   - []api.VoterHistoryRep: The votes of the voter with the outcome of their proposal.
+//   - error: An error if the address is invalid or the query operation fails; otherwise, nil.
+func (v *VoteService) GetVoterHistory(ctx context.Context, req api.VoterHistoryReq) ([]api.VoterHistoryRep, error) {
+	addressReq := api.AddressReq{Address: req.Address}
+	ethAddr, err := addressReq.ToEthAddr(req.ChainId)
+	if err != nil {
+		zap.L().Error("req.ToEthAddr failed", zap.String("address", req.Address), zap.Error(err))
+		return nil, err
+	}
+
+	votes, err := v.repo.GetVoterVotes(ctx, req.ChainId, ethAddr)
+	if err != nil {
+		zap.L().Error("GetVoterVotes failed", zap.String("address", ethAddr), zap.Error(err))
+		return nil, errors.New("fail to get voter history")
+	}
+
+	history := make([]api.VoterHistoryRep, 0, len(votes))
+	for _, vote := range votes {
+		rep := api.VoterHistoryRep{
+			ProposalId:  vote.ProposalId,
+			ChainId:     vote.ChainId,
+			Title:       vote.Title,
+			BlockNumber: vote.BlockNumber,
+			VotedTime:   vote.Timestamp,
+			Counted:     vote.ProposalCounted == constant.ProposalCounted,
+		}
+		if rep.Counted {
+			rep.VotedResult = vote.VoteResult
+			rep.Outcome = vote.Outcome
+			rep.PowerRep = api.PowerRep{
+				SpPower:          vote.SpPower,
+				TokenHolderPower: vote.TokenHolderPower,
+				ClientPower:      vote.ClientPower,
+				DeveloperPower:   vote.DeveloperPower,
+			}
+		}
+		history = append(history, rep)
+	}
+
+	return history, nil
+}
+
+func (f *VoteService) GetFipEditorGistInfo(ctx context.Context, req api.VoterInfoReq) (*api.FipEditorGistInfoRep, error) {
+	network, ok := config.GetNetwork(req.ChainId)
+	if !ok {
+		return nil, fmt.Errorf("network %d is not configured", req.ChainId)
+	}
+
+	ethAddr, err := req.ToEthAddr(network.ChainId)
 	if err != nil {
 		zap.L().Error("req.ToEthAddr failed", zap.String("address", req.Address), zap.Error(err))
 		return nil, err
 	}
 
-	voterInfo, err := f.repo.GetVoterInfoByAddress(ctx, ethAddr)
+	voterInfo, err := f.repo.GetVoterInfoByAddress(ctx, network.ChainId, ethAddr)
 	if err != nil {
 		zap.L().Error("GetVoterInfoByAddress failed", zap.String("address", ethAddr), zap.Error(err))
 		return nil, err
@@ -188,13 +342,13 @@ func (f *VoteService) GetFipEditorGistInfo(ctx context.Context, req api.AddressR
 			return true
 		}
 
-		reqAddrActorId, err := f.lotusRepo.GetActorIdByAddress(ctx, req.Address)
+		reqAddrActorId, err := f.lotusRepo.GetActorIdByAddress(ctx, req.ChainId, req.Address)
 		if err != nil {
 			zap.L().Error("GetActorIdByAddress failed by VerifyGist", zap.String("address", req.Address), zap.Error(err))
 			return false
 		}
 
-		gistAddrActorId, err := f.lotusRepo.GetActorIdByAddress(ctx, gistAddr)
+		gistAddrActorId, err := f.lotusRepo.GetActorIdByAddress(ctx, req.ChainId, gistAddr)
 		if err != nil {
 			zap.L().Error("GetActorIdByAddress failed by VerifyGist", zap.String("address", gistAddr), zap.Error(err))
 			return false
@@ -245,13 +399,13 @@ func (f *VoteService) VerifyGist(ctx context.Context, req api.VerifyGistReq) (*m
 			return true
 		}
 
-		reqAddrActorId, err := f.lotusRepo.GetActorIdByAddress(ctx, req.Address)
+		reqAddrActorId, err := f.lotusRepo.GetActorIdByAddress(ctx, req.ChainId, req.Address)
 		if err != nil {
 			zap.L().Error("GetActorIdByAddress failed by VerifyGist", zap.String("address", req.Address), zap.Error(err))
 			return false
 		}
 
-		gistAddrActorId, err := f.lotusRepo.GetActorIdByAddress(ctx, gistAddr)
+		gistAddrActorId, err := f.lotusRepo.GetActorIdByAddress(ctx, req.ChainId, gistAddr)
 		if err != nil {
 			zap.L().Error("GetActorIdByAddress failed by VerifyGist", zap.String("address", gistAddr), zap.Error(err))
 			return false
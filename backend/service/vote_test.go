@@ -0,0 +1,99 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package service_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/constant"
+	"powervoting-server/mock"
+	"powervoting-server/model"
+	"powervoting-server/model/api"
+	"powervoting-server/service"
+)
+
+func TestGetVoteHistory(t *testing.T) {
+	voteService := service.NewVoteService(&mock.MockVoteService{}, nil)
+
+	revisions, err := voteService.GetVoteHistory(context.Background(), api.VoteHistoryReq{
+		ProposalReq: api.ProposalReq{ProposalId: 1, ChainIdParam: api.ChainIdParam{ChainId: 314159}},
+		Address:     "0x1234567890123456789012345678901234567890",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, revisions, 3)
+	assert.Equal(t, 1, revisions[0].Revision)
+	assert.False(t, revisions[0].Current)
+	// the vote cast after the end time is not counted, the latest valid vote is
+	assert.True(t, revisions[1].Current)
+	assert.False(t, revisions[2].Valid)
+	assert.False(t, revisions[2].Current)
+}
+
+func TestGetVoterHistory(t *testing.T) {
+	voteService := service.NewVoteService(&mock.MockVoteService{}, nil)
+
+	history, err := voteService.GetVoterHistory(context.Background(), api.VoterHistoryReq{
+		ChainIdParam: api.ChainIdParam{ChainId: 314159},
+		Address:      "0x1234567890123456789012345678901234567890",
+	})
+	assert.NoError(t, err)
+	assert.Len(t, history, 2)
+	// the vote on a proposal that is not counted yet is still encrypted
+	assert.False(t, history[0].Counted)
+	assert.Empty(t, history[0].VotedResult)
+	assert.Empty(t, history[0].TokenHolderPower)
+	assert.True(t, history[1].Counted)
+	assert.Equal(t, constant.VoteApprove, history[1].VotedResult)
+	assert.Equal(t, constant.ProposalOutcomePassed, history[1].Outcome)
+	assert.Equal(t, "1000", history[1].TokenHolderPower)
+	assert.Equal(t, int64(100), history[1].BlockNumber)
+}
+
+// unknownProposalRepo does not know any proposal.
+type unknownProposalRepo struct {
+	mock.MockProposalService
+}
+
+func (r *unknownProposalRepo) GetProposalById(ctx context.Context, req api.ProposalReq) (*model.ProposalTbl, error) {
+	return nil, nil
+}
+
+// historyVoteRepo records the vote history it saves.
+type historyVoteRepo struct {
+	mock.MockVoteService
+	history []model.VoteHistoryTbl
+}
+
+func (r *historyVoteRepo) CreateVoteHistory(ctx context.Context, in *model.VoteHistoryTbl) error {
+	r.history = append(r.history, *in)
+	return nil
+}
+
+func TestAddVoteOnUnknownProposal(t *testing.T) {
+	voteRepo := &historyVoteRepo{}
+	syncService := service.NewSyncService(nil, voteRepo, &unknownProposalRepo{}, nil, nil, nil, nil)
+
+	err := syncService.AddVote(context.Background(), &model.VoteHistoryTbl{
+		ProposalId: 1,
+		ChainId:    314159,
+		Address:    "0x1234567890123456789012345678901234567890",
+		Timestamp:  1735689600,
+	})
+	// the vote is not taken as valid, its event log is dead-lettered until the proposal is synced
+	assert.ErrorIs(t, err, constant.ErrProposalNotFound)
+	assert.Empty(t, voteRepo.history)
+}
@@ -0,0 +1,195 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"slices"
+
+	"go.uber.org/zap"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+	"powervoting-server/model/api"
+)
+
+// WebhookRepo defines the interface for the webhooks, the events emitted for them and their delivery log.
+type WebhookRepo interface {
+	CreateWebhook(ctx context.Context, in *model.WebhookTbl) error
+	GetWebhookList(ctx context.Context) ([]model.WebhookTbl, error)
+	DeleteWebhook(ctx context.Context, id int64) error
+	CreateWebhookEvent(ctx context.Context, in *model.WebhookEventTbl) (bool, error)
+	CreateWebhookDeliveries(ctx context.Context, list []model.WebhookDeliveryTbl) error
+	GetDueWebhookDeliveries(ctx context.Context, now int64, limit int) ([]model.WebhookDueDelivery, error)
+	UpdateWebhookDelivery(ctx context.Context, in *model.WebhookDeliveryTbl) error
+	GetWebhookDeliveryListWithPagination(ctx context.Context, req api.WebhookDeliveryListReq) ([]model.WebhookDeliveryTbl, int64, error)
+	PublishWebhookEvents(ctx context.Context, limit int) error
+	GetWebhookEventsAfter(ctx context.Context, req api.StreamReq, cursor int64, limit int) ([]model.WebhookEventTbl, error)
+	GetLastWebhookEventSeq(ctx context.Context) (int64, error)
+}
+
+type IWebhookService interface {
+	// AddWebhook subscribes a URL to governance lifecycle events.
+	// A secret is generated for the webhook to verify the signature of the deliveries, it is only returned here.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - req: The URL, the events, the chain ID and the description of the webhook.
+	//
+	// Returns:
+	//   - *api.WebhookRep: The added webhook with its secret.
+	//   - error: An error if an event is unknown or the webhook can not be saved; otherwise, nil.
+	AddWebhook(ctx context.Context, req api.AddWebhookReq) (*api.WebhookRep, error)
+
+	// GetWebhookList retrieves every webhook without its secret.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//
+	// Returns:
+	//   - []api.WebhookRep: The webhooks, the oldest first.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetWebhookList(ctx context.Context) ([]api.WebhookRep, error)
+
+	// DeleteWebhook deletes a webhook, its pending deliveries are cancelled.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - req: The webhook ID.
+	//
+	// Returns:
+	//   - error: An error if the webhook does not exist or can not be deleted; otherwise, nil.
+	DeleteWebhook(ctx context.Context, req api.WebhookReq) error
+
+	// GetWebhookDeliveryList retrieves the delivery log of a webhook.
+	//
+	// Parameters:
+	//   - ctx: Context for request cancellation and timeout.
+	//   - req: The webhook ID, the pagination and an optional delivery status.
+	//
+	// Returns:
+	//   - *api.CountListRep: The total count and the page of deliveries, the most recent first.
+	//   - error: An error if the query operation fails; otherwise, nil.
+	GetWebhookDeliveryList(ctx context.Context, req api.WebhookDeliveryListReq) (*api.CountListRep, error)
+}
+
+type WebhookService struct {
+	repo WebhookRepo
+}
+
+func NewWebhookService(repo WebhookRepo) *WebhookService {
+	return &WebhookService{
+		repo: repo,
+	}
+}
+
+func (w *WebhookService) AddWebhook(ctx context.Context, req api.AddWebhookReq) (*api.WebhookRep, error) {
+	for _, event := range req.Events {
+		if !slices.Contains(constant.WebhookEvents, event) {
+			return nil, fmt.Errorf("unknown webhook event %q", event)
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		zap.L().Error("generate webhook secret error", zap.Error(err))
+		return nil, err
+	}
+
+	webhook := &model.WebhookTbl{
+		Url:         req.Url,
+		Secret:      hex.EncodeToString(secret),
+		Events:      slices.Compact(slices.Sorted(slices.Values(req.Events))),
+		ChainId:     req.ChainId,
+		Description: req.Description,
+	}
+	if err := w.repo.CreateWebhook(ctx, webhook); err != nil {
+		zap.L().Error("CreateWebhook error", zap.String("url", req.Url), zap.Error(err))
+		return nil, err
+	}
+
+	res := webhookRep(*webhook)
+	res.Secret = webhook.Secret
+	return &res, nil
+}
+
+func (w *WebhookService) GetWebhookList(ctx context.Context) ([]api.WebhookRep, error) {
+	list, err := w.repo.GetWebhookList(ctx)
+	if err != nil {
+		zap.L().Error("GetWebhookList error", zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]api.WebhookRep, 0, len(list))
+	for _, webhook := range list {
+		res = append(res, webhookRep(webhook))
+	}
+
+	return res, nil
+}
+
+func (w *WebhookService) DeleteWebhook(ctx context.Context, req api.WebhookReq) error {
+	if err := w.repo.DeleteWebhook(ctx, req.Id); err != nil {
+		zap.L().Error("DeleteWebhook error", zap.Int64("id", req.Id), zap.Error(err))
+		return err
+	}
+
+	return nil
+}
+
+func (w *WebhookService) GetWebhookDeliveryList(ctx context.Context, req api.WebhookDeliveryListReq) (*api.CountListRep, error) {
+	list, total, err := w.repo.GetWebhookDeliveryListWithPagination(ctx, req)
+	if err != nil {
+		zap.L().Error("GetWebhookDeliveryListWithPagination error", zap.Error(err))
+		return nil, err
+	}
+
+	res := make([]api.WebhookDeliveryRep, 0, len(list))
+	for _, delivery := range list {
+		res = append(res, api.WebhookDeliveryRep{
+			Id:              delivery.ID,
+			WebhookId:       delivery.WebhookId,
+			EventId:         delivery.EventId,
+			Event:           delivery.Event,
+			Attempts:        delivery.Attempts,
+			Status:          delivery.Status,
+			NextAttemptTime: delivery.NextAttemptTime,
+			ResponseStatus:  delivery.ResponseStatus,
+			Error:           delivery.Error,
+			DeliveredTime:   delivery.DeliveredTime,
+			CreatedTime:     delivery.CreatedAt.Unix(),
+		})
+	}
+
+	return &api.CountListRep{
+		Total: total,
+		List:  res,
+	}, nil
+}
+
+// webhookRep converts a webhook to its response without the secret.
+func webhookRep(webhook model.WebhookTbl) api.WebhookRep {
+	return api.WebhookRep{
+		Id:          webhook.ID,
+		Url:         webhook.Url,
+		Events:      webhook.Events,
+		ChainId:     webhook.ChainId,
+		Description: webhook.Description,
+		CreatedTime: webhook.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+	"powervoting-server/model/api"
+)
+
+// fakeSyncRepo runs the transactions of the sync service without a database.
+type fakeSyncRepo struct {
+	SyncRepo
+}
+
+func (fakeSyncRepo) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+// fakeWebhookRepo keeps the webhook events and deliveries in memory.
+type fakeWebhookRepo struct {
+	WebhookRepo
+	webhooks   []model.WebhookTbl
+	events     map[string]bool
+	deliveries []model.WebhookDeliveryTbl
+}
+
+func (f *fakeWebhookRepo) CreateWebhook(ctx context.Context, in *model.WebhookTbl) error {
+	in.ID = int64(len(f.webhooks) + 1)
+	f.webhooks = append(f.webhooks, *in)
+	return nil
+}
+
+func (f *fakeWebhookRepo) GetWebhookList(ctx context.Context) ([]model.WebhookTbl, error) {
+	return f.webhooks, nil
+}
+
+func (f *fakeWebhookRepo) CreateWebhookEvent(ctx context.Context, in *model.WebhookEventTbl) (bool, error) {
+	key := in.Event + "@" + in.Subject
+	if f.events[key] {
+		return false, nil
+	}
+	f.events[key] = true
+	in.ID = int64(len(f.events))
+	return true, nil
+}
+
+func (f *fakeWebhookRepo) CreateWebhookDeliveries(ctx context.Context, list []model.WebhookDeliveryTbl) error {
+	f.deliveries = append(f.deliveries, list...)
+	return nil
+}
+
+func TestAddWebhookEvent(t *testing.T) {
+	webhookRepo := &fakeWebhookRepo{
+		webhooks: []model.WebhookTbl{
+			{BaseField: model.BaseField{ID: 1}, Events: model.StringSlice{constant.WebhookProposalCreated}},
+			{BaseField: model.BaseField{ID: 2}, Events: model.StringSlice{constant.WebhookProposalCreated}, ChainId: 314},
+			{BaseField: model.BaseField{ID: 3}, Events: model.StringSlice{constant.WebhookProposalCounted}, ChainId: 314159},
+		},
+		events: map[string]bool{},
+	}
+	s := &SyncService{repo: fakeSyncRepo{}, webhookRepo: webhookRepo}
+
+	event := &model.WebhookEventTbl{ChainId: 314159, Event: constant.WebhookProposalCreated, Subject: "proposal:1"}
+	assert.NoError(t, s.AddWebhookEvent(context.Background(), event))
+	// only the webhook of every network subscribed to the event
+	assert.Len(t, webhookRepo.deliveries, 1)
+	assert.Equal(t, int64(1), webhookRepo.deliveries[0].WebhookId)
+	assert.Equal(t, event.ID, webhookRepo.deliveries[0].EventId)
+	assert.Equal(t, constant.WebhookDeliveryStatusPending, webhookRepo.deliveries[0].Status)
+
+	// an event emitted again for the same subject is not delivered twice
+	event = &model.WebhookEventTbl{ChainId: 314159, Event: constant.WebhookProposalCreated, Subject: "proposal:1"}
+	assert.NoError(t, s.AddWebhookEvent(context.Background(), event))
+	assert.Len(t, webhookRepo.deliveries, 1)
+}
+
+func TestAddWebhook(t *testing.T) {
+	webhookRepo := &fakeWebhookRepo{}
+	w := NewWebhookService(webhookRepo)
+
+	_, err := w.AddWebhook(context.Background(), api.AddWebhookReq{Url: "https://example.com/hook", Events: []string{"proposal.deleted"}})
+	assert.Error(t, err)
+	assert.Empty(t, webhookRepo.webhooks)
+
+	res, err := w.AddWebhook(context.Background(), api.AddWebhookReq{
+		Url:    "https://example.com/hook",
+		Events: []string{constant.WebhookProposalCounted, constant.WebhookProposalCreated, constant.WebhookProposalCounted},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, res.Secret, 64)
+	assert.Equal(t, []string{constant.WebhookProposalCounted, constant.WebhookProposalCreated}, res.Events)
+
+	// the secret is only returned when the webhook is added
+	list, err := w.GetWebhookList(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, list, 1)
+	assert.Empty(t, list[0].Secret)
+}
@@ -0,0 +1,169 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"sort"
+
+	"github.com/shopspring/decimal"
+
+	"powervoting-server/config"
+	"powervoting-server/model"
+)
+
+// tallyAuditVersion is the version of the audit bundle format, bump it when the bundle fields change
+const tallyAuditVersion = 3
+
+// tallyInput is everything processCounting used to count a proposal.
+type tallyInput struct {
+	proposal     model.ProposalTbl
+	algorithm    string
+	options      []string
+	allPowers    model.SnapshotAllPower
+	votesInfo    []model.VoteTbl
+	voteList     []model.VoteTbl
+	creditsMap   map[string]model.VoterPowerCount
+	totalCredits model.VoterPowerCount
+	electorate   model.VoterPowerCount
+	percentages  map[string]decimal.Decimal
+	final        map[string]decimal.Decimal
+	quorumMet    bool
+	outcome      string
+}
+
+// buildTallyAudit collects the inputs and intermediate values of a count into an audit bundle.
+// Every vote of the proposal is included with its ciphertext, the votes that could not be decrypted
+// or are not an option of the proposal are marked as not counted.
+// The snapshot power rows and the votes are sorted by address so that the bundle does not depend
+// on the order the rows were read in.
+//
+// Parameters:
+//   - in: The inputs and results of the count.
+//
+// Returns:
+//   - model.TallyAudit: The audit bundle of the count.
+func buildTallyAudit(in tallyInput) model.TallyAudit {
+	snapshotPower := make([]model.AuditPower, 0, len(in.allPowers.AddrPower))
+	for _, power := range in.allPowers.AddrPower {
+		snapshotPower = append(snapshotPower, model.AuditPower{
+			Address:          power.Address,
+			SpPower:          bigIntString(power.SpPower),
+			ClientPower:      bigIntString(power.ClientPower),
+			TokenHolderPower: bigIntString(power.TokenHolderPower),
+			DeveloperPower:   bigIntString(power.DeveloperPower),
+			BlockHeight:      power.BlockHeight,
+		})
+	}
+	sort.Slice(snapshotPower, func(i, j int) bool {
+		return snapshotPower[i].Address < snapshotPower[j].Address
+	})
+
+	counted := make(map[string]model.VoteTbl, len(in.voteList))
+	for _, vote := range in.voteList {
+		counted[vote.Address] = vote
+	}
+
+	votes := make([]model.AuditVote, 0, len(in.votesInfo))
+	for _, voteInfo := range in.votesInfo {
+		vote, ok := counted[voteInfo.Address]
+		if !ok {
+			votes = append(votes, model.AuditVote{
+				Address:       voteInfo.Address,
+				VoteEncrypted: voteInfo.VoteEncrypted,
+			})
+			continue
+		}
+
+		votes = append(votes, model.AuditVote{
+			Address:       vote.Address,
+			VoteEncrypted: voteInfo.VoteEncrypted,
+			Beacon: model.DrandBeacon{
+				Round:     vote.DrandRound,
+				Signature: vote.DrandSignature,
+			},
+			Choice:           vote.VoteResult,
+			Counted:          true,
+			PowerMissing:     vote.PowerMissing,
+			SpPower:          vote.SpPower,
+			ClientPower:      vote.ClientPower,
+			TokenHolderPower: vote.TokenHolderPower,
+			DeveloperPower:   vote.DeveloperPower,
+		})
+	}
+	sort.Slice(votes, func(i, j int) bool {
+		return votes[i].Address < votes[j].Address
+	})
+
+	optionSums := make(map[string]model.AuditPowerCount, len(in.creditsMap))
+	for option, credits := range in.creditsMap {
+		optionSums[option] = model.NewAuditPowerCount(credits)
+	}
+
+	algorithmPercentages := make(map[string]string, len(in.percentages))
+	for option, percentage := range in.percentages {
+		algorithmPercentages[option] = percentage.String()
+	}
+
+	return model.TallyAudit{
+		Version:              tallyAuditVersion,
+		ProposalId:           in.proposal.ProposalId,
+		ChainId:              in.proposal.ChainId,
+		CountingAlgorithm:    in.algorithm,
+		Options:              in.options,
+		Percentage:           in.proposal.Percentage,
+		Quorum:               in.proposal.QuorumRule,
+		DrandChainHash:       config.Client.Drand.ChainHash,
+		SnapshotDay:          in.proposal.SnapshotDay,
+		SnapshotHeight:       in.proposal.SnapshotBlockHeight,
+		SnapshotPower:        snapshotPower,
+		Votes:                votes,
+		OptionSums:           optionSums,
+		TotalSum:             model.NewAuditPowerCount(in.totalCredits),
+		Electorate:           model.NewAuditPowerCount(in.electorate),
+		AlgorithmPercentages: algorithmPercentages,
+		Percentages:          exactPercentages(in.final),
+		QuorumMet:            in.quorumMet,
+		Outcome:              in.outcome,
+	}
+}
+
+// newProposalAudit encodes an audit bundle to its canonical JSON and hashes it.
+func newProposalAudit(audit model.TallyAudit) (*model.ProposalAuditTbl, error) {
+	bundle, err := json.Marshal(audit)
+	if err != nil {
+		return nil, err
+	}
+
+	sum := sha256.Sum256(bundle)
+	return &model.ProposalAuditTbl{
+		ProposalId: audit.ProposalId,
+		ChainId:    audit.ChainId,
+		Bundle:     string(bundle),
+		Sha256:     hex.EncodeToString(sum[:]),
+	}, nil
+}
+
+// bigIntString formats a power value, a missing value is zero.
+func bigIntString(n *big.Int) string {
+	if n == nil {
+		return "0"
+	}
+
+	return n.String()
+}
@@ -0,0 +1,107 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+)
+
+func mockTallyInput(powers []model.AddrPower, votes []model.VoteTbl) tallyInput {
+	credits := newVoterPowerCount()
+	credits.TokenPower = decimal.NewFromInt(1000)
+
+	return tallyInput{
+		proposal:  model.ProposalTbl{ProposalId: 1, ChainId: 314159, SnapshotDay: "20250105"},
+		algorithm: constant.CountingAlgorithmWeightedShare,
+		options:   constant.DefaultVoteOptions,
+		allPowers: model.SnapshotAllPower{AddrPower: powers},
+		votesInfo: votes,
+		voteList: []model.VoteTbl{{
+			Address:          "0x1234567890123456789012345678901234567890",
+			VoteResult:       constant.VoteApprove,
+			SpPower:          "0",
+			ClientPower:      "0",
+			TokenHolderPower: "1000",
+			DeveloperPower:   "0",
+			DrandRound:       42,
+			DrandSignature:   "abcd",
+		}},
+		creditsMap: map[string]model.VoterPowerCount{
+			constant.VoteApprove: credits,
+			constant.VoteReject:  newVoterPowerCount(),
+			constant.VoteAbstain: newVoterPowerCount(),
+		},
+		totalCredits: credits,
+		electorate:   newVoterPowerCount(),
+		percentages:  map[string]decimal.Decimal{constant.VoteApprove: decimal.NewFromInt(100)},
+		final:        map[string]decimal.Decimal{constant.VoteApprove: decimal.NewFromInt(100), constant.VoteReject: decimal.Zero, constant.VoteAbstain: decimal.Zero},
+		quorumMet:    true,
+		outcome:      constant.ProposalOutcomePassed,
+	}
+}
+
+func TestBuildTallyAudit(t *testing.T) {
+	votes := []model.VoteTbl{
+		{Address: "0x1234567890123456789012345678901234567891", VoteEncrypted: "cipher-2"},
+		{Address: "0x1234567890123456789012345678901234567890", VoteEncrypted: "cipher-1"},
+	}
+	audit := buildTallyAudit(mockTallyInput(mockPower(), votes))
+
+	assert.Len(t, audit.SnapshotPower, 2)
+	assert.Len(t, audit.Votes, 2)
+	assert.Equal(t, "0x1234567890123456789012345678901234567890", audit.Votes[0].Address)
+	assert.True(t, audit.Votes[0].Counted)
+	assert.Equal(t, "cipher-1", audit.Votes[0].VoteEncrypted)
+	assert.Equal(t, model.DrandBeacon{Round: 42, Signature: "abcd"}, audit.Votes[0].Beacon)
+	assert.Equal(t, constant.VoteApprove, audit.Votes[0].Choice)
+	// the second vote was not counted
+	assert.False(t, audit.Votes[1].Counted)
+	assert.Equal(t, "cipher-2", audit.Votes[1].VoteEncrypted)
+	assert.Equal(t, "1000", audit.OptionSums[constant.VoteApprove].TokenHolderPower)
+	assert.Equal(t, "100", audit.AlgorithmPercentages[constant.VoteApprove])
+	assert.Equal(t, "100", audit.Percentages[constant.VoteApprove])
+	assert.Equal(t, "0", audit.Percentages[constant.VoteReject])
+}
+
+func TestProposalAuditDeterministic(t *testing.T) {
+	votes := []model.VoteTbl{
+		{Address: "0x1234567890123456789012345678901234567890", VoteEncrypted: "cipher-1"},
+		{Address: "0x1234567890123456789012345678901234567891", VoteEncrypted: "cipher-2"},
+	}
+	powers := mockPower()
+	first, err := newProposalAudit(buildTallyAudit(mockTallyInput(powers, votes)))
+	assert.NoError(t, err)
+
+	// the same count read in another order gives the same bundle
+	reversedPowers := []model.AddrPower{powers[1], powers[0]}
+	reversedVotes := []model.VoteTbl{votes[1], votes[0]}
+	second, err := newProposalAudit(buildTallyAudit(mockTallyInput(reversedPowers, reversedVotes)))
+	assert.NoError(t, err)
+
+	assert.Equal(t, first.Bundle, second.Bundle)
+	assert.Equal(t, first.Sha256, second.Sha256)
+
+	var audit model.TallyAudit
+	assert.NoError(t, json.Unmarshal([]byte(first.Bundle), &audit))
+	assert.Equal(t, tallyAuditVersion, audit.Version)
+	assert.Equal(t, constant.ProposalOutcomePassed, audit.Outcome)
+}
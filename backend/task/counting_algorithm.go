@@ -0,0 +1,257 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"math/big"
+	"sort"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+	"powervoting-server/utils"
+)
+
+// CountingAlgorithm turns the counted votes of a proposal into the percentage of each vote option.
+// The algorithm used for a proposal is the one configured on the PowerVotingConf contract when the proposal was created.
+type CountingAlgorithm interface {
+	// Name returns the algorithm name, as set by setVotingCountingAlgorithm on the PowerVotingConf contract.
+	Name() string
+
+	// Count calculates the percentage (0-100) of each vote option.
+	// Each percentage is computed as a fraction and rounded once to utils.ResultPlaces decimal places,
+	// it is stored as is and only rounded to fewer places for display.
+	//
+	// Parameters:
+	//   - votes: The votes of the proposal with the decoded vote result and the voter power.
+	//   - percentage: The weight of each power category set by the proposal creator.
+	//
+	// Returns:
+	//   - map[string]decimal.Decimal: The percentage of each vote option, options without votes may be missing.
+	Count(votes []model.VoteTbl, percentage model.Percentage) map[string]decimal.Decimal
+}
+
+// countingAlgorithms holds all available counting algorithms, indexed by name.
+var countingAlgorithms = make(map[string]CountingAlgorithm)
+
+func init() {
+	RegisterCountingAlgorithm(weightedShareAlgorithm{})
+	RegisterCountingAlgorithm(categoryMajorityAlgorithm{})
+	RegisterCountingAlgorithm(quadraticAlgorithm{})
+}
+
+// RegisterCountingAlgorithm adds a counting algorithm to the registry, replacing any algorithm with the same name.
+// It is not safe for concurrent use and should be called during initialization.
+func RegisterCountingAlgorithm(algorithm CountingAlgorithm) {
+	countingAlgorithms[algorithm.Name()] = algorithm
+}
+
+// GetCountingAlgorithm returns the counting algorithm registered under the given name.
+// Proposals created before an algorithm was configured on chain, or configured with an unknown name,
+// are counted with the weighted share algorithm.
+func GetCountingAlgorithm(name string) CountingAlgorithm {
+	if algorithm, ok := countingAlgorithms[name]; ok {
+		return algorithm
+	}
+
+	if name != "" {
+		zap.L().Warn("unknown counting algorithm, fallback to default", zap.String("algorithm", name))
+	}
+
+	return countingAlgorithms[constant.CountingAlgorithmWeightedShare]
+}
+
+// weightedShareAlgorithm computes, for each category, the share of power an option received
+// and weights the shares with the proposal percentages.
+type weightedShareAlgorithm struct{}
+
+func (weightedShareAlgorithm) Name() string {
+	return constant.CountingAlgorithmWeightedShare
+}
+
+func (weightedShareAlgorithm) Count(votes []model.VoteTbl, percentage model.Percentage) map[string]decimal.Decimal {
+	creditsMap, totalCredits := sumVotesPower(votes, nil)
+
+	res := make(map[string]decimal.Decimal, len(creditsMap))
+	for option, credits := range creditsMap {
+		res[option] = calculateWeightedPercentage(credits, totalCredits, percentage)
+	}
+
+	return res
+}
+
+// categoryMajorityAlgorithm gives the whole percentage of a category to the option with the most power in it.
+// When several options tie in a category, its percentage is split evenly between them.
+type categoryMajorityAlgorithm struct{}
+
+func (categoryMajorityAlgorithm) Name() string {
+	return constant.CountingAlgorithmCategoryMajority
+}
+
+func (categoryMajorityAlgorithm) Count(votes []model.VoteTbl, percentage model.Percentage) map[string]decimal.Decimal {
+	creditsMap, totalCredits := sumVotesPower(votes, nil)
+
+	// Iterate the options in a fixed order so that the result does not depend on map ordering
+	options := make([]string, 0, len(creditsMap))
+	for option := range creditsMap {
+		options = append(options, option)
+	}
+	sort.Strings(options)
+
+	// The won percentages are kept as fractions, a split category would not divide exactly
+	var (
+		totalPercentage = new(big.Rat)
+		won             = make(map[string]*big.Rat, len(options))
+	)
+
+	for _, category := range powerCategories(percentage) {
+		// Categories without any voting power do not take part in the result
+		if category.power(totalCredits).IsZero() {
+			continue
+		}
+
+		var (
+			maxPower = decimal.Zero
+			winners  []string
+		)
+		for _, option := range options {
+			power := category.power(creditsMap[option])
+			switch {
+			case power.GreaterThan(maxPower):
+				maxPower = power
+				winners = []string{option}
+			case power.Equal(maxPower):
+				winners = append(winners, option)
+			}
+		}
+
+		weight := new(big.Rat).SetInt64(int64(category.percentage))
+		share := new(big.Rat).Quo(weight, big.NewRat(int64(len(winners)), 1))
+		for _, option := range winners {
+			if won[option] == nil {
+				won[option] = new(big.Rat)
+			}
+			won[option].Add(won[option], share)
+		}
+		totalPercentage.Add(totalPercentage, weight)
+	}
+
+	res := make(map[string]decimal.Decimal, len(options))
+	for _, option := range options {
+		if totalPercentage.Sign() == 0 || won[option] == nil {
+			res[option] = decimal.Zero
+			continue
+		}
+		res[option] = percentOf(won[option], totalPercentage)
+	}
+
+	return res
+}
+
+// quadraticAlgorithm applies the weighted share formula to the square root of each voter's power,
+// which reduces the influence of large holders in every category.
+type quadraticAlgorithm struct{}
+
+func (quadraticAlgorithm) Name() string {
+	return constant.CountingAlgorithmQuadratic
+}
+
+func (quadraticAlgorithm) Count(votes []model.VoteTbl, percentage model.Percentage) map[string]decimal.Decimal {
+	creditsMap, totalCredits := sumVotesPower(votes, func(power *big.Int) *big.Int {
+		return new(big.Int).Sqrt(power)
+	})
+
+	res := make(map[string]decimal.Decimal, len(creditsMap))
+	for option, credits := range creditsMap {
+		res[option] = calculateWeightedPercentage(credits, totalCredits, percentage)
+	}
+
+	return res
+}
+
+// powerCategory describes one power category of a proposal and the weight set for it by the creator.
+type powerCategory struct {
+	name       string                                            // category name in the result breakdown
+	percentage uint16                                            // weight of the category on the proposal
+	power      func(count model.VoterPowerCount) decimal.Decimal // selects the category power from a count
+}
+
+// powerCategories returns the power categories in a fixed order with their proposal percentages.
+func powerCategories(percentage model.Percentage) []powerCategory {
+	return []powerCategory{
+		{constant.PowerCategorySp, percentage.SpPercentage, func(c model.VoterPowerCount) decimal.Decimal { return c.SpPower }},
+		{constant.PowerCategoryClient, percentage.ClientPercentage, func(c model.VoterPowerCount) decimal.Decimal { return c.ClientPower }},
+		{constant.PowerCategoryTokenHolder, percentage.TokenHolderPercentage, func(c model.VoterPowerCount) decimal.Decimal { return c.TokenPower }},
+		{constant.PowerCategoryDeveloper, percentage.DeveloperPercentage, func(c model.VoterPowerCount) decimal.Decimal { return c.DeveloperPower }},
+	}
+}
+
+// sumVotesPower aggregates the power of the votes per vote option and in total.
+// If weight is not nil, it is applied to each voter's power in each category before summing.
+func sumVotesPower(votes []model.VoteTbl, weight func(power *big.Int) *big.Int) (map[string]model.VoterPowerCount, model.VoterPowerCount) {
+	apply := func(power string) decimal.Decimal {
+		value := utils.StringConvToBigInt(power)
+		if weight != nil && value.Sign() > 0 {
+			value = weight(value)
+		}
+
+		return decimal.NewFromBigInt(value, 0)
+	}
+
+	var (
+		creditsMap   = make(map[string]model.VoterPowerCount)
+		totalCredits = newVoterPowerCount()
+	)
+
+	for _, vote := range votes {
+		credits, ok := creditsMap[vote.VoteResult]
+		if !ok {
+			credits = newVoterPowerCount()
+		}
+
+		sp := apply(vote.SpPower)
+		cp := apply(vote.ClientPower)
+		tp := apply(vote.TokenHolderPower)
+		dp := apply(vote.DeveloperPower)
+
+		creditsMap[vote.VoteResult] = model.VoterPowerCount{
+			SpPower:        credits.SpPower.Add(sp),
+			ClientPower:    credits.ClientPower.Add(cp),
+			TokenPower:     credits.TokenPower.Add(tp),
+			DeveloperPower: credits.DeveloperPower.Add(dp),
+		}
+
+		totalCredits = model.VoterPowerCount{
+			SpPower:        totalCredits.SpPower.Add(sp),
+			ClientPower:    totalCredits.ClientPower.Add(cp),
+			TokenPower:     totalCredits.TokenPower.Add(tp),
+			DeveloperPower: totalCredits.DeveloperPower.Add(dp),
+		}
+	}
+
+	return creditsMap, totalCredits
+}
+
+// newVoterPowerCount returns a power count with every category set to zero.
+func newVoterPowerCount() model.VoterPowerCount {
+	return model.VoterPowerCount{
+		SpPower:        decimal.Zero,
+		ClientPower:    decimal.Zero,
+		TokenPower:     decimal.Zero,
+		DeveloperPower: decimal.Zero,
+	}
+}
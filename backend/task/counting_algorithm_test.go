@@ -0,0 +1,94 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+)
+
+// mockCountingVotes returns one large approving token holder and three small rejecting ones,
+// plus a single approving developer.
+func mockCountingVotes() []model.VoteTbl {
+	return []model.VoteTbl{
+		{Address: "0x01", VoteResult: constant.VoteApprove, TokenHolderPower: "900", SpPower: "0", ClientPower: "0", DeveloperPower: "0"},
+		{Address: "0x02", VoteResult: constant.VoteReject, TokenHolderPower: "100", SpPower: "0", ClientPower: "0", DeveloperPower: "0"},
+		{Address: "0x03", VoteResult: constant.VoteReject, TokenHolderPower: "100", SpPower: "0", ClientPower: "0", DeveloperPower: "0"},
+		{Address: "0x04", VoteResult: constant.VoteReject, TokenHolderPower: "100", SpPower: "0", ClientPower: "0", DeveloperPower: "0"},
+		{Address: "0x05", VoteResult: constant.VoteApprove, TokenHolderPower: "0", SpPower: "0", ClientPower: "0", DeveloperPower: "1"},
+	}
+}
+
+var countingPercentage = model.Percentage{
+	TokenHolderPercentage: 7500,
+	DeveloperPercentage:   2500,
+}
+
+func TestGetCountingAlgorithm(t *testing.T) {
+	assert.Equal(t, constant.CountingAlgorithmWeightedShare, GetCountingAlgorithm("").Name())
+	assert.Equal(t, constant.CountingAlgorithmWeightedShare, GetCountingAlgorithm("unknown").Name())
+	assert.Equal(t, constant.CountingAlgorithmCategoryMajority, GetCountingAlgorithm(constant.CountingAlgorithmCategoryMajority).Name())
+	assert.Equal(t, constant.CountingAlgorithmQuadratic, GetCountingAlgorithm(constant.CountingAlgorithmQuadratic).Name())
+}
+
+func TestWeightedShareAlgorithm(t *testing.T) {
+	res := GetCountingAlgorithm(constant.CountingAlgorithmWeightedShare).Count(mockCountingVotes(), countingPercentage)
+
+	// token: 900/1200 * 75 = 56.25, developer: 1/1 * 25 = 25
+	assert.True(t, decimal.NewFromFloat(81.25).Equal(res[constant.VoteApprove]), res[constant.VoteApprove].String())
+	// token: 300/1200 * 75 = 18.75
+	assert.True(t, decimal.NewFromFloat(18.75).Equal(res[constant.VoteReject]), res[constant.VoteReject].String())
+
+	// a percentage that does not divide exactly is rounded once, to utils.ResultPlaces decimal places
+	votes := []model.VoteTbl{
+		{VoteResult: constant.VoteApprove, TokenHolderPower: "1"},
+		{VoteResult: constant.VoteReject, TokenHolderPower: "1"},
+		{VoteResult: constant.VoteAbstain, TokenHolderPower: "1"},
+	}
+	res = GetCountingAlgorithm(constant.CountingAlgorithmWeightedShare).Count(votes, countingPercentage)
+	for _, option := range constant.DefaultVoteOptions {
+		assert.Equal(t, "33.333333333333333333333333333333", res[option].String())
+	}
+}
+
+func TestCategoryMajorityAlgorithm(t *testing.T) {
+	res := GetCountingAlgorithm(constant.CountingAlgorithmCategoryMajority).Count(mockCountingVotes(), countingPercentage)
+
+	// approve wins both the token holder and the developer category
+	assert.True(t, decimal.NewFromInt(100).Equal(res[constant.VoteApprove]), res[constant.VoteApprove].String())
+	assert.True(t, res[constant.VoteReject].IsZero(), res[constant.VoteReject].String())
+
+	// a tie in the token holder category splits its percentage
+	votes := []model.VoteTbl{
+		{VoteResult: constant.VoteApprove, TokenHolderPower: "100"},
+		{VoteResult: constant.VoteReject, TokenHolderPower: "100"},
+	}
+	res = GetCountingAlgorithm(constant.CountingAlgorithmCategoryMajority).Count(votes, countingPercentage)
+	assert.True(t, decimal.NewFromInt(50).Equal(res[constant.VoteApprove]), res[constant.VoteApprove].String())
+	assert.True(t, decimal.NewFromInt(50).Equal(res[constant.VoteReject]), res[constant.VoteReject].String())
+}
+
+func TestQuadraticAlgorithm(t *testing.T) {
+	res := GetCountingAlgorithm(constant.CountingAlgorithmQuadratic).Count(mockCountingVotes(), countingPercentage)
+
+	// token: sqrt(900)=30 against 3*sqrt(100)=30, so 37.5 each, developer: 25 to approve
+	assert.True(t, decimal.NewFromFloat(62.5).Equal(res[constant.VoteApprove]), res[constant.VoteApprove].String())
+	assert.True(t, decimal.NewFromFloat(37.5).Equal(res[constant.VoteReject]), res[constant.VoteReject].String())
+}
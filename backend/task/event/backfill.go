@@ -0,0 +1,192 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+
+	"powervoting-server/config"
+	"powervoting-server/constant"
+	"powervoting-server/model"
+)
+
+// backfillChunk is a range of blocks whose logs are fetched concurrently with the other chunks of a backfill.
+type backfillChunk struct {
+	from, to int64
+	logs     []types.Log
+	err      error
+	done     chan struct{} // closed when the logs are fetched
+}
+
+// backfillPolicy returns the backfill configuration with the defaults for the values that are not set.
+func backfillPolicy() config.Backfill {
+	policy := config.Client.Backfill
+	if policy.Threshold <= 0 {
+		policy.Threshold = constant.BackfillThreshold
+	}
+	if policy.ChunkSize <= 0 {
+		policy.ChunkSize = constant.BackfillChunkSize
+	}
+	if policy.Workers <= 0 {
+		policy.Workers = constant.BackfillWorkers
+	}
+
+	return policy
+}
+
+// backfillNeeded checks whether the blocks up to endBlock are synced by a backfill instead of a single range.
+// An unfinished backfill is always resumed, so that its progress and its shrunk chunk size are not lost.
+func backfillNeeded(progress *model.SyncBackfillTbl, syncedHeight, endBlock int64) bool {
+	if progress != nil && !progress.Finished {
+		return true
+	}
+
+	return endBlock-syncedHeight > backfillPolicy().Threshold
+}
+
+// Backfill syncs the blocks after the synced height up to endBlock in chunks.
+// The logs of the next chunks are fetched concurrently while a chunk is applied, and the chunks are applied strictly in order.
+// Each chunk is applied in one transaction with the new synced height and the backfill progress,
+// so an interrupted backfill resumes after its last applied chunk.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - progress: The progress of an unfinished backfill to resume, nil to start a new one.
+//   - syncedHeight: The last synced height.
+//   - endBlock: The last height to sync.
+//
+// Returns:
+//   - error: An error if a chunk can not be fetched or applied; otherwise, nil.
+func (ev *Event) Backfill(ctx context.Context, progress *model.SyncBackfillTbl, syncedHeight, endBlock int64) error {
+	policy := backfillPolicy()
+	if progress == nil || progress.Finished {
+		progress = &model.SyncBackfillTbl{
+			ChainId:     ev.Client.ChainId,
+			StartHeight: syncedHeight,
+			ChunkSize:   policy.ChunkSize,
+		}
+	} else if progress.ChunkSize <= 0 {
+		progress.ChunkSize = policy.ChunkSize
+	}
+	progress.TargetHeight = endBlock
+	progress.AppliedHeight = syncedHeight
+
+	zap.L().Info("Backfill started",
+		zap.Int64("network id", ev.Client.ChainId),
+		zap.Int64("synced height", syncedHeight),
+		zap.Int64("target height", endBlock),
+		zap.Int64("chunk size", progress.ChunkSize),
+		zap.Int("workers", policy.Workers))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var chunkSize atomic.Int64
+	chunkSize.Store(progress.ChunkSize)
+
+	// The chunk being applied is out of the queue, so the queue holds one chunk less than the workers
+	queue := make(chan *backfillChunk, policy.Workers-1)
+	go func() {
+		defer close(queue)
+		for from := syncedHeight + 1; from <= endBlock; {
+			chunk := &backfillChunk{
+				from: from,
+				to:   min(from+chunkSize.Load()-1, endBlock),
+				done: make(chan struct{}),
+			}
+			select {
+			case queue <- chunk:
+			case <-ctx.Done():
+				return
+			}
+
+			go func() {
+				defer close(chunk.done)
+				chunk.logs, chunk.err = ev.fetchRange(ctx, chunk.from, chunk.to, &chunkSize)
+			}()
+			from = chunk.to + 1
+		}
+	}()
+
+	for chunk := range queue {
+		<-chunk.done
+		if chunk.err != nil {
+			return fmt.Errorf("fetch event logs of blocks %d to %d: %w", chunk.from, chunk.to, chunk.err)
+		}
+
+		progress.AppliedHeight = chunk.to
+		progress.ChunkSize = chunkSize.Load()
+		progress.Chunks++
+		progress.Finished = chunk.to == endBlock
+		if err := ev.applyRange(ctx, chunk.logs, chunk.to, progress); err != nil {
+			return fmt.Errorf("apply event logs of blocks %d to %d: %w", chunk.from, chunk.to, err)
+		}
+
+		zap.L().Info("Backfill chunk applied",
+			zap.Int64("network id", ev.Client.ChainId),
+			zap.Int64("from block", chunk.from),
+			zap.Int64("to block", chunk.to),
+			zap.Int("event logs length", len(chunk.logs)),
+			zap.Int64("target height", endBlock))
+	}
+
+	return ctx.Err()
+}
+
+// fetchRange fetches the event logs of a range of blocks.
+// When the RPC rejects the range, it is split in two halves that are fetched one after the other.
+// The chunk size of the next chunks is shrunk to the size of a half once a half is fetched,
+// so an RPC that is down does not shrink the chunks.
+func (ev *Event) fetchRange(ctx context.Context, from, to int64, chunkSize *atomic.Int64) ([]types.Log, error) {
+	logs, err := ev.FetchMatchingEventLogs(ctx, big.NewInt(from), big.NewInt(to))
+	if err == nil || from == to || ctx.Err() != nil {
+		return logs, err
+	}
+
+	half := (to - from + 1) / 2
+	zap.L().Warn("Event log range rejected, fetching it in halves",
+		zap.Int64("from block", from),
+		zap.Int64("to block", to),
+		zap.Error(err))
+
+	left, err := ev.fetchRange(ctx, from, from+half-1, chunkSize)
+	if err != nil {
+		return nil, err
+	}
+	shrinkChunkSize(chunkSize, half)
+
+	right, err := ev.fetchRange(ctx, from+half, to, chunkSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(left, right...), nil
+}
+
+// shrinkChunkSize lowers the chunk size to size, a chunk size that is already lower is kept.
+func shrinkChunkSize(chunkSize *atomic.Int64, size int64) {
+	for {
+		current := chunkSize.Load()
+		if size >= current || chunkSize.CompareAndSwap(current, size) {
+			return
+		}
+	}
+}
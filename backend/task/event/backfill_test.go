@@ -0,0 +1,163 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/config"
+	"powervoting-server/mock"
+	"powervoting-server/model"
+)
+
+// fakeLogRPC serves eth_getLogs without logs, rejecting the ranges longer than maxRange, and eth_getBlockByNumber for every height.
+func fakeLogRPC(t *testing.T, maxRange uint64, calls *atomic.Int64) *ethclient.Client {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Id     json.RawMessage   `json:"id"`
+			Method string            `json:"method"`
+			Params []json.RawMessage `json:"params"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		res := map[string]any{"jsonrpc": "2.0", "id": req.Id}
+		switch req.Method {
+		case "eth_getLogs":
+			calls.Add(1)
+			var query struct {
+				FromBlock hexutil.Uint64 `json:"fromBlock"`
+				ToBlock   hexutil.Uint64 `json:"toBlock"`
+			}
+			assert.NoError(t, json.Unmarshal(req.Params[0], &query))
+			if uint64(query.ToBlock-query.FromBlock)+1 > maxRange {
+				res["error"] = map[string]any{"code": -32005, "message": "block range too large"}
+			} else {
+				res["result"] = []any{}
+			}
+		default:
+			var height hexutil.Uint64
+			assert.NoError(t, json.Unmarshal(req.Params[0], &height))
+			res["result"] = block(uint64(height), "0x1", "0x2")
+		}
+		assert.NoError(t, json.NewEncoder(w).Encode(res))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := ethclient.Dial(server.URL)
+	assert.NoError(t, err)
+
+	return client
+}
+
+type backfillSyncService struct {
+	mock.MockSyncService
+	heights  []int64
+	progress []model.SyncBackfillTbl
+}
+
+func (s *backfillSyncService) UpdateSyncEventInfo(ctx context.Context, chainId int64, addr string, height int64) error {
+	s.heights = append(s.heights, height)
+
+	return nil
+}
+
+func (s *backfillSyncService) SaveSyncBackfill(ctx context.Context, in *model.SyncBackfillTbl) error {
+	s.progress = append(s.progress, *in)
+
+	return nil
+}
+
+func newBackfillEvent(t *testing.T, maxRange uint64, calls *atomic.Int64) (*Event, *backfillSyncService) {
+	syncService := &backfillSyncService{}
+
+	return &Event{
+		Client:      &model.GoEthClient{ChainId: 314159, Client: fakeLogRPC(t, maxRange, calls), ABI: testABI()},
+		SyncService: syncService,
+		Network:     &config.Network{PowerVotingContract: common.HexToAddress("0x1").Hex()},
+	}, syncService
+}
+
+func TestBackfillAppliesChunksInOrder(t *testing.T) {
+	config.Client.Backfill = config.Backfill{ChunkSize: 16, Workers: 3}
+	t.Cleanup(func() { config.Client.Backfill = config.Backfill{} })
+
+	var calls atomic.Int64
+	ev, syncService := newBackfillEvent(t, 4, &calls)
+	assert.NoError(t, ev.Backfill(context.Background(), nil, 100, 150))
+
+	// the first chunk is split until the RPC accepts its range, the next chunks use the shrunk size
+	assert.Equal(t, int64(150), syncService.heights[len(syncService.heights)-1])
+	for i := 1; i < len(syncService.heights); i++ {
+		assert.Less(t, syncService.heights[i-1], syncService.heights[i])
+	}
+
+	last := syncService.progress[len(syncService.progress)-1]
+	assert.True(t, last.Finished)
+	assert.Equal(t, int64(100), last.StartHeight)
+	assert.Equal(t, int64(150), last.TargetHeight)
+	assert.Equal(t, int64(150), last.AppliedHeight)
+	assert.Equal(t, int64(4), last.ChunkSize)
+	assert.Equal(t, int64(len(syncService.heights)), last.Chunks)
+}
+
+func TestBackfillResumes(t *testing.T) {
+	config.Client.Backfill = config.Backfill{ChunkSize: 16, Workers: 2}
+	t.Cleanup(func() { config.Client.Backfill = config.Backfill{} })
+
+	var calls atomic.Int64
+	ev, syncService := newBackfillEvent(t, 4, &calls)
+	progress := &model.SyncBackfillTbl{ChainId: 314159, StartHeight: 0, TargetHeight: 80, AppliedHeight: 60, ChunkSize: 4, Chunks: 15}
+	assert.NoError(t, ev.Backfill(context.Background(), progress, 60, 80))
+
+	// the shrunk chunk size is kept, no range is rejected again
+	assert.Equal(t, []int64{64, 68, 72, 76, 80}, syncService.heights)
+	assert.Equal(t, int64(5), calls.Load())
+
+	last := syncService.progress[len(syncService.progress)-1]
+	assert.True(t, last.Finished)
+	assert.Equal(t, int64(0), last.StartHeight)
+	assert.Equal(t, int64(20), last.Chunks)
+}
+
+func TestFetchRangeKeepsChunkSizeWhenRPCFails(t *testing.T) {
+	var calls atomic.Int64
+	ev, _ := newBackfillEvent(t, 0, &calls)
+
+	var chunkSize atomic.Int64
+	chunkSize.Store(8)
+	_, err := ev.fetchRange(context.Background(), 1, 8, &chunkSize)
+	assert.Error(t, err)
+	assert.Equal(t, int64(8), chunkSize.Load())
+}
+
+func TestBackfillNeeded(t *testing.T) {
+	config.Client.Backfill = config.Backfill{Threshold: 100}
+	t.Cleanup(func() { config.Client.Backfill = config.Backfill{} })
+
+	assert.False(t, backfillNeeded(nil, 0, 100))
+	assert.True(t, backfillNeeded(nil, 0, 101))
+	assert.False(t, backfillNeeded(&model.SyncBackfillTbl{Finished: true}, 0, 100))
+	assert.True(t, backfillNeeded(&model.SyncBackfillTbl{}, 0, 10))
+}
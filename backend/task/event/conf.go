@@ -0,0 +1,165 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+)
+
+// confEvents are the PowerVotingConf contract events that are synced.
+var confEvents = []string{
+	constant.ConfGithubRepoAddedEvt,
+	constant.ConfGithubRepoRemovedEvt,
+	constant.ConfSnapshotDaysEvt,
+	constant.ConfSnapshotExpirationDayEvt,
+	constant.ConfVotingCountingAlgorithmEvt,
+}
+
+// confEnabled checks whether the PowerVotingConf contract is synced, which needs both its address and its ABI.
+func (ev *Event) confEnabled() bool {
+	return strings.TrimSpace(ev.Network.PowerVotingConfContract) != "" && ev.Client.ABI.PowerVotingConfAbi != nil
+}
+
+// confTopics returns the topics of the synced PowerVotingConf contract events.
+func (ev *Event) confTopics() []common.Hash {
+	topics := make([]common.Hash, 0, len(confEvents))
+	for _, name := range confEvents {
+		topics = append(topics, ev.Client.ABI.PowerVotingConfAbi.Events[name].ID)
+	}
+
+	return topics
+}
+
+// Parse the event log using the client's PowerVotingConf Abi and the log data.
+func (ev *Event) parseConfEvent(event any, unpackName string, log []byte) error {
+	return ev.Client.ABI.PowerVotingConfAbi.UnpackIntoInterface(event, unpackName, log)
+}
+
+// handleConfEvent parses a PowerVotingConf contract event log emitted in the given block and stores the parsed results to the database.
+func (ev *Event) handleConfEvent(ctx context.Context, vLog types.Log, blockHeader *types.Header) error {
+	confAbi := ev.Client.ABI.PowerVotingConfAbi
+	switch vLog.Topics[0] {
+	case confAbi.Events[constant.ConfGithubRepoAddedEvt].ID:
+		var event GithubRepoAddedEvent
+		if err := ev.parseConfEvent(&event, constant.ConfGithubRepoAddedEvt, vLog.Data); err != nil {
+			return fmt.Errorf("unpack github repo added event error: %v", err)
+		}
+
+		if err := ev.HandleGithubRepoAdded(ctx, event, blockHeader); err != nil {
+			return fmt.Errorf("handle github repo added error: %v", err)
+		}
+
+	case confAbi.Events[constant.ConfGithubRepoRemovedEvt].ID:
+		var event GithubRepoRemovedEvent
+		if err := ev.parseConfEvent(&event, constant.ConfGithubRepoRemovedEvt, vLog.Data); err != nil {
+			return fmt.Errorf("unpack github repo removed event error: %v", err)
+		}
+
+		if err := ev.HandleGithubRepoRemoved(ctx, event, blockHeader); err != nil {
+			return fmt.Errorf("handle github repo removed error: %v", err)
+		}
+
+	case confAbi.Events[constant.ConfSnapshotDaysEvt].ID:
+		var event SnapshotDaysEvent
+		if err := ev.parseConfEvent(&event, constant.ConfSnapshotDaysEvt, vLog.Data); err != nil {
+			return fmt.Errorf("unpack snapshot days event error: %v", err)
+		}
+
+		if err := ev.HandleSnapshotDays(ctx, event, blockHeader); err != nil {
+			return fmt.Errorf("handle snapshot days error: %v", err)
+		}
+
+	case confAbi.Events[constant.ConfSnapshotExpirationDayEvt].ID:
+		var event SnapshotExpirationDayEvent
+		if err := ev.parseConfEvent(&event, constant.ConfSnapshotExpirationDayEvt, vLog.Data); err != nil {
+			return fmt.Errorf("unpack snapshot expiration day event error: %v", err)
+		}
+
+		if err := ev.HandleConfChange(ctx, constant.ConfSettingSnapshotExpirationDays, event.OldExp.String(), event.NewExp.String(), vLog, blockHeader); err != nil {
+			return fmt.Errorf("handle snapshot expiration day error: %v", err)
+		}
+
+	case confAbi.Events[constant.ConfVotingCountingAlgorithmEvt].ID:
+		var event VotingCountingAlgorithmEvent
+		if err := ev.parseConfEvent(&event, constant.ConfVotingCountingAlgorithmEvt, vLog.Data); err != nil {
+			return fmt.Errorf("unpack voting counting algorithm event error: %v", err)
+		}
+
+		if err := ev.HandleConfChange(ctx, constant.ConfSettingCountingAlgorithm, event.OldAlgorithm, event.Algorithm, vLog, blockHeader); err != nil {
+			return fmt.Errorf("handle voting counting algorithm error: %v", err)
+		}
+
+	default:
+		return fmt.Errorf("unknown event")
+	}
+
+	return nil
+}
+
+func (ev *Event) HandleGithubRepoAdded(ctx context.Context, event GithubRepoAddedEvent, blockHeader *types.Header) error {
+	zap.L().Info("github repo added event handled", zap.Int64("repo id", event.Id.Int64()), zap.String("repo name", event.RepoInfo.RepoName))
+
+	return ev.SyncService.AddGithubRepo(ctx, &model.GithubRepoTbl{
+		ChainId:     ev.Client.ChainId,
+		RepoId:      event.Id.Int64(),
+		RepoName:    event.RepoInfo.RepoName,
+		OrgType:     int(event.RepoInfo.OrgType),
+		BlockNumber: blockHeader.Number.Int64(),
+		Timestamp:   int64(blockHeader.Time),
+	})
+}
+
+func (ev *Event) HandleGithubRepoRemoved(ctx context.Context, event GithubRepoRemovedEvent, blockHeader *types.Header) error {
+	zap.L().Info("github repo removed event handled", zap.Int64("repo id", event.Id.Int64()))
+
+	return ev.SyncService.RemoveGithubRepo(ctx, ev.Client.ChainId, event.Id.Int64(), blockHeader.Number.Int64())
+}
+
+func (ev *Event) HandleSnapshotDays(ctx context.Context, event SnapshotDaysEvent, blockHeader *types.Header) error {
+	zap.L().Info("snapshot days event handled", zap.String("day", event.DateStr), zap.Uint64("height", event.Height))
+
+	return ev.SyncService.AddSnapshotHeight(ctx, &model.SnapshotHeightTbl{
+		ChainId:     ev.Client.ChainId,
+		Day:         event.DateStr,
+		Height:      int64(event.Height),
+		BlockNumber: blockHeader.Number.Int64(),
+		Timestamp:   int64(blockHeader.Time),
+	})
+}
+
+// HandleConfChange records a change of a PowerVotingConf contract setting.
+func (ev *Event) HandleConfChange(ctx context.Context, setting, oldValue, newValue string, vLog types.Log, blockHeader *types.Header) error {
+	zap.L().Info("conf change event handled", zap.String("setting", setting), zap.String("old value", oldValue), zap.String("new value", newValue))
+
+	return ev.SyncService.AddConfChange(ctx, &model.ConfChangeTbl{
+		ChainId:     ev.Client.ChainId,
+		Setting:     setting,
+		OldValue:    oldValue,
+		NewValue:    newValue,
+		BlockNumber: blockHeader.Number.Int64(),
+		TxHash:      vLog.TxHash.Hex(),
+		LogIndex:    vLog.Index,
+		Timestamp:   int64(blockHeader.Time),
+	})
+}
@@ -0,0 +1,151 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/config"
+	"powervoting-server/constant"
+	"powervoting-server/data"
+	"powervoting-server/mock"
+	"powervoting-server/model"
+)
+
+type confSyncService struct {
+	mock.MockSyncService
+	repos   []model.GithubRepoTbl
+	removed []int64
+	heights []model.SnapshotHeightTbl
+	changes []model.ConfChangeTbl
+}
+
+func (s *confSyncService) AddGithubRepo(ctx context.Context, in *model.GithubRepoTbl) error {
+	s.repos = append(s.repos, *in)
+
+	return nil
+}
+
+func (s *confSyncService) RemoveGithubRepo(ctx context.Context, chainId, repoId, blockNumber int64) error {
+	s.removed = append(s.removed, repoId)
+
+	return nil
+}
+
+func (s *confSyncService) AddSnapshotHeight(ctx context.Context, in *model.SnapshotHeightTbl) error {
+	s.heights = append(s.heights, *in)
+
+	return nil
+}
+
+func (s *confSyncService) AddConfChange(ctx context.Context, in *model.ConfChangeTbl) error {
+	s.changes = append(s.changes, *in)
+
+	return nil
+}
+
+func newConfEvent(syncService *confSyncService) *Event {
+	abi := testABI()
+	abi.PowerVotingConfAbi = data.GetAbiFromLocalFile("../../abi/power-voting-conf.json")
+
+	return &Event{
+		Client:      &model.GoEthClient{ChainId: 314159, ABI: abi},
+		SyncService: syncService,
+		Network:     &config.Network{PowerVotingConfContract: "0x2"},
+	}
+}
+
+// confLog packs a PowerVotingConf event into an event log.
+func confLog(t *testing.T, ev *Event, name string, index uint, args ...any) types.Log {
+	event := ev.Client.ABI.PowerVotingConfAbi.Events[name]
+	packed, err := event.Inputs.Pack(args...)
+	assert.NoError(t, err)
+
+	return types.Log{
+		Address:     common.HexToAddress("0x2"),
+		Topics:      []common.Hash{event.ID},
+		Data:        packed,
+		BlockNumber: 100,
+		TxHash:      common.HexToHash("0xabc"),
+		Index:       index,
+	}
+}
+
+func TestHandleConfEvents(t *testing.T) {
+	syncService := &confSyncService{}
+	ev := newConfEvent(syncService)
+	header := &types.Header{Number: big.NewInt(100), Time: 1700000000}
+	ctx := context.Background()
+
+	logs := []types.Log{
+		confLog(t, ev, constant.ConfGithubRepoAddedEvt, 0, big.NewInt(7), GithubRepoInfo{RepoName: "filecoin-project/lotus", OrgType: 1}),
+		confLog(t, ev, constant.ConfGithubRepoRemovedEvt, 1, big.NewInt(7)),
+		confLog(t, ev, constant.ConfSnapshotDaysEvt, 2, "20240101", uint64(3500000)),
+		confLog(t, ev, constant.ConfSnapshotExpirationDayEvt, 3, big.NewInt(60), big.NewInt(90)),
+		confLog(t, ev, constant.ConfVotingCountingAlgorithmEvt, 4, constant.CountingAlgorithmWeightedShare, "quadratic"),
+	}
+	for _, vLog := range logs {
+		assert.NoError(t, ev.handleEvent(ctx, vLog, header))
+	}
+
+	assert.Equal(t, []model.GithubRepoTbl{{
+		ChainId:     314159,
+		RepoId:      7,
+		RepoName:    "filecoin-project/lotus",
+		OrgType:     1,
+		BlockNumber: 100,
+		Timestamp:   1700000000,
+	}}, syncService.repos)
+	assert.Equal(t, []int64{7}, syncService.removed)
+	assert.Equal(t, []model.SnapshotHeightTbl{{
+		ChainId:     314159,
+		Day:         "20240101",
+		Height:      3500000,
+		BlockNumber: 100,
+		Timestamp:   1700000000,
+	}}, syncService.heights)
+
+	assert.Len(t, syncService.changes, 2)
+	assert.Equal(t, constant.ConfSettingSnapshotExpirationDays, syncService.changes[0].Setting)
+	assert.Equal(t, "60", syncService.changes[0].OldValue)
+	assert.Equal(t, "90", syncService.changes[0].NewValue)
+	assert.Equal(t, uint(3), syncService.changes[0].LogIndex)
+	assert.Equal(t, constant.ConfSettingCountingAlgorithm, syncService.changes[1].Setting)
+	assert.Equal(t, "quadratic", syncService.changes[1].NewValue)
+
+	assert.Equal(t, constant.ConfSnapshotDaysEvt, ev.eventName(logs[2]))
+}
+
+func TestConfEventsNeedConfiguration(t *testing.T) {
+	syncService := &confSyncService{}
+	ev := newConfEvent(syncService)
+	vLog := confLog(t, ev, constant.ConfGithubRepoRemovedEvt, 0, big.NewInt(7))
+
+	// without the contract address the conf events are not synced
+	ev.Network = &config.Network{}
+	assert.False(t, ev.confEnabled())
+	assert.ErrorContains(t, ev.handleEvent(context.Background(), vLog, &types.Header{Number: big.NewInt(100)}), "unknown event")
+	assert.Empty(t, syncService.removed)
+
+	ev.Network.PowerVotingConfContract = "0x2"
+	assert.True(t, ev.confEnabled())
+	assert.Len(t, ev.confTopics(), len(confEvents))
+}
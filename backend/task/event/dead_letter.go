@@ -0,0 +1,177 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+	"powervoting-server/service"
+	"powervoting-server/utils"
+)
+
+// applyEventLog parses an event log and stores its result in a savepoint of the sync transaction,
+// so that a failed log leaves nothing behind while the other logs of the range are kept.
+// The context carries the block of the log, for the repositories to record what a rollback of the block restores.
+func (ev *Event) applyEventLog(ctx context.Context, vLog types.Log, blockHeader *types.Header) error {
+	return ev.SyncService.Transaction(ctx, func(ctx context.Context) error {
+		return ev.handleEvent(service.WithSyncBlock(ctx, int64(vLog.BlockNumber), vLog.BlockHash.Hex()), vLog, blockHeader)
+	})
+}
+
+// addDeadLetter saves an event log that failed to be applied, to be replayed by ReplayDeadLetters.
+func (ev *Event) addDeadLetter(ctx context.Context, vLog types.Log, cause error) error {
+	rawLog, err := json.Marshal(vLog)
+	if err != nil {
+		return fmt.Errorf("marshal event log error: %w", err)
+	}
+
+	now := time.Now().Unix()
+	return ev.SyncService.AddDeadLetter(ctx, &model.EventDeadLetterTbl{
+		ChainId:         ev.Client.ChainId,
+		ContractAddress: vLog.Address.Hex(),
+		Event:           ev.eventName(vLog),
+		BlockNumber:     int64(vLog.BlockNumber),
+		TxHash:          vLog.TxHash.Hex(),
+		LogIndex:        vLog.Index,
+		RawLog:          string(rawLog),
+		Error:           cause.Error(),
+		Attempts:        1,
+		Status:          constant.DeadLetterStatusPending,
+		NextAttemptTime: nextDeadLetterAttempt(1, now),
+	})
+}
+
+// eventName returns the name of the contract event of a log, or its signature topic when the event is unknown.
+func (ev *Event) eventName(vLog types.Log) string {
+	if len(vLog.Topics) == 0 {
+		return ""
+	}
+
+	topic := vLog.Topics[0]
+	if event, err := ev.Client.ABI.PowerVotingAbi.EventByID(topic); err == nil {
+		return event.Name
+	}
+	if event, err := ev.Client.ABI.FipAbi.EventByID(topic); err == nil {
+		return event.Name
+	}
+	if event, err := ev.Client.ABI.OracleAbi.EventByID(topic); err == nil {
+		return event.Name
+	}
+	if ev.Client.ABI.PowerVotingConfAbi != nil {
+		if event, err := ev.Client.ABI.PowerVotingConfAbi.EventByID(topic); err == nil {
+			return event.Name
+		}
+	}
+
+	return topic.Hex()
+}
+
+// nextDeadLetterAttempt returns the time of the next replay of a dead letter that has been attempted the given number of times.
+// The interval doubles after every attempt, up to the maximum interval.
+func nextDeadLetterAttempt(attempts int, now int64) int64 {
+	return utils.NextBackoff(attempts, now, constant.DeadLetterRetryInterval, constant.DeadLetterRetryMaxInterval)
+}
+
+// deadLetterLog returns the event log of a dead letter and the header of its block, as it was journaled when the log was synced.
+// The header of a log that is not in the journal is fetched from the network.
+func (ev *Event) deadLetterLog(ctx context.Context, letter model.EventDeadLetterTbl) (types.Log, *types.Header, error) {
+	var vLog types.Log
+	if err := json.Unmarshal([]byte(letter.RawLog), &vLog); err != nil {
+		return vLog, nil, fmt.Errorf("unmarshal event log error: %w", err)
+	}
+
+	entry, err := ev.SyncService.GetEventLog(ctx, letter.ChainId, letter.TxHash, letter.LogIndex)
+	if err != nil {
+		return vLog, nil, err
+	}
+	if entry != nil {
+		_, blockHeader := journalLog(*entry)
+		return vLog, blockHeader, nil
+	}
+
+	headers, err := ev.blockHeaders(ctx, []types.Log{vLog})
+	if err != nil {
+		return vLog, nil, err
+	}
+
+	return vLog, headers[vLog.BlockNumber], nil
+}
+
+// ReplayDeadLetters applies again the event logs that failed in the previous syncs and are due for a replay.
+// A log is applied like in the sync, in a savepoint and with its journaled block, so that a rollback of the block also reverts it.
+// A log that is applied is marked as resolved. A log that fails again is replayed later,
+// until it has been attempted DeadLetterMaxAttempts times and is left for an operator as exhausted.
+//
+// Returns:
+//   - error: An error if the dead letters can not be read or updated; otherwise, nil.
+func (ev *Event) ReplayDeadLetters() error {
+	ctx := context.Background()
+	now := time.Now().Unix()
+	letters, err := ev.SyncService.GetDueDeadLetters(ctx, ev.Client.ChainId, now, constant.DeadLetterReplayLimit)
+	if err != nil {
+		return err
+	}
+
+	for _, letter := range letters {
+		letter.Attempts++
+
+		vLog, blockHeader, replayErr := ev.deadLetterLog(ctx, letter)
+		if replayErr == nil {
+			replayErr = ev.SyncService.Transaction(ctx, func(ctx context.Context) error {
+				if err := ev.applyEventLog(ctx, vLog, blockHeader); err != nil {
+					return err
+				}
+
+				resolved := letter
+				resolved.Error = ""
+				resolved.Status = constant.DeadLetterStatusResolved
+				return ev.SyncService.UpdateDeadLetter(ctx, &resolved)
+			})
+		}
+
+		if replayErr == nil {
+			zap.L().Info("Dead letter replayed",
+				zap.String("event", letter.Event),
+				zap.String("tx hash", letter.TxHash),
+				zap.Int("attempts", letter.Attempts))
+			continue
+		}
+
+		letter.Error = replayErr.Error()
+		letter.NextAttemptTime = nextDeadLetterAttempt(letter.Attempts, now)
+		if letter.Attempts >= constant.DeadLetterMaxAttempts {
+			letter.Status = constant.DeadLetterStatusExhausted
+		}
+		zap.L().Warn("Dead letter replay failed",
+			zap.String("event", letter.Event),
+			zap.String("tx hash", letter.TxHash),
+			zap.Int("attempts", letter.Attempts),
+			zap.Error(replayErr))
+
+		if err := ev.SyncService.UpdateDeadLetter(ctx, &letter); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,215 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/config"
+	"powervoting-server/constant"
+	"powervoting-server/data"
+	"powervoting-server/mock"
+	"powervoting-server/model"
+)
+
+type deadLetterSyncService struct {
+	mock.MockSyncService
+	deadLetters []model.EventDeadLetterTbl
+	eventLogs   []model.EventLogTbl
+}
+
+func (s *deadLetterSyncService) AddEventLog(ctx context.Context, in *model.EventLogTbl) error {
+	s.eventLogs = append(s.eventLogs, *in)
+
+	return nil
+}
+
+func (s *deadLetterSyncService) AddDeadLetter(ctx context.Context, in *model.EventDeadLetterTbl) error {
+	s.deadLetters = append(s.deadLetters, *in)
+
+	return nil
+}
+
+func TestNextDeadLetterAttempt(t *testing.T) {
+	assert.Equal(t, int64(1000+constant.DeadLetterRetryInterval), nextDeadLetterAttempt(1, 1000))
+	assert.Equal(t, int64(1000+4*constant.DeadLetterRetryInterval), nextDeadLetterAttempt(3, 1000))
+	// capped at the maximum interval
+	assert.Equal(t, int64(1000+constant.DeadLetterRetryMaxInterval), nextDeadLetterAttempt(constant.DeadLetterMaxAttempts, 1000))
+}
+
+func TestProcessingEventLogsDeadLetter(t *testing.T) {
+	syncService := &deadLetterSyncService{}
+	ev := &Event{
+		Client: &model.GoEthClient{
+			ChainId: 314159,
+			Client: fakeChain(t, map[uint64]any{
+				100: &types.Header{Number: big.NewInt(100), Time: 1700000000, Difficulty: big.NewInt(0)},
+			}),
+			ABI: &model.ABI{
+				PowerVotingAbi: data.GetAbiFromLocalFile("../../abi/power-voting.json"),
+				FipAbi:         data.GetAbiFromLocalFile("../../abi/power-voting-fip.json"),
+				OracleAbi:      data.GetAbiFromLocalFile("../../abi/oracle.json"),
+			},
+		},
+		SyncService: syncService,
+		Network:     &config.Network{},
+	}
+
+	unknown := types.Log{
+		Address:     common.HexToAddress("0x1"),
+		Topics:      []common.Hash{common.HexToHash("0xdead")},
+		Data:        []byte{1, 2, 3},
+		BlockNumber: 100,
+		TxHash:      common.HexToHash("0xabc"),
+		Index:       2,
+	}
+	removed := unknown
+	removed.Removed = true
+	removed.Index = 3
+
+	assert.NoError(t, ev.ProcessingEventLogs(context.Background(), []types.Log{unknown, removed}))
+
+	// the failed log is journaled and kept to be replayed, the removed log is skipped
+	assert.Len(t, syncService.eventLogs, 1)
+	assert.Equal(t, int64(1700000000), syncService.eventLogs[0].BlockTime)
+	assert.Len(t, syncService.deadLetters, 1)
+	letter := syncService.deadLetters[0]
+	assert.Equal(t, int64(314159), letter.ChainId)
+	assert.Equal(t, common.HexToHash("0xdead").Hex(), letter.Event)
+	assert.Equal(t, int64(100), letter.BlockNumber)
+	assert.Equal(t, uint(2), letter.LogIndex)
+	assert.Equal(t, 1, letter.Attempts)
+	assert.Equal(t, constant.DeadLetterStatusPending, letter.Status)
+	assert.Contains(t, letter.Error, "unknown event")
+
+	var vLog types.Log
+	assert.NoError(t, json.Unmarshal([]byte(letter.RawLog), &vLog))
+	assert.Equal(t, unknown, vLog)
+}
+
+func TestEventName(t *testing.T) {
+	powerVotingAbi := data.GetAbiFromLocalFile("../../abi/power-voting.json")
+	ev := &Event{
+		Client: &model.GoEthClient{
+			ABI: &model.ABI{
+				PowerVotingAbi: powerVotingAbi,
+				FipAbi:         data.GetAbiFromLocalFile("../../abi/power-voting-fip.json"),
+				OracleAbi:      data.GetAbiFromLocalFile("../../abi/oracle.json"),
+			},
+		},
+	}
+
+	vLog := types.Log{Topics: []common.Hash{powerVotingAbi.Events[constant.VoteEvt].ID}}
+	assert.Equal(t, constant.VoteEvt, ev.eventName(vLog))
+	assert.Empty(t, ev.eventName(types.Log{}))
+}
+
+// replaySyncService serves due dead letters and their journaled logs, and records what their replay saves.
+type replaySyncService struct {
+	mock.MockSyncService
+	due      []model.EventDeadLetterTbl
+	journal  []model.EventLogTbl
+	updated  []model.EventDeadLetterTbl
+	webhooks []model.WebhookEventTbl
+}
+
+func (s *replaySyncService) GetDueDeadLetters(ctx context.Context, chainId, now int64, limit int) ([]model.EventDeadLetterTbl, error) {
+	return s.due, nil
+}
+
+func (s *replaySyncService) GetEventLog(ctx context.Context, chainId int64, txHash string, logIndex uint) (*model.EventLogTbl, error) {
+	for _, entry := range s.journal {
+		if entry.ChainId == chainId && entry.TxHash == txHash && entry.LogIndex == logIndex {
+			return &entry, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *replaySyncService) UpdateDeadLetter(ctx context.Context, in *model.EventDeadLetterTbl) error {
+	s.updated = append(s.updated, *in)
+
+	return nil
+}
+
+func (s *replaySyncService) AddVote(ctx context.Context, in *model.VoteHistoryTbl) error {
+	return nil
+}
+
+func (s *replaySyncService) AddVoterAddress(ctx context.Context, in *model.VoterInfoTbl) error {
+	return nil
+}
+
+func (s *replaySyncService) AddWebhookEvent(ctx context.Context, in *model.WebhookEventTbl) error {
+	s.webhooks = append(s.webhooks, *in)
+
+	return nil
+}
+
+func TestReplayDeadLetters(t *testing.T) {
+	abi := testABI()
+	syncService := &replaySyncService{}
+	ev := &Event{
+		Client:      &model.GoEthClient{ChainId: 314159, ABI: abi},
+		SyncService: syncService,
+		Network:     &config.Network{},
+	}
+
+	voteData, err := abi.PowerVotingAbi.Events[constant.VoteEvt].Inputs.NonIndexed().
+		Pack(big.NewInt(1), common.HexToAddress("0xa"), "cipher")
+	assert.NoError(t, err)
+	vote := types.Log{
+		Topics:      []common.Hash{abi.PowerVotingAbi.Events[constant.VoteEvt].ID},
+		Data:        voteData,
+		BlockNumber: 10,
+		BlockHash:   common.HexToHash("0xb10"),
+		TxHash:      common.HexToHash("0x1"),
+		Index:       1,
+	}
+	rawLog, err := json.Marshal(vote)
+	assert.NoError(t, err)
+
+	// the header of the block is read from the journal, not from the network
+	syncService.journal = []model.EventLogTbl{*ev.newEventLog(vote, &types.Header{Time: 1700000000})}
+	syncService.due = []model.EventDeadLetterTbl{{
+		ChainId:  314159,
+		TxHash:   vote.TxHash.Hex(),
+		LogIndex: vote.Index,
+		RawLog:   string(rawLog),
+		Attempts: 1,
+		Status:   constant.DeadLetterStatusPending,
+	}}
+
+	assert.NoError(t, ev.ReplayDeadLetters())
+
+	assert.Len(t, syncService.updated, 1)
+	assert.Equal(t, constant.DeadLetterStatusResolved, syncService.updated[0].Status)
+	assert.Equal(t, 2, syncService.updated[0].Attempts)
+
+	// the replayed event is emitted with its block, to be withdrawn when the block is rolled back
+	assert.Len(t, syncService.webhooks, 1)
+	emitted := syncService.webhooks[0]
+	assert.Equal(t, int64(10), emitted.BlockNumber)
+	assert.Equal(t, vote.BlockHash.Hex(), emitted.BlockHash)
+	assert.Equal(t, int64(1700000000), emitted.Timestamp)
+}
@@ -39,6 +39,8 @@ type Event struct {
 	Client      *model.GoEthClient
 	SyncService service.ISyncService
 	Network     *config.Network
+	Headers     *HeaderCache // Block headers of the network, shared by the runs of its sync task
+	replay      *replayState // Recorded state used instead of the network while the journal is replayed, nil when syncing
 }
 
 func (ev *Event) SubscribeEvent() error {
@@ -46,7 +48,7 @@ func (ev *Event) SubscribeEvent() error {
 	// Collect log information
 
 	// Get the latest processed block info
-	syncInfo, err := ev.SyncService.GetSyncEventInfo(ctx, ev.Network.PowerVotingContract)
+	syncInfo, err := ev.SyncService.GetSyncEventInfo(ctx, ev.Client.ChainId, ev.Network.PowerVotingContract)
 	if err != nil {
 		zap.L().Error("Get sync event info error: %v", zap.Error(err))
 		return err
@@ -59,16 +61,39 @@ func (ev *Event) SubscribeEvent() error {
 		return err
 	}
 
-	endBlock := header.Number.Int64()
+	head := header.Number.Int64()
+	if head < syncInfo.SyncedHeight {
+		zap.L().Info("current block height is less than or equal to the latest processed block height", zap.Int64("latest block height", head))
+		return fmt.Errorf("invalid block height: %d", head)
+	}
+
+	// Roll back the blocks synced from a chain that has been reorganized, they are synced again on the next run
+	rolledBack, err := ev.checkReorg(ctx, syncInfo.SyncedHeight, head)
+	if err != nil {
+		zap.L().Error("Check chain reorganization error", zap.Error(err))
+		return err
+	}
+	if rolledBack {
+		return nil
+	}
+
+	// Only sync the blocks that are deep enough behind the head to not be reorganized
+	endBlock := head - ev.confirmationDepth()
 	if endBlock <= syncInfo.SyncedHeight {
-		if endBlock == syncInfo.SyncedHeight {
-			zap.L().Debug("It has been synchronized to the latest block height", zap.Int64("latest block height", endBlock))
-			return constant.ErrAlreadySyncHeight
-		}
+		zap.L().Debug("It has been synchronized to the latest confirmed block height", zap.Int64("latest block height", head))
+		return constant.ErrAlreadySyncHeight
+	}
 
-		zap.L().Info("current block height is less than or equal to the latest processed block height", zap.Int64("latest block height", endBlock))
-		return fmt.Errorf("invalid block height: %d", endBlock)
+	// A long range of historical blocks is backfilled in chunks fetched concurrently
+	progress, err := ev.SyncService.GetSyncBackfill(ctx, ev.Client.ChainId)
+	if err != nil {
+		zap.L().Error("Get sync backfill error", zap.Error(err))
+		return err
 	}
+	if backfillNeeded(progress, syncInfo.SyncedHeight, endBlock) {
+		return ev.Backfill(ctx, progress, syncInfo.SyncedHeight, endBlock)
+	}
+
 	// Limit the number of blocks per processing
 	if endBlock-syncInfo.SyncedHeight > constant.SyncBlockLimit {
 		endBlock = syncInfo.SyncedHeight + constant.SyncBlockLimit
@@ -90,12 +115,34 @@ func (ev *Event) SubscribeEvent() error {
 		return err
 	}
 
-	// Process the log information
-	ev.ProcessingEventLogs(ctx, logs)
+	return ev.applyRange(ctx, logs, endBlock, nil)
+}
+
+// applyRange processes the event logs of a range of blocks and updates the latest processed block height in one transaction,
+// so that the whole range is synced again when it can not be saved. The synced blocks, recorded to detect a reorganization
+// on the next run, and the backfill progress, when not nil, are saved in the same transaction.
+func (ev *Event) applyRange(ctx context.Context, logs []types.Log, endBlock int64, progress *model.SyncBackfillTbl) error {
+	err := ev.SyncService.Transaction(ctx, func(ctx context.Context) error {
+		if err := ev.ProcessingEventLogs(ctx, logs); err != nil {
+			return err
+		}
+
+		// The height never moves past blocks that are not recorded, their parent would not match on the next run
+		if err := ev.recordSyncedBlocks(ctx, logs, endBlock); err != nil {
+			return err
+		}
+
+		if err := ev.SyncService.UpdateSyncEventInfo(ctx, ev.Client.ChainId, ev.Network.PowerVotingContract, endBlock); err != nil {
+			return err
+		}
 
-	// Update the latest processed block height to the database
-	if err = ev.SyncService.UpdateSyncEventInfo(ctx, ev.Network.PowerVotingContract, endBlock); err != nil {
-		zap.L().Error("Update sync event info error: %v", zap.Error(err))
+		if progress == nil {
+			return nil
+		}
+		return ev.SyncService.SaveSyncBackfill(ctx, progress)
+	})
+	if err != nil {
+		zap.L().Error("Apply event logs error", zap.Int64("end block", endBlock), zap.Error(err))
 		return err
 	}
 
@@ -128,8 +175,13 @@ func (ev *Event) FetchMatchingEventLogs(ctx context.Context, fromBlock, ToBlock
 			},
 		},
 	}
-	// Get specified Logs using rpc of getth call chain
-	logs, err := ev.Client.Client.FilterLogs(ctx, query)
+	// The PowerVotingConf contract is only synced when both its address and its ABI are configured
+	if ev.confEnabled() {
+		query.Addresses = append(query.Addresses, common.HexToAddress(ev.Network.PowerVotingConfContract))
+		query.Topics[0] = append(query.Topics[0], ev.confTopics()...)
+	}
+	// Get specified Logs from the node, or from the indexer when the node fails
+	logs, err := ev.fetchLogs(ctx, query)
 	if err != nil {
 		zap.L().Error("Get event logs error", zap.Error(err))
 		return nil, err
@@ -139,32 +191,57 @@ func (ev *Event) FetchMatchingEventLogs(ctx context.Context, fromBlock, ToBlock
 }
 
 // ProcessingEventLogs processes a list of event logs using a provided Ethereum client.
-func (ev *Event) ProcessingEventLogs(ctx context.Context, logs []types.Log) {
+// The headers of their blocks are fetched first, the logs are not processed when a header can not be fetched.
+// A log that fails is rolled back alone and saved as a dead letter to be replayed,
+// an error is only returned when the dead letter can not be saved.
+func (ev *Event) ProcessingEventLogs(ctx context.Context, logs []types.Log) error {
+	headers, err := ev.blockHeaders(ctx, logs)
+	if err != nil {
+		return err
+	}
+
 	for _, vLog := range logs {
+		// Logs removed by a chain reorganization are not on the chain anymore
+		if vLog.Removed {
+			zap.L().Warn("Skip removed event log", zap.Uint64("block number", vLog.BlockNumber), zap.String("tx hash", vLog.TxHash.Hex()))
+			continue
+		}
+
+		// Keep the raw log in the journal with its block time, even when it fails to be applied
+		blockHeader := headers[vLog.BlockNumber]
+		if err := ev.SyncService.AddEventLog(ctx, ev.newEventLog(vLog, blockHeader)); err != nil {
+			return err
+		}
+
 		// Attempt to parse the event log using the client's PowerVotingAbi and the log data.
-		err := ev.parseEvent(ctx, vLog)
+		err := ev.applyEventLog(ctx, vLog, blockHeader)
 		if err != nil {
-			zap.L().Error("Parse event error", zap.String("rpc url", config.Client.Network.Rpc), zap.Error(err))
+			zap.L().Error("Parse event error", zap.String("rpc url", ev.Network.Rpc), zap.Error(err))
+			if err := ev.addDeadLetter(ctx, vLog, err); err != nil {
+				return err
+			}
 			continue
 		}
 
 		zap.L().Info("Event parsed result:", zap.Any("event", vLog.Topics[0].Hex()))
 	}
+
+	return nil
 }
 
 // parses the event log for the forum and stores the parsed results to the database.
 func (ev *Event) parseEvent(ctx context.Context, vLog types.Log) error {
 	// Get the block header of the block containing the event log
-	blockHeader, err := ev.Client.Client.HeaderByNumber(ctx, big.NewInt(int64(vLog.BlockNumber)))
+	headers, err := ev.blockHeaders(ctx, []types.Log{vLog})
 	if err != nil {
-		// return fmt.Errorf("get block header error: %v", err)
-		zap.L().Warn("get block header error ", zap.Error(err))
-		blockHeader = &types.Header{
-			Time:   uint64(time.Now().Unix()),
-			Number: big.NewInt(int64(vLog.BlockNumber)),
-		}
+		return err
 	}
 
+	return ev.handleEvent(ctx, vLog, headers[vLog.BlockNumber])
+}
+
+// handleEvent parses an event log emitted in the given block and stores the parsed results to the database.
+func (ev *Event) handleEvent(ctx context.Context, vLog types.Log, blockHeader *types.Header) error {
 	switch vLog.Topics[0].Hex() {
 	// Parse the proposal event
 	case ev.Client.ABI.PowerVotingAbi.Events[constant.ProposalEvt].ID.Hex():
@@ -183,7 +260,7 @@ func (ev *Event) parseEvent(ctx context.Context, vLog types.Log) error {
 			return err
 		}
 
-		if err := ev.HandleVote(ctx, event, blockHeader); err != nil {
+		if err := ev.HandleVote(ctx, event, vLog, blockHeader); err != nil {
 			return fmt.Errorf("handle vote error: %v", err)
 		}
 
@@ -238,6 +315,9 @@ func (ev *Event) parseEvent(ctx context.Context, vLog types.Log) error {
 		}
 
 	default:
+		if ev.confEnabled() {
+			return ev.handleConfEvent(ctx, vLog, blockHeader)
+		}
 		return fmt.Errorf("unknown event")
 	}
 
@@ -261,6 +341,7 @@ func (ev *Event) parseOracleEvent(event any, unpackName string, log []byte) erro
 	return ev.Client.ABI.OracleAbi.UnpackIntoInterface(event, unpackName, log)
 }
 
+// FetchEventFromRPC fetches a page of the events of an address from a Filfox compatible event API.
 func FetchEventFromRPC(rpcUrl string) ([]types.Log, error) {
 	client := resty.New().
 		SetTimeout(constant.RequestTimeout).
@@ -290,6 +371,9 @@ func FetchEventFromRPC(rpcUrl string) ([]types.Log, error) {
 			Data:        common.FromHex(log.Data),
 			Topics:      utils.ConvertTopics(log.Topics),
 			BlockNumber: uint64(log.BlockNumber),
+			TxHash:      common.HexToHash(log.TransactionHash),
+			TxIndex:     uint(log.TransactionIndex),
+			Index:       uint(log.LogIndex),
 			Removed:     log.Removed,
 		})
 	}
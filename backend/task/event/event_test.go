@@ -20,6 +20,8 @@ func getSyncService() *service.SyncService {
 		repo.NewProposalRepo(data.NewMysql()),
 		repo.NewFipRepo(data.NewMysql()),
 		repo.NewLotusRPCRepo(),
+		repo.NewConfRepo(data.NewMysql()),
+		repo.NewWebhookRepo(data.NewMysql()),
 	)
 }
 
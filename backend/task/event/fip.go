@@ -16,6 +16,7 @@ package event
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/core/types"
 	"go.uber.org/zap"
@@ -25,7 +26,7 @@ import (
 )
 
 func (ev *Event) HandleFipEditorProposalCreateEvent(ctx context.Context, event FipEditorProposalCreateEvent, blockHeader *types.Header) error {
-	if err := ev.SyncService.CreateFipProposal(ctx, &model.FipProposalTbl{
+	fipProposal := model.FipProposalTbl{
 		ProposalId:       event.Proposal.ProposalId.Int64(),
 		ChainId:          ev.Client.ChainId,
 		ProposalType:     int(event.Proposal.ProposalType),
@@ -34,7 +35,8 @@ func (ev *Event) HandleFipEditorProposalCreateEvent(ctx context.Context, event F
 		CandidateInfo:    event.Proposal.CandidateInfo,
 		BlockNumber:      blockHeader.Number.Int64(),
 		Timestamp:        int64(blockHeader.Time),
-	}); err != nil {
+	}
+	if err := ev.SyncService.CreateFipProposal(ctx, &fipProposal); err != nil {
 		zap.L().Error(
 			"failed to create fip proposal",
 			zap.Int64("proposal id", event.Proposal.ProposalId.Int64()),
@@ -50,6 +52,11 @@ func (ev *Event) HandleFipEditorProposalCreateEvent(ctx context.Context, event F
 		zap.Any("event", event),
 	)
 
+	if err := ev.emitWebhookEvent(ctx, 0, constant.WebhookFipProposalCreated, fmt.Sprintf("fip_proposal:%d", fipProposal.ProposalId),
+		fipProposal.Timestamp, model.NewWebhookFipProposalData(fipProposal)); err != nil {
+		return fmt.Errorf("emit %s webhook event error: %w", constant.WebhookFipProposalCreated, err)
+	}
+
 	return nil
 }
 
@@ -81,9 +88,10 @@ func (ev *Event) HandleFipEditorProposalPassedEvent(ctx context.Context, event F
 	} else {
 		zap.L().Info("fip editor approve", zap.Int64("proposal id", event.ProposalId.Int64()))
 		if err := ev.SyncService.CreateFipEditor(ctx, &model.FipEditorTbl{
-			ChainId:  ev.Client.ChainId,
-			Editor:   fipProposal.CandidateAddress,
-			IsRemove: constant.FipEditorValid,
+			ChainId:     ev.Client.ChainId,
+			Editor:      fipProposal.CandidateAddress,
+			IsRemove:    constant.FipEditorValid,
+			BlockNumber: blockHeader.Number.Int64(),
 		}); err != nil {
 			zap.L().Error(
 				"failed to create fip voter",
@@ -95,6 +103,11 @@ func (ev *Event) HandleFipEditorProposalPassedEvent(ctx context.Context, event F
 		zap.L().Info("fip editor approved successfully", zap.Int64("proposal id", event.ProposalId.Int64()), zap.String("approve address", fipProposal.CandidateAddress))
 	}
 
+	if err := ev.emitWebhookEvent(ctx, 0, constant.WebhookFipProposalPassed, fmt.Sprintf("fip_proposal:%d", fipProposal.ProposalId),
+		int64(blockHeader.Time), model.NewWebhookFipProposalData(*fipProposal)); err != nil {
+		return fmt.Errorf("emit %s webhook event error: %w", constant.WebhookFipProposalPassed, err)
+	}
+
 	return nil
 }
 
@@ -0,0 +1,170 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+
+	"powervoting-server/constant"
+)
+
+// HeaderCache keeps the headers of the blocks with event logs of a network, the least recently used headers are evicted.
+// Only the block number and time of the headers are kept, they are the fields read by the event handlers.
+type HeaderCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List // Block numbers, the most recently used first
+	headers map[uint64]*list.Element
+}
+
+type cachedHeader struct {
+	number uint64
+	header *types.Header
+}
+
+// NewHeaderCache creates a header cache that keeps at most size headers.
+func NewHeaderCache(size int) *HeaderCache {
+	return &HeaderCache{
+		size:    size,
+		order:   list.New(),
+		headers: make(map[uint64]*list.Element),
+	}
+}
+
+// Get returns the cached header of a block.
+func (c *HeaderCache) Get(number uint64) (*types.Header, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.headers[number]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+
+	return elem.Value.(*cachedHeader).header, true
+}
+
+// Add caches the header of a block and evicts the least recently used header when the cache is full.
+func (c *HeaderCache) Add(header *types.Header) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	number := header.Number.Uint64()
+	if elem, ok := c.headers[number]; ok {
+		elem.Value.(*cachedHeader).header = header
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.headers[number] = c.order.PushFront(&cachedHeader{number: number, header: header})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.headers, oldest.Value.(*cachedHeader).number)
+	}
+}
+
+// DropFrom removes the headers of the blocks at and above a height, after they were rolled back by a reorganization.
+func (c *HeaderCache) DropFrom(height uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for number, elem := range c.headers {
+		if number >= height {
+			c.order.Remove(elem)
+			delete(c.headers, number)
+		}
+	}
+}
+
+// headerCache returns the header cache of the network, a cache that only lives as long as the event when none is set.
+func (ev *Event) headerCache() *HeaderCache {
+	if ev.Headers == nil {
+		ev.Headers = NewHeaderCache(constant.BlockHeaderCacheSize)
+	}
+
+	return ev.Headers
+}
+
+// blockHeaders returns the headers of the blocks of the event logs, by block number.
+// The headers that are not cached are fetched with batched JSON-RPC calls, one call for every distinct block.
+// An error is returned when a header can not be fetched, the block time of an event is never guessed.
+func (ev *Event) blockHeaders(ctx context.Context, logs []types.Log) (map[uint64]*types.Header, error) {
+	cache := ev.headerCache()
+	headers := make(map[uint64]*types.Header)
+	var missing []uint64
+	for _, vLog := range logs {
+		if vLog.Removed {
+			continue
+		}
+		if _, ok := headers[vLog.BlockNumber]; ok {
+			continue
+		}
+
+		header, ok := cache.Get(vLog.BlockNumber)
+		if !ok {
+			missing = append(missing, vLog.BlockNumber)
+		}
+		// A nil header marks a block that is fetched below
+		headers[vLog.BlockNumber] = header
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+
+	for start := 0; start < len(missing); start += constant.BlockHeaderBatchSize {
+		batch := missing[start:min(start+constant.BlockHeaderBatchSize, len(missing))]
+		blocks := make([]*chainBlock, len(batch))
+		elems := make([]rpc.BatchElem, len(batch))
+		for i, number := range batch {
+			elems[i] = rpc.BatchElem{
+				Method: "eth_getBlockByNumber",
+				Args:   []any{hexutil.EncodeUint64(number), false},
+				Result: &blocks[i],
+			}
+		}
+
+		if err := ev.Client.Client.Client().BatchCallContext(ctx, elems); err != nil {
+			return nil, fmt.Errorf("get block headers error: %w", err)
+		}
+
+		for i, number := range batch {
+			if elems[i].Error != nil {
+				return nil, fmt.Errorf("get block header %d error: %w", number, elems[i].Error)
+			}
+			if blocks[i] == nil {
+				return nil, fmt.Errorf("get block header %d error: block not found", number)
+			}
+
+			header := &types.Header{
+				Number:     new(big.Int).SetUint64(number),
+				Time:       uint64(blocks[i].Timestamp),
+				ParentHash: blocks[i].ParentHash,
+			}
+			cache.Add(header)
+			headers[number] = header
+		}
+	}
+
+	return headers, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/model"
+)
+
+func header(number, time uint64) *types.Header {
+	return &types.Header{Number: new(big.Int).SetUint64(number), Time: time, Difficulty: big.NewInt(0)}
+}
+
+func TestBlockHeaders(t *testing.T) {
+	ev := &Event{
+		Client: &model.GoEthClient{ChainId: 314159, Client: fakeChain(t, map[uint64]any{
+			10: header(10, 1700000000),
+			11: header(11, 1700000030),
+			12: header(12, 1700000060),
+		})},
+	}
+
+	headers, err := ev.blockHeaders(context.Background(), []types.Log{{BlockNumber: 12}, {BlockNumber: 10}, {BlockNumber: 12}, {BlockNumber: 11, Removed: true}})
+	assert.NoError(t, err)
+	assert.Len(t, headers, 2)
+	assert.Equal(t, uint64(1700000000), headers[10].Time)
+	assert.Equal(t, uint64(1700000060), headers[12].Time)
+
+	// the fetched headers are cached, the removed log was skipped
+	cached, ok := ev.Headers.Get(12)
+	assert.True(t, ok)
+	assert.Equal(t, int64(12), cached.Number.Int64())
+	_, ok = ev.Headers.Get(11)
+	assert.False(t, ok)
+}
+
+func TestBlockHeadersFailure(t *testing.T) {
+	ev := &Event{
+		Client: &model.GoEthClient{ChainId: 314159, Client: fakeChain(t, map[uint64]any{
+			10: header(10, 1700000000),
+		})},
+	}
+
+	// no block time is made up for a header that can not be fetched
+	_, err := ev.blockHeaders(context.Background(), []types.Log{{BlockNumber: 10}, {BlockNumber: 13}})
+	assert.Error(t, err)
+	assert.Error(t, ev.parseEvent(context.Background(), types.Log{BlockNumber: 13}))
+}
+
+func TestHeaderCache(t *testing.T) {
+	cache := NewHeaderCache(2)
+	cache.Add(header(1, 100))
+	cache.Add(header(2, 200))
+	_, ok := cache.Get(1)
+	assert.True(t, ok)
+
+	// the least recently used header is evicted
+	cache.Add(header(3, 300))
+	_, ok = cache.Get(2)
+	assert.False(t, ok)
+	_, ok = cache.Get(1)
+	assert.True(t, ok)
+
+	cache.DropFrom(3)
+	_, ok = cache.Get(3)
+	assert.False(t, ok)
+	_, ok = cache.Get(1)
+	assert.True(t, ok)
+}
@@ -0,0 +1,337 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+	"powervoting-server/utils"
+)
+
+// newEventLog returns the journal entry of an event log emitted in the given block.
+func (ev *Event) newEventLog(vLog types.Log, blockHeader *types.Header) *model.EventLogTbl {
+	topics := make(model.StringSlice, 0, len(vLog.Topics))
+	for _, topic := range vLog.Topics {
+		topics = append(topics, topic.Hex())
+	}
+
+	return &model.EventLogTbl{
+		ChainId:         ev.Client.ChainId,
+		ContractAddress: vLog.Address.Hex(),
+		Event:           ev.eventName(vLog),
+		BlockNumber:     int64(vLog.BlockNumber),
+		BlockHash:       vLog.BlockHash.Hex(),
+		BlockTime:       int64(blockHeader.Time),
+		TxHash:          vLog.TxHash.Hex(),
+		TxIndex:         vLog.TxIndex,
+		LogIndex:        vLog.Index,
+		Topics:          topics,
+		Data:            hexutil.Encode(vLog.Data),
+	}
+}
+
+// journalLog returns the event log of a journal entry and the header of its block, as far as the handlers read it.
+func journalLog(entry model.EventLogTbl) (types.Log, *types.Header) {
+	vLog := types.Log{
+		Address:     common.HexToAddress(entry.ContractAddress),
+		Topics:      utils.ConvertTopics(entry.Topics),
+		Data:        common.FromHex(entry.Data),
+		BlockNumber: uint64(entry.BlockNumber),
+		BlockHash:   common.HexToHash(entry.BlockHash),
+		TxHash:      common.HexToHash(entry.TxHash),
+		TxIndex:     entry.TxIndex,
+		Index:       entry.LogIndex,
+	}
+	blockHeader := &types.Header{
+		Number: big.NewInt(entry.BlockNumber),
+		Time:   uint64(entry.BlockTime),
+	}
+
+	return vLog, blockHeader
+}
+
+// rebuildRecountReason is the reason kept in the result history of a counted proposal whose votes changed in a rebuild.
+const rebuildRecountReason = "votes changed in a rebuild from the event journal"
+
+// replayState is the state recorded by the sync that the handlers reuse while the event journal is replayed,
+// in place of the configuration of today and of the data they fetch from the network when a log is synced.
+type replayState struct {
+	proposals map[int64]model.ProposalTbl   // Recorded proposals by proposal ID
+	voters    map[string]model.VoterInfoTbl // Recorded voters by address
+}
+
+// recordedProposal is a recorded proposal and its votes, kept while the derived tables are rebuilt.
+type recordedProposal struct {
+	proposal model.ProposalTbl
+	votes    []model.VoteTbl
+}
+
+// Rebuild deletes the rows derived from the event logs of the chain and applies the event journal again
+// through the same handlers as the sync, so that a schema change or a handler fix is applied to every past event
+// without scanning the chain again. Everything runs in one transaction, the API serves the previous rows until it is committed.
+// Event logs that fail are saved as dead letters, like in the sync.
+//
+// The replay has no side effects outside the database: the recorded proposals and voters are read before the transaction,
+// and the handlers take the quorum rules, counting algorithm, snapshot and voter identities from them instead of
+// the configuration and the network. A log whose proposal or voter was not recorded fails and is left as a dead letter,
+// to be synced from the network by the dead letter replay.
+//
+// The snapshot power of old proposals may not be available anymore, so the count state of the proposals is kept:
+// it is restored when the rebuilt votes of the proposal are the recorded votes. Otherwise a counted proposal keeps its
+// result in the result history and is counted again as its next revision. The audit bundles and result histories
+// of the proposals that are not rebuilt are deleted.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//
+// Returns:
+//   - int: The number of event logs applied.
+//   - int: The number of event logs that failed and were saved as dead letters.
+//   - error: An error if the journal can not be read or the rebuilt rows can not be saved; otherwise, nil.
+func (ev *Event) Rebuild(ctx context.Context) (int, int, error) {
+	state, recorded, err := ev.recordedState(ctx)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	replayer := *ev
+	replayer.replay = state
+
+	var applied, failed int
+	err = ev.SyncService.Transaction(ctx, func(ctx context.Context) error {
+		applied, failed = 0, 0
+
+		if err := ev.SyncService.DeleteDerivedEvents(ctx, ev.Client.ChainId); err != nil {
+			return err
+		}
+
+		// The initial FIP editor is configured, not created by an event
+		if err := ev.SyncService.CreateFipEditor(ctx, &model.FipEditorTbl{
+			ChainId: ev.Client.ChainId,
+			Editor:  ev.Network.FipInitEditor,
+		}); err != nil {
+			return err
+		}
+
+		for offset := 0; ; offset += constant.RebuildBatchSize {
+			entries, err := ev.SyncService.GetEventLogs(ctx, ev.Client.ChainId, offset, constant.RebuildBatchSize)
+			if err != nil {
+				return err
+			}
+
+			for _, entry := range entries {
+				vLog, blockHeader := journalLog(entry)
+				if err := replayer.applyEventLog(ctx, vLog, blockHeader); err != nil {
+					zap.L().Error("Rebuild event error", zap.String("tx hash", entry.TxHash), zap.Uint("log index", entry.LogIndex), zap.Error(err))
+					if err := replayer.addDeadLetter(ctx, vLog, err); err != nil {
+						return err
+					}
+					failed++
+					continue
+				}
+				applied++
+			}
+
+			if len(entries) < constant.RebuildBatchSize {
+				break
+			}
+		}
+
+		return ev.restoreProposals(ctx, recorded)
+	})
+
+	return applied, failed, err
+}
+
+// recordedState reads the recorded proposals and voters of the chain, with the votes of every proposal.
+func (ev *Event) recordedState(ctx context.Context) (*replayState, []recordedProposal, error) {
+	proposals, err := ev.SyncService.ProposalList(ctx, ev.Client.ChainId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	state := &replayState{
+		proposals: make(map[int64]model.ProposalTbl, len(proposals)),
+		voters:    make(map[string]model.VoterInfoTbl),
+	}
+	recorded := make([]recordedProposal, 0, len(proposals))
+	for _, proposal := range proposals {
+		votes, err := ev.SyncService.GetVotedList(ctx, ev.Client.ChainId, proposal.ProposalId)
+		if err != nil {
+			return nil, nil, err
+		}
+		state.proposals[proposal.ProposalId] = proposal
+		recorded = append(recorded, recordedProposal{proposal: proposal, votes: votes})
+	}
+
+	voters, err := ev.SyncService.VoterList(ctx, ev.Client.ChainId)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, voter := range voters {
+		state.voters[voter.Address] = voter
+	}
+
+	return state, recorded, nil
+}
+
+// replayProposal sets what was recorded when the proposal was synced on a replayed proposal:
+// its quorum rules, counting algorithm, snapshot and vote options.
+func (s *replayState) replayProposal(proposal *model.ProposalTbl) error {
+	recorded, ok := s.proposals[proposal.ProposalId]
+	if !ok {
+		return fmt.Errorf("proposal %d was not recorded before the replay", proposal.ProposalId)
+	}
+
+	proposal.QuorumRule = recorded.QuorumRule
+	proposal.CountingAlgorithm = recorded.CountingAlgorithm
+	proposal.SnapshotDay = recorded.SnapshotDay
+	proposal.SnapshotBlockHeight = recorded.SnapshotBlockHeight
+	proposal.VoteOptions = recorded.VoteOptions
+
+	return nil
+}
+
+// restoreVoter saves the recorded voter of an address on a replayed event, instead of resolving its identity on the network.
+// The recorded voter holds the effect of every event of the voter, so the later events of the voter leave it as it is.
+func (ev *Event) restoreVoter(ctx context.Context, address string) error {
+	voter, ok := ev.replay.voters[address]
+	if !ok {
+		return fmt.Errorf("voter %s was not recorded before the replay", address)
+	}
+
+	return ev.SyncService.RestoreVoter(ctx, &voter)
+}
+
+// restoreProposals restores the count state of the recorded proposals whose votes are the same after the rebuild.
+// A counted proposal whose votes changed keeps its result in the result history, loses its audit bundle
+// and is counted again as its next revision. The audit bundle and result history of a proposal that is not rebuilt are deleted.
+func (ev *Event) restoreProposals(ctx context.Context, recorded []recordedProposal) error {
+	proposals, err := ev.SyncService.ProposalList(ctx, ev.Client.ChainId)
+	if err != nil {
+		return err
+	}
+
+	rebuilt := make(map[int64]bool, len(proposals))
+	for _, proposal := range proposals {
+		rebuilt[proposal.ProposalId] = true
+	}
+
+	for _, r := range recorded {
+		proposalId := r.proposal.ProposalId
+		if !rebuilt[proposalId] {
+			zap.L().Warn("Recorded proposal is not rebuilt, its results are deleted", zap.Int64("proposal id", proposalId))
+			if err := ev.SyncService.DeleteProposalAudit(ctx, ev.Client.ChainId, proposalId); err != nil {
+				return err
+			}
+			if err := ev.SyncService.DeleteProposalResultHistory(ctx, ev.Client.ChainId, proposalId); err != nil {
+				return err
+			}
+			continue
+		}
+
+		votes, err := ev.SyncService.GetVotedList(ctx, ev.Client.ChainId, proposalId)
+		if err != nil {
+			return err
+		}
+
+		if sameVotes(r.votes, votes) {
+			if err := ev.SyncService.UpdateProposal(ctx, &r.proposal); err != nil {
+				return err
+			}
+			if err := ev.SyncService.UpdateProposalPowerRetry(ctx, &r.proposal); err != nil {
+				return err
+			}
+			if err := ev.SyncService.BatchUpdateVotes(ctx, r.votes); err != nil {
+				return err
+			}
+			continue
+		}
+
+		// An uncounted proposal is counted from its rebuilt votes
+		if r.proposal.Counted != constant.ProposalCounted {
+			continue
+		}
+
+		zap.L().Warn("Votes of a counted proposal changed in the rebuild, it will be counted again",
+			zap.Int64("proposal id", proposalId),
+			zap.Int("counted votes", len(r.votes)),
+			zap.Int("rebuilt votes", len(votes)))
+		if err := ev.recountLater(ctx, r.proposal); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// recountLater keeps the result of a counted proposal in the result history and deletes its audit bundle,
+// the proposal keeps its result revision so that its next count is a new revision.
+func (ev *Event) recountLater(ctx context.Context, proposal model.ProposalTbl) error {
+	// Proposals counted before result revisions were recorded hold their first result
+	proposal.ResultRevision = max(proposal.ResultRevision, 1)
+
+	audit, err := ev.SyncService.GetProposalAudit(ctx, ev.Client.ChainId, proposal.ProposalId)
+	if err != nil {
+		return err
+	}
+
+	if err := ev.SyncService.AddProposalResultHistory(ctx, model.NewResultHistory(proposal, audit, rebuildRecountReason)); err != nil {
+		return err
+	}
+	if err := ev.SyncService.DeleteProposalAudit(ctx, ev.Client.ChainId, proposal.ProposalId); err != nil {
+		return err
+	}
+
+	return ev.SyncService.UpdateProposal(ctx, &model.ProposalTbl{
+		ProposalId:     proposal.ProposalId,
+		ChainId:        proposal.ChainId,
+		Counted:        constant.ProposalCreate,
+		ResultRevision: proposal.ResultRevision,
+	})
+}
+
+// sameVotes checks whether two vote lists hold the same vote event of every voter.
+func sameVotes(a, b []model.VoteTbl) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	type voteEvent struct {
+		blockNumber int64
+		logIndex    uint
+	}
+	events := make(map[string]voteEvent, len(a))
+	for _, vote := range a {
+		events[vote.Address] = voteEvent{vote.BlockNumber, vote.LogIndex}
+	}
+
+	for _, vote := range b {
+		if event, ok := events[vote.Address]; !ok || event != (voteEvent{vote.BlockNumber, vote.LogIndex}) {
+			return false
+		}
+	}
+
+	return true
+}
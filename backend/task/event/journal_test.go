@@ -0,0 +1,303 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/config"
+	"powervoting-server/constant"
+	"powervoting-server/data"
+	"powervoting-server/mock"
+	"powervoting-server/model"
+)
+
+func testABI() *model.ABI {
+	return &model.ABI{
+		PowerVotingAbi: data.GetAbiFromLocalFile("../../abi/power-voting.json"),
+		FipAbi:         data.GetAbiFromLocalFile("../../abi/power-voting-fip.json"),
+		OracleAbi:      data.GetAbiFromLocalFile("../../abi/oracle.json"),
+	}
+}
+
+func TestJournalLog(t *testing.T) {
+	ev := &Event{Client: &model.GoEthClient{ChainId: 314159, ABI: testABI()}}
+	vLog := types.Log{
+		Address:     common.HexToAddress("0x1"),
+		Topics:      []common.Hash{ev.Client.ABI.PowerVotingAbi.Events[constant.VoteEvt].ID, common.HexToHash("0x2")},
+		Data:        []byte{1, 2, 3},
+		BlockNumber: 100,
+		BlockHash:   common.HexToHash("0xb"),
+		TxHash:      common.HexToHash("0xabc"),
+		TxIndex:     4,
+		Index:       7,
+	}
+	blockHeader := &types.Header{Number: big.NewInt(100), Time: 1700000000}
+
+	entry := ev.newEventLog(vLog, blockHeader)
+	assert.Equal(t, constant.VoteEvt, entry.Event)
+	assert.Equal(t, "0x010203", entry.Data)
+
+	replayed, replayedHeader := journalLog(*entry)
+	assert.Equal(t, vLog, replayed)
+	assert.Equal(t, blockHeader.Number, replayedHeader.Number)
+	assert.Equal(t, blockHeader.Time, replayedHeader.Time)
+}
+
+func TestSameVotes(t *testing.T) {
+	votes := []model.VoteTbl{
+		{Address: "0xa", BlockNumber: 10, LogIndex: 1},
+		{Address: "0xb", BlockNumber: 12, LogIndex: 0},
+	}
+
+	assert.True(t, sameVotes(votes, []model.VoteTbl{votes[1], votes[0]}))
+	assert.False(t, sameVotes(votes, votes[:1]))
+	// the voter changed the vote
+	assert.False(t, sameVotes(votes, []model.VoteTbl{votes[0], {Address: "0xb", BlockNumber: 13}}))
+}
+
+type rebuildSyncService struct {
+	mock.MockSyncService
+	journal          []model.EventLogTbl
+	recorded         []model.ProposalTbl
+	voters           []model.VoterInfoTbl
+	before           map[int64][]model.VoteTbl
+	after            map[int64][]model.VoteTbl
+	deleted          bool
+	rebuilt          bool
+	editors          []model.FipEditorTbl
+	proposals        []model.ProposalTbl
+	histories        []model.VoteHistoryTbl
+	restoredVoters   []string
+	deadLetters      []model.EventDeadLetterTbl
+	updated          []model.ProposalTbl
+	retried          []int64
+	results          []model.ProposalResultHistoryTbl
+	deletedAudits    []int64
+	deletedHistories []int64
+}
+
+func (s *rebuildSyncService) ProposalList(ctx context.Context, chainId int64) ([]model.ProposalTbl, error) {
+	if !s.rebuilt {
+		return s.recorded, nil
+	}
+
+	var rebuilt []model.ProposalTbl
+	for _, proposal := range s.recorded {
+		if _, ok := s.after[proposal.ProposalId]; ok {
+			rebuilt = append(rebuilt, model.ProposalTbl{ProposalId: proposal.ProposalId, ChainId: chainId})
+		}
+	}
+
+	return rebuilt, nil
+}
+
+func (s *rebuildSyncService) VoterList(ctx context.Context, chainId int64) ([]model.VoterInfoTbl, error) {
+	return s.voters, nil
+}
+
+func (s *rebuildSyncService) GetVotedList(ctx context.Context, chainId int64, proposalId int64) ([]model.VoteTbl, error) {
+	if s.rebuilt {
+		return s.after[proposalId], nil
+	}
+
+	return s.before[proposalId], nil
+}
+
+func (s *rebuildSyncService) DeleteDerivedEvents(ctx context.Context, chainId int64) error {
+	s.deleted = true
+
+	return nil
+}
+
+func (s *rebuildSyncService) CreateFipEditor(ctx context.Context, in *model.FipEditorTbl) error {
+	s.editors = append(s.editors, *in)
+
+	return nil
+}
+
+func (s *rebuildSyncService) GetEventLogs(ctx context.Context, chainId int64, offset, limit int) ([]model.EventLogTbl, error) {
+	s.rebuilt = true
+	if offset >= len(s.journal) {
+		return nil, nil
+	}
+
+	return s.journal[offset:min(offset+limit, len(s.journal))], nil
+}
+
+func (s *rebuildSyncService) AddProposal(ctx context.Context, in *model.ProposalTbl) error {
+	s.proposals = append(s.proposals, *in)
+
+	return nil
+}
+
+func (s *rebuildSyncService) AddVote(ctx context.Context, in *model.VoteHistoryTbl) error {
+	s.histories = append(s.histories, *in)
+
+	return nil
+}
+
+func (s *rebuildSyncService) AddVoterAddress(ctx context.Context, in *model.VoterInfoTbl) error {
+	panic("the voter of a replayed vote is not resolved on the network")
+}
+
+func (s *rebuildSyncService) RestoreVoter(ctx context.Context, in *model.VoterInfoTbl) error {
+	s.restoredVoters = append(s.restoredVoters, in.Address)
+
+	return nil
+}
+
+func (s *rebuildSyncService) AddDeadLetter(ctx context.Context, in *model.EventDeadLetterTbl) error {
+	s.deadLetters = append(s.deadLetters, *in)
+
+	return nil
+}
+
+func (s *rebuildSyncService) UpdateProposal(ctx context.Context, in *model.ProposalTbl) error {
+	s.updated = append(s.updated, *in)
+
+	return nil
+}
+
+func (s *rebuildSyncService) UpdateProposalPowerRetry(ctx context.Context, in *model.ProposalTbl) error {
+	s.retried = append(s.retried, in.ProposalId)
+
+	return nil
+}
+
+func (s *rebuildSyncService) AddProposalResultHistory(ctx context.Context, in *model.ProposalResultHistoryTbl) error {
+	s.results = append(s.results, *in)
+
+	return nil
+}
+
+func (s *rebuildSyncService) DeleteProposalAudit(ctx context.Context, chainId, proposalId int64) error {
+	s.deletedAudits = append(s.deletedAudits, proposalId)
+
+	return nil
+}
+
+func (s *rebuildSyncService) DeleteProposalResultHistory(ctx context.Context, chainId, proposalId int64) error {
+	s.deletedHistories = append(s.deletedHistories, proposalId)
+
+	return nil
+}
+
+func TestRebuild(t *testing.T) {
+	abi := testABI()
+	ev := &Event{
+		Client:  &model.GoEthClient{ChainId: 314159, ABI: abi},
+		Network: &config.Network{FipInitEditor: "0xeditor"},
+	}
+
+	proposalData, err := abi.PowerVotingAbi.Events[constant.ProposalEvt].Inputs.NonIndexed().
+		Pack(big.NewInt(1), ProposalEvent{
+			Creator:           common.HexToAddress("0xc"),
+			StartTime:         big.NewInt(1700000000),
+			EndTime:           big.NewInt(1700086400),
+			Timestamp:         big.NewInt(1700000000),
+			SnapshotTimestamp: big.NewInt(1699990000),
+			Content:           "content",
+			Title:             "title",
+		})
+	assert.NoError(t, err)
+	proposal := types.Log{
+		Topics:      []common.Hash{abi.PowerVotingAbi.Events[constant.ProposalEvt].ID},
+		Data:        proposalData,
+		BlockNumber: 9,
+		TxHash:      common.HexToHash("0x9"),
+	}
+
+	newVote := func(voter string, txHash string) types.Log {
+		voteData, err := abi.PowerVotingAbi.Events[constant.VoteEvt].Inputs.NonIndexed().
+			Pack(big.NewInt(1), common.HexToAddress(voter), "cipher")
+		assert.NoError(t, err)
+		return types.Log{
+			Topics:      []common.Hash{abi.PowerVotingAbi.Events[constant.VoteEvt].ID},
+			Data:        voteData,
+			BlockNumber: 10,
+			TxHash:      common.HexToHash(txHash),
+			Index:       1,
+		}
+	}
+	unknown := types.Log{
+		Topics:      []common.Hash{common.HexToHash("0xdead")},
+		BlockNumber: 11,
+		TxHash:      common.HexToHash("0x2"),
+	}
+
+	counted := []model.VoteTbl{{Address: common.HexToAddress("0xa").Hex(), BlockNumber: 10, LogIndex: 1}}
+	recordedRule := model.QuorumRule{QuorumMinVoters: 42}
+	syncService := &rebuildSyncService{
+		journal: []model.EventLogTbl{
+			*ev.newEventLog(proposal, &types.Header{Time: 1700000000}),
+			*ev.newEventLog(newVote("0xa", "0x1"), &types.Header{Time: 1700000000}),
+			// the voter was not recorded
+			*ev.newEventLog(newVote("0xb", "0x3"), &types.Header{Time: 1700000000}),
+			*ev.newEventLog(unknown, &types.Header{Time: 1700000030}),
+		},
+		recorded: []model.ProposalTbl{
+			{ProposalId: 1, ChainId: 314159, Counted: constant.ProposalCounted, ResultRevision: 2,
+				CountingAlgorithm: "quadratic", SnapshotDay: "20231114", QuorumRule: recordedRule},
+			{ProposalId: 2, ChainId: 314159, Counted: constant.ProposalCounted},
+			{ProposalId: 3, ChainId: 314159, Counted: constant.ProposalCounted},
+		},
+		voters: []model.VoterInfoTbl{{Address: common.HexToAddress("0xa").Hex(), OwnerId: "1001"}},
+		before: map[int64][]model.VoteTbl{1: counted, 2: counted, 3: counted},
+		// the votes of proposal 2 are not rebuilt the same and proposal 3 is not rebuilt
+		after: map[int64][]model.VoteTbl{1: counted, 2: nil},
+	}
+	ev.SyncService = syncService
+
+	applied, failed, err := ev.Rebuild(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, applied)
+	assert.Equal(t, 2, failed)
+
+	assert.True(t, syncService.deleted)
+	assert.Equal(t, []model.FipEditorTbl{{ChainId: 314159, Editor: "0xeditor"}}, syncService.editors)
+
+	// the proposal keeps the recorded rules, algorithm and snapshot instead of the configuration and the network
+	assert.Len(t, syncService.proposals, 1)
+	assert.Equal(t, recordedRule, syncService.proposals[0].QuorumRule)
+	assert.Equal(t, "quadratic", syncService.proposals[0].CountingAlgorithm)
+	assert.Equal(t, "20231114", syncService.proposals[0].SnapshotDay)
+
+	// the vote of the voter that was not recorded is rolled back with the savepoint of its log in the database
+	assert.Len(t, syncService.histories, 2)
+	assert.Equal(t, int64(1700000000), syncService.histories[0].Timestamp)
+	assert.Equal(t, "cipher", syncService.histories[0].VoteEncrypted)
+	assert.Equal(t, []string{common.HexToAddress("0xa").Hex()}, syncService.restoredVoters)
+	assert.Len(t, syncService.deadLetters, 2)
+
+	// proposal 1 gets back its count state, proposal 2 is counted again as its next revision
+	assert.Len(t, syncService.updated, 2)
+	assert.Equal(t, int64(1), syncService.updated[0].ProposalId)
+	assert.Equal(t, int64(2), syncService.updated[0].ResultRevision)
+	assert.Equal(t, []int64{1}, syncService.retried)
+	assert.Equal(t, model.ProposalTbl{ProposalId: 2, ChainId: 314159, ResultRevision: 1}, syncService.updated[1])
+	assert.Len(t, syncService.results, 1)
+	assert.Equal(t, int64(2), syncService.results[0].ProposalId)
+	assert.Equal(t, int64(1), syncService.results[0].ResultRevision)
+	assert.Equal(t, rebuildRecountReason, syncService.results[0].Reason)
+	assert.Equal(t, []int64{2, 3}, syncService.deletedAudits)
+	assert.Equal(t, []int64{3}, syncService.deletedHistories)
+}
@@ -0,0 +1,214 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"go.uber.org/zap"
+
+	"powervoting-server/constant"
+)
+
+// LogSource fetches the event logs that match a filter query.
+type LogSource interface {
+	// Name returns the name of the source used in the logs.
+	Name() string
+	// FetchLogs returns the event logs that match the query, in the order they were emitted.
+	FetchLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+}
+
+// nodeLogSource fetches the event logs from the node with eth_getLogs.
+type nodeLogSource struct {
+	client *ethclient.Client
+}
+
+// Name implements LogSource.
+func (s *nodeLogSource) Name() string {
+	return "node"
+}
+
+// FetchLogs implements LogSource.
+func (s *nodeLogSource) FetchLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	return s.client.FilterLogs(ctx, query)
+}
+
+// indexerLogSource fetches the event logs from a Filfox compatible event API.
+// The API lists the events of an address by pages, the newest events first,
+// so the pages of each address are read until an event older than the range is reached.
+type indexerLogSource struct {
+	url string
+}
+
+// Name implements LogSource.
+func (s *indexerLogSource) Name() string {
+	return "indexer"
+}
+
+// FetchLogs implements LogSource.
+func (s *indexerLogSource) FetchLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	topics := make(map[common.Hash]bool)
+	if len(query.Topics) > 0 {
+		for _, topic := range query.Topics[0] {
+			topics[topic] = true
+		}
+	}
+
+	var logs []types.Log
+	for _, address := range query.Addresses {
+		for page := 0; ; page++ {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+
+			url := fmt.Sprintf("%s/address/%s/events?pageSize=%d&page=%d",
+				strings.TrimRight(s.url, "/"), address.Hex(), constant.IndexerPageSize, page)
+			events, err := FetchEventFromRPC(url)
+			if err != nil {
+				return nil, err
+			}
+
+			older := false
+			for _, vLog := range events {
+				if vLog.BlockNumber < query.FromBlock.Uint64() {
+					older = true
+					continue
+				}
+				if vLog.BlockNumber > query.ToBlock.Uint64() || (len(topics) > 0 && (len(vLog.Topics) == 0 || !topics[vLog.Topics[0]])) {
+					continue
+				}
+				logs = append(logs, vLog)
+			}
+
+			if older || len(events) < constant.IndexerPageSize {
+				break
+			}
+		}
+	}
+
+	sort.Slice(logs, func(i, j int) bool {
+		if logs[i].BlockNumber != logs[j].BlockNumber {
+			return logs[i].BlockNumber < logs[j].BlockNumber
+		}
+		return logs[i].Index < logs[j].Index
+	})
+
+	return logs, nil
+}
+
+// logSources returns the node source of the network and its indexer source, nil when no indexer is configured.
+func (ev *Event) logSources() (LogSource, LogSource) {
+	var indexer LogSource
+	if ev.Network.IndexerUrl != "" {
+		indexer = &indexerLogSource{url: ev.Network.IndexerUrl}
+	}
+
+	return &nodeLogSource{client: ev.Client.Client}, indexer
+}
+
+// fetchLogs fetches the event logs of a query from the node and fails over to the indexer when the node errors.
+// In verify mode the proposal and vote events of the node are compared with the indexer,
+// and the differences are reported as an alert without failing the sync.
+func (ev *Event) fetchLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	node, indexer := ev.logSources()
+	logs, err := node.FetchLogs(ctx, query)
+	if err != nil {
+		if indexer == nil {
+			return nil, err
+		}
+
+		zap.L().Warn("Fetch event logs from the node failed, failing over to the indexer",
+			zap.Int64("network id", ev.Client.ChainId),
+			zap.Uint64("from block", query.FromBlock.Uint64()),
+			zap.Uint64("to block", query.ToBlock.Uint64()),
+			zap.Error(err))
+		return indexer.FetchLogs(ctx, query)
+	}
+
+	if indexer != nil && ev.Network.VerifyLogs {
+		ev.verifyLogs(ctx, query, logs, indexer)
+	}
+
+	return logs, nil
+}
+
+// verifyLogs compares the proposal and vote events fetched from the node with the events of another source.
+func (ev *Event) verifyLogs(ctx context.Context, query ethereum.FilterQuery, logs []types.Log, source LogSource) {
+	other, err := source.FetchLogs(ctx, query)
+	if err != nil {
+		zap.L().Warn("Verify event logs failed",
+			zap.String("source", source.Name()),
+			zap.Uint64("from block", query.FromBlock.Uint64()),
+			zap.Uint64("to block", query.ToBlock.Uint64()),
+			zap.Error(err))
+		return
+	}
+
+	missing, extra := ev.diffLogs(logs, other)
+	if len(missing) == 0 && len(extra) == 0 {
+		return
+	}
+
+	zap.L().Error("Event log sources differ",
+		zap.Int64("network id", ev.Client.ChainId),
+		zap.String("source", source.Name()),
+		zap.Uint64("from block", query.FromBlock.Uint64()),
+		zap.Uint64("to block", query.ToBlock.Uint64()),
+		zap.Strings("missing from source", missing),
+		zap.Strings("extra in source", extra))
+}
+
+// diffLogs returns the proposal and vote events of the node that are missing from the other source,
+// and the proposal and vote events of the other source that the node does not have.
+// The events are described by their name, block number, transaction hash and log index.
+func (ev *Event) diffLogs(logs, other []types.Log) ([]string, []string) {
+	index := func(list []types.Log) map[string]bool {
+		res := make(map[string]bool)
+		for _, vLog := range list {
+			if vLog.Removed {
+				continue
+			}
+
+			name := ev.eventName(vLog)
+			if name != constant.ProposalEvt && name != constant.VoteEvt {
+				continue
+			}
+			res[fmt.Sprintf("%s@%d:%s:%d", name, vLog.BlockNumber, vLog.TxHash.Hex(), vLog.Index)] = true
+		}
+		return res
+	}
+
+	diff := func(from, to map[string]bool) []string {
+		var res []string
+		for key := range from {
+			if !to[key] {
+				res = append(res, key)
+			}
+		}
+		sort.Strings(res)
+		return res
+	}
+
+	nodeEvents, otherEvents := index(logs), index(other)
+
+	return diff(nodeEvents, otherEvents), diff(otherEvents, nodeEvents)
+}
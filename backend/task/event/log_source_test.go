@@ -0,0 +1,140 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/config"
+	"powervoting-server/constant"
+	"powervoting-server/model"
+)
+
+// fakeIndexer serves the events of an address at the blocks 1 to blocks, the newest first, with the given topic.
+func fakeIndexer(t *testing.T, address common.Address, topic common.Hash, blocks int, pages *atomic.Int64) string {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages.Add(1)
+		page, err := strconv.Atoi(r.URL.Query().Get("page"))
+		assert.NoError(t, err)
+
+		// the other addresses have no events
+		var events FilFoxRPCEvents
+		if r.URL.Path == "/address/"+address.Hex()+"/events" {
+			events.TotalCount = int64(blocks)
+		}
+		for i := page * constant.IndexerPageSize; i < min((page+1)*constant.IndexerPageSize, int(events.TotalCount)); i++ {
+			number := int64(blocks - i)
+			events.EventLogs = append(events.EventLogs, EventLog{
+				Address:         address.Hex(),
+				Topics:          []string{topic.Hex()},
+				BlockNumber:     number,
+				TransactionHash: common.BigToHash(big.NewInt(number)).Hex(),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		assert.NoError(t, json.NewEncoder(w).Encode(events))
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func TestIndexerLogSource(t *testing.T) {
+	address := common.HexToAddress("0x1")
+	topic := common.HexToHash("0xabc")
+	var pages atomic.Int64
+	source := &indexerLogSource{url: fakeIndexer(t, address, topic, 150, &pages)}
+
+	logs, err := source.FetchLogs(context.Background(), ethereum.FilterQuery{
+		FromBlock: big.NewInt(20),
+		ToBlock:   big.NewInt(30),
+		Addresses: []common.Address{address},
+		Topics:    [][]common.Hash{{topic}},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, logs, 11)
+	assert.Equal(t, uint64(20), logs[0].BlockNumber)
+	assert.Equal(t, uint64(30), logs[10].BlockNumber)
+	// the second page reaches the events older than the range
+	assert.Equal(t, int64(2), pages.Load())
+
+	logs, err = source.FetchLogs(context.Background(), ethereum.FilterQuery{
+		FromBlock: big.NewInt(20),
+		ToBlock:   big.NewInt(30),
+		Addresses: []common.Address{address},
+		Topics:    [][]common.Hash{{common.HexToHash("0xdef")}},
+	})
+	assert.NoError(t, err)
+	assert.Empty(t, logs)
+}
+
+func TestFetchLogsFailover(t *testing.T) {
+	abi := testABI()
+	contract := common.HexToAddress("0x1")
+	voteTopic := abi.PowerVotingAbi.Events[constant.VoteEvt].ID
+	var pages, calls atomic.Int64
+	ev := &Event{
+		// the node rejects every range
+		Client: &model.GoEthClient{ChainId: 314159, Client: fakeLogRPC(t, 0, &calls), ABI: abi},
+		Network: &config.Network{
+			PowerVotingContract: contract.Hex(),
+			IndexerUrl:          fakeIndexer(t, contract, voteTopic, 10, &pages),
+		},
+	}
+
+	logs, err := ev.FetchMatchingEventLogs(context.Background(), big.NewInt(5), big.NewInt(8))
+	assert.NoError(t, err)
+	assert.Len(t, logs, 4)
+	assert.Equal(t, int64(1), calls.Load())
+
+	// without an indexer the error of the node is returned
+	ev.Network.IndexerUrl = ""
+	_, err = ev.FetchMatchingEventLogs(context.Background(), big.NewInt(5), big.NewInt(8))
+	assert.Error(t, err)
+}
+
+func TestDiffLogs(t *testing.T) {
+	abi := testABI()
+	ev := &Event{Client: &model.GoEthClient{ABI: abi}}
+	vote := func(block uint64, index uint) types.Log {
+		return types.Log{
+			Topics:      []common.Hash{abi.PowerVotingAbi.Events[constant.VoteEvt].ID},
+			BlockNumber: block,
+			TxHash:      common.HexToHash("0x1"),
+			Index:       index,
+		}
+	}
+	fip := types.Log{Topics: []common.Hash{abi.FipAbi.Events[constant.FipVoteEvt].ID}, BlockNumber: 12}
+
+	missing, extra := ev.diffLogs([]types.Log{vote(10, 0), vote(11, 1), fip}, []types.Log{vote(10, 0), vote(12, 0)})
+	assert.Equal(t, []string{"Vote@11:" + common.HexToHash("0x1").Hex() + ":1"}, missing)
+	assert.Equal(t, []string{"Vote@12:" + common.HexToHash("0x1").Hex() + ":0"}, extra)
+
+	missing, extra = ev.diffLogs([]types.Log{vote(10, 0)}, []types.Log{vote(10, 0)})
+	assert.Empty(t, missing)
+	assert.Empty(t, extra)
+}
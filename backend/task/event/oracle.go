@@ -16,27 +16,48 @@ package event
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/core/types"
 	"go.uber.org/zap"
 
+	"powervoting-server/constant"
 	"powervoting-server/model"
 )
 
 func (ev *Event) HandleOracleUpdateGistId(ctx context.Context, event OracleUpdateGistIdsEvent, chainId int64, blockHeader *types.Header) error {
 	zap.L().Info("oracle update gist ids event handled", zap.String("voter", event.VoterAddress.Hex()), zap.String("gist id", event.GistId))
 
-	if err := ev.SyncService.UpdateVoterAndProposalGithubNameByGistInfo(ctx, &model.VoterInfoTbl{
+	// The identity of a replayed voter is recorded, its event was emitted when the log was synced
+	if ev.replay != nil {
+		return ev.restoreVoter(ctx, event.VoterAddress.Hex())
+	}
+
+	voterInfo := model.VoterInfoTbl{
 		Address:     event.VoterAddress.Hex(),
 		GistId:      event.GistId,
 		ChainId:     chainId,
 		BlockNumber: blockHeader.Number.Int64(),
 		Timestamp:   int64(blockHeader.Time),
-	}); err != nil {
+	}
+	if err := ev.SyncService.UpdateVoterAndProposalGithubNameByGistInfo(ctx, &voterInfo); err != nil {
 		zap.L().Error("func HandleOracleUpdateGistId: failed to update voter by gist info", zap.Error(err))
 		return err
 	}
 
+	// The gist is cleared when it does not prove the identity of the voter
+	if voterInfo.GistId != "" {
+		if err := ev.emitWebhookEvent(ctx, 0, constant.WebhookVoterIdentityBound, fmt.Sprintf("voter:%s:gist:%s", voterInfo.Address, voterInfo.GistId),
+			voterInfo.Timestamp, model.WebhookVoterData{
+				Address:     voterInfo.Address,
+				GistId:      voterInfo.GistId,
+				GithubName:  voterInfo.GithubName,
+				BlockNumber: voterInfo.BlockNumber,
+			}); err != nil {
+			return fmt.Errorf("emit %s webhook event error: %w", constant.WebhookVoterIdentityBound, err)
+		}
+	}
+
 	zap.L().Info("oracle update gist ids event handled successfully")
 	return nil
 }
@@ -44,7 +65,11 @@ func (ev *Event) HandleOracleUpdateGistId(ctx context.Context, event OracleUpdat
 func (ev *Event) HandleOracleUpdateMinerIds(ctx context.Context, event OracleUpdateMinerIdsEvent, blockHeader *types.Header) error {
 	zap.L().Info("oracle update miner ids event handled", zap.String("voter", event.VoterAddress.Hex()), zap.Any("miner ids", event.MinerIds))
 
-	if err := ev.SyncService.UpdateVoterByMinerIds(ctx, event.VoterAddress.Hex(), event.MinerIds); err != nil {
+	if ev.replay != nil {
+		return ev.restoreVoter(ctx, event.VoterAddress.Hex())
+	}
+
+	if err := ev.SyncService.UpdateVoterByMinerIds(ctx, ev.Client.ChainId, event.VoterAddress.Hex(), event.MinerIds); err != nil {
 		zap.L().Error("failed to update voter by miner ids", zap.Error(err))
 		return err
 	}
@@ -24,8 +24,10 @@ import (
 	"go.uber.org/zap"
 
 	snapshot "powervoting-server/api/rpc"
+	"powervoting-server/config"
 	"powervoting-server/constant"
 	"powervoting-server/model"
+	"powervoting-server/utils"
 )
 
 func (ev *Event) HandleProposalCreate(ctx context.Context, event ProposalCreateEvent, blockHeader *types.Header) error {
@@ -43,6 +45,16 @@ func (ev *Event) HandleProposalCreate(ctx context.Context, event ProposalCreateE
 			ClientPercentage:      event.Proposal.ClientPercentage,
 			DeveloperPercentage:   event.Proposal.DeveloperPercentage,
 		},
+		// The proposal event does not carry options, a proposal declares them in its content
+		VoteOptions: utils.ParseVoteOptions(event.Proposal.Content),
+		// Record the quorum rules in force, later configuration changes do not apply to existing proposals
+		QuorumRule: model.QuorumRule{
+			QuorumMinVoters:        config.Client.Quorum.MinVoters,
+			QuorumSpShare:          config.Client.Quorum.SpPowerShare,
+			QuorumClientShare:      config.Client.Quorum.ClientPowerShare,
+			QuorumTokenHolderShare: config.Client.Quorum.TokenHolderPowerShare,
+			QuorumDeveloperShare:   config.Client.Quorum.DeveloperPowerShare,
+		},
 		Timestamp:   int64(blockHeader.Time),
 		BlockNumber: blockHeader.Number.Int64(),
 		ChainId:     ev.Client.ChainId,
@@ -62,6 +74,31 @@ func (ev *Event) HandleProposalCreate(ctx context.Context, event ProposalCreateE
 		zap.Int64("snapshot timestamp", event.Proposal.SnapshotTimestamp.Int64()),
 	)
 
+	// A replayed proposal keeps what was recorded when it was synced
+	if ev.replay != nil {
+		if err := ev.replay.replayProposal(&data); err != nil {
+			return fmt.Errorf("parse %s event error: %w", constant.ProposalEvt, err)
+		}
+	} else if err := ev.recordProposalSettings(&data, event, blockHeader); err != nil {
+		return fmt.Errorf("parse %s event error: %w", constant.ProposalEvt, err)
+	}
+
+	if err := ev.SyncService.AddProposal(ctx, &data); err != nil {
+		return fmt.Errorf("parse %s event error: %w", constant.ProposalEvt, err)
+	}
+	zap.L().Info("Sync proposal event success", zap.Int64("proposal id", event.Id.Int64()))
+
+	if err := ev.emitWebhookEvent(ctx, data.ProposalId, constant.WebhookProposalCreated, fmt.Sprintf("proposal:%d", data.ProposalId),
+		data.Timestamp, model.NewWebhookProposalData(data)); err != nil {
+		return fmt.Errorf("emit %s webhook event error: %w", constant.WebhookProposalCreated, err)
+	}
+
+	return nil
+}
+
+// recordProposalSettings records the snapshot of a synced proposal and the counting algorithm in force when it was created.
+// It fails when the algorithm can not be read, so that the event is retried instead of counting with the wrong algorithm.
+func (ev *Event) recordProposalSettings(data *model.ProposalTbl, event ProposalCreateEvent, blockHeader *types.Header) error {
 	snapshotday := carbon.CreateFromTimestamp(event.Proposal.SnapshotTimestamp.Int64()).ToShortDateString()
 	snapshotInfo, err := snapshot.UploadSnapshotInfo(ev.Client.ChainId, snapshotday)
 	if err != nil {
@@ -78,10 +115,18 @@ func (ev *Event) HandleProposalCreate(ctx context.Context, event ProposalCreateE
 	data.SnapshotBlockHeight = snapshotInfo.Height
 	data.SnapshotDay = snapshotday
 
-	if err = ev.SyncService.AddProposal(ctx, &data); err != nil {
-		return fmt.Errorf("parse %s event error: %w", constant.ProposalEvt, err)
+	// Record the counting algorithm configured when the proposal was created,
+	// a network without the PowerVotingConf contract counts with the default algorithm
+	if !ev.confEnabled() {
+		return nil
 	}
-	zap.L().Info("Sync proposal event success", zap.Int64("proposal id", event.Id.Int64()))
+
+	algorithm, err := utils.GetVotingCountingAlgorithm(ev.Client, blockHeader.Number)
+	if err != nil {
+		zap.L().Error("get voting counting algorithm error", zap.Int64("proposal id", event.Id.Int64()), zap.Error(err))
+		return fmt.Errorf("get voting counting algorithm error: %w", err)
+	}
+	data.CountingAlgorithm = algorithm
 
 	return nil
 }
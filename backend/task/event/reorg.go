@@ -0,0 +1,217 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"go.uber.org/zap"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+)
+
+// chainBlock is the part of a block returned by eth_getBlockByNumber that is needed to follow the chain.
+// The hash is read from the node instead of computed from the header, because the Filecoin block hash
+// is not the hash of the Ethereum header fields.
+type chainBlock struct {
+	Number     hexutil.Uint64 `json:"number"`
+	Hash       common.Hash    `json:"hash"`
+	ParentHash common.Hash    `json:"parentHash"`
+	Timestamp  hexutil.Uint64 `json:"timestamp"`
+}
+
+// confirmationDepth returns the number of blocks behind the chain head that are synced.
+func (ev *Event) confirmationDepth() int64 {
+	if ev.Network.ConfirmationDepth <= 0 {
+		return constant.SyncConfirmationDepth
+	}
+
+	return ev.Network.ConfirmationDepth
+}
+
+// blockByNumber returns the block at a height, or nil when the height is a null round.
+func (ev *Event) blockByNumber(ctx context.Context, height int64) (*chainBlock, error) {
+	var block *chainBlock
+	err := ev.Client.Client.Client().CallContext(ctx, &block, "eth_getBlockByNumber", hexutil.EncodeUint64(uint64(height)), false)
+	if err != nil {
+		if strings.Contains(err.Error(), "null round") {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return block, nil
+}
+
+// lastBlockAtOrBelow returns the highest block at or below a height, skipping null rounds.
+// It returns nil when every height searched is a null round.
+func (ev *Event) lastBlockAtOrBelow(ctx context.Context, height int64) (*chainBlock, error) {
+	for h := height; h > 0 && h > height-constant.SyncNullRoundLimit; h-- {
+		block, err := ev.blockByNumber(ctx, h)
+		if err != nil || block != nil {
+			return block, err
+		}
+	}
+
+	return nil, nil
+}
+
+// firstBlockAbove returns the lowest block above a height and at or below the chain head, skipping null rounds.
+// It returns nil when every height searched is a null round.
+func (ev *Event) firstBlockAbove(ctx context.Context, height, head int64) (*chainBlock, error) {
+	for h := height + 1; h <= head && h <= height+constant.SyncNullRoundLimit; h++ {
+		block, err := ev.blockByNumber(ctx, h)
+		if err != nil || block != nil {
+			return block, err
+		}
+	}
+
+	return nil, nil
+}
+
+// checkReorg compares the blocks recorded by the previous syncs with the chain.
+// The latest recorded block must still be on the chain, and the next block must be its child.
+// When the chain was reorganized, everything synced above the last recorded block that is still on the chain
+// is rolled back, so that the events of the new chain are synced from there.
+//
+// Parameters:
+//   - ctx: Context for request cancellation and timeout.
+//   - syncedHeight: The height synced by the previous syncs.
+//   - head: The height of the chain head.
+//
+// Returns:
+//   - bool: Whether the sync was rolled back.
+//   - error: An error if the chain or the recorded blocks can not be read, or the rollback fails; otherwise, nil.
+func (ev *Event) checkReorg(ctx context.Context, syncedHeight, head int64) (bool, error) {
+	recorded, err := ev.SyncService.GetSyncBlocks(ctx, ev.Client.ChainId, syncedHeight, constant.SyncReorgSearchLimit)
+	if err != nil || len(recorded) == 0 {
+		return false, err
+	}
+
+	latest := recorded[0]
+	block, err := ev.blockByNumber(ctx, latest.Height)
+	if err != nil {
+		return false, err
+	}
+
+	if block != nil && block.Hash.Hex() == latest.Hash {
+		// The tip checkpoint is the last block of the synced range, the next block must build on it
+		if latest.ParentHash == "" {
+			return false, nil
+		}
+
+		next, err := ev.firstBlockAbove(ctx, syncedHeight, head)
+		if err != nil || next == nil || next.ParentHash.Hex() == latest.Hash {
+			return false, err
+		}
+
+		zap.L().Warn("Parent hash of the next block changed",
+			zap.Int64("height", int64(next.Number)),
+			zap.String("parent hash", next.ParentHash.Hex()),
+			zap.String("recorded hash", latest.Hash))
+
+		// The latest recorded block is not the parent of the chain anymore, even if the node still returns it
+		recorded = recorded[1:]
+	}
+
+	height := latest.Height - 1
+	if len(recorded) > 0 {
+		if height, err = ev.commonAncestor(ctx, recorded); err != nil {
+			return false, err
+		}
+	}
+
+	zap.L().Warn("Chain reorganization detected, rolling back the sync",
+		zap.Int64("synced height", syncedHeight),
+		zap.Int64("rollback height", height))
+
+	if err := ev.SyncService.RollbackSync(ctx, ev.Network.PowerVotingContract, ev.Client.ChainId, height); err != nil {
+		return false, err
+	}
+	// The blocks above the rollback height are replaced by the blocks of the new chain
+	ev.headerCache().DropFrom(uint64(height) + 1)
+
+	return true, nil
+}
+
+// commonAncestor returns the height of the highest recorded block that is still on the chain.
+// When none of the recorded blocks is on the chain, the height below the oldest of them is returned.
+func (ev *Event) commonAncestor(ctx context.Context, recorded []model.SyncBlockTbl) (int64, error) {
+	for _, synced := range recorded {
+		block, err := ev.blockByNumber(ctx, synced.Height)
+		if err != nil {
+			return 0, err
+		}
+
+		if block != nil && block.Hash.Hex() == synced.Hash {
+			return synced.Height, nil
+		}
+	}
+
+	oldest := recorded[len(recorded)-1].Height - 1
+	zap.L().Error("No recorded block is on the chain, rolling back below the oldest recorded block",
+		zap.Int("recorded blocks", len(recorded)),
+		zap.Int64("rollback height", oldest))
+
+	return oldest, nil
+}
+
+// recordSyncedBlocks records the blocks of the event logs and the last block of the synced range,
+// so that the next sync can detect a reorganization of these blocks.
+func (ev *Event) recordSyncedBlocks(ctx context.Context, logs []types.Log, endBlock int64) error {
+	blocks := make(map[int64]model.SyncBlockTbl)
+	for _, vLog := range logs {
+		// The logs of the indexer do not have the hash of their block
+		if vLog.Removed || vLog.BlockHash == (common.Hash{}) {
+			continue
+		}
+
+		blocks[int64(vLog.BlockNumber)] = model.SyncBlockTbl{
+			ChainId: ev.Client.ChainId,
+			Height:  int64(vLog.BlockNumber),
+			Hash:    vLog.BlockHash.Hex(),
+		}
+	}
+
+	checkpoint, err := ev.lastBlockAtOrBelow(ctx, endBlock)
+	if err != nil {
+		return err
+	}
+
+	if checkpoint != nil {
+		blocks[int64(checkpoint.Number)] = model.SyncBlockTbl{
+			ChainId:    ev.Client.ChainId,
+			Height:     int64(checkpoint.Number),
+			Hash:       checkpoint.Hash.Hex(),
+			ParentHash: checkpoint.ParentHash.Hex(),
+		}
+	}
+
+	res := make([]model.SyncBlockTbl, 0, len(blocks))
+	for _, block := range blocks {
+		res = append(res, block)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Height < res[j].Height
+	})
+
+	return ev.SyncService.AddSyncBlocks(ctx, res, endBlock-constant.SyncBlockRetention)
+}
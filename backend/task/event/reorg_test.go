@@ -0,0 +1,263 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/config"
+	"powervoting-server/constant"
+	"powervoting-server/mock"
+	"powervoting-server/model"
+)
+
+// fakeChain serves eth_getBlockByNumber from a map of blocks or headers, the heights without a block are null rounds.
+// Batched calls are served too.
+func fakeChain(t *testing.T, blocks map[uint64]any) *ethclient.Client {
+	type request struct {
+		Id     json.RawMessage `json:"id"`
+		Params []any           `json:"params"`
+	}
+	respond := func(req request) map[string]any {
+		height, err := hexutil.DecodeUint64(req.Params[0].(string))
+		assert.NoError(t, err)
+
+		res := map[string]any{"jsonrpc": "2.0", "id": req.Id}
+		if block, ok := blocks[height]; ok {
+			res["result"] = block
+		} else {
+			res["error"] = map[string]any{"code": 1, "message": "requested epoch was a null round"}
+		}
+		return res
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		if bytes.HasPrefix(bytes.TrimSpace(body), []byte("[")) {
+			var batch []request
+			assert.NoError(t, json.Unmarshal(body, &batch))
+			res := make([]map[string]any, 0, len(batch))
+			for _, req := range batch {
+				res = append(res, respond(req))
+			}
+			assert.NoError(t, json.NewEncoder(w).Encode(res))
+			return
+		}
+
+		var req request
+		assert.NoError(t, json.Unmarshal(body, &req))
+		assert.NoError(t, json.NewEncoder(w).Encode(respond(req)))
+	}))
+	t.Cleanup(server.Close)
+
+	client, err := ethclient.Dial(server.URL)
+	assert.NoError(t, err)
+
+	return client
+}
+
+func block(number uint64, hash, parent string) chainBlock {
+	return chainBlock{Number: hexutil.Uint64(number), Hash: common.HexToHash(hash), ParentHash: common.HexToHash(parent)}
+}
+
+func synced(height int64, hash, parent string) model.SyncBlockTbl {
+	res := model.SyncBlockTbl{ChainId: 314159, Height: height, Hash: common.HexToHash(hash).Hex()}
+	if parent != "" {
+		res.ParentHash = common.HexToHash(parent).Hex()
+	}
+
+	return res
+}
+
+type reorgSyncService struct {
+	mock.MockSyncService
+	recorded   []model.SyncBlockTbl
+	rollbackTo int64
+	rolledBack bool
+	pruneBelow int64
+	recordErr  error
+	syncedTo   int64
+}
+
+func (s *reorgSyncService) GetSyncBlocks(ctx context.Context, chainId, maxHeight int64, limit int) ([]model.SyncBlockTbl, error) {
+	return s.recorded, nil
+}
+
+func (s *reorgSyncService) RollbackSync(ctx context.Context, addr string, chainId, height int64) error {
+	s.rolledBack = true
+	s.rollbackTo = height
+
+	return nil
+}
+
+func (s *reorgSyncService) AddSyncBlocks(ctx context.Context, blocks []model.SyncBlockTbl, pruneBelow int64) error {
+	if s.recordErr != nil {
+		return s.recordErr
+	}
+	s.recorded = blocks
+	s.pruneBelow = pruneBelow
+
+	return nil
+}
+
+func (s *reorgSyncService) UpdateSyncEventInfo(ctx context.Context, chainId int64, addr string, height int64) error {
+	s.syncedTo = height
+
+	return nil
+}
+
+func newReorgEvent(t *testing.T, blocks map[uint64]any, recorded []model.SyncBlockTbl) (*Event, *reorgSyncService) {
+	syncService := &reorgSyncService{recorded: recorded}
+
+	return &Event{
+		Client:      &model.GoEthClient{ChainId: 314159, Client: fakeChain(t, blocks)},
+		SyncService: syncService,
+		Network:     &config.Network{PowerVotingContract: "0x1"},
+	}, syncService
+}
+
+func TestCheckReorgSameChain(t *testing.T) {
+	blocks := map[uint64]any{
+		100: block(100, "0xa", "0x9"),
+		// 101 is a null round
+		102: block(102, "0xc", "0xa"),
+	}
+	ev, syncService := newReorgEvent(t, blocks, []model.SyncBlockTbl{synced(100, "0xa", "0x9")})
+
+	rolledBack, err := ev.checkReorg(context.Background(), 101, 102)
+	assert.NoError(t, err)
+	assert.False(t, rolledBack)
+	assert.False(t, syncService.rolledBack)
+}
+
+func TestCheckReorgChangedParent(t *testing.T) {
+	blocks := map[uint64]any{
+		98:  block(98, "0x8", "0x7"),
+		100: block(100, "0xa", "0x8"),
+		// the next block builds on another block 100
+		101: block(101, "0xb", "0xaa"),
+	}
+	ev, syncService := newReorgEvent(t, blocks, []model.SyncBlockTbl{
+		synced(100, "0xa", "0x8"),
+		synced(98, "0x8", ""),
+	})
+
+	rolledBack, err := ev.checkReorg(context.Background(), 100, 101)
+	assert.NoError(t, err)
+	assert.True(t, rolledBack)
+	// block 100 is still returned by the node but it is not the parent of the chain anymore
+	assert.Equal(t, int64(98), syncService.rollbackTo)
+}
+
+func TestCheckReorgOrphanedBlocks(t *testing.T) {
+	blocks := map[uint64]any{
+		95:  block(95, "0x5", "0x4"),
+		98:  block(98, "0x88", "0x5"),
+		100: block(100, "0xaa", "0x88"),
+	}
+	ev, syncService := newReorgEvent(t, blocks, []model.SyncBlockTbl{
+		synced(100, "0xa", "0x8"),
+		synced(98, "0x8", ""),
+		synced(95, "0x5", ""),
+	})
+
+	rolledBack, err := ev.checkReorg(context.Background(), 100, 100)
+	assert.NoError(t, err)
+	assert.True(t, rolledBack)
+	assert.Equal(t, int64(95), syncService.rollbackTo)
+
+	// no recorded block is on the chain anymore
+	ev, syncService = newReorgEvent(t, blocks, []model.SyncBlockTbl{
+		synced(100, "0xa", "0x8"),
+		synced(98, "0x8", ""),
+	})
+
+	rolledBack, err = ev.checkReorg(context.Background(), 100, 100)
+	assert.NoError(t, err)
+	assert.True(t, rolledBack)
+	assert.Equal(t, int64(97), syncService.rollbackTo)
+}
+
+func TestCheckReorgNothingRecorded(t *testing.T) {
+	ev, syncService := newReorgEvent(t, nil, nil)
+
+	rolledBack, err := ev.checkReorg(context.Background(), 100, 120)
+	assert.NoError(t, err)
+	assert.False(t, rolledBack)
+	assert.False(t, syncService.rolledBack)
+}
+
+func TestRecordSyncedBlocks(t *testing.T) {
+	blocks := map[uint64]any{
+		120: block(120, "0xc", "0xb"),
+	}
+	ev, syncService := newReorgEvent(t, blocks, nil)
+
+	logs := []types.Log{
+		{BlockNumber: 110, BlockHash: common.HexToHash("0x1")},
+		{BlockNumber: 110, BlockHash: common.HexToHash("0x1")},
+		{BlockNumber: 115, BlockHash: common.HexToHash("0x2"), Removed: true},
+		{BlockNumber: 105, BlockHash: common.HexToHash("0x3")},
+	}
+	// 121 and 122 are null rounds, the last synced block is 120
+	assert.NoError(t, ev.recordSyncedBlocks(context.Background(), logs, 122))
+
+	assert.Equal(t, []model.SyncBlockTbl{
+		synced(105, "0x3", ""),
+		synced(110, "0x1", ""),
+		synced(120, "0xc", "0xb"),
+	}, syncService.recorded)
+	assert.Equal(t, int64(122-constant.SyncBlockRetention), syncService.pruneBelow)
+}
+
+func TestApplyRangeRecordsSyncedBlocks(t *testing.T) {
+	blocks := map[uint64]any{
+		120: block(120, "0xc", "0xb"),
+	}
+	ev, syncService := newReorgEvent(t, blocks, nil)
+
+	// the height is not moved when the synced blocks can not be recorded
+	syncService.recordErr = errors.New("record error")
+	assert.Error(t, ev.applyRange(context.Background(), nil, 120, nil))
+	assert.Zero(t, syncService.syncedTo)
+
+	syncService.recordErr = nil
+	assert.NoError(t, ev.applyRange(context.Background(), nil, 120, nil))
+	assert.Equal(t, int64(120), syncService.syncedTo)
+	assert.Equal(t, []model.SyncBlockTbl{synced(120, "0xc", "0xb")}, syncService.recorded)
+}
+
+func TestConfirmationDepth(t *testing.T) {
+	ev := &Event{Network: &config.Network{}}
+	assert.Equal(t, int64(constant.SyncConfirmationDepth), ev.confirmationDepth())
+
+	ev.Network.ConfirmationDepth = 5
+	assert.Equal(t, int64(5), ev.confirmationDepth())
+}
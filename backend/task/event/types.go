@@ -86,6 +86,41 @@ type OracleUpdateMinerIdsEvent struct {
 	MinerIds     []uint64
 }
 
+// Contract Event - GithubRepoAdded
+type GithubRepoAddedEvent struct {
+	Id       *big.Int       // repository id
+	RepoInfo GithubRepoInfo // repository info
+}
+
+// GithubRepoAdded info. Structure according to the field order of the Event, do not change the field position of this structure at will.
+type GithubRepoInfo struct {
+	RepoName string // The name of the repository
+	OrgType  uint8  // 0: core Filecoin org, 1: ecosystem org, 2: GitHub user
+}
+
+// Contract Event - GithubRepoRemoved
+type GithubRepoRemovedEvent struct {
+	Id *big.Int // repository id
+}
+
+// Contract Event - SnapshotDays
+type SnapshotDaysEvent struct {
+	DateStr string // The snapshot day, e.g. 20240101
+	Height  uint64 // The block height of the snapshot
+}
+
+// Contract Event - SnapshotExpirationDay
+type SnapshotExpirationDayEvent struct {
+	OldExp *big.Int // The snapshot expiration days before the change
+	NewExp *big.Int // The snapshot expiration days after the change
+}
+
+// Contract Event - VotingCountingAlgorithm
+type VotingCountingAlgorithmEvent struct {
+	OldAlgorithm string // The vote counting algorithm before the change
+	Algorithm    string // The vote counting algorithm after the change
+}
+
 type FilFoxRPCEvents struct {
 	TotalCount int64      `json:"totalCount"`
 	EventLogs  []EventLog `json:"eventLogs"`
@@ -26,14 +26,16 @@ import (
 	"powervoting-server/model"
 )
 
-func (ev *Event) HandleVote(ctx context.Context, event VoteEvent, blockHeader *types.Header) error {
-	voteData := model.VoteTbl{
+func (ev *Event) HandleVote(ctx context.Context, event VoteEvent, vLog types.Log, blockHeader *types.Header) error {
+	voteData := model.VoteHistoryTbl{
 		ProposalId:    event.Id.Int64(),
 		Address:       event.Voter.Hex(),
 		VoteEncrypted: event.VoteInfo,
 		ChainId:       ev.Client.ChainId,
 		Timestamp:     int64(blockHeader.Time),
 		BlockNumber:   blockHeader.Number.Int64(),
+		LogIndex:      vLog.Index,
+		TxHash:        vLog.TxHash.Hex(),
 		BaseField: model.BaseField{
 			CreatedAt: time.Unix(int64(blockHeader.Time), 0),
 		},
@@ -56,10 +58,26 @@ func (ev *Event) HandleVote(ctx context.Context, event VoteEvent, blockHeader *t
 		return fmt.Errorf("parse %s event error: %w", constant.VoteEvt, err)
 	}
 
-	if err := ev.SyncService.AddVoterAddress(ctx, &voterAddressData); err != nil {
+	if ev.replay != nil {
+		if err := ev.restoreVoter(ctx, voterAddressData.Address); err != nil {
+			return fmt.Errorf("parse %s event error: %w", constant.VoteEvt, err)
+		}
+	} else if err := ev.SyncService.AddVoterAddress(ctx, &voterAddressData); err != nil {
 		return fmt.Errorf("parse %s event error: %w", constant.VoteEvt, err)
 	}
 
+	if err := ev.emitWebhookEvent(ctx, voteData.ProposalId, constant.WebhookVoteCast, fmt.Sprintf("vote:%s:%d", voteData.TxHash, voteData.LogIndex),
+		voteData.Timestamp, model.WebhookVoteData{
+			ProposalId:    voteData.ProposalId,
+			Address:       voteData.Address,
+			VoteEncrypted: voteData.VoteEncrypted,
+			BlockNumber:   voteData.BlockNumber,
+			TxHash:        voteData.TxHash,
+			LogIndex:      voteData.LogIndex,
+		}); err != nil {
+		return fmt.Errorf("emit %s webhook event error: %w", constant.WebhookVoteCast, err)
+	}
+
 	zap.L().Info("Sync vote event success", zap.Int64("proposal id", event.Id.Int64()))
 	return nil
 }
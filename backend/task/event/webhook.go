@@ -0,0 +1,37 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+
+	"powervoting-server/model"
+	"powervoting-server/service"
+)
+
+// emitWebhookEvent emits a governance lifecycle event of the network for the webhooks and the stream, in the transaction of the synced logs.
+// Subjects identify what the event is about, an event is only emitted once for a subject and block.
+// The proposal ID is 0 for the events that are not about a proposal.
+// The event keeps the block of the applied log, so that it is withdrawn when the block is rolled back
+// and emitted again when the log is synced from another block.
+func (ev *Event) emitWebhookEvent(ctx context.Context, proposalId int64, event, subject string, timestamp int64, data any) error {
+	in, err := model.NewWebhookEvent(ev.Client.ChainId, proposalId, event, subject, timestamp, data)
+	if err != nil {
+		return err
+	}
+	in.BlockNumber, in.BlockHash, _ = service.SyncBlock(ctx)
+
+	return ev.SyncService.AddWebhookEvent(ctx, in)
+}
@@ -0,0 +1,73 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package event
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/constant"
+	"powervoting-server/mock"
+	"powervoting-server/model"
+	"powervoting-server/service"
+)
+
+// webhookSyncService records the webhook events emitted by the event handlers.
+type webhookSyncService struct {
+	mock.MockSyncService
+	events []model.WebhookEventTbl
+}
+
+func (s *webhookSyncService) AddVote(ctx context.Context, in *model.VoteHistoryTbl) error {
+	return nil
+}
+
+func (s *webhookSyncService) AddVoterAddress(ctx context.Context, in *model.VoterInfoTbl) error {
+	return nil
+}
+
+func (s *webhookSyncService) AddWebhookEvent(ctx context.Context, in *model.WebhookEventTbl) error {
+	s.events = append(s.events, *in)
+	return nil
+}
+
+func TestHandleVoteEmitsWebhookEvent(t *testing.T) {
+	syncService := &webhookSyncService{}
+	ev := &Event{
+		Client:      &model.GoEthClient{ChainId: 314159},
+		SyncService: syncService,
+	}
+
+	vLog := types.Log{TxHash: common.HexToHash("0x1"), Index: 3}
+	header := &types.Header{Number: big.NewInt(10), Time: 1700000000}
+	event := VoteEvent{Id: big.NewInt(7), Voter: common.HexToAddress("0xa"), VoteInfo: "cipher"}
+	assert.NoError(t, ev.HandleVote(service.WithSyncBlock(context.Background(), 10, "0xb"), event, vLog, header))
+
+	assert.Len(t, syncService.events, 1)
+	emitted := syncService.events[0]
+	assert.Equal(t, constant.WebhookVoteCast, emitted.Event)
+	assert.Equal(t, int64(7), emitted.ProposalId)
+	assert.Equal(t, int64(314159), emitted.ChainId)
+	assert.Equal(t, "vote:"+vLog.TxHash.Hex()+":3", emitted.Subject)
+	assert.Equal(t, int64(1700000000), emitted.Timestamp)
+	assert.Equal(t, int64(10), emitted.BlockNumber)
+	assert.Equal(t, "0xb", emitted.BlockHash)
+	assert.JSONEq(t, `{"proposalId":7,"address":"`+event.Voter.Hex()+`","voteEncrypted":"cipher","blockNumber":10,"txHash":"`+vLog.TxHash.Hex()+`","logIndex":3}`, emitted.Data)
+}
@@ -26,19 +26,19 @@ import (
 	"powervoting-server/task/event"
 )
 
-// SyncEventHandler handles the synchronization of contract events across multiple networks.
-// It initializes a goroutine for each network to subscribe to and process contract events.
-// Errors encountered during synchronization are collected and logged at the end.
+// SyncEventHandler handles the synchronization of the contract events of a network.
+// The scheduler runs it for every configured network, each network is synced independently.
+// Errors encountered during synchronization are logged.
 //
 // Parameters:
 //   - syncService: The sync service used to manage synchronization operations.
-func SyncEventHandler(syncService *service.SyncService) {
-	network := config.Client.Network
-
+//   - network: The network whose contract events are synced.
+//   - headers: The cache of the block headers of the network.
+func SyncEventHandler(syncService *service.SyncService, network config.Network, headers *event.HeaderCache) {
 	// Get the Ethereum client for the current network
 	ethClient, err := data.GetClient(syncService, network.ChainId)
 	if err != nil {
-		zap.L().Error("get go-eth client error:", zap.Error(err))
+		zap.L().Error("get go-eth client error:", zap.Int64("network id", network.ChainId), zap.Error(err))
 		return
 	}
 
@@ -47,13 +47,16 @@ func SyncEventHandler(syncService *service.SyncService) {
 		SyncService: syncService,
 		Network:     &network,
 		Client:      ethClient,
+		Headers:     headers,
 	}
 
 	// Subscribe to contract events for the current network
-	if err := syncEvent.SubscribeEvent(); err != nil {
-		if errors.Is(err, constant.ErrAlreadySyncHeight) {
-			return
-		}
-		zap.L().Error("sync finished with err:", zap.Error(err))
+	if err := syncEvent.SubscribeEvent(); err != nil && !errors.Is(err, constant.ErrAlreadySyncHeight) {
+		zap.L().Error("sync finished with err:", zap.Int64("network id", network.ChainId), zap.Error(err))
+	}
+
+	// Retry the event logs that failed in the previous syncs
+	if err := syncEvent.ReplayDeadLetters(); err != nil {
+		zap.L().Error("replay dead letters finished with err:", zap.Int64("network id", network.ChainId), zap.Error(err))
 	}
 }
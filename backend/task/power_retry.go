@@ -0,0 +1,106 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"sort"
+
+	"go.uber.org/zap"
+
+	snapshot "powervoting-server/api/rpc"
+	"powervoting-server/config"
+	"powervoting-server/model"
+	"powervoting-server/utils"
+)
+
+const (
+	defaultPowerRetryInterval    = 10 * 60          // 10 minutes, two runs of the counting task
+	defaultPowerRetryMaxInterval = 6 * 60 * 60      // 6 hours
+	defaultPowerDeadline         = 3 * 24 * 60 * 60 // 3 days after the proposal end time
+)
+
+// powerRetryPolicy returns the counting configuration with the defaults for the values that are not set.
+func powerRetryPolicy() config.Counting {
+	policy := config.Client.Counting
+	if policy.PowerRetryInterval <= 0 {
+		policy.PowerRetryInterval = defaultPowerRetryInterval
+	}
+	if policy.PowerRetryMaxInterval <= 0 {
+		policy.PowerRetryMaxInterval = defaultPowerRetryMaxInterval
+	}
+	if policy.PowerDeadline <= 0 {
+		policy.PowerDeadline = defaultPowerDeadline
+	}
+
+	return policy
+}
+
+// nextPowerRetry returns the time of the next count of a proposal that has been deferred the given number of times.
+// The interval doubles after every attempt, up to the maximum interval.
+func nextPowerRetry(policy config.Counting, retries int, now int64) int64 {
+	return utils.NextBackoff(retries, now, policy.PowerRetryInterval, policy.PowerRetryMaxInterval)
+}
+
+// powerDeadlinePassed checks whether a proposal has waited long enough for missing voter power
+// and must be counted with the missing power as zero.
+func powerDeadlinePassed(policy config.Counting, proposal model.ProposalTbl, now int64) bool {
+	return now >= proposal.EndTime+policy.PowerDeadline
+}
+
+// missingPowerVoters returns the sorted addresses of the voters that have no power in the snapshot.
+func missingPowerVoters(votes []model.VoteTbl, powersMap map[string]model.AddrPower) []string {
+	var missing []string
+	for _, vote := range votes {
+		if _, ok := powersMap[vote.Address]; !ok {
+			missing = append(missing, vote.Address)
+		}
+	}
+	sort.Strings(missing)
+
+	return missing
+}
+
+// deferCounting leaves a proposal uncounted until its next retry because voter power is missing.
+// The snapshot service is asked to sync the power of the missing voters, so that it is in the snapshot at the next retry.
+//
+// Parameters:
+//   - proposal: The proposal that can not be counted yet.
+//   - missing: The voters without power in the snapshot, nil when the snapshot could not be fetched.
+//   - now: The current unix time.
+//
+// Returns:
+//   - error: An error if the deferred counting state can not be saved; otherwise, nil.
+func (vc *VoteCount) deferCounting(proposal model.ProposalTbl, missing []string, now int64) error {
+	for _, address := range missing {
+		// The error is logged by the client, the voter stays missing until the next retry
+		_ = snapshot.SyncAddressPower(proposal.ChainId, address)
+	}
+
+	policy := powerRetryPolicy()
+	proposal.AwaitingPower = true
+	proposal.PowerRetries++
+	proposal.NextCountTime = nextPowerRetry(policy, proposal.PowerRetries, now)
+	proposal.MissingPowerVoters = missing
+
+	zap.L().Warn("Proposal counting deferred, waiting for voter power",
+		zap.Int64("proposal ID", proposal.ProposalId),
+		zap.Strings("missing voters", missing),
+		zap.Int("retries", proposal.PowerRetries),
+		zap.Int64("next count time", proposal.NextCountTime),
+		zap.Int64("deadline", proposal.EndTime+policy.PowerDeadline))
+
+	return vc.SyncService.UpdateProposalPowerRetry(context.Background(), &proposal)
+}
@@ -0,0 +1,74 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/config"
+	"powervoting-server/model"
+	"powervoting-server/utils"
+)
+
+func TestNextPowerRetry(t *testing.T) {
+	policy := config.Counting{PowerRetryInterval: 100, PowerRetryMaxInterval: 350, PowerDeadline: 1000}
+
+	assert.Equal(t, int64(1100), nextPowerRetry(policy, 1, 1000))
+	assert.Equal(t, int64(1200), nextPowerRetry(policy, 2, 1000))
+	// capped at the maximum interval
+	assert.Equal(t, int64(1350), nextPowerRetry(policy, 3, 1000))
+	assert.Equal(t, int64(1350), nextPowerRetry(policy, 30, 1000))
+}
+
+func TestPowerDeadlinePassed(t *testing.T) {
+	policy := config.Counting{PowerDeadline: 1000}
+	proposal := model.ProposalTbl{EndTime: 5000}
+
+	assert.False(t, powerDeadlinePassed(policy, proposal, 5999))
+	assert.True(t, powerDeadlinePassed(policy, proposal, 6000))
+}
+
+func TestPowerRetryPolicyDefaults(t *testing.T) {
+	config.GetDefaultConfig()
+
+	policy := powerRetryPolicy()
+	assert.Equal(t, int64(defaultPowerRetryInterval), policy.PowerRetryInterval)
+	assert.Equal(t, int64(defaultPowerRetryMaxInterval), policy.PowerRetryMaxInterval)
+	assert.Equal(t, int64(defaultPowerDeadline), policy.PowerDeadline)
+}
+
+func TestMissingPowerVoters(t *testing.T) {
+	powersMap := utils.PowersInfoToMap(mockPower())
+	votes := []model.VoteTbl{
+		{Address: "0xb"},
+		{Address: "0x1234567890123456789012345678901234567890"},
+		{Address: "0xa"},
+	}
+
+	assert.Equal(t, []string{"0xa", "0xb"}, missingPowerVoters(votes, powersMap))
+	assert.Empty(t, missingPowerVoters(votes[1:2], powersMap))
+}
+
+func TestZeroPowerVoters(t *testing.T) {
+	voteList := []model.VoteTbl{
+		{Address: "0xb", PowerMissing: true},
+		{Address: "0xc"},
+		{Address: "0xa", PowerMissing: true},
+	}
+
+	assert.Equal(t, model.StringSlice{"0xa", "0xb"}, zeroPowerVoters(voteList))
+}
@@ -0,0 +1,134 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shopspring/decimal"
+	"go.uber.org/zap"
+
+	"powervoting-server/constant"
+	"powervoting-server/model"
+)
+
+// basisPoints is the scale of the quorum power shares, 10000 = 100%
+var basisPoints = decimal.NewFromInt(10000)
+
+// electoratePower calculates the total power that could have voted on a proposal in each category.
+// The SP power is the network raw byte power from Lotus at the snapshot height, it is only queried when the
+// proposal has an SP power quorum. The other categories sum the power of all addresses in the snapshot.
+//
+// Parameters:
+//   - proposal: The proposal being counted, with its quorum rules and snapshot height.
+//   - allPowers: The power of all addresses at the proposal snapshot day.
+//
+// Returns:
+//   - model.VoterPowerCount: The total power of each category.
+//   - error: An error if the network power cannot be retrieved; otherwise, nil.
+func (vc *VoteCount) electoratePower(proposal model.ProposalTbl, allPowers model.SnapshotAllPower) (model.VoterPowerCount, error) {
+	electorate := newVoterPowerCount()
+	for _, power := range allPowers.AddrPower {
+		if power.ClientPower != nil {
+			electorate.ClientPower = electorate.ClientPower.Add(decimal.NewFromBigInt(power.ClientPower, 0))
+		}
+		if power.TokenHolderPower != nil {
+			electorate.TokenPower = electorate.TokenPower.Add(decimal.NewFromBigInt(power.TokenHolderPower, 0))
+		}
+		if power.DeveloperPower != nil {
+			electorate.DeveloperPower = electorate.DeveloperPower.Add(decimal.NewFromBigInt(power.DeveloperPower, 0))
+		}
+	}
+
+	if proposal.QuorumSpShare > 0 {
+		networkPower, err := vc.SyncService.GetNetworkPower(context.Background(), proposal.ChainId, proposal.SnapshotBlockHeight)
+		if err != nil {
+			return model.VoterPowerCount{}, fmt.Errorf("get network power at height %d: %w", proposal.SnapshotBlockHeight, err)
+		}
+		electorate.SpPower = decimal.NewFromBigInt(networkPower.RawBytePower, 0)
+	}
+
+	return electorate, nil
+}
+
+// meetsQuorum checks the counted votes against the quorum rules of a proposal.
+// A power rule is met when the voting power of its category reaches the required share of the electorate,
+// so a category without any power in the electorate can not meet a non zero rule.
+//
+// Parameters:
+//   - rule: The quorum rules recorded on the proposal.
+//   - voters: The number of counted votes.
+//   - participating: The power of the counted votes in each category.
+//   - electorate: The power that could have voted in each category.
+//
+// Returns:
+//   - bool: True if every rule is met.
+func meetsQuorum(rule model.QuorumRule, voters int, participating, electorate model.VoterPowerCount) bool {
+	if int64(voters) < rule.QuorumMinVoters {
+		zap.L().Info("quorum not met", zap.String("rule", "min voters"), zap.Int("voters", voters), zap.Int64("required", rule.QuorumMinVoters))
+		return false
+	}
+
+	shares := []struct {
+		name     string
+		required uint16
+		power    func(model.VoterPowerCount) decimal.Decimal
+	}{
+		{"sp", rule.QuorumSpShare, func(c model.VoterPowerCount) decimal.Decimal { return c.SpPower }},
+		{"client", rule.QuorumClientShare, func(c model.VoterPowerCount) decimal.Decimal { return c.ClientPower }},
+		{"token holder", rule.QuorumTokenHolderShare, func(c model.VoterPowerCount) decimal.Decimal { return c.TokenPower }},
+		{"developer", rule.QuorumDeveloperShare, func(c model.VoterPowerCount) decimal.Decimal { return c.DeveloperPower }},
+	}
+
+	for _, share := range shares {
+		if share.required == 0 {
+			continue
+		}
+
+		total := share.power(electorate)
+		if total.IsZero() {
+			zap.L().Info("quorum not met", zap.String("rule", share.name), zap.String("reason", "no power in electorate"))
+			return false
+		}
+
+		// voted / total >= required / 10000
+		voted := share.power(participating).Mul(basisPoints)
+		if voted.LessThan(total.Mul(decimal.NewFromInt(int64(share.required)))) {
+			zap.L().Info("quorum not met",
+				zap.String("rule", share.name),
+				zap.String("voted", share.power(participating).String()),
+				zap.String("total", total.String()),
+				zap.Uint16("required", share.required))
+			return false
+		}
+	}
+
+	return true
+}
+
+// proposalOutcome decides the outcome of a counted proposal.
+// A proposal passes when the quorum is met and strictly more voted to approve than to reject,
+// compared on the exact percentages so that rounding can never change the outcome.
+func proposalOutcome(quorumMet bool, percentages map[string]decimal.Decimal) string {
+	switch {
+	case !quorumMet:
+		return constant.ProposalOutcomeFailedQuorum
+	case percentages[constant.VoteApprove].GreaterThan(percentages[constant.VoteReject]):
+		return constant.ProposalOutcomePassed
+	default:
+		return constant.ProposalOutcomeRejected
+	}
+}
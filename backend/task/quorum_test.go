@@ -0,0 +1,82 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/constant"
+	"powervoting-server/mock"
+	"powervoting-server/model"
+)
+
+func TestMeetsQuorum(t *testing.T) {
+	participating := newVoterPowerCount()
+	participating.SpPower = decimal.NewFromInt(2000)
+	participating.TokenPower = decimal.NewFromInt(100)
+
+	electorate := newVoterPowerCount()
+	electorate.SpPower = decimal.NewFromInt(10000)
+	electorate.TokenPower = decimal.NewFromInt(1000)
+
+	// no rules
+	assert.True(t, meetsQuorum(model.QuorumRule{}, 1, participating, electorate))
+
+	// min voters
+	assert.False(t, meetsQuorum(model.QuorumRule{QuorumMinVoters: 2}, 1, participating, electorate))
+	assert.True(t, meetsQuorum(model.QuorumRule{QuorumMinVoters: 2}, 2, participating, electorate))
+
+	// 20% of the SP power voted
+	assert.True(t, meetsQuorum(model.QuorumRule{QuorumSpShare: 2000}, 1, participating, electorate))
+	assert.False(t, meetsQuorum(model.QuorumRule{QuorumSpShare: 2001}, 1, participating, electorate))
+
+	// 10% of the token holder power voted
+	assert.True(t, meetsQuorum(model.QuorumRule{QuorumTokenHolderShare: 1000}, 1, participating, electorate))
+	assert.False(t, meetsQuorum(model.QuorumRule{QuorumSpShare: 2000, QuorumTokenHolderShare: 1500}, 1, participating, electorate))
+
+	// no client power in the electorate
+	assert.False(t, meetsQuorum(model.QuorumRule{QuorumClientShare: 1}, 1, participating, electorate))
+}
+
+func TestProposalOutcome(t *testing.T) {
+	assert.Equal(t, constant.ProposalOutcomeFailedQuorum, proposalOutcome(false, map[string]decimal.Decimal{constant.VoteApprove: decimal.NewFromInt(100)}))
+	assert.Equal(t, constant.ProposalOutcomePassed, proposalOutcome(true, map[string]decimal.Decimal{constant.VoteApprove: decimal.NewFromInt(60), constant.VoteReject: decimal.NewFromInt(40)}))
+	assert.Equal(t, constant.ProposalOutcomeRejected, proposalOutcome(true, map[string]decimal.Decimal{constant.VoteApprove: decimal.NewFromInt(50), constant.VoteReject: decimal.NewFromInt(50)}))
+	assert.Equal(t, constant.ProposalOutcomeRejected, proposalOutcome(true, map[string]decimal.Decimal{constant.VoteAbstain: decimal.NewFromInt(100)}))
+	// a lead lost when rounding to 2 places still passes
+	assert.Equal(t, constant.ProposalOutcomePassed, proposalOutcome(true, map[string]decimal.Decimal{
+		constant.VoteApprove: decimal.RequireFromString("50.0001"),
+		constant.VoteReject:  decimal.RequireFromString("49.9999"),
+	}))
+}
+
+func TestElectoratePower(t *testing.T) {
+	vc := &VoteCount{SyncService: &mock.MockSyncService{}}
+
+	allPowers := model.SnapshotAllPower{AddrPower: mockPower()}
+	electorate, err := vc.electoratePower(model.ProposalTbl{}, allPowers)
+	assert.NoError(t, err)
+	assert.True(t, electorate.TokenPower.Equal(decimal.NewFromInt(10000)))
+	// the network power is only queried with an SP quorum
+	assert.True(t, electorate.SpPower.IsZero())
+
+	electorate, err = vc.electoratePower(model.ProposalTbl{QuorumRule: model.QuorumRule{QuorumSpShare: 1}}, allPowers)
+	assert.NoError(t, err)
+	assert.True(t, electorate.SpPower.Equal(decimal.NewFromBigInt(big.NewInt(10000), 0)))
+}
@@ -0,0 +1,64 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"powervoting-server/config"
+	"powervoting-server/data"
+	"powervoting-server/service"
+	"powervoting-server/task/event"
+)
+
+// RebuildHandler rebuilds the tables derived from the contract events of a configured network from the event journal.
+// The sync must not run while the tables are rebuilt.
+//
+// Parameters:
+//   - syncService: The service used to read the journal and save the rebuilt rows.
+//   - chainId: The chain ID of the network to rebuild, 0 for the primary network.
+//
+// Returns:
+//   - int: The number of event logs applied.
+//   - int: The number of event logs that failed and were saved as dead letters.
+//   - error: An error if the tables can not be rebuilt; otherwise, nil.
+func RebuildHandler(syncService *service.SyncService, chainId int64) (int, int, error) {
+	network, ok := config.GetNetwork(chainId)
+	if !ok {
+		return 0, 0, fmt.Errorf("network %d is not configured", chainId)
+	}
+
+	ethClient, err := data.GetClient(syncService, network.ChainId)
+	if err != nil {
+		return 0, 0, fmt.Errorf("get go-eth client: %w", err)
+	}
+
+	ev := &event.Event{
+		SyncService: syncService,
+		Network:     &network,
+		Client:      ethClient,
+	}
+
+	applied, failed, err := ev.Rebuild(context.Background())
+	if err != nil {
+		return 0, 0, err
+	}
+	zap.L().Info("Derived tables rebuilt from the event journal", zap.Int64("network id", network.ChainId), zap.Int("applied", applied), zap.Int("failed", failed))
+
+	return applied, failed, nil
+}
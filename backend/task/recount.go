@@ -0,0 +1,104 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	snapshot "powervoting-server/api/rpc"
+	"powervoting-server/config"
+	"powervoting-server/constant"
+	"powervoting-server/data"
+	"powervoting-server/model"
+	"powervoting-server/service"
+)
+
+// RecountHandler counts an already counted proposal of a configured network again.
+// The current result is kept in the result history with the reason of the recount,
+// then the snapshot power is fetched again, every vote is decrypted again and a new result revision is saved.
+//
+// Parameters:
+//   - syncService: The service used to read and save the proposal and its votes.
+//   - chainId: The chain ID of the proposal, 0 for the primary network.
+//   - proposalId: The ID of the proposal to recount.
+//   - reason: Why the proposal is recounted, it is saved with the replaced result.
+//
+// Returns:
+//   - error: An error if the proposal can not be recounted; otherwise, nil.
+func RecountHandler(syncService service.ISyncService, chainId, proposalId int64, reason string) error {
+	network, ok := config.GetNetwork(chainId)
+	if !ok {
+		return fmt.Errorf("network %d is not configured", chainId)
+	}
+
+	ethClient, err := data.GetClient(syncService, network.ChainId)
+	if err != nil {
+		return fmt.Errorf("get go-eth client: %w", err)
+	}
+
+	voteCount := &VoteCount{
+		EthClient:   ethClient,
+		SyncService: syncService,
+	}
+
+	return voteCount.recount(proposalId, reason)
+}
+
+// recount keeps the current result of a counted proposal in the result history and counts all of its votes again.
+// Unlike the scheduled count, a recount fails when the snapshot power can not be fetched or a vote can not be decoded,
+// instead of saving a result without them.
+func (vc *VoteCount) recount(proposalId int64, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return errors.New("a recount needs a reason")
+	}
+
+	ctx := context.Background()
+	chainId := vc.EthClient.ChainId
+	proposal, err := vc.SyncService.GetProposal(ctx, chainId, proposalId)
+	if err != nil {
+		return fmt.Errorf("get proposal %d: %w", proposalId, err)
+	}
+
+	if proposal.Counted != constant.ProposalCounted {
+		return fmt.Errorf("proposal %d has not been counted yet", proposalId)
+	}
+
+	// Proposals counted before result revisions were recorded hold their first result
+	if proposal.ResultRevision == 0 {
+		proposal.ResultRevision = 1
+	}
+
+	allPowers, err := snapshot.GetAllAddressPowerByDay(chainId, proposal.SnapshotDay)
+	if err != nil {
+		return fmt.Errorf("get address power for proposal %d: %w", proposalId, err)
+	}
+
+	// Every vote of the proposal, with the votes the previous count could not decode
+	votesInfo, err := vc.SyncService.GetVotedList(ctx, chainId, proposalId)
+	if err != nil {
+		return fmt.Errorf("get vote info for proposal %d: %w", proposalId, err)
+	}
+
+	audit, err := vc.SyncService.GetProposalAudit(ctx, chainId, proposalId)
+	if err != nil {
+		return fmt.Errorf("get audit bundle for proposal %d: %w", proposalId, err)
+	}
+
+	return vc.countProposal(*proposal, allPowers, votesInfo, model.NewResultHistory(*proposal, audit, reason))
+}
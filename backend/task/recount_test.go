@@ -0,0 +1,135 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/constant"
+	"powervoting-server/mock"
+	"powervoting-server/model"
+)
+
+func TestNewResultHistory(t *testing.T) {
+	proposal := model.ProposalTbl{
+		ProposalId:     1,
+		ChainId:        314159,
+		ResultRevision: 2,
+		ProposalResult: model.ProposalResult{
+			ApprovePercentage: 60,
+			RejectPercentage:  40,
+			Outcome:           constant.ProposalOutcomePassed,
+		},
+		TotalPower: model.TotalPower{TotalTokenHolderPower: "1000"},
+	}
+
+	history := model.NewResultHistory(proposal, nil, "missing power")
+	assert.Equal(t, int64(2), history.ResultRevision)
+	assert.Equal(t, "missing power", history.Reason)
+	assert.Equal(t, proposal.ProposalResult, history.ProposalResult)
+	assert.Equal(t, "1000", history.TotalTokenHolderPower)
+	assert.Empty(t, history.AuditSha256)
+
+	history = model.NewResultHistory(proposal, &model.ProposalAuditTbl{Sha256: "hash", Bundle: "{}"}, "missing power")
+	assert.Equal(t, "hash", history.AuditSha256)
+	assert.Equal(t, "{}", history.AuditBundle)
+}
+
+func TestRecountNeedsReason(t *testing.T) {
+	vc := &VoteCount{
+		EthClient:   &model.GoEthClient{ChainId: 314159},
+		SyncService: &mock.MockSyncService{},
+	}
+
+	assert.Error(t, vc.recount(1, " "))
+}
+
+func TestRecountFailsOnUndecodedVote(t *testing.T) {
+	vc := &VoteCount{
+		EthClient:   &model.GoEthClient{ChainId: 314159},
+		SyncService: &mock.MockSyncService{},
+	}
+	proposal := model.ProposalTbl{ProposalId: 1, ChainId: 314159, Counted: constant.ProposalCounted, ResultRevision: 1}
+	votes := []model.VoteTbl{{ProposalId: 1, Address: "0x1234567890123456789012345678901234567890", VoteEncrypted: "not a vote"}}
+
+	// the replaced result is not kept and no result is saved without the vote
+	err := vc.countProposal(proposal, model.SnapshotAllPower{AddrPower: mockPower()}, votes, model.NewResultHistory(proposal, nil, "drand outage"))
+	assert.ErrorContains(t, err, "decode the votes of [0x1234567890123456789012345678901234567890]")
+}
+
+// txKey marks the context of the transaction of countTxSyncService.
+type txKey struct{}
+
+// countTxSyncService records the writes of a count and whether they were made in its transaction.
+type countTxSyncService struct {
+	mock.MockSyncService
+	writes []string
+	outTx  []string
+}
+
+func (s *countTxSyncService) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, txKey{}, true))
+}
+
+func (s *countTxSyncService) write(ctx context.Context, name string) {
+	s.writes = append(s.writes, name)
+	if ctx.Value(txKey{}) == nil {
+		s.outTx = append(s.outTx, name)
+	}
+}
+
+func (s *countTxSyncService) AddProposalResultHistory(ctx context.Context, in *model.ProposalResultHistoryTbl) error {
+	s.write(ctx, "history")
+	return nil
+}
+
+func (s *countTxSyncService) AddProposalAudit(ctx context.Context, in *model.ProposalAuditTbl) error {
+	s.write(ctx, "audit")
+	return nil
+}
+
+func (s *countTxSyncService) UpdateProposal(ctx context.Context, in *model.ProposalTbl) error {
+	s.write(ctx, "proposal")
+	return nil
+}
+
+func (s *countTxSyncService) BatchUpdateVotes(ctx context.Context, votes []model.VoteTbl) error {
+	s.write(ctx, "votes")
+	return nil
+}
+
+func (s *countTxSyncService) AddWebhookEvent(ctx context.Context, in *model.WebhookEventTbl) error {
+	s.write(ctx, "event")
+	return nil
+}
+
+func TestCountProposalInOneTransaction(t *testing.T) {
+	syncService := &countTxSyncService{}
+	vc := &VoteCount{
+		EthClient:   &model.GoEthClient{ChainId: 314159},
+		SyncService: syncService,
+	}
+	proposal := model.ProposalTbl{ProposalId: 1, ChainId: 314159, Counted: constant.ProposalCounted, ResultRevision: 1}
+
+	err := vc.countProposal(proposal, model.SnapshotAllPower{AddrPower: mockPower()}, nil, model.NewResultHistory(proposal, nil, "missing power"))
+	assert.NoError(t, err)
+
+	// a recount is saved as a whole or not at all
+	assert.Equal(t, []string{"history", "audit", "proposal", "votes", "event"}, syncService.writes)
+	assert.Empty(t, syncService.outTx)
+}
@@ -21,15 +21,27 @@ import (
 	"github.com/robfig/cron/v3"
 	"go.uber.org/zap"
 
+	"powervoting-server/config"
+	"powervoting-server/constant"
 	"powervoting-server/service"
+	"powervoting-server/task/event"
 )
 
+// Safejob runs the tasks of one network, a task is skipped while its previous run on the network has not finished.
 type Safejob struct {
 	syncService               *service.SyncService
+	network                   config.Network
+	headers                   *event.HeaderCache // Block headers of the network, kept between the sync runs
 	isRunningSyncEventTask    int32
 	isRunningVoteCountingTask int32
 }
 
+// WebhookJob posts the webhook deliveries of every network, a run is skipped while the previous run has not finished.
+type WebhookJob struct {
+	syncService           *service.SyncService
+	isRunningDeliveryTask int32
+}
+
 // TaskScheduler initializes and starts the task scheduler.
 // It creates a new cron scheduler with seconds precision.
 // It defines task functions for voting count, proposal synchronization, and vote synchronization.
@@ -38,6 +50,9 @@ type Safejob struct {
 //   - Proposal synchronization task runs every 30 seconds.
 //   - Vote synchronization task runs every 30 seconds.
 //
+// The tasks are scheduled for every configured network, the runs of different networks are concurrent.
+// The webhook deliveries of all the networks are posted by one task every 10 seconds.
+//
 // Any error encountered during task scheduling is logged.
 func TaskScheduler(syncService *service.SyncService) {
 	// create a new scheduler
@@ -45,19 +60,30 @@ func TaskScheduler(syncService *service.SyncService) {
 	// stop the scheduler when the program exits
 	defer crontab.Stop()
 
-	job := Safejob{
-		syncService: syncService,
-	}
-
-	_, err := crontab.AddFunc("0/10 * * * * ?", job.RunSyncEventTask)
-	if err != nil {
-		zap.L().Error("add proposal sync task failed: ", zap.Error(err))
-		return
+	for _, network := range config.Networks() {
+		job := &Safejob{
+			syncService: syncService,
+			network:     network,
+			headers:     event.NewHeaderCache(constant.BlockHeaderCacheSize),
+		}
+
+		_, err := crontab.AddFunc("0/10 * * * * ?", job.RunSyncEventTask)
+		if err != nil {
+			zap.L().Error("add proposal sync task failed: ", zap.Int64("network id", network.ChainId), zap.Error(err))
+			return
+		}
+
+		_, err = crontab.AddFunc("0 0/5 * * * ?", job.RunVoteCountingTask)
+		if err != nil {
+			zap.L().Error("add voting count task failed: ", zap.Int64("network id", network.ChainId), zap.Error(err))
+			return
+		}
+		zap.L().Info("network tasks scheduled", zap.String("network name", network.Name), zap.Int64("network id", network.ChainId))
 	}
 
-	_, err = crontab.AddFunc("0 0/5 * * * ?", job.RunVoteCountingTask)
-	if err != nil {
-		zap.L().Error("add voting count task failed: ", zap.Error(err))
+	webhookJob := &WebhookJob{syncService: syncService}
+	if _, err := crontab.AddFunc("0/10 * * * * ?", webhookJob.RunWebhookDeliveryTask); err != nil {
+		zap.L().Error("add webhook delivery task failed: ", zap.Error(err))
 		return
 	}
 
@@ -72,11 +98,11 @@ func (j *Safejob) RunSyncEventTask() {
 	if atomic.CompareAndSwapInt32(&j.isRunningSyncEventTask, 0, 1) {
 		defer atomic.StoreInt32(&j.isRunningSyncEventTask, 0)
 
-		zap.L().Debug("start sync event ")
-		SyncEventHandler(j.syncService)
-		zap.L().Debug("sync event finished, end time:", zap.Int64("end time", time.Now().Unix()))
+		zap.L().Debug("start sync event ", zap.Int64("network id", j.network.ChainId))
+		SyncEventHandler(j.syncService, j.network, j.headers)
+		zap.L().Debug("sync event finished, end time:", zap.Int64("network id", j.network.ChainId), zap.Int64("end time", time.Now().Unix()))
 	} else {
-		zap.L().Warn("sync event task is running, continue")
+		zap.L().Warn("sync event task is running, continue", zap.Int64("network id", j.network.ChainId))
 	}
 }
 
@@ -85,10 +111,21 @@ func (j *Safejob) RunVoteCountingTask() {
 	if atomic.CompareAndSwapInt32(&j.isRunningVoteCountingTask, 0, 1) {
 		defer atomic.StoreInt32(&j.isRunningVoteCountingTask, 0)
 
-		zap.L().Debug("start sync voting count ")
-		VotingCountHandler(j.syncService)
-		zap.L().Debug("sync voting count finished, end time: ", zap.Int64("end time", time.Now().Unix()))
+		zap.L().Debug("start sync voting count ", zap.Int64("network id", j.network.ChainId))
+		VotingCountHandler(j.syncService, j.network)
+		zap.L().Debug("sync voting count finished, end time: ", zap.Int64("network id", j.network.ChainId), zap.Int64("end time", time.Now().Unix()))
+	} else {
+		zap.L().Warn("sync voting count task is running, continue", zap.Int64("network id", j.network.ChainId))
+	}
+}
+
+// RunWebhookDeliveryTask Secure delivery of the webhook events
+func (j *WebhookJob) RunWebhookDeliveryTask() {
+	if atomic.CompareAndSwapInt32(&j.isRunningDeliveryTask, 0, 1) {
+		defer atomic.StoreInt32(&j.isRunningDeliveryTask, 0)
+
+		WebhookDeliveryHandler(j.syncService)
 	} else {
-		zap.L().Warn("sync voting count task is running, continue")
+		zap.L().Warn("webhook delivery task is running, continue")
 	}
 }
@@ -16,8 +16,11 @@ package task
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"sort"
+	"time"
 
 	"github.com/shopspring/decimal"
 	"go.uber.org/zap"
@@ -38,15 +41,13 @@ type VoteCount struct {
 	SyncService service.ISyncService
 }
 
-// VotingCountHandler initiates the voting count process.
-// It iterates through the network configurations and retrieves the Ethereum client for each network.
-// It then launches a goroutine to handle the voting count for each network.
+// VotingCountHandler initiates the voting count process of a network.
+// The scheduler runs it for every configured network, the proposals of each network are counted independently.
 // Any errors encountered during the retrieval of the Ethereum client are logged.
-func VotingCountHandler(syncService service.ISyncService) {
-	network := config.Client.Network
+func VotingCountHandler(syncService service.ISyncService, network config.Network) {
 	ethClient, err := data.GetClient(syncService, network.ChainId)
 	if err != nil {
-		zap.L().Error("get go-eth client error:", zap.Error(err))
+		zap.L().Error("get go-eth client error:", zap.Int64("network id", network.ChainId), zap.Error(err))
 		return
 	}
 
@@ -55,14 +56,14 @@ func VotingCountHandler(syncService service.ISyncService) {
 		SyncService: syncService,
 	}
 
-	syncEventInfo, err := syncService.GetSyncEventInfo(context.Background(), network.PowerVotingContract)
+	syncEventInfo, err := syncService.GetSyncEventInfo(context.Background(), network.ChainId, network.PowerVotingContract)
 	if err != nil {
-		zap.L().Error("get sync event info error:", zap.Error(err))
+		zap.L().Error("get sync event info error:", zap.Int64("network id", network.ChainId), zap.Error(err))
 		return
 	}
 
 	if err := voteCount.voteCounting(syncEventInfo.SyncedHeight); err != nil {
-		zap.L().Error("vote count with err:", zap.Error(err))
+		zap.L().Error("vote count with err:", zap.Int64("network id", network.ChainId), zap.Error(err))
 		return
 	}
 
@@ -93,11 +94,27 @@ func (vc *VoteCount) voteCounting(syncedHeight int64) error {
 	// Parallel handling of proposals
 	errList := make([]error, 0, len(proposals))
 
+	// Tell the webhooks about the proposals whose voting started or ended, before they are counted
+	if err := vc.emitVotingEvents(syncedTimestamp, proposals); err != nil {
+		errList = append(errList, fmt.Errorf("emit voting webhook events: %w", err))
+	}
+
+	now := time.Now().Unix()
 	for _, p := range proposals {
+		// Proposals waiting for missing voter power are retried with a backoff
+		if p.NextCountTime > now {
+			continue
+		}
+
 		// Retrieve the snapshot of voting power for all addresses at the specified block height
 		allPowers, err := snapshot.GetAllAddressPowerByDay(vc.EthClient.ChainId, p.SnapshotDay)
 		if err != nil {
 			errList = append(errList, fmt.Errorf("get address power for proposal %d: %w", p.ProposalId, err))
+			// Without a snapshot no voter has power, the proposal is not counted until it can be fetched
+			if err := vc.deferCounting(p, nil, now); err != nil {
+				errList = append(errList, fmt.Errorf("defer counting for proposal %d: %w", p.ProposalId, err))
+			}
+			continue
 		}
 
 		if err := vc.processCounting(vc.EthClient, p, allPowers); err != nil {
@@ -129,44 +146,160 @@ func (vc *VoteCount) processCounting(ethClient *model.GoEthClient, proposal mode
 		return fmt.Errorf("get vote info for proposal %d: %w", proposal.ProposalId, err)
 	}
 
+	// Wait for the power of voters missing from the snapshot until the deadline,
+	// after it they are counted with zero power
+	if missing := missingPowerVoters(votesInfo, utils.PowersInfoToMap(allPowers.AddrPower)); len(missing) > 0 {
+		now := time.Now().Unix()
+		if !powerDeadlinePassed(powerRetryPolicy(), proposal, now) {
+			return vc.deferCounting(proposal, missing, now)
+		}
+
+		zap.L().Warn("Power deadline passed, counting missing voters as zero",
+			zap.Int64("proposal ID", proposal.ProposalId),
+			zap.Strings("missing voters", missing))
+	}
+
+	return vc.countProposal(proposal, allPowers, votesInfo, nil)
+}
+
+// countProposal counts the votes of a proposal with the snapshot power and saves a new result revision in one transaction:
+// the audit bundle of the count, the proposal result, the power of every counted vote and the counted event.
+// A recount saves the result it replaces in the result history first. Unlike the first count, which skips them,
+// a recount fails when a vote can not be decoded, so that a drand outage does not replace the result with one missing votes.
+//
+// Parameters:
+//   - proposal: The proposal for which votes are being counted.
+//   - allPowers: The power of all addresses at the proposal snapshot day.
+//   - votesInfo: The votes to count.
+//   - replaced: The result replaced by a recount, nil for the first count.
+//
+// Returns:
+//   - error: An error if any step in the process fails; otherwise, nil.
+func (vc *VoteCount) countProposal(proposal model.ProposalTbl, allPowers model.SnapshotAllPower, votesInfo []model.VoteTbl, replaced *model.ProposalResultHistoryTbl) error {
 	// Convert the address power information to a map for quick lookup
 	powersMap := utils.PowersInfoToMap(allPowers.AddrPower)
 	// powersMap := make(map[string]model.AddrPower)
 	// Calculate the total voting power and update the vote list with weights
-	creditsMap, totalCredits, voteList := vc.countWeightCredits(proposal.ProposalId, powersMap, votesInfo, ethClient.ChainId)
+	options := proposal.Options()
+	creditsMap, totalCredits, voteList, undecoded := vc.countWeightCredits(proposal.ProposalId, options, powersMap, votesInfo, proposal.ChainId)
+	if replaced != nil && len(undecoded) > 0 {
+		return fmt.Errorf("decode the votes of %v for proposal %d", undecoded, proposal.ProposalId)
+	}
+
+	// Count the votes with the algorithm in force when the proposal was created
+	algorithm := GetCountingAlgorithm(proposal.CountingAlgorithm)
+	percentages := algorithm.Count(voteList, proposal.Percentage)
+	zap.L().Info("Counting algorithm",
+		zap.Int64("proposal ID", proposal.ProposalId),
+		zap.String("algorithm", algorithm.Name()))
 
-	approvePercentage := vc.calculateVotesPercentage(creditsMap[constant.VoteApprove], totalCredits, proposal.Percentage)
-	rejectPercentage := vc.calculateVotesPercentage(creditsMap[constant.VoteReject], totalCredits, proposal.Percentage)
-	// Calculate the final approval and rejection percentages
-	resultPercent := vc.calculateFinalPercentages(approvePercentage, rejectPercentage, len(voteList))
+	// Calculate the final percentage of each option
+	resultPercent := vc.calculateFinalPercentages(percentages, options, len(voteList))
 	zap.L().Info("Final percentages",
 		zap.Int64("proposal ID", proposal.ProposalId),
-		zap.Float64(constant.VoteApprove, resultPercent[constant.VoteApprove]),
-		zap.Float64(constant.VoteReject, resultPercent[constant.VoteReject]))
+		zap.Any("options", resultPercent))
+
+	// Check the quorum rules recorded on the proposal to decide its outcome
+	electorate, err := vc.electoratePower(proposal, allPowers)
+	if err != nil {
+		return fmt.Errorf("get electorate power for proposal %d: %w", proposal.ProposalId, err)
+	}
+	quorumMet := meetsQuorum(proposal.QuorumRule, len(voteList), totalCredits, electorate)
+	outcome := proposalOutcome(quorumMet, resultPercent)
+	zap.L().Info("Proposal outcome",
+		zap.Int64("proposal ID", proposal.ProposalId),
+		zap.Bool("quorum met", quorumMet),
+		zap.String("outcome", outcome))
+
+	// Save the inputs and intermediate sums of the count so that the result can be recomputed
+	audit := buildTallyAudit(tallyInput{
+		proposal:     proposal,
+		algorithm:    algorithm.Name(),
+		options:      options,
+		allPowers:    allPowers,
+		votesInfo:    votesInfo,
+		voteList:     voteList,
+		creditsMap:   creditsMap,
+		totalCredits: totalCredits,
+		electorate:   electorate,
+		percentages:  percentages,
+		final:        resultPercent,
+		quorumMet:    quorumMet,
+		outcome:      outcome,
+	})
+	auditTbl, err := newProposalAudit(audit)
+	if err != nil {
+		return fmt.Errorf("encode audit bundle for proposal %d: %w", proposal.ProposalId, err)
+	}
 
 	// Update the proposal with the calculated results
 	proposal.Counted = constant.ProposalCounted
-	proposal.ProposalResult.ApprovePercentage = resultPercent[constant.VoteApprove]
-	proposal.ProposalResult.RejectPercentage = resultPercent[constant.VoteReject]
+	proposal.ResultRevision++
+	proposal.AwaitingPower = false
+	proposal.MissingPowerVoters = zeroPowerVoters(voteList)
+	// The exact percentages are the result, the float percentages are kept rounded for display
+	displayPercent := displayPercentages(resultPercent)
+	proposal.ProposalResult.Outcome = outcome
+	proposal.ProposalResult.ApprovePercentage = displayPercent[constant.VoteApprove]
+	proposal.ProposalResult.RejectPercentage = displayPercent[constant.VoteReject]
+	proposal.ProposalResult.AbstainPercentage = displayPercent[constant.VoteAbstain]
+	proposal.ProposalResult.OptionPercentages = displayPercent
+	proposal.ProposalResult.ExactPercentages = exactPercentages(resultPercent)
+	proposal.ProposalResult.CategoryBreakdown = categoryBreakdown(proposal.Percentage, options, creditsMap, totalCredits)
 	proposal.TotalSpPower = totalCredits.SpPower.String()
 	proposal.TotalClientPower = totalCredits.ClientPower.String()
 	proposal.TotalTokenHolderPower = totalCredits.TokenPower.String()
 	proposal.TotalDeveloperPower = totalCredits.DeveloperPower.String()
 
-	// Persist the updated proposal to the database
-	if err := vc.SyncService.UpdateProposal(context.Background(), &proposal); err != nil {
-		return fmt.Errorf("update proposal %d: %w", proposal.ProposalId, err)
+	// Save the revision in one transaction, a count that fails partway leaves nothing behind and is retried as a whole
+	err = vc.SyncService.Transaction(context.Background(), func(ctx context.Context) error {
+		// Keep the current result before it is replaced by the recount
+		if replaced != nil {
+			if err := vc.SyncService.AddProposalResultHistory(ctx, replaced); err != nil {
+				return fmt.Errorf("save result history for proposal %d: %w", proposal.ProposalId, err)
+			}
+		}
+		if err := vc.SyncService.AddProposalAudit(ctx, auditTbl); err != nil {
+			return fmt.Errorf("save audit bundle for proposal %d: %w", proposal.ProposalId, err)
+		}
+
+		// Persist the updated proposal to the database
+		if err := vc.SyncService.UpdateProposal(ctx, &proposal); err != nil {
+			return fmt.Errorf("update proposal %d: %w", proposal.ProposalId, err)
+		}
+
+		// Batch update the vote records in the database
+		if err := vc.SyncService.BatchUpdateVotes(ctx, voteList); err != nil {
+			return fmt.Errorf("batch update vote: %w", err)
+		}
+
+		// Every result revision is an event, so that a recount is delivered as well
+		if err := vc.emitWebhookEvent(ctx, constant.WebhookProposalCounted,
+			fmt.Sprintf("proposal:%d:revision:%d", proposal.ProposalId, proposal.ResultRevision),
+			time.Now().Unix(), proposal); err != nil {
+			return fmt.Errorf("emit counted webhook event for proposal %d: %w", proposal.ProposalId, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+
+	if replaced != nil {
+		zap.L().Info("Recounting proposal",
+			zap.Int64("proposal ID", proposal.ProposalId),
+			zap.Int64("replaced revision", replaced.ResultRevision),
+			zap.String("reason", replaced.Reason))
+	}
+	zap.L().Info("Proposal audit saved",
+		zap.Int64("proposal ID", proposal.ProposalId),
+		zap.String("sha256", auditTbl.Sha256))
 	zap.L().Info(
 		"Proposal voted completed",
 		zap.Int64("proposal ID", proposal.ProposalId),
 		zap.Any("result", proposal.ProposalResult),
 	)
-
-	// Batch update the vote records in the database
-	if err := vc.SyncService.BatchUpdateVotes(context.Background(), voteList); err != nil {
-		return fmt.Errorf("batch update vote: %w", err)
-	}
 	zap.L().Info("Batch update vote completed", zap.Any("vote power number", len(voteList)))
 
 	return nil
@@ -178,23 +311,27 @@ func (vc *VoteCount) processCounting(ethClient *model.GoEthClient, proposal mode
 //
 // Parameters:
 //   - proposalId: The ID of the proposal being calculated.
+//   - options: The vote options of the proposal, votes for any other value are ignored.
 //   - powersMap: A map containing the voting power of each address, indexed by address.
 //   - voteInfos: A list of vote records for the proposal, including the voter's address and encrypted vote result.
 //   - chainId: The chain ID associated with the proposal, used for logging and context.
 //
 // Returns:
-//   - map[string]model.VoterPowerCount: A map containing the aggregated voting power for each vote result (e.g., "approve", "reject").
+//   - map[string]model.VoterPowerCount: A map containing the aggregated voting power for each vote option (e.g., "approve", "reject", "abstain").
 //   - model.VoterPowerCount: A struct containing the total voting power across all vote results.
 //   - []model.VoteTbl: A list of updated vote records with calculated voting weights.
-func (vc *VoteCount) countWeightCredits(proposalId int64, powersMap map[string]model.AddrPower, voteInfos []model.VoteTbl, chainId int64) (map[string]model.VoterPowerCount, model.VoterPowerCount, []model.VoteTbl) {
-	// Initialize the vote power struct with zero values
+//   - []string: The addresses of the voters whose vote could not be decoded, they are not counted.
+func (vc *VoteCount) countWeightCredits(proposalId int64, options []string, powersMap map[string]model.AddrPower, voteInfos []model.VoteTbl, chainId int64) (map[string]model.VoterPowerCount, model.VoterPowerCount, []model.VoteTbl, []string) {
+	// Initialize the vote power struct of every option with zero values
 	var (
-		creditsMap = map[string]model.VoterPowerCount{
-			constant.VoteApprove: {SpPower: decimal.Zero, ClientPower: decimal.Zero, TokenPower: decimal.Zero, DeveloperPower: decimal.Zero},
-			constant.VoteReject:  {SpPower: decimal.Zero, ClientPower: decimal.Zero, TokenPower: decimal.Zero, DeveloperPower: decimal.Zero},
-		} // Map to store vote results and their percentages
-		voteList []model.VoteTbl
+		creditsMap   = make(map[string]model.VoterPowerCount, len(options)) // Map to store vote results and their percentages
+		totalCredits = newVoterPowerCount()
+		voteList     []model.VoteTbl
+		undecoded    []string
 	)
+	for _, option := range options {
+		creditsMap[option] = newVoterPowerCount()
+	}
 
 	// Iterate over each vote record
 	for _, voteInfo := range voteInfos {
@@ -209,7 +346,7 @@ func (vc *VoteCount) countWeightCredits(proposalId int64, powersMap map[string]m
 			)
 			// If the address votes at the time,
 			// there may be a situation where the snapshot is synchronized with the address computing power.
-			// Here is a protection
+			// Counting waits for the missing power until the deadline, after it the vote is counted as zero
 			power = model.AddrPower{
 				Address:          voteInfo.Address,
 				SpPower:          big.NewInt(0),
@@ -221,9 +358,30 @@ func (vc *VoteCount) countWeightCredits(proposalId int64, powersMap map[string]m
 		}
 
 		// Decode the encrypted vote result
-		voteResult, err := utils.DecodeVoteResult(voteInfo.VoteEncrypted)
+		voteResult, beacon, err := utils.DecodeVoteResultWithBeacon(voteInfo.VoteEncrypted)
+		if errors.Is(err, constant.ErrInvalidBallot) {
+			// A malformed ballot can never be counted, it is rejected like a vote for an unknown option
+			zap.L().Warn(
+				"vote ballot is invalid",
+				zap.String("address", voteInfo.Address),
+				zap.Int64("proposalId", voteInfo.ProposalId),
+				zap.Error(err),
+			)
+			continue
+		}
 		if err != nil {
-			zap.L().Error("Decode vote info error", zap.Error(err))
+			zap.L().Error("Decode vote info error", zap.String("address", voteInfo.Address), zap.Error(err))
+			undecoded = append(undecoded, voteInfo.Address)
+			continue
+		}
+
+		if _, ok := creditsMap[voteResult]; !ok {
+			zap.L().Warn(
+				"vote result is not an option of the proposal",
+				zap.String("address", voteInfo.Address),
+				zap.Int64("proposalId", voteInfo.ProposalId),
+				zap.String("result", voteResult),
+			)
 			continue
 		}
 
@@ -240,27 +398,85 @@ func (vc *VoteCount) countWeightCredits(proposalId int64, powersMap map[string]m
 			DeveloperPower: dp,
 		}
 
+		// Accumulate the total voting power across all vote options
+		totalCredits = model.VoterPowerCount{
+			SpPower:        totalCredits.SpPower.Add(decimal.NewFromBigInt(power.SpPower, 0)),
+			ClientPower:    totalCredits.ClientPower.Add(decimal.NewFromBigInt(power.ClientPower, 0)),
+			TokenPower:     totalCredits.TokenPower.Add(decimal.NewFromBigInt(power.TokenHolderPower, 0)),
+			DeveloperPower: totalCredits.DeveloperPower.Add(decimal.NewFromBigInt(power.DeveloperPower, 0)),
+		}
+
 		// Update the vote record with the calculated values
 		voteList = append(voteList, model.VoteTbl{
 			ProposalId:       proposalId,
+			ChainId:          chainId,
 			Address:          voteInfo.Address,
+			VoteEncrypted:    voteInfo.VoteEncrypted,
 			VoteResult:       voteResult,
 			SpPower:          power.SpPower.String(),
 			ClientPower:      power.ClientPower.String(),
 			TokenHolderPower: power.TokenHolderPower.String(),
 			DeveloperPower:   power.DeveloperPower.String(),
+			DrandRound:       beacon.Round,
+			DrandSignature:   beacon.Signature,
+			PowerMissing:     !exists,
 		})
 	}
 
-	// Calculate the total voting power across all vote results
-	totalCredits := model.VoterPowerCount{
-		SpPower:        creditsMap[constant.VoteApprove].SpPower.Add(creditsMap[constant.VoteReject].SpPower),
-		ClientPower:    creditsMap[constant.VoteApprove].ClientPower.Add(creditsMap[constant.VoteReject].ClientPower),
-		TokenPower:     creditsMap[constant.VoteApprove].TokenPower.Add(creditsMap[constant.VoteReject].TokenPower),
-		DeveloperPower: creditsMap[constant.VoteApprove].DeveloperPower.Add(creditsMap[constant.VoteReject].DeveloperPower),
+	return creditsMap, totalCredits, voteList, undecoded
+}
+
+// categoryBreakdown shows how each power category voted: the power of each option in the category
+// and its share of the category power. The shares do not depend on the counting algorithm.
+//
+// Parameters:
+//   - percentage: The weight of each power category set by the proposal creator.
+//   - options: The vote options of the proposal.
+//   - creditsMap: The power of the counted votes for each option.
+//   - totalCredits: The power of all counted votes.
+//
+// Returns:
+//   - model.CategoryBreakdown: The result of each power category, indexed by category name.
+func categoryBreakdown(percentage model.Percentage, options []string, creditsMap map[string]model.VoterPowerCount, totalCredits model.VoterPowerCount) model.CategoryBreakdown {
+	breakdown := make(model.CategoryBreakdown, 4)
+	for _, category := range powerCategories(percentage) {
+		total := category.power(totalCredits)
+		result := model.CategoryResult{
+			Weight:     category.percentage,
+			TotalPower: total.String(),
+			Options:    make(map[string]model.OptionPower, len(options)),
+		}
+
+		for _, option := range options {
+			power := category.power(creditsMap[option])
+			share := decimal.Zero
+			if !total.IsZero() {
+				share = power.Mul(oneHundred).DivRound(total, utils.ResultPlaces)
+			}
+			result.Options[option] = model.OptionPower{
+				Power:      power.String(),
+				Share:      utils.RoundPercentage(share),
+				ExactShare: share.String(),
+			}
+		}
+
+		breakdown[category.name] = result
 	}
 
-	return creditsMap, totalCredits, voteList
+	return breakdown
+}
+
+// zeroPowerVoters returns the sorted addresses of the counted votes that were missing from the snapshot.
+func zeroPowerVoters(voteList []model.VoteTbl) model.StringSlice {
+	var voters model.StringSlice
+	for _, vote := range voteList {
+		if vote.PowerMissing {
+			voters = append(voters, vote.Address)
+		}
+	}
+	sort.Strings(voters)
+
+	return voters
 }
 
 // calculateVotesPercentage calculates the weighted voting percentage for a given voter based on their power contributions.
@@ -275,15 +491,21 @@ func (vc *VoteCount) countWeightCredits(proposalId int64, powersMap map[string]m
 // Returns:
 //   - decimal.Decimal: The calculated weighted voting percentage, scaled to a value between 0 and 100.
 func (vc *VoteCount) calculateVotesPercentage(votesPower model.VoterPowerCount, totalPower model.VoterPowerCount, percentage model.Percentage) decimal.Decimal {
+	return calculateWeightedPercentage(votesPower, totalPower, percentage)
+}
+
+// calculateWeightedPercentage is the weighted share formula shared by the counting algorithms,
+// see calculateVotesPercentage for the parameters.
+func calculateWeightedPercentage(votesPower model.VoterPowerCount, totalPower model.VoterPowerCount, percentage model.Percentage) decimal.Decimal {
 	var (
-		totalPercentage = uint16(0)             // Accumulates the total percentage weights used in the calculation
-		totalWeight     = decimal.NewFromInt(0) // Accumulates the weighted sum of the voter's power contributions
+		totalPercentage = uint16(0)    // Accumulates the total percentage weights used in the calculation
+		totalWeight     = new(big.Rat) // Accumulates the weighted sum of the voter's power contributions, kept as a fraction
 	)
 
 	// Calculate the weighted contribution for SP (Storage Provider) power
 	if !totalPower.SpPower.IsZero() {
 		if !votesPower.SpPower.IsZero() {
-			totalWeight = totalWeight.Add(votesPower.SpPower.Div(totalPower.SpPower).Mul(decimal.NewFromInt(int64(percentage.SpPercentage))))
+			totalWeight.Add(totalWeight, weightedShare(votesPower.SpPower, totalPower.SpPower, percentage.SpPercentage))
 		}
 		totalPercentage += percentage.SpPercentage
 		zap.L().Debug("SP Percentage", zap.Uint16("percentage", percentage.SpPercentage), zap.String("totalWeight", totalWeight.String()))
@@ -292,7 +514,7 @@ func (vc *VoteCount) calculateVotesPercentage(votesPower model.VoterPowerCount,
 	// Calculate the weighted contribution for Client power
 	if !totalPower.ClientPower.IsZero() {
 		if !votesPower.ClientPower.IsZero() {
-			totalWeight = totalWeight.Add(votesPower.ClientPower.Div(totalPower.ClientPower).Mul(decimal.NewFromInt(int64(percentage.ClientPercentage))))
+			totalWeight.Add(totalWeight, weightedShare(votesPower.ClientPower, totalPower.ClientPower, percentage.ClientPercentage))
 		}
 		totalPercentage += percentage.ClientPercentage
 		zap.L().Debug("Client Percentage", zap.Uint16("percentage", percentage.ClientPercentage), zap.String("totalWeight", totalWeight.String()))
@@ -301,7 +523,7 @@ func (vc *VoteCount) calculateVotesPercentage(votesPower model.VoterPowerCount,
 	// Calculate the weighted contribution for Token Holder power
 	if !totalPower.TokenPower.IsZero() {
 		if !votesPower.TokenPower.IsZero() {
-			totalWeight = totalWeight.Add(votesPower.TokenPower.Div(totalPower.TokenPower).Mul(decimal.NewFromInt(int64(percentage.TokenHolderPercentage))))
+			totalWeight.Add(totalWeight, weightedShare(votesPower.TokenPower, totalPower.TokenPower, percentage.TokenHolderPercentage))
 		}
 		totalPercentage += percentage.TokenHolderPercentage
 		zap.L().Debug("Token Percentage", zap.Uint16("percentage", percentage.TokenHolderPercentage), zap.String("totalWeight", totalWeight.String()))
@@ -310,7 +532,7 @@ func (vc *VoteCount) calculateVotesPercentage(votesPower model.VoterPowerCount,
 	// Calculate the weighted contribution for Developer power
 	if !totalPower.DeveloperPower.IsZero() {
 		if !votesPower.DeveloperPower.IsZero() {
-			totalWeight = totalWeight.Add(votesPower.DeveloperPower.Div(totalPower.DeveloperPower).Mul(decimal.NewFromInt(int64(percentage.DeveloperPercentage))))
+			totalWeight.Add(totalWeight, weightedShare(votesPower.DeveloperPower, totalPower.DeveloperPower, percentage.DeveloperPercentage))
 		}
 		totalPercentage += percentage.DeveloperPercentage
 		zap.L().Debug("Developer Percentage", zap.Uint16("percentage", percentage.DeveloperPercentage), zap.String("totalWeight", totalWeight.String()))
@@ -321,39 +543,57 @@ func (vc *VoteCount) calculateVotesPercentage(votesPower model.VoterPowerCount,
 		return decimal.NewFromInt(0)
 	}
 
-	zap.L().Info("calculateVotesPercentage", zap.String("totalWeight", totalWeight.String()), zap.Uint16("totalPercentage", totalPercentage))
-	// Normalize the weighted sum to a percentage value (0-100)
-	return totalWeight.Div(decimal.NewFromInt(int64(totalPercentage))).Mul(oneHundred)
+	zap.L().Info("calculateWeightedPercentage", zap.String("totalWeight", totalWeight.String()), zap.Uint16("totalPercentage", totalPercentage))
+	// Normalize the weighted sum to a percentage value (0-100), the only rounded division
+	return percentOf(totalWeight, new(big.Rat).SetInt64(int64(totalPercentage)))
 }
 
-// calculateFinalPercentages calculates the final voting percentages and handles precision.
-// It ensures the total percentage adds up to 100% by distributing any remainder.
-func (vc *VoteCount) calculateFinalPercentages(approve, reject decimal.Decimal, totalVotes int) map[string]float64 {
-	if totalVotes == 0 {
-		return map[string]float64{
-			constant.VoteApprove: 0,
-			constant.VoteReject:  0,
+// weightedShare returns the share of the total power of a category, weighted with the category percentage, as an exact fraction.
+func weightedShare(power, total decimal.Decimal, weight uint16) *big.Rat {
+	share := new(big.Rat).Quo(power.Rat(), total.Rat())
+	return share.Mul(share, new(big.Rat).SetInt64(int64(weight)))
+}
+
+// percentOf returns part as a percentage of whole, rounded to utils.ResultPlaces decimal places.
+func percentOf(part, whole *big.Rat) decimal.Decimal {
+	percent := new(big.Rat).Quo(part, whole)
+	return decimal.NewFromBigRat(percent.Mul(percent, big.NewRat(100, 1)), utils.ResultPlaces)
+}
+
+// calculateFinalPercentages returns the exact percentage of every vote option of the proposal.
+// The percentages are neither rounded nor adjusted: no remainder is moved between options,
+// so they add up to 100 only up to the precision of the counting algorithm.
+// Rounding is left to the display, see utils.RoundPercentage.
+func (vc *VoteCount) calculateFinalPercentages(percentages map[string]decimal.Decimal, options []string, totalVotes int) map[string]decimal.Decimal {
+	res := make(map[string]decimal.Decimal, len(options))
+	for _, option := range options {
+		res[option] = decimal.Zero
+		if totalVotes > 0 {
+			if percentage, ok := percentages[option]; ok {
+				res[option] = percentage
+			}
 		}
 	}
 
-	offset := decimal.NewFromInt(100).Sub(approve).Sub(reject)
-
-	zap.L().Info("calculateFinalPercentages", zap.Float64("offset", offset.InexactFloat64()))
-	// Process remainder allocation
-	switch {
-	case approve.GreaterThan(reject):
-		approve = approve.Add(offset)
-	case reject.GreaterThan(approve):
-		reject = reject.Add(offset)
-	default:
-		approve = decimal.NewFromFloat(50)
-		reject = decimal.NewFromFloat(50)
+	return res
+}
+
+// displayPercentages rounds the exact percentage of each vote option for display.
+func displayPercentages(percentages map[string]decimal.Decimal) model.Float64Map {
+	res := make(model.Float64Map, len(percentages))
+	for option, percentage := range percentages {
+		res[option] = utils.RoundPercentage(percentage)
 	}
 
-	zap.L().Info("Final percentages", zap.Any("approve", approve), zap.Any("reject", reject))
-	// Rounding process
-	return map[string]float64{
-		constant.VoteApprove: approve.Round(2).InexactFloat64(),
-		constant.VoteReject:  reject.Round(2).InexactFloat64(),
+	return res
+}
+
+// exactPercentages formats the exact percentage of each vote option as a decimal string.
+func exactPercentages(percentages map[string]decimal.Decimal) model.StringMap {
+	res := make(model.StringMap, len(percentages))
+	for option, percentage := range percentages {
+		res[option] = percentage.String()
 	}
+
+	return res
 }
@@ -62,8 +62,9 @@ func TestCountWeightCredits(t *testing.T) {
 
 	votes, err := vc.SyncService.GetUncountedVotedList(context.Background(), 314159, 1)
 	assert.NoError(t, err)
-	votePower, totalPower, votesList := vc.countWeightCredits(
+	votePower, totalPower, votesList, _ := vc.countWeightCredits(
 		1,
+		[]string{constant.VoteApprove, constant.VoteReject},
 		map[string]model.AddrPower{
 			"0x1234567890123456789012345678901234567890": {
 				Address:          "0x1234567890123456789012345678901234567890",
@@ -92,6 +93,9 @@ func TestCountWeightCredits(t *testing.T) {
 	assert.NotNil(t, votePower)
 	assert.NotNil(t, totalPower)
 	assert.NotNil(t, votesList)
+	for _, vote := range votesList {
+		assert.Equal(t, int64(314159), vote.ChainId)
+	}
 }
 
 func TestCalculateVotesPercentage(t *testing.T) {
@@ -159,3 +163,80 @@ func mockPower() []model.AddrPower {
 		},
 	}
 }
+
+func TestCalculateFinalPercentages(t *testing.T) {
+	vc := newVoteCount(t)
+
+	// the percentages are kept exact, no remainder is moved to the leading option
+	third := decimal.RequireFromString("33.333333333333333333333333333333")
+	res := vc.calculateFinalPercentages(map[string]decimal.Decimal{
+		constant.VoteApprove: third,
+		constant.VoteReject:  third,
+		constant.VoteAbstain: third,
+	}, constant.DefaultVoteOptions, 3)
+	assert.True(t, third.Equal(res[constant.VoteApprove]))
+	assert.True(t, third.Equal(res[constant.VoteReject]))
+	assert.True(t, third.Equal(res[constant.VoteAbstain]))
+
+	// options without votes are zero
+	res = vc.calculateFinalPercentages(map[string]decimal.Decimal{
+		constant.VoteApprove: decimal.NewFromInt(100),
+	}, constant.DefaultVoteOptions, 1)
+	assert.Len(t, res, 3)
+	assert.True(t, res[constant.VoteReject].IsZero())
+
+	// no votes
+	res = vc.calculateFinalPercentages(nil, constant.DefaultVoteOptions, 0)
+	assert.Len(t, res, 3)
+	assert.True(t, res[constant.VoteApprove].IsZero())
+}
+
+func TestDisplayPercentages(t *testing.T) {
+	exact := map[string]decimal.Decimal{
+		constant.VoteApprove: decimal.RequireFromString("60.004"),
+		constant.VoteReject:  decimal.RequireFromString("29.996"),
+		constant.VoteAbstain: decimal.RequireFromString("10"),
+	}
+
+	display := displayPercentages(exact)
+	assert.Equal(t, 60.0, display[constant.VoteApprove])
+	assert.Equal(t, 30.0, display[constant.VoteReject])
+	assert.Equal(t, 10.0, display[constant.VoteAbstain])
+
+	assert.Equal(t, model.StringMap{
+		constant.VoteApprove: "60.004",
+		constant.VoteReject:  "29.996",
+		constant.VoteAbstain: "10",
+	}, exactPercentages(exact))
+}
+
+func TestCategoryBreakdown(t *testing.T) {
+	approve := newVoterPowerCount()
+	approve.SpPower = decimal.NewFromInt(300)
+	approve.TokenPower = decimal.NewFromInt(1)
+	reject := newVoterPowerCount()
+	reject.SpPower = decimal.NewFromInt(600)
+	reject.TokenPower = decimal.NewFromInt(2)
+	total := newVoterPowerCount()
+	total.SpPower = decimal.NewFromInt(900)
+	total.TokenPower = decimal.NewFromInt(3)
+
+	breakdown := categoryBreakdown(
+		model.Percentage{SpPercentage: 4000, TokenHolderPercentage: 6000},
+		constant.DefaultVoteOptions,
+		map[string]model.VoterPowerCount{constant.VoteApprove: approve, constant.VoteReject: reject},
+		total,
+	)
+
+	assert.Len(t, breakdown, 4)
+	sp := breakdown[constant.PowerCategorySp]
+	assert.Equal(t, uint16(4000), sp.Weight)
+	assert.Equal(t, "900", sp.TotalPower)
+	assert.Equal(t, model.OptionPower{Power: "300", Share: 33.33, ExactShare: "33.333333333333333333333333333333"}, sp.Options[constant.VoteApprove])
+	assert.Equal(t, model.OptionPower{Power: "600", Share: 66.67, ExactShare: "66.666666666666666666666666666667"}, sp.Options[constant.VoteReject])
+	// options without votes are listed with zero power
+	assert.Equal(t, model.OptionPower{Power: "0", Share: 0, ExactShare: "0"}, sp.Options[constant.VoteAbstain])
+	// categories without votes have no shares
+	assert.Equal(t, "0", breakdown[constant.PowerCategoryClient].TotalPower)
+	assert.Equal(t, 0.0, breakdown[constant.PowerCategoryClient].Options[constant.VoteApprove].Share)
+}
@@ -0,0 +1,208 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"go.uber.org/zap"
+
+	"powervoting-server/config"
+	"powervoting-server/constant"
+	"powervoting-server/model"
+	"powervoting-server/service"
+	"powervoting-server/utils"
+)
+
+// Headers of the webhook deliveries
+const (
+	webhookEventHeader     = "X-PowerVoting-Event"     // Event name
+	webhookDeliveryHeader  = "X-PowerVoting-Delivery"  // Delivery ID, the same for every attempt of the delivery
+	webhookTimestampHeader = "X-PowerVoting-Timestamp" // Unix time of the attempt, part of the signed content
+	webhookSignatureHeader = "X-PowerVoting-Signature" // HMAC-SHA256 of the timestamp and the body
+)
+
+// emitWebhookEvent emits a governance lifecycle event of a proposal of the network for the webhooks and the stream.
+// The event keeps the block of the proposal, so that it is withdrawn when the proposal is rolled back.
+func (vc *VoteCount) emitWebhookEvent(ctx context.Context, event, subject string, timestamp int64, proposal model.ProposalTbl) error {
+	in, err := model.NewWebhookEvent(vc.EthClient.ChainId, proposal.ProposalId, event, subject, timestamp, model.NewWebhookProposalData(proposal))
+	if err != nil {
+		return err
+	}
+	in.BlockNumber = proposal.BlockNumber
+
+	return vc.SyncService.AddWebhookEvent(ctx, in)
+}
+
+// emitVotingEvents emits the voting started event of the uncounted proposals whose voting started before the synced time
+// and the voting ended event of the ended proposals waiting to be counted.
+// The proposals are listed at every run, an event already emitted for a proposal is ignored.
+func (vc *VoteCount) emitVotingEvents(syncedTimestamp int64, ended []model.ProposalTbl) error {
+	started, err := vc.SyncService.StartedProposalList(context.Background(), vc.EthClient.ChainId, syncedTimestamp)
+	if err != nil {
+		return fmt.Errorf("get started proposals: %w", err)
+	}
+
+	for _, proposal := range started {
+		if err := vc.emitWebhookEvent(context.Background(), constant.WebhookProposalVotingStarted, fmt.Sprintf("proposal:%d", proposal.ProposalId),
+			proposal.StartTime, proposal); err != nil {
+			return err
+		}
+	}
+
+	for _, proposal := range ended {
+		if err := vc.emitWebhookEvent(context.Background(), constant.WebhookProposalVotingEnded, fmt.Sprintf("proposal:%d", proposal.ProposalId),
+			proposal.EndTime, proposal); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WebhookDeliveryHandler posts the due webhook deliveries and saves the result of every attempt in the delivery log.
+// A delivery is accepted by a 2xx answer, a failed delivery is attempted again with an interval doubled after every attempt
+// until it has been attempted WebhookMaxAttempts times and is left in the delivery log as exhausted.
+func WebhookDeliveryHandler(syncService service.ISyncService) {
+	ctx := context.Background()
+	deliveries, err := syncService.GetDueWebhookDeliveries(ctx, time.Now().Unix(), constant.WebhookDeliveryLimit)
+	if err != nil {
+		zap.L().Error("get due webhook deliveries error", zap.Error(err))
+		return
+	}
+
+	client := resty.New().SetTimeout(webhookTimeout())
+	workers := make(chan struct{}, constant.WebhookDeliveryWorkers)
+	var wg sync.WaitGroup
+	for _, delivery := range deliveries {
+		workers <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-workers
+				wg.Done()
+			}()
+
+			result := deliverWebhook(client, delivery, time.Now().Unix())
+			if result.Status != constant.WebhookDeliveryStatusDelivered {
+				zap.L().Warn("webhook delivery failed",
+					zap.Int64("delivery id", delivery.ID),
+					zap.String("url", delivery.Url),
+					zap.Int("attempts", result.Attempts),
+					zap.String("error", result.Error))
+			}
+			if err := syncService.UpdateWebhookDelivery(ctx, &result); err != nil {
+				zap.L().Error("update webhook delivery error", zap.Int64("delivery id", delivery.ID), zap.Error(err))
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// webhookTimeout returns the time a webhook has to answer a delivery.
+func webhookTimeout() time.Duration {
+	timeout := config.Client.Webhook.Timeout
+	if timeout <= 0 {
+		timeout = constant.WebhookTimeout
+	}
+
+	return time.Duration(timeout) * time.Second
+}
+
+// deliverWebhook attempts a delivery and returns the delivery with the result of the attempt.
+func deliverWebhook(client *resty.Client, delivery model.WebhookDueDelivery, now int64) model.WebhookDeliveryTbl {
+	result := delivery.WebhookDeliveryTbl
+	result.Attempts++
+
+	status, err := postWebhook(client, delivery, now)
+	result.ResponseStatus = status
+	if err == nil {
+		result.Status = constant.WebhookDeliveryStatusDelivered
+		result.Error = ""
+		result.DeliveredTime = now
+		return result
+	}
+
+	result.Error = err.Error()
+	result.NextAttemptTime = nextWebhookAttempt(result.Attempts, now)
+	if result.Attempts >= constant.WebhookMaxAttempts {
+		result.Status = constant.WebhookDeliveryStatusExhausted
+	}
+
+	return result
+}
+
+// postWebhook posts the payload of a delivery to its webhook, signed with the secret of the webhook.
+// It returns the HTTP status of the answer, 0 when there was no answer.
+func postWebhook(client *resty.Client, delivery model.WebhookDueDelivery, now int64) (int, error) {
+	body, err := json.Marshal(model.WebhookPayload{
+		Id:        delivery.EventId,
+		Event:     delivery.Event,
+		ChainId:   delivery.ChainId,
+		Subject:   delivery.Subject,
+		Timestamp: delivery.Timestamp,
+		Data:      json.RawMessage(delivery.Data),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("encode payload: %w", err)
+	}
+
+	resp, err := client.R().
+		SetHeader("Content-Type", "application/json").
+		SetHeader(webhookEventHeader, delivery.Event).
+		SetHeader(webhookDeliveryHeader, strconv.FormatInt(delivery.ID, 10)).
+		SetHeader(webhookTimestampHeader, strconv.FormatInt(now, 10)).
+		SetHeader(webhookSignatureHeader, signWebhook(delivery.Secret, now, body)).
+		SetBody(body).
+		Post(delivery.Url)
+	if err != nil {
+		return 0, err
+	}
+
+	if !resp.IsSuccess() {
+		answer := resp.String()
+		if len(answer) > constant.WebhookResponseLimit {
+			answer = answer[:constant.WebhookResponseLimit]
+		}
+		return resp.StatusCode(), fmt.Errorf("webhook answered %d: %s", resp.StatusCode(), answer)
+	}
+
+	return resp.StatusCode(), nil
+}
+
+// signWebhook returns the signature header of a delivery: the hex HMAC-SHA256 of "timestamp.body" keyed by the webhook secret.
+func signWebhook(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// nextWebhookAttempt returns the time of the next attempt of a delivery that has been attempted the given number of times.
+// The interval doubles after every attempt, up to the maximum interval.
+func nextWebhookAttempt(attempts int, now int64) int64 {
+	return utils.NextBackoff(attempts, now, constant.WebhookRetryInterval, constant.WebhookRetryMaxInterval)
+}
@@ -0,0 +1,138 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/stretchr/testify/assert"
+
+	"powervoting-server/constant"
+	"powervoting-server/mock"
+	"powervoting-server/model"
+)
+
+func TestSignWebhook(t *testing.T) {
+	assert.Equal(t,
+		"sha256=3dd1b9aef568d75f6790a84bd2e5dfa1f44409eef3cbdbd3f10b837376100c11",
+		signWebhook("secret", 1700000000, []byte(`{"id":1}`)))
+}
+
+func TestNextWebhookAttempt(t *testing.T) {
+	assert.Equal(t, int64(1030), nextWebhookAttempt(1, 1000))
+	assert.Equal(t, int64(1060), nextWebhookAttempt(2, 1000))
+	assert.Equal(t, int64(1120), nextWebhookAttempt(3, 1000))
+	// capped at the maximum interval
+	assert.Equal(t, int64(1000+constant.WebhookRetryMaxInterval), nextWebhookAttempt(30, 1000))
+}
+
+func TestDeliverWebhook(t *testing.T) {
+	status := http.StatusOK
+	var received model.WebhookPayload
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		timestamp, _ := strconv.ParseInt(r.Header.Get(webhookTimestampHeader), 10, 64)
+		assert.Equal(t, signWebhook("secret", timestamp, body), r.Header.Get(webhookSignatureHeader))
+		assert.Equal(t, constant.WebhookProposalCounted, r.Header.Get(webhookEventHeader))
+		assert.Equal(t, "7", r.Header.Get(webhookDeliveryHeader))
+		assert.NoError(t, json.Unmarshal(body, &received))
+
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte("busy"))
+	}))
+	defer server.Close()
+
+	delivery := model.WebhookDueDelivery{
+		WebhookDeliveryTbl: model.WebhookDeliveryTbl{
+			BaseField: model.BaseField{ID: 7},
+			EventId:   3,
+			Event:     constant.WebhookProposalCounted,
+		},
+		Url:       server.URL,
+		Secret:    "secret",
+		ChainId:   314159,
+		Subject:   "proposal:1:revision:1",
+		Timestamp: 900,
+		Data:      `{"proposalId":1}`,
+	}
+
+	result := deliverWebhook(resty.New(), delivery, 1000)
+	assert.Equal(t, constant.WebhookDeliveryStatusDelivered, result.Status)
+	assert.Equal(t, int64(1000), result.DeliveredTime)
+	assert.Equal(t, 1, result.Attempts)
+	assert.Equal(t, int64(3), received.Id)
+	assert.Equal(t, "proposal:1:revision:1", received.Subject)
+	assert.JSONEq(t, `{"proposalId":1}`, string(received.Data))
+
+	// a failed delivery is attempted again later
+	status = http.StatusServiceUnavailable
+	result = deliverWebhook(resty.New(), delivery, 1000)
+	assert.Equal(t, constant.WebhookDeliveryStatusPending, result.Status)
+	assert.Equal(t, http.StatusServiceUnavailable, result.ResponseStatus)
+	assert.Equal(t, nextWebhookAttempt(1, 1000), result.NextAttemptTime)
+	assert.Contains(t, result.Error, "busy")
+
+	// until it has been attempted the maximum number of times
+	delivery.Attempts = constant.WebhookMaxAttempts - 1
+	result = deliverWebhook(resty.New(), delivery, 1000)
+	assert.Equal(t, constant.WebhookDeliveryStatusExhausted, result.Status)
+}
+
+// webhookSyncService records the webhook events emitted by the counting task.
+type webhookSyncService struct {
+	mock.MockSyncService
+	started []model.ProposalTbl
+	events  []model.WebhookEventTbl
+}
+
+func (s *webhookSyncService) StartedProposalList(ctx context.Context, chainId, startTime int64) ([]model.ProposalTbl, error) {
+	return s.started, nil
+}
+
+func (s *webhookSyncService) AddWebhookEvent(ctx context.Context, in *model.WebhookEventTbl) error {
+	s.events = append(s.events, *in)
+	return nil
+}
+
+func TestEmitVotingEvents(t *testing.T) {
+	syncService := &webhookSyncService{
+		started: []model.ProposalTbl{
+			{ProposalId: 1, StartTime: 100, EndTime: 200},
+			{ProposalId: 2, StartTime: 150, EndTime: 500},
+		},
+	}
+	vc := &VoteCount{
+		EthClient:   &model.GoEthClient{ChainId: 314159},
+		SyncService: syncService,
+	}
+
+	assert.NoError(t, vc.emitVotingEvents(300, syncService.started[:1]))
+	assert.Len(t, syncService.events, 3)
+	assert.Equal(t, constant.WebhookProposalVotingStarted, syncService.events[0].Event)
+	assert.Equal(t, "proposal:1", syncService.events[0].Subject)
+	assert.Equal(t, int64(100), syncService.events[0].Timestamp)
+	assert.Equal(t, constant.WebhookProposalVotingStarted, syncService.events[1].Event)
+	assert.Equal(t, constant.WebhookProposalVotingEnded, syncService.events[2].Event)
+	assert.Equal(t, int64(200), syncService.events[2].Timestamp)
+	assert.Equal(t, int64(314159), syncService.events[2].ChainId)
+	assert.JSONEq(t, `{"proposalId":1,"creator":"","title":"","startTime":100,"endTime":200,"snapshotDay":"","blockNumber":0}`, syncService.events[2].Data)
+}
@@ -0,0 +1,25 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+// NextBackoff returns the time of the next attempt of an operation that has been attempted the given number of times.
+// The first retry waits the given interval, and the interval doubles after every attempt, up to the maximum interval.
+func NextBackoff(attempts int, now, interval, maxInterval int64) int64 {
+	for i := 1; i < attempts && interval < maxInterval; i++ {
+		interval *= 2
+	}
+
+	return now + min(interval, maxInterval)
+}
@@ -0,0 +1,30 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextBackoff(t *testing.T) {
+	assert.Equal(t, int64(1100), NextBackoff(0, 1000, 100, 350))
+	assert.Equal(t, int64(1100), NextBackoff(1, 1000, 100, 350))
+	assert.Equal(t, int64(1200), NextBackoff(2, 1000, 100, 350))
+	// capped at the maximum interval
+	assert.Equal(t, int64(1350), NextBackoff(3, 1000, 100, 350))
+	assert.Equal(t, int64(1350), NextBackoff(100, 1000, 100, 350))
+}
@@ -110,3 +110,37 @@ func GetActorIdByAddress(client *model.GoEthClient, address string) (uint64, err
 
 	return upPacked[0].(uint64), err
 }
+
+// GetVotingCountingAlgorithm reads the vote counting algorithm configured on the PowerVotingConf contract
+// at the given block height. A nil block number reads the latest state.
+func GetVotingCountingAlgorithm(client *model.GoEthClient, blockNumber *big.Int) (string, error) {
+	if client.ABI.PowerVotingConfAbi == nil {
+		return "", fmt.Errorf("power voting conf abi is not configured")
+	}
+
+	data, err := client.ABI.PowerVotingConfAbi.Pack("votingCountingAlgorithm")
+	if err != nil {
+		return "", fmt.Errorf("error packing votingCountingAlgorithm: %v", err)
+	}
+
+	msg := ethereum.CallMsg{
+		To:   &client.PowerVotingConfContract,
+		Data: data,
+	}
+
+	result, err := client.Client.CallContract(context.Background(), msg, blockNumber)
+	if err != nil {
+		return "", fmt.Errorf("error calling votingCountingAlgorithm: %v", err)
+	}
+
+	upPacked, err := client.ABI.PowerVotingConfAbi.Unpack("votingCountingAlgorithm", result)
+	if err != nil {
+		return "", fmt.Errorf("error unpacking votingCountingAlgorithm: %v", err)
+	}
+
+	if len(upPacked) == 0 {
+		return "", fmt.Errorf("votingCountingAlgorithm returned empty result")
+	}
+
+	return upPacked[0].(string), nil
+}
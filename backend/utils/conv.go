@@ -56,6 +56,20 @@ func BigIntDiv(x *big.Int, y *big.Int) decimal.Decimal {
 	return xd.Div(yd).Round(5)
 }
 
+// PercentagePlaces is the number of decimal places percentages are displayed with
+const PercentagePlaces = 2
+
+// ResultPlaces is the number of decimal places the exact percentages are stored with.
+// An exact percentage is computed as a fraction and divided once, rounded half away from zero to ResultPlaces decimal places.
+const ResultPlaces = 30
+
+// RoundPercentage rounds an exact percentage for display.
+// The value is rounded half away from zero to PercentagePlaces decimal places and nothing else is adjusted,
+// so the rounded percentages of a proposal may not add up to exactly 100.
+func RoundPercentage(d decimal.Decimal) float64 {
+	return d.Round(PercentagePlaces).InexactFloat64()
+}
+
 // ParseStringToInt64 is a function that converts a string to an int64.
 func ParseStringToInt64(v string) int64 {
 	res, err := strconv.ParseInt(v, 10, 64)
@@ -17,6 +17,7 @@ package utils
 import (
 	"testing"
 
+	"github.com/shopspring/decimal"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -42,3 +43,11 @@ func TestStringToBase64URL(t *testing.T) {
 	res := StringToBase64URL("test")
 	assert.NotEmpty(t, res)
 }
+
+func TestRoundPercentage(t *testing.T) {
+	assert.Equal(t, 33.33, RoundPercentage(decimal.RequireFromString("33.3333333333333333")))
+	assert.Equal(t, 66.67, RoundPercentage(decimal.RequireFromString("66.6666666666666667")))
+	// halves are rounded away from zero
+	assert.Equal(t, 0.13, RoundPercentage(decimal.RequireFromString("0.125")))
+	assert.Equal(t, 100.0, RoundPercentage(decimal.NewFromInt(100)))
+}
@@ -16,6 +16,7 @@ package utils
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"strings"
@@ -26,23 +27,33 @@ import (
 	"go.uber.org/zap"
 
 	"powervoting-server/config"
+	"powervoting-server/constant"
+	"powervoting-server/model"
 )
 
 // It decrypts the encrypted data, unmarshals it into a structured format,
 // and constructs a list of vote counts for each option.
 // The function returns the decoded vote list or an error if the decoding fails.
 func DecodeVoteResult(voteInfo string) (string, error) {
+	result, _, err := DecodeVoteResultWithBeacon(voteInfo)
+	return result, err
+}
+
+// DecodeVoteResultWithBeacon decodes the vote like DecodeVoteResult,
+// and also returns the drand beacon that unlocked it so that the decryption can be verified.
+func DecodeVoteResultWithBeacon(voteInfo string) (string, model.DrandBeacon, error) {
 	var (
 		decrypt []byte
+		beacon  model.DrandBeacon
 		err     error
 	)
 	retry_times := 5
 
 	for i := 0; i < retry_times; i++ {
-		decrypt, err = Decrypt(voteInfo)
+		decrypt, beacon, err = DecryptWithBeacon(voteInfo)
 		if i == retry_times-1 && err != nil {
 			zap.L().Error("decrypt error:", zap.Error(err))
-			return "", err
+			return "", beacon, err
 		}
 		if err != nil {
 			zap.L().Warn(fmt.Sprintf("Decrypt failed: %v, retry times: %d\n", err, i))
@@ -50,15 +61,26 @@ func DecodeVoteResult(voteInfo string) (string, error) {
 		}
 		break
 	}
-	var parsedResult [][]string
-	err = json.Unmarshal(decrypt, &parsedResult)
+	result, err := ParseBallot(decrypt)
 	if err != nil {
-		zap.L().Error("unmarshal error：", zap.Error(err))
-		return "", err
+		zap.L().Error("parse ballot error：", zap.Error(err))
+		return "", beacon, err
+	}
+
+	return result, beacon, nil
+}
+
+// ParseBallot returns the vote option of a decrypted ballot, the JSON [["option"]] the voters encrypt.
+// A ballot of any other shape, such as several rows, several options or an empty option,
+// is rejected with constant.ErrInvalidBallot instead of counting one of its fields.
+func ParseBallot(ballot []byte) (string, error) {
+	var parsedResult [][]string
+	if err := json.Unmarshal(ballot, &parsedResult); err != nil {
+		return "", fmt.Errorf("%w: %v", constant.ErrInvalidBallot, err)
 	}
 
-	if len(parsedResult) == 0 && len(parsedResult[0]) == 0 {
-		return "", fmt.Errorf("no vote information")
+	if len(parsedResult) != 1 || len(parsedResult[0]) != 1 || parsedResult[0][0] == "" {
+		return "", fmt.Errorf("%w: %s", constant.ErrInvalidBallot, ballot)
 	}
 
 	return parsedResult[0][0], nil
@@ -69,6 +91,32 @@ func DecodeVoteResult(voteInfo string) (string, error) {
 // and decrypts the data using T-lock encryption.
 // The function returns the decrypted data or an error if decryption fails.
 func Decrypt(decStr string) ([]byte, error) {
+	data, _, err := DecryptWithBeacon(decStr)
+	return data, err
+}
+
+// beaconRecorder wraps a drand network to record the beacon fetched by tlock to decrypt.
+type beaconRecorder struct {
+	tlock.Network
+	beacon model.DrandBeacon
+}
+
+// Signature fetches the signature of the round from the wrapped network and records it.
+func (b *beaconRecorder) Signature(roundNumber uint64) ([]byte, error) {
+	signature, err := b.Network.Signature(roundNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	b.beacon = model.DrandBeacon{
+		Round:     roundNumber,
+		Signature: hex.EncodeToString(signature),
+	}
+	return signature, nil
+}
+
+// DecryptWithBeacon decrypts the data like Decrypt, and also returns the drand beacon used to decrypt it.
+func DecryptWithBeacon(decStr string) ([]byte, model.DrandBeacon, error) {
 	// Construct a network that can talk to a drand network. Example using the mainnet fastnet network.
 	replace := strings.ReplaceAll(decStr, "\\n", "\n")
 	replace2 := strings.ReplaceAll(replace, "\"", "")
@@ -81,21 +129,26 @@ func Decrypt(decStr string) ([]byte, error) {
 			break
 		}
 	}
+	if network == nil {
+		return nil, model.DrandBeacon{}, fmt.Errorf("connect drand network error: %w", err)
+	}
+	recorder := &beaconRecorder{Network: network}
+
 	reader := strings.NewReader(replace2)
 	// Write the encrypted file data to this buffer.
 	var cipherData bytes.Buffer
 	// Encrypt the data for the given round.
-	if err := tlock.New(network).Decrypt(&cipherData, reader); err != nil {
+	if err := tlock.New(recorder).Decrypt(&cipherData, reader); err != nil {
 		zap.L().Error("Decrypt: %v", zap.Error(err))
-		return nil, err
+		return nil, model.DrandBeacon{}, err
 	}
 
 	data := make([]byte, cipherData.Len())
 	_, err = cipherData.Read(data)
 	if err != nil {
 		zap.L().Error("read: %v", zap.Error(err))
-		return nil, err
+		return nil, model.DrandBeacon{}, err
 	}
-	return data, nil
+	return data, recorder.beacon, nil
 }
 
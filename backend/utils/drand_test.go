@@ -67,6 +67,25 @@ l0YZKbPlAmnEhcp3EAwQ84BvVSibhTmIzq/MYdsHnTX/1O8=
 	assert.Equal(t, constant.VoteReject, res)
 }
 
+func TestParseBallot(t *testing.T) {
+	res, err := utils.ParseBallot([]byte(`[["approve"]]`))
+	assert.Nil(t, err)
+	assert.Equal(t, constant.VoteApprove, res)
+
+	for _, ballot := range []string{
+		`[]`,
+		`[[]]`,
+		`[[""]]`,
+		`[["approve","reject"]]`,
+		`[["approve"],["reject"]]`,
+		`[[1]]`,
+		`"approve"`,
+	} {
+		_, err := utils.ParseBallot([]byte(ballot))
+		assert.ErrorIs(t, err, constant.ErrInvalidBallot, ballot)
+	}
+}
+
 func TestDecrypt(t *testing.T) {
 	initConfig()
 	decStr := `-----BEGIN AGE ENCRYPTED FILE-----
@@ -198,21 +198,29 @@ func VerifyFilecoinAddrSignature(address string, signature string, msgData []byt
 		return false, err
 	}
 
-	if len(signatureBytes) != 66 {
+	if len(signatureBytes) == 0 {
 		return false, errors.New("invalid signature length")
 	}
 
-	alg := ""
+	// The signature is prefixed with its type, a secp256k1 signature has 65 bytes and a BLS signature 96
+	alg, length := "", 0
 	switch signatureBytes[0] {
 	case byte(constant.SigTypeSecp256k1):
-		alg = constant.KTSecp256k1
+		alg, length = constant.KTSecp256k1, 66
 	case byte(constant.SigTypeBLS):
-		alg = constant.KTBLS
+		alg, length = constant.KTBLS, 97
 	default:
 		return false, errors.New("unsupported filecoin signature algorithm")
 	}
 
+	if len(signatureBytes) != length {
+		return false, errors.New("invalid signature length")
+	}
+
 	sig, err := createFilecoinSignature(alg, signatureBytes[1:])
+	if err != nil {
+		return false, err
+	}
 
 	verify, err := WalletVerify(context.Background(), address, sig, msgData)
 	if err != nil {
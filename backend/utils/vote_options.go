@@ -0,0 +1,52 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"slices"
+	"strings"
+
+	"powervoting-server/constant"
+)
+
+// ParseVoteOptions returns the vote options a proposal declares in the last line of its content
+// that starts with "Vote options:", a comma separated list such as "Vote options: approve, reject, abstain, delay".
+// The options are lowercased and deduplicated, and approve and reject are always offered since the outcome is decided on them.
+// A content that declares no options offers the default approve, reject and abstain.
+func ParseVoteOptions(content string) []string {
+	lines := strings.Split(content, "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := strings.TrimSpace(lines[i])
+		if len(line) < len(constant.VoteOptionsPrefix) || !strings.EqualFold(line[:len(constant.VoteOptionsPrefix)], constant.VoteOptionsPrefix) {
+			continue
+		}
+
+		options := []string{constant.VoteApprove, constant.VoteReject}
+		for _, option := range strings.Split(line[len(constant.VoteOptionsPrefix):], ",") {
+			option = strings.ToLower(strings.TrimSpace(option))
+			if option == "" || len(option) > constant.MaxVoteOptionLength || slices.Contains(options, option) {
+				continue
+			}
+			if len(options) == constant.MaxVoteOptions {
+				break
+			}
+			options = append(options, option)
+		}
+
+		return options
+	}
+
+	return slices.Clone(constant.DefaultVoteOptions)
+}
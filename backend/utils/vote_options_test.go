@@ -0,0 +1,33 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseVoteOptions(t *testing.T) {
+	// a content without a declaration offers the default options
+	assert.Equal(t, []string{"approve", "reject", "abstain"}, ParseVoteOptions("Fund the grant program"))
+
+	// the last declaration wins, approve and reject are always offered
+	content := "Fund the grant program\nVote options: approve, reject\n\nvote OPTIONS: Delay, abstain, delay, , reject\n"
+	assert.Equal(t, []string{"approve", "reject", "delay", "abstain"}, ParseVoteOptions(content))
+
+	// a yes or no ballot leaves abstain out
+	assert.Equal(t, []string{"approve", "reject"}, ParseVoteOptions("Vote options: approve, reject"))
+}
@@ -0,0 +1,106 @@
+// Package client is a typed client of the PowerVoting backend REST API.
+// The request and response types and the API methods are generated from the OpenAPI document of the backend
+// into client_gen.go, run `go generate ./router` in the backend to update them.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// codeOK is the code of a successful answer of the API.
+const codeOK = 0
+
+// Client calls the REST API of the PowerVoting backend.
+type Client struct {
+	BaseURL    string       // URL of the API prefix, such as https://example.com/power_voting/api
+	Token      string       // Bearer token of the routes that need one, such as the webhook management
+	HTTPClient *http.Client // HTTP client used for the requests, http.DefaultClient if nil
+}
+
+// NewClient returns a client of the API served at baseURL.
+func NewClient(baseURL string) *Client {
+	return &Client{BaseURL: strings.TrimSuffix(baseURL, "/")}
+}
+
+// Error is an answer of the API whose code is not 0.
+type Error struct {
+	Code    int    // Code of the answer
+	Message string // Message of the answer
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("power voting api error %d: %s", e.Code, e.Message)
+}
+
+// envelope is the JSON answer of every API route.
+type envelope struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// do sends a request to the API and decodes the data of its answer into out, when out is not nil.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body any, out any) error {
+	u := c.BaseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("encode request body: %w", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: unexpected status %s", method, path, resp.Status)
+	}
+
+	var answer envelope
+	if err := json.NewDecoder(resp.Body).Decode(&answer); err != nil {
+		return fmt.Errorf("decode answer of %s %s: %w", method, path, err)
+	}
+	if answer.Code != codeOK {
+		return &Error{Code: answer.Code, Message: answer.Message}
+	}
+
+	if out == nil || len(answer.Data) == 0 || string(answer.Data) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(answer.Data, out); err != nil {
+		return fmt.Errorf("decode data of %s %s: %w", method, path, err)
+	}
+
+	return nil
+}
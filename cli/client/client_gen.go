@@ -0,0 +1,940 @@
+// Code generated by powervoting-server/openapi/gen from the OpenAPI document. DO NOT EDIT.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+type AddProposalDraftReq struct {
+	DraftId               int64  `json:"draftId"`
+	Name                  string `json:"name"`
+	Creator               string `json:"creator"`
+	StartTime             int64  `json:"startTime"`
+	EndTime               int64  `json:"endTime"`
+	Timezone              string `json:"timezone"`
+	Title                 string `json:"title"`
+	Content               string `json:"content"`
+	ChainId               int64  `json:"chainId"`
+	TokenHolderPercentage int    `json:"tokenHolderPercentage"`
+	SpPercentage          int    `json:"spPercentage"`
+	ClientPercentage      int    `json:"clientPercentage"`
+	DeveloperPercentage   int    `json:"developerPercentage"`
+	Timestamp             int64  `json:"timestamp"`
+	Nonce                 string `json:"nonce"`
+	Signature             string `json:"signature"`
+}
+
+type AddWebhookReq struct {
+	Url         string   `json:"url"`
+	Events      []string `json:"events"`
+	ChainId     int64    `json:"chainId"`
+	Description string   `json:"description"`
+}
+
+type CategoryRep struct {
+	Weight     int                       `json:"weight"`
+	TotalPower string                    `json:"totalPower"`
+	Options    map[string]OptionPowerRep `json:"options"`
+}
+
+type ConfChangeRep struct {
+	OldValue    string `json:"oldValue"`
+	NewValue    string `json:"newValue"`
+	BlockNumber int64  `json:"blockNumber"`
+	TxHash      string `json:"txHash"`
+	Timestamp   int64  `json:"timestamp"`
+}
+
+type CountListRepOfDeadLetterRep struct {
+	Total int64           `json:"total"`
+	List  []DeadLetterRep `json:"list"`
+}
+
+type CountListRepOfFipProposalRep struct {
+	Total int64            `json:"total"`
+	List  []FipProposalRep `json:"list"`
+}
+
+type CountListRepOfProposalRep struct {
+	Total int64         `json:"total"`
+	List  []ProposalRep `json:"list"`
+}
+
+type CountListRepOfSnapshotHeightRep struct {
+	Total int64               `json:"total"`
+	List  []SnapshotHeightRep `json:"list"`
+}
+
+type CountListRepOfWebhookDeliveryRep struct {
+	Total int64                `json:"total"`
+	List  []WebhookDeliveryRep `json:"list"`
+}
+
+type DeadLetterRep struct {
+	Id              int64           `json:"id"`
+	ChainId         int64           `json:"chainId"`
+	Event           string          `json:"event"`
+	ContractAddress string          `json:"contractAddress"`
+	BlockNumber     int64           `json:"blockNumber"`
+	TxHash          string          `json:"txHash"`
+	LogIndex        int             `json:"logIndex"`
+	RawLog          json.RawMessage `json:"rawLog"`
+	Error           string          `json:"error"`
+	Attempts        int             `json:"attempts"`
+	Status          int             `json:"status"`
+	NextAttemptTime int64           `json:"nextAttemptTime"`
+	FailedTime      int64           `json:"failedTime"`
+}
+
+type DraftDiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+type DraftFieldChange struct {
+	Field string `json:"field"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+type FipEditorGistInfoRep struct {
+	GistId     string    `json:"gistId"`
+	GistSigObj SigObject `json:"gistSigObj"`
+	MinerIds   []string  `json:"minerIds"`
+	ActorId    string    `json:"actorId"`
+}
+
+type FipEditorRep struct {
+	Editor    string `json:"editor"`
+	ChainId   int64  `json:"chainId"`
+	Timestamp int64  `json:"timestamp"`
+}
+
+type FipProposalRep struct {
+	ProposalId       int64    `json:"proposalId"`
+	ChainId          int64    `json:"chainId"`
+	ProposalType     int      `json:"proposalType"`
+	Creator          string   `json:"creator"`
+	CandidateAddress string   `json:"candidateAddress"`
+	CandidateInfo    string   `json:"candidateInfo"`
+	Timestamp        int64    `json:"timestamp"`
+	VotedCount       int64    `json:"votedCount"`
+	EditorCount      int64    `json:"editorCount"`
+	Status           int      `json:"status"`
+	VotedAddresss    []string `json:"votedAddresss"`
+}
+
+type GithubRepoRep struct {
+	RepoId      int64  `json:"repoId"`
+	RepoName    string `json:"repoName"`
+	OrgType     int    `json:"orgType"`
+	Removed     bool   `json:"removed"`
+	BlockNumber int64  `json:"blockNumber"`
+	Timestamp   int64  `json:"timestamp"`
+}
+
+type OptionPowerRep struct {
+	Power      string  `json:"power"`
+	Share      float64 `json:"share"`
+	ExactShare string  `json:"exactShare"`
+}
+
+type ParticipationPoint struct {
+	Time   int64      `json:"time"`
+	Voters int64      `json:"voters"`
+	Power  TotalPower `json:"power"`
+}
+
+type PowerRep struct {
+	DeveloperPower   string `json:"developerPower"`
+	SpPower          string `json:"spPower"`
+	ClientPower      string `json:"clientPower"`
+	TokenHolderPower string `json:"tokenHolderPower"`
+}
+
+type ProposalAuditRep struct {
+	ProposalId int64           `json:"proposalId"`
+	ChainId    int64           `json:"chainId"`
+	Sha256     string          `json:"sha256"`
+	Bundle     json.RawMessage `json:"bundle"`
+}
+
+type ProposalDraftDiffRep struct {
+	DraftId int64              `json:"draftId"`
+	From    int                `json:"from"`
+	To      int                `json:"to"`
+	Fields  []DraftFieldChange `json:"fields"`
+	Content []DraftDiffLine    `json:"content"`
+}
+
+type ProposalDraftRep struct {
+	DraftId               int64  `json:"draftId"`
+	Name                  string `json:"name"`
+	Creator               string `json:"creator"`
+	ChainId               int64  `json:"chainId"`
+	Version               int    `json:"version"`
+	SubmittedVersion      int    `json:"submittedVersion"`
+	ProposalId            int64  `json:"proposalId"`
+	UpdatedAt             int64  `json:"updatedAt"`
+	Title                 string `json:"title"`
+	Content               string `json:"content"`
+	StartTime             int64  `json:"startTime"`
+	EndTime               int64  `json:"endTime"`
+	Timezone              string `json:"timezone"`
+	TokenHolderPercentage int    `json:"tokenHolderPercentage"`
+	SpPercentage          int    `json:"spPercentage"`
+	DeveloperPercentage   int    `json:"developerPercentage"`
+	ClientPercentage      int    `json:"clientPercentage"`
+}
+
+type ProposalDraftVersionRep struct {
+	Version               int    `json:"version"`
+	CreatedAt             int64  `json:"createdAt"`
+	Title                 string `json:"title"`
+	Content               string `json:"content"`
+	StartTime             int64  `json:"startTime"`
+	EndTime               int64  `json:"endTime"`
+	Timezone              string `json:"timezone"`
+	TokenHolderPercentage int    `json:"tokenHolderPercentage"`
+	SpPercentage          int    `json:"spPercentage"`
+	DeveloperPercentage   int    `json:"developerPercentage"`
+	ClientPercentage      int    `json:"clientPercentage"`
+}
+
+type ProposalParticipationRep struct {
+	ProposalId   int64                `json:"proposalId"`
+	ChainId      int64                `json:"chainId"`
+	SnapshotDay  string               `json:"snapshotDay"`
+	Voters       int64                `json:"voters"`
+	MissingPower int64                `json:"missingPower"`
+	Power        TotalPower           `json:"power"`
+	Electorate   TotalPower           `json:"electorate"`
+	Interval     int64                `json:"interval"`
+	Timeline     []ParticipationPoint `json:"timeline"`
+}
+
+type ProposalPercentage struct {
+	TokenHolderPercentage int `json:"tokenHolderPercentage"`
+	SpPercentage          int `json:"spPercentage"`
+	ClientPercentage      int `json:"clientPercentage"`
+	DeveloperPercentage   int `json:"developerPercentage"`
+}
+
+type ProposalQuorum struct {
+	MinVoters             int64 `json:"minVoters"`
+	SpPowerShare          int   `json:"spPowerShare"`
+	ClientPowerShare      int   `json:"clientPowerShare"`
+	TokenHolderPowerShare int   `json:"tokenHolderPowerShare"`
+	DeveloperPowerShare   int   `json:"developerPowerShare"`
+}
+
+type ProposalRep struct {
+	ProposalId         int64                  `json:"proposalId"`
+	Address            string                 `json:"address"`
+	GithubName         string                 `json:"githubName"`
+	GithubAvatar       string                 `json:"githubAvatar"`
+	StartTime          int64                  `json:"startTime"`
+	EndTime            int64                  `json:"endTime"`
+	ChainId            int64                  `json:"chainId"`
+	Title              string                 `json:"title"`
+	Content            string                 `json:"content"`
+	CreatedAt          int64                  `json:"createdAt"`
+	UpdatedAt          int64                  `json:"updatedAt"`
+	Voted              bool                   `json:"voted"`
+	Status             int                    `json:"status"`
+	VotePercentage     ProposalVotePercentage `json:"votePercentage"`
+	SnapshotInfo       SnapshotInfo           `json:"snapshotInfo"`
+	Percentage         ProposalPercentage     `json:"percentage"`
+	TotalPower         TotalPower             `json:"totalPower"`
+	CountingAlgorithm  string                 `json:"countingAlgorithm"`
+	Options            []string               `json:"options"`
+	Outcome            string                 `json:"outcome"`
+	Quorum             ProposalQuorum         `json:"quorum"`
+	ResultRevision     int64                  `json:"resultRevision"`
+	AwaitingPower      bool                   `json:"awaitingPower"`
+	MissingPowerVoters []string               `json:"missingPowerVoters"`
+	CategoryBreakdown  map[string]CategoryRep `json:"categoryBreakdown"`
+}
+
+type ProposalResultHistoryRep struct {
+	ResultRevision int64                  `json:"resultRevision"`
+	Reason         string                 `json:"reason"`
+	RecountedAt    int64                  `json:"recountedAt"`
+	Outcome        string                 `json:"outcome"`
+	VotePercentage ProposalVotePercentage `json:"votePercentage"`
+	TotalPower     TotalPower             `json:"totalPower"`
+	AuditSha256    string                 `json:"auditSha256"`
+}
+
+type ProposalVotePercentage struct {
+	Approve float64            `json:"approve"`
+	Reject  float64            `json:"reject"`
+	Abstain float64            `json:"abstain"`
+	Options map[string]float64 `json:"options"`
+	Exact   map[string]string  `json:"exact"`
+}
+
+type SigObject struct {
+	GithubName    string `json:"githubName"`
+	WalletAddress string `json:"walletAddress"`
+	Timestamp     int64  `json:"timestamp"`
+}
+
+type SnapshotHeightRep struct {
+	Day         string `json:"day"`
+	Height      int64  `json:"height"`
+	BlockNumber int64  `json:"blockNumber"`
+	Timestamp   int64  `json:"timestamp"`
+}
+
+type SnapshotInfo struct {
+	SnapshotDay    string `json:"snapshotDay"`
+	SnapshotHeight int64  `json:"snapshotHeight"`
+}
+
+type SubmitProposalDraftReq struct {
+	Creator   string `json:"creator"`
+	Version   int    `json:"version"`
+	ChainId   int64  `json:"chainId"`
+	DraftId   int64  `json:"draftId"`
+	Timestamp int64  `json:"timestamp"`
+	Nonce     string `json:"nonce"`
+	Signature string `json:"signature"`
+}
+
+type TotalPower struct {
+	SpPower          string `json:"spPower"`
+	TokenHolderPower string `json:"tokenHolderPower"`
+	DeveloperPower   string `json:"developerPower"`
+	ClientPower      string `json:"clientPower"`
+}
+
+type VoteRevision struct {
+	Revision      int    `json:"revision"`
+	VoteEncrypted string `json:"voteEncrypted"`
+	BlockNumber   int64  `json:"blockNumber"`
+	LogIndex      int    `json:"logIndex"`
+	TxHash        string `json:"txHash"`
+	VotedTime     int64  `json:"votedTime"`
+	Valid         bool   `json:"valid"`
+	Current       bool   `json:"current"`
+}
+
+type Voted struct {
+	ProposalId       int64  `json:"proposalId"`
+	ChainId          int64  `json:"chainId"`
+	VoterAddress     string `json:"voterAddress"`
+	VotedResult      string `json:"votedResult"`
+	Percentage       string `json:"percentage"`
+	VotedTime        int64  `json:"votedTime"`
+	DeveloperPower   string `json:"developerPower"`
+	SpPower          string `json:"spPower"`
+	ClientPower      string `json:"clientPower"`
+	TokenHolderPower string `json:"tokenHolderPower"`
+}
+
+type VoterHistoryRep struct {
+	ProposalId       int64  `json:"proposalId"`
+	ChainId          int64  `json:"chainId"`
+	Title            string `json:"title"`
+	BlockNumber      int64  `json:"blockNumber"`
+	VotedTime        int64  `json:"votedTime"`
+	VotedResult      string `json:"votedResult"`
+	Counted          bool   `json:"counted"`
+	Outcome          string `json:"outcome"`
+	DeveloperPower   string `json:"developerPower"`
+	SpPower          string `json:"spPower"`
+	ClientPower      string `json:"clientPower"`
+	TokenHolderPower string `json:"tokenHolderPower"`
+}
+
+type WebhookDeliveryRep struct {
+	Id              int64  `json:"id"`
+	WebhookId       int64  `json:"webhookId"`
+	EventId         int64  `json:"eventId"`
+	Event           string `json:"event"`
+	Attempts        int    `json:"attempts"`
+	Status          int    `json:"status"`
+	NextAttemptTime int64  `json:"nextAttemptTime"`
+	ResponseStatus  int    `json:"responseStatus"`
+	Error           string `json:"error"`
+	DeliveredTime   int64  `json:"deliveredTime"`
+	CreatedTime     int64  `json:"createdTime"`
+}
+
+type WebhookRep struct {
+	Id          int64    `json:"id"`
+	Url         string   `json:"url"`
+	Events      []string `json:"events"`
+	ChainId     int64    `json:"chainId"`
+	Description string   `json:"description"`
+	Secret      string   `json:"secret"`
+	CreatedTime int64    `json:"createdTime"`
+}
+
+// AddWebhook calls POST /webhook/add: Subscribe a URL to governance lifecycle events.
+func (c *Client) AddWebhook(ctx context.Context, body AddWebhookReq) (*WebhookRep, error) {
+	var data WebhookRep
+	if err := c.do(ctx, "POST", "/webhook/add", nil, body, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// DeleteDraftParams holds the query parameters of DeleteDraft.
+type DeleteDraftParams struct {
+	Address   string
+	ChainId   int64
+	DraftId   int64
+	Timestamp int64
+	Nonce     string
+	Signature string
+}
+
+// DeleteDraft calls DELETE /proposal/draft/delete: Delete a specific proposal draft.
+func (c *Client) DeleteDraft(ctx context.Context, params DeleteDraftParams) error {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", fmt.Sprint(params.Address))
+	}
+	query.Set("chainId", fmt.Sprint(params.ChainId))
+	query.Set("draftId", fmt.Sprint(params.DraftId))
+	query.Set("timestamp", fmt.Sprint(params.Timestamp))
+	query.Set("nonce", fmt.Sprint(params.Nonce))
+	query.Set("signature", fmt.Sprint(params.Signature))
+	return c.do(ctx, "DELETE", "/proposal/draft/delete", query, nil, nil)
+}
+
+// DeleteWebhookParams holds the query parameters of DeleteWebhook.
+type DeleteWebhookParams struct {
+	Id int64
+}
+
+// DeleteWebhook calls DELETE /webhook/delete: Delete a webhook.
+func (c *Client) DeleteWebhook(ctx context.Context, params DeleteWebhookParams) error {
+	query := url.Values{}
+	query.Set("id", fmt.Sprint(params.Id))
+	return c.do(ctx, "DELETE", "/webhook/delete", query, nil, nil)
+}
+
+// GetAddressPowerParams holds the query parameters of GetAddressPower.
+type GetAddressPowerParams struct {
+	PowerDay string
+	ChainId  int64
+	Address  string
+}
+
+// GetAddressPower calls GET /power/getPower: Get power distribution for a specific address.
+func (c *Client) GetAddressPower(ctx context.Context, params GetAddressPowerParams) (*PowerRep, error) {
+	query := url.Values{}
+	query.Set("powerDay", fmt.Sprint(params.PowerDay))
+	query.Set("chainId", fmt.Sprint(params.ChainId))
+	if params.Address != "" {
+		query.Set("address", fmt.Sprint(params.Address))
+	}
+	var data PowerRep
+	if err := c.do(ctx, "GET", "/power/getPower", query, nil, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetCountedVotesInfoParams holds the query parameters of GetCountedVotesInfo.
+type GetCountedVotesInfoParams struct {
+	ProposalId int64
+	ChainId    int64
+}
+
+// GetCountedVotesInfo calls GET /proposal/votes: Get counted votes for a proposal.
+func (c *Client) GetCountedVotesInfo(ctx context.Context, params GetCountedVotesInfoParams) ([]Voted, error) {
+	query := url.Values{}
+	query.Set("proposalId", fmt.Sprint(params.ProposalId))
+	query.Set("chainId", fmt.Sprint(params.ChainId))
+	var data []Voted
+	if err := c.do(ctx, "GET", "/proposal/votes", query, nil, &data); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// GetCountingAlgorithmHistoryParams holds the query parameters of GetCountingAlgorithmHistory.
+type GetCountingAlgorithmHistoryParams struct {
+	ChainId int64
+}
+
+// GetCountingAlgorithmHistory calls GET /conf/countingAlgorithm/history: Get the changes of the vote counting algorithm.
+func (c *Client) GetCountingAlgorithmHistory(ctx context.Context, params GetCountingAlgorithmHistoryParams) ([]ConfChangeRep, error) {
+	query := url.Values{}
+	query.Set("chainId", fmt.Sprint(params.ChainId))
+	var data []ConfChangeRep
+	if err := c.do(ctx, "GET", "/conf/countingAlgorithm/history", query, nil, &data); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// GetDeadLetterListParams holds the query parameters of GetDeadLetterList.
+type GetDeadLetterListParams struct {
+	Page      int
+	PageSize  int
+	ChainId   int64
+	Exhausted bool
+}
+
+// GetDeadLetterList calls GET /event/deadLetter/list: Get the event logs that failed to be applied.
+func (c *Client) GetDeadLetterList(ctx context.Context, params GetDeadLetterListParams) (*CountListRepOfDeadLetterRep, error) {
+	query := url.Values{}
+	if params.Page != 0 {
+		query.Set("page", fmt.Sprint(params.Page))
+	}
+	if params.PageSize != 0 {
+		query.Set("pageSize", fmt.Sprint(params.PageSize))
+	}
+	query.Set("chainId", fmt.Sprint(params.ChainId))
+	if params.Exhausted != false {
+		query.Set("exhausted", fmt.Sprint(params.Exhausted))
+	}
+	var data CountListRepOfDeadLetterRep
+	if err := c.do(ctx, "GET", "/event/deadLetter/list", query, nil, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetDraftParams holds the query parameters of GetDraft.
+type GetDraftParams struct {
+	Address string
+	DraftId int64
+}
+
+// GetDraft calls GET /proposal/draft/get: Get a specific proposal draft.
+func (c *Client) GetDraft(ctx context.Context, params GetDraftParams) (*ProposalDraftRep, error) {
+	query := url.Values{}
+	if params.Address != "" {
+		query.Set("address", fmt.Sprint(params.Address))
+	}
+	if params.DraftId != 0 {
+		query.Set("draftId", fmt.Sprint(params.DraftId))
+	}
+	var data ProposalDraftRep
+	if err := c.do(ctx, "GET", "/proposal/draft/get", query, nil, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetDraftDiffParams holds the query parameters of GetDraftDiff.
+type GetDraftDiffParams struct {
+	DraftId int64
+	From    int
+	To      int
+}
+
+// GetDraftDiff calls GET /proposal/draft/diff: Compare two versions of a proposal draft.
+func (c *Client) GetDraftDiff(ctx context.Context, params GetDraftDiffParams) (*ProposalDraftDiffRep, error) {
+	query := url.Values{}
+	query.Set("draftId", fmt.Sprint(params.DraftId))
+	query.Set("from", fmt.Sprint(params.From))
+	query.Set("to", fmt.Sprint(params.To))
+	var data ProposalDraftDiffRep
+	if err := c.do(ctx, "GET", "/proposal/draft/diff", query, nil, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetDraftListParams holds the query parameters of GetDraftList.
+type GetDraftListParams struct {
+	Address string
+	ChainId int64
+}
+
+// GetDraftList calls GET /proposal/draft/list: Get the proposal drafts of a creator.
+func (c *Client) GetDraftList(ctx context.Context, params GetDraftListParams) ([]ProposalDraftRep, error) {
+	query := url.Values{}
+	query.Set("address", fmt.Sprint(params.Address))
+	if params.ChainId != 0 {
+		query.Set("chainId", fmt.Sprint(params.ChainId))
+	}
+	var data []ProposalDraftRep
+	if err := c.do(ctx, "GET", "/proposal/draft/list", query, nil, &data); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// GetDraftVersionsParams holds the query parameters of GetDraftVersions.
+type GetDraftVersionsParams struct {
+	DraftId int64
+}
+
+// GetDraftVersions calls GET /proposal/draft/versions: Get the version history of a proposal draft.
+func (c *Client) GetDraftVersions(ctx context.Context, params GetDraftVersionsParams) ([]ProposalDraftVersionRep, error) {
+	query := url.Values{}
+	query.Set("draftId", fmt.Sprint(params.DraftId))
+	var data []ProposalDraftVersionRep
+	if err := c.do(ctx, "GET", "/proposal/draft/versions", query, nil, &data); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// GetFipEditorGistInfoParams holds the query parameters of GetFipEditorGistInfo.
+type GetFipEditorGistInfoParams struct {
+	ChainId int64
+	Address string
+}
+
+// GetFipEditorGistInfo calls GET /voter/info: Get the gist info of a voter.
+func (c *Client) GetFipEditorGistInfo(ctx context.Context, params GetFipEditorGistInfoParams) (*FipEditorGistInfoRep, error) {
+	query := url.Values{}
+	if params.ChainId != 0 {
+		query.Set("chainId", fmt.Sprint(params.ChainId))
+	}
+	if params.Address != "" {
+		query.Set("address", fmt.Sprint(params.Address))
+	}
+	var data FipEditorGistInfoRep
+	if err := c.do(ctx, "GET", "/voter/info", query, nil, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetFipEditorListParams holds the query parameters of GetFipEditorList.
+type GetFipEditorListParams struct {
+	ChainId int64
+}
+
+// GetFipEditorList calls GET /fipEditor/list: Get a list of FIP editors.
+func (c *Client) GetFipEditorList(ctx context.Context, params GetFipEditorListParams) ([]FipEditorRep, error) {
+	query := url.Values{}
+	query.Set("chainId", fmt.Sprint(params.ChainId))
+	var data []FipEditorRep
+	if err := c.do(ctx, "GET", "/fipEditor/list", query, nil, &data); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// GetFipProposalListParams holds the query parameters of GetFipProposalList.
+type GetFipProposalListParams struct {
+	Page         int
+	PageSize     int
+	ProposalType int
+	ChainId      int64
+}
+
+// GetFipProposalList calls GET /fipProposal/list: Get a list of FIP editor proposals.
+func (c *Client) GetFipProposalList(ctx context.Context, params GetFipProposalListParams) (*CountListRepOfFipProposalRep, error) {
+	query := url.Values{}
+	if params.Page != 0 {
+		query.Set("page", fmt.Sprint(params.Page))
+	}
+	if params.PageSize != 0 {
+		query.Set("pageSize", fmt.Sprint(params.PageSize))
+	}
+	if params.ProposalType != 0 {
+		query.Set("proposalType", fmt.Sprint(params.ProposalType))
+	}
+	query.Set("chainId", fmt.Sprint(params.ChainId))
+	var data CountListRepOfFipProposalRep
+	if err := c.do(ctx, "GET", "/fipProposal/list", query, nil, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetGithubRepoListParams holds the query parameters of GetGithubRepoList.
+type GetGithubRepoListParams struct {
+	ChainId        int64
+	IncludeRemoved bool
+}
+
+// GetGithubRepoList calls GET /conf/githubRepo/list: Get the GitHub repositories of the developer power.
+func (c *Client) GetGithubRepoList(ctx context.Context, params GetGithubRepoListParams) ([]GithubRepoRep, error) {
+	query := url.Values{}
+	query.Set("chainId", fmt.Sprint(params.ChainId))
+	if params.IncludeRemoved != false {
+		query.Set("includeRemoved", fmt.Sprint(params.IncludeRemoved))
+	}
+	var data []GithubRepoRep
+	if err := c.do(ctx, "GET", "/conf/githubRepo/list", query, nil, &data); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// GetProposalAuditParams holds the query parameters of GetProposalAudit.
+type GetProposalAuditParams struct {
+	ProposalId int64
+	ChainId    int64
+}
+
+// GetProposalAudit calls GET /proposal/audit: Get the tally audit bundle of a counted proposal.
+func (c *Client) GetProposalAudit(ctx context.Context, params GetProposalAuditParams) (*ProposalAuditRep, error) {
+	query := url.Values{}
+	query.Set("proposalId", fmt.Sprint(params.ProposalId))
+	query.Set("chainId", fmt.Sprint(params.ChainId))
+	var data ProposalAuditRep
+	if err := c.do(ctx, "GET", "/proposal/audit", query, nil, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetProposalDetailParams holds the query parameters of GetProposalDetail.
+type GetProposalDetailParams struct {
+	ProposalId int64
+	ChainId    int64
+}
+
+// GetProposalDetail calls GET /proposal/details: Get details of a specific proposal.
+func (c *Client) GetProposalDetail(ctx context.Context, params GetProposalDetailParams) (*ProposalRep, error) {
+	query := url.Values{}
+	query.Set("proposalId", fmt.Sprint(params.ProposalId))
+	query.Set("chainId", fmt.Sprint(params.ChainId))
+	var data ProposalRep
+	if err := c.do(ctx, "GET", "/proposal/details", query, nil, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetProposalListParams holds the query parameters of GetProposalList.
+type GetProposalListParams struct {
+	Status    int
+	SearchKey string
+	Address   string
+	Page      int
+	PageSize  int
+	ChainId   int64
+}
+
+// GetProposalList calls GET /proposal/list: Get a list of proposals.
+func (c *Client) GetProposalList(ctx context.Context, params GetProposalListParams) (*CountListRepOfProposalRep, error) {
+	query := url.Values{}
+	if params.Status != 0 {
+		query.Set("status", fmt.Sprint(params.Status))
+	}
+	if params.SearchKey != "" {
+		query.Set("searchKey", fmt.Sprint(params.SearchKey))
+	}
+	if params.Address != "" {
+		query.Set("address", fmt.Sprint(params.Address))
+	}
+	if params.Page != 0 {
+		query.Set("page", fmt.Sprint(params.Page))
+	}
+	if params.PageSize != 0 {
+		query.Set("pageSize", fmt.Sprint(params.PageSize))
+	}
+	query.Set("chainId", fmt.Sprint(params.ChainId))
+	var data CountListRepOfProposalRep
+	if err := c.do(ctx, "GET", "/proposal/list", query, nil, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetProposalParticipationParams holds the query parameters of GetProposalParticipation.
+type GetProposalParticipationParams struct {
+	ProposalId int64
+	ChainId    int64
+	Interval   int64
+}
+
+// GetProposalParticipation calls GET /proposal/participation: Get the voters and committed power of a proposal over time.
+func (c *Client) GetProposalParticipation(ctx context.Context, params GetProposalParticipationParams) (*ProposalParticipationRep, error) {
+	query := url.Values{}
+	query.Set("proposalId", fmt.Sprint(params.ProposalId))
+	query.Set("chainId", fmt.Sprint(params.ChainId))
+	if params.Interval != 0 {
+		query.Set("interval", fmt.Sprint(params.Interval))
+	}
+	var data ProposalParticipationRep
+	if err := c.do(ctx, "GET", "/proposal/participation", query, nil, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetProposalResultHistoryParams holds the query parameters of GetProposalResultHistory.
+type GetProposalResultHistoryParams struct {
+	ProposalId int64
+	ChainId    int64
+}
+
+// GetProposalResultHistory calls GET /proposal/result/history: Get the results of a proposal replaced by recounts.
+func (c *Client) GetProposalResultHistory(ctx context.Context, params GetProposalResultHistoryParams) ([]ProposalResultHistoryRep, error) {
+	query := url.Values{}
+	query.Set("proposalId", fmt.Sprint(params.ProposalId))
+	query.Set("chainId", fmt.Sprint(params.ChainId))
+	var data []ProposalResultHistoryRep
+	if err := c.do(ctx, "GET", "/proposal/result/history", query, nil, &data); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// GetSnapshotExpirationHistoryParams holds the query parameters of GetSnapshotExpirationHistory.
+type GetSnapshotExpirationHistoryParams struct {
+	ChainId int64
+}
+
+// GetSnapshotExpirationHistory calls GET /conf/snapshotExpiration/history: Get the changes of the snapshot expiration days.
+func (c *Client) GetSnapshotExpirationHistory(ctx context.Context, params GetSnapshotExpirationHistoryParams) ([]ConfChangeRep, error) {
+	query := url.Values{}
+	query.Set("chainId", fmt.Sprint(params.ChainId))
+	var data []ConfChangeRep
+	if err := c.do(ctx, "GET", "/conf/snapshotExpiration/history", query, nil, &data); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// GetSnapshotHeightListParams holds the query parameters of GetSnapshotHeightList.
+type GetSnapshotHeightListParams struct {
+	Page     int
+	PageSize int
+	ChainId  int64
+}
+
+// GetSnapshotHeightList calls GET /conf/snapshotHeight/list: Get the snapshot heights of the days.
+func (c *Client) GetSnapshotHeightList(ctx context.Context, params GetSnapshotHeightListParams) (*CountListRepOfSnapshotHeightRep, error) {
+	query := url.Values{}
+	if params.Page != 0 {
+		query.Set("page", fmt.Sprint(params.Page))
+	}
+	if params.PageSize != 0 {
+		query.Set("pageSize", fmt.Sprint(params.PageSize))
+	}
+	query.Set("chainId", fmt.Sprint(params.ChainId))
+	var data CountListRepOfSnapshotHeightRep
+	if err := c.do(ctx, "GET", "/conf/snapshotHeight/list", query, nil, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetVoteHistoryParams holds the query parameters of GetVoteHistory.
+type GetVoteHistoryParams struct {
+	ProposalId int64
+	ChainId    int64
+	Address    string
+}
+
+// GetVoteHistory calls GET /proposal/votes/history: Get every vote of a voter on a proposal.
+func (c *Client) GetVoteHistory(ctx context.Context, params GetVoteHistoryParams) ([]VoteRevision, error) {
+	query := url.Values{}
+	query.Set("proposalId", fmt.Sprint(params.ProposalId))
+	query.Set("chainId", fmt.Sprint(params.ChainId))
+	query.Set("address", fmt.Sprint(params.Address))
+	var data []VoteRevision
+	if err := c.do(ctx, "GET", "/proposal/votes/history", query, nil, &data); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// GetVoterHistoryParams holds the query parameters of GetVoterHistory.
+type GetVoterHistoryParams struct {
+	ChainId int64
+	Address string
+}
+
+// GetVoterHistory calls GET /voter/history: Get the vote of a voter on every proposal.
+func (c *Client) GetVoterHistory(ctx context.Context, params GetVoterHistoryParams) ([]VoterHistoryRep, error) {
+	query := url.Values{}
+	query.Set("chainId", fmt.Sprint(params.ChainId))
+	query.Set("address", fmt.Sprint(params.Address))
+	var data []VoterHistoryRep
+	if err := c.do(ctx, "GET", "/voter/history", query, nil, &data); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// GetWebhookDeliveryListParams holds the query parameters of GetWebhookDeliveryList.
+type GetWebhookDeliveryListParams struct {
+	Page     int
+	PageSize int
+	Id       int64
+	Status   *int
+}
+
+// GetWebhookDeliveryList calls GET /webhook/delivery/list: Get the delivery log of a webhook.
+func (c *Client) GetWebhookDeliveryList(ctx context.Context, params GetWebhookDeliveryListParams) (*CountListRepOfWebhookDeliveryRep, error) {
+	query := url.Values{}
+	if params.Page != 0 {
+		query.Set("page", fmt.Sprint(params.Page))
+	}
+	if params.PageSize != 0 {
+		query.Set("pageSize", fmt.Sprint(params.PageSize))
+	}
+	query.Set("id", fmt.Sprint(params.Id))
+	if params.Status != nil {
+		query.Set("status", fmt.Sprint(*params.Status))
+	}
+	var data CountListRepOfWebhookDeliveryRep
+	if err := c.do(ctx, "GET", "/webhook/delivery/list", query, nil, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// GetWebhookList calls GET /webhook/list: Get the webhooks.
+func (c *Client) GetWebhookList(ctx context.Context) ([]WebhookRep, error) {
+	var data []WebhookRep
+	if err := c.do(ctx, "GET", "/webhook/list", nil, nil, &data); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+// HealthCheck calls GET /health_check: Check that the server is up.
+func (c *Client) HealthCheck(ctx context.Context) error {
+	return c.do(ctx, "GET", "/health_check", nil, nil, nil)
+}
+
+// PostDraft calls POST /proposal/draft/add: Add a new proposal draft, or a new version of a draft.
+func (c *Client) PostDraft(ctx context.Context, body AddProposalDraftReq) (*ProposalDraftRep, error) {
+	var data ProposalDraftRep
+	if err := c.do(ctx, "POST", "/proposal/draft/add", nil, body, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// SubmitDraft calls POST /proposal/draft/submit: Mark the draft version a proposal is created from.
+func (c *Client) SubmitDraft(ctx context.Context, body SubmitProposalDraftReq) error {
+	return c.do(ctx, "POST", "/proposal/draft/submit", nil, body, nil)
+}
+
+// VerifyGistValidParams holds the query parameters of VerifyGistValid.
+type VerifyGistValidParams struct {
+	GistId  string
+	ChainId int64
+	Address string
+}
+
+// VerifyGistValid calls GET /fipEditor/checkGist: Check that a gist binds a GitHub account to the addresses.
+func (c *Client) VerifyGistValid(ctx context.Context, params VerifyGistValidParams) (*SigObject, error) {
+	query := url.Values{}
+	query.Set("gistId", fmt.Sprint(params.GistId))
+	if params.ChainId != 0 {
+		query.Set("chainId", fmt.Sprint(params.ChainId))
+	}
+	if params.Address != "" {
+		query.Set("address", fmt.Sprint(params.Address))
+	}
+	var data SigObject
+	if err := c.do(ctx, "GET", "/fipEditor/checkGist", query, nil, &data); err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
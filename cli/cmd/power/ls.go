@@ -68,18 +68,18 @@ func LsCmd(client *service.RPCClient) *cobra.Command {
 
 				// Convert the Token Holder Power from attoFIL to a readable format
 				tokenHolderPower := new(big.Int)
-				// Assume power.Data.TokenHolderPower is in attoFIL, and convert it
-				tokenHolderPower.SetString(power.Data.TokenHolderPower, 10)
+				// Assume power.TokenHolderPower is in attoFIL, and convert it
+				tokenHolderPower.SetString(power.TokenHolderPower, 10)
 				convertedTokenHolderPower := utils.ConvertToFIL(tokenHolderPower, config.Client.Network.ChainID)
 
-				spPower, err := strconv.ParseInt(power.Data.SpPower, 10, 64)
+				spPower, err := strconv.ParseInt(power.SpPower, 10, 64)
 				convertedSpPower := utils.ConvertSize(spPower)
 
 				table.Append([]string{
 					v,
 					convertedSpPower,
-					power.Data.ClientPower,
-					power.Data.DeveloperPower,
+					power.ClientPower,
+					power.DeveloperPower,
 					convertedTokenHolderPower,
 				})
 			}
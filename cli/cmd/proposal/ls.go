@@ -2,6 +2,7 @@ package proposal
 
 import (
 	"bufio"
+	"fil-vote/client"
 	"fil-vote/model"
 	"fil-vote/service"
 	"fmt"
@@ -36,7 +37,7 @@ func ListProposalsCmd() *cobra.Command {
 
 				// If we're on the last page, don't show the table
 				if showTable {
-					displayProposals(proposals.Data.List)
+					displayProposals(proposals.List)
 				}
 
 				// Display user interaction options
@@ -47,7 +48,7 @@ func ListProposalsCmd() *cobra.Command {
 					input := strings.TrimSpace(scanner.Text())
 
 					// Process user input for pagination or selecting a proposal
-					if exit := handleUserInput(input, &page, proposals.Data.Total, scanner, &showTable); exit {
+					if exit := handleUserInput(input, &page, int(proposals.Total), scanner, &showTable); exit {
 						break // Exit the loop if 'q' is pressed
 					}
 				}
@@ -124,11 +125,11 @@ func processProposalID(input string, scanner *bufio.Scanner) {
 }
 
 // displayProposals prints the list of proposals in a formatted table.
-func displayProposals(proposals []model.Proposal) {
+func displayProposals(proposals []client.ProposalRep) {
 	table := createTable([]string{"Proposal ID", "Creator", "Title", "Status"})
 
 	for _, proposal := range proposals {
-		status := proposal.Status.String()
+		status := model.ProposalStatus(proposal.Status).String()
 		table.Append([]string{
 			fmt.Sprintf("%d", proposal.ProposalId),
 			proposal.Address,
@@ -142,7 +143,7 @@ func displayProposals(proposals []model.Proposal) {
 }
 
 // displayProposalDetails prints the detailed content of a proposal.
-func displayProposalDetails(proposal model.Proposal) {
+func displayProposalDetails(proposal client.ProposalRep) {
 	proposalTable := createTable([]string{"Field", "Details"})
 
 	proposalTable.Append([]string{"Proposal ID", fmt.Sprintf("%d", proposal.ProposalId)})
@@ -152,10 +153,20 @@ func displayProposalDetails(proposal model.Proposal) {
 	proposalTable.Append([]string{"Start Time", time.Unix(proposal.StartTime, 0).Format("2006-01-02 15:04")})
 	proposalTable.Append([]string{"End Time", time.Unix(proposal.EndTime, 0).Format("2006-01-02 15:04")})
 	proposalTable.Append([]string{"Snapshot Block Height", fmt.Sprintf("%d", proposal.SnapshotInfo.SnapshotHeight)})
-	proposalTable.Append([]string{"Status", proposal.Status.String()})
-	if proposal.Status == model.ProposalStatusCompleted {
+	proposalTable.Append([]string{"Status", model.ProposalStatus(proposal.Status).String()})
+	proposalTable.Append([]string{"Vote Options", strings.Join(proposal.Options, ", ")})
+	if model.ProposalStatus(proposal.Status) == model.ProposalStatusCompleted {
+		proposalTable.Append([]string{"Outcome", proposal.Outcome})
 		proposalTable.Append([]string{"Vote Approve", fmt.Sprintf("%.2f%%", proposal.VotePercentage.Approve)})
 		proposalTable.Append([]string{"Vote Reject", fmt.Sprintf("%.2f%%", proposal.VotePercentage.Reject)})
+		proposalTable.Append([]string{"Vote Abstain", fmt.Sprintf("%.2f%%", proposal.VotePercentage.Abstain)})
+		// Options other than the standard ones are listed by name
+		for _, option := range proposal.Options {
+			if option == model.Approve || option == model.Reject || option == model.Abstain {
+				continue
+			}
+			proposalTable.Append([]string{"Vote " + option, fmt.Sprintf("%.2f%%", proposal.VotePercentage.Options[option])})
+		}
 	}
 
 	// Render the proposal content table
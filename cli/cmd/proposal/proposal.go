@@ -14,6 +14,8 @@ func NewProposalCmd(client *service.RPCClient) *cobra.Command {
 	proposalCmd.AddCommand(ListProposalsCmd())
 	proposalCmd.AddCommand(ApproveCmd(client))
 	proposalCmd.AddCommand(RejectCmd(client))
+	proposalCmd.AddCommand(AbstainCmd(client))
+	proposalCmd.AddCommand(VoteCmd(client))
 	proposalCmd.AddCommand(ResultsCmd())
 
 	return proposalCmd
@@ -1,6 +1,7 @@
 package proposal
 
 import (
+	"fil-vote/client"
 	"fil-vote/config"
 	"fil-vote/model"
 	"fil-vote/service"
@@ -60,9 +61,10 @@ func ResultsCmd() *cobra.Command {
 
 				// Print the proposal and votes
 				printProposalContents(proposal, votes)
+				printCategoryBreakdown(proposal)
 			} else {
 				// Log proposal status info
-				zap.L().Info("Proposal is not completed, no voting results available", zap.Int64("proposalID", proposalID), zap.String("status", proposal.Status.String()))
+				zap.L().Info("Proposal is not completed, no voting results available", zap.Int64("proposalID", proposalID), zap.String("status", model.ProposalStatus(proposal.Status).String()))
 			}
 		},
 	}
@@ -74,7 +76,7 @@ func logError(message string, err error, proposalID interface{}) {
 }
 
 // printProposalContents prints the proposal and votes in a formatted table
-func printProposalContents(proposal model.Proposal, votes []model.Vote) {
+func printProposalContents(proposal client.ProposalRep, votes []client.Voted) {
 	voteTable := tablewriter.NewWriter(os.Stdout)
 	voteTable.SetHeader([]string{"Voter Address", "SP Power", "Client Power", "Developer Power", "TokenHolder Power", "Power Percentage", "Result"})
 	voteTable.SetBorder(true)
@@ -158,7 +160,7 @@ func printProposalContents(proposal model.Proposal, votes []model.Vote) {
 			powerPercent = new(big.Float)
 		}
 
-		// Determine the result of the vote (Approve/Reject)
+		// Determine the result of the vote (Approve/Reject/Abstain)
 		votedResult := getVotedResult(v.VotedResult)
 
 		spPowerInt, err := strconv.ParseInt(v.SpPower, 10, 64)
@@ -179,15 +181,66 @@ func printProposalContents(proposal model.Proposal, votes []model.Vote) {
 	voteTable.Render()
 }
 
-// getVotedResult converts the vote result into a string (Approve/Reject)
+// categoryNames are the display names of the power categories, in display order
+var categoryNames = []struct {
+	key  string
+	name string
+}{
+	{"sp", "SP"},
+	{"client", "Client"},
+	{"token_holder", "TokenHolder"},
+	{"developer", "Developer"},
+}
+
+// printCategoryBreakdown prints how each power category voted, with the power and share of each option
+func printCategoryBreakdown(proposal client.ProposalRep) {
+	if len(proposal.CategoryBreakdown) == 0 {
+		return
+	}
+
+	header := []string{"Category", "Weight", "Total Power"}
+	for _, option := range proposal.Options {
+		header = append(header, getVotedResult(option))
+	}
+
+	breakdownTable := tablewriter.NewWriter(os.Stdout)
+	breakdownTable.SetHeader(header)
+	breakdownTable.SetBorder(true)
+	breakdownTable.SetRowLine(true)
+	breakdownTable.SetAutoFormatHeaders(true)
+	breakdownTable.SetColumnSeparator("|")
+
+	for _, category := range categoryNames {
+		result, ok := proposal.CategoryBreakdown[category.key]
+		if !ok {
+			continue
+		}
+
+		row := []string{category.name, fmt.Sprintf("%.2f%%", float64(result.Weight)/100), result.TotalPower}
+		for _, option := range proposal.Options {
+			power := result.Options[option]
+			row = append(row, fmt.Sprintf("%s (%.2f%%)", power.Power, power.Share))
+		}
+		breakdownTable.Append(row)
+	}
+
+	breakdownTable.Render()
+}
+
+// getVotedResult converts the vote result into a string (Approve/Reject/Abstain)
 func getVotedResult(result string) string {
 	switch result {
 	case model.Approve:
 		return model.Approved
 	case model.Reject:
 		return model.Rejected
-	default:
+	case model.Abstain:
+		return model.Abstained
+	case "":
 		return "Unknown"
+	default:
+		// Options other than the standard ones are displayed as voted
+		return result
 	}
 }
 
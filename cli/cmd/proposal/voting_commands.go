@@ -21,7 +21,42 @@ func RejectCmd(client *service.RPCClient) *cobra.Command {
 	return createVoteCommand(client, model.Reject, "Vote on a proposal to reject")
 }
 
-// createVoteCommand is a helper function that creates both approve and reject commands.
+// AbstainCmd creates a command to handle voting on proposals (abstain).
+func AbstainCmd(client *service.RPCClient) *cobra.Command {
+	return createVoteCommand(client, model.Abstain, "Vote on a proposal to abstain")
+}
+
+// VoteCmd creates a command to vote on a proposal with any of its options.
+func VoteCmd(client *service.RPCClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "vote [option]",
+		Short: "Vote on a proposal with one of its options",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			from, proposalId, err := retrieveVoteParameters(cmd, client)
+			if err != nil {
+				zap.L().Error("Failed to retrieve voting parameters", zap.Error(err))
+				return
+			}
+
+			// Vote on the proposal and handle errors
+			messageHash, err := service.Vote(client, from, args[0], proposalId)
+			if err != nil {
+				zap.L().Error("Failed to submit vote", zap.Error(err))
+				return
+			}
+
+			// Display the message hash in a table format
+			displayMessageHash(messageHash)
+		},
+	}
+
+	// Add flags for the command
+	AddVoteFlags(cmd)
+	return cmd
+}
+
+// createVoteCommand is a helper function that creates the commands voting for a fixed option.
 func createVoteCommand(client *service.RPCClient, voteType, description string) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   voteType,
@@ -6,11 +6,13 @@ const (
 	Page     = 1
 	PageSize = 10
 
-	Approved = "Approved"
-	Rejected = "Rejected"
+	Approved  = "Approved"
+	Rejected  = "Rejected"
+	Abstained = "Abstained"
 
 	Approve             = "approve"
 	Reject              = "reject"
+	Abstain             = "abstain"
 	BaseProposalAPIPath = "/api"
 
 	GithubAPI = "https://api.github.com/users/"
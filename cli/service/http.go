@@ -1,102 +1,72 @@
 package service
 
 import (
-	"encoding/json"
+	"context"
+	"fil-vote/client"
 	"fil-vote/config"
 	"fil-vote/model"
-	"fmt"
 	"go.uber.org/zap"
-	"io"
-	"net/http"
 )
 
-// Helper function for making GET requests
-func makeGETRequest(url string) ([]byte, error) {
-	resp, err := http.Get(url)
-	if err != nil {
-		// Log the error using zap
-		zap.L().Error("failed to execute GET request", zap.String("url", url), zap.Error(err))
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		// Log the error using zap
-		zap.L().Error("failed to read response body", zap.Error(err))
-		return nil, err
-	}
-	return body, nil
+// apiClient returns a client of the backend REST API configured for the current network
+func apiClient() *client.Client {
+	return client.NewClient(config.Client.Network.PowerBackendURL + model.BaseProposalAPIPath)
 }
 
-// GetProposalList sends a GET request to retrieve a list of proposals and returns a list of Proposal models
-func GetProposalList(page, pageSize int) (model.ProposalsResponse, error) {
-	url := fmt.Sprintf(config.Client.Network.PowerBackendURL+model.BaseProposalAPIPath+"/proposal/list?status=0&searchKey=&chainId=%d&page=%d&pageSize=%d", config.Client.Network.ChainID, page, pageSize)
-	body, err := makeGETRequest(url)
+// GetProposalList retrieves a page of the proposals of the current network
+func GetProposalList(page, pageSize int) (*client.CountListRepOfProposalRep, error) {
+	result, err := apiClient().GetProposalList(context.Background(), client.GetProposalListParams{
+		ChainId:  int64(config.Client.Network.ChainID),
+		Page:     page,
+		PageSize: pageSize,
+	})
 	if err != nil {
-		return model.ProposalsResponse{}, err
+		zap.L().Error("failed to get proposal list", zap.Int("page", page), zap.Error(err))
+		return nil, err
 	}
 
-	var result model.ProposalsResponse
-	err = json.Unmarshal(body, &result)
-	if err != nil {
-		zap.L().Error("failed to parse JSON response", zap.Error(err))
-		return model.ProposalsResponse{}, err
-	}
 	return result, nil
 }
 
 // GetProposalByID retrieves a proposal by its ID
-func GetProposalByID(proposalId int64) (model.Proposal, error) {
-	url := fmt.Sprintf(config.Client.Network.PowerBackendURL+model.BaseProposalAPIPath+"/proposal/details?chainId=%d&proposalId=%d", config.Client.Network.ChainID, proposalId)
-	body, err := makeGETRequest(url)
-	if err != nil {
-		return model.Proposal{}, err
-	}
-
-	var result struct {
-		Proposals model.Proposal `json:"data"`
-	}
-	err = json.Unmarshal(body, &result)
+func GetProposalByID(proposalId int64) (client.ProposalRep, error) {
+	result, err := apiClient().GetProposalDetail(context.Background(), client.GetProposalDetailParams{
+		ChainId:    int64(config.Client.Network.ChainID),
+		ProposalId: proposalId,
+	})
 	if err != nil {
-		zap.L().Error("failed to parse JSON response", zap.Error(err))
-		return model.Proposal{}, err
+		zap.L().Error("failed to get proposal", zap.Int64("proposalId", proposalId), zap.Error(err))
+		return client.ProposalRep{}, err
 	}
 
-	return result.Proposals, nil
+	return *result, nil
 }
 
-// GetVotesByProposalID retrieves votes for a specific proposal by its ID
-func GetVotesByProposalID(proposalId int64) ([]model.Vote, error) {
-	url := fmt.Sprintf(config.Client.Network.PowerBackendURL+model.BaseProposalAPIPath+"/proposal/votes?chainId=%d&proposalId=%d", config.Client.Network.ChainID, proposalId)
-	body, err := makeGETRequest(url)
+// GetVotesByProposalID retrieves the counted votes of a proposal by its ID
+func GetVotesByProposalID(proposalId int64) ([]client.Voted, error) {
+	votes, err := apiClient().GetCountedVotesInfo(context.Background(), client.GetCountedVotesInfoParams{
+		ChainId:    int64(config.Client.Network.ChainID),
+		ProposalId: proposalId,
+	})
 	if err != nil {
+		zap.L().Error("failed to get votes", zap.Int64("proposalId", proposalId), zap.Error(err))
 		return nil, err
 	}
 
-	var result model.VoteResponse
-	err = json.Unmarshal(body, &result)
-	if err != nil {
-		zap.L().Error("failed to parse JSON response", zap.Error(err))
-		return nil, err
-	}
-
-	return result.Data, nil
+	return votes, nil
 }
 
-func GetPower(day, address string) (model.Power, error) {
-	url := fmt.Sprintf(config.Client.Network.PowerBackendURL+model.BaseProposalAPIPath+"/power/getPower?powerDay=%s&chainId=%d&address=%s", day, config.Client.Network.ChainID, address)
-	body, err := makeGETRequest(url)
+// GetPower retrieves the power of an address on a day
+func GetPower(day, address string) (client.PowerRep, error) {
+	power, err := apiClient().GetAddressPower(context.Background(), client.GetAddressPowerParams{
+		ChainId:  int64(config.Client.Network.ChainID),
+		PowerDay: day,
+		Address:  address,
+	})
 	if err != nil {
-		return model.Power{}, err
+		zap.L().Error("failed to get power", zap.String("address", address), zap.String("day", day), zap.Error(err))
+		return client.PowerRep{}, err
 	}
 
-	var result model.Power
-	err = json.Unmarshal(body, &result)
-	if err != nil {
-		zap.L().Error("failed to parse JSON response", zap.Error(err))
-		return model.Power{}, err
-	}
-
-	return result, nil
+	return *power, nil
 }
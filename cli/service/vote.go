@@ -13,12 +13,14 @@ import (
 	"go.uber.org/zap"
 	"math/big"
 	"os"
+	"slices"
+	"strings"
 
 	"github.com/filecoin-project/lotus/chain/types"
 	cbor "github.com/whyrusleeping/cbor/go"
 )
 
-// Vote allows a user to vote on a proposal (approve or reject).
+// Vote allows a user to vote on a proposal with one of its options (e.g. approve, reject or abstain).
 func Vote(client *RPCClient, from, action string, proposalId int64) (string, error) {
 	// Fetch proposal details using the provided proposal ID
 	proposalDetail, err := GetProposalByID(proposalId)
@@ -35,7 +37,13 @@ func Vote(client *RPCClient, from, action string, proposalId int64) (string, err
 		return "", errors.New("the proposal is not in an 'in-progress' state, voting cannot proceed")
 	}
 
-	// Encrypt the vote result (approve or reject) using proposal's end time
+	// The options are declared by the proposal and listed in lowercase
+	action = strings.ToLower(strings.TrimSpace(action))
+	if !slices.Contains(proposalDetail.Options, action) {
+		return "", fmt.Errorf("invalid vote option %q, the proposal accepts %v", action, proposalDetail.Options)
+	}
+
+	// Encrypt the vote option using proposal's end time
 	result, err := utils.EncryptVoteResult([][]string{{action}}, proposalDetail.EndTime)
 	if err != nil {
 		zap.L().Error("Failed to encrypt vote result", zap.Error(err))
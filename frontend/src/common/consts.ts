@@ -32,6 +32,10 @@ export const proposalVoteDataApi = `${baseUrl}/proposal/votes`;
 export const proposalDraftAddApi = `${baseUrl}/proposal/draft/add`;
 export const proposalDraftGetApi = `${baseUrl}/proposal/draft/get`;
 export const proposalDraftDeleteApi = `${baseUrl}/proposal/draft/delete`;
+export const proposalDraftListApi = `${baseUrl}/proposal/draft/list`;
+export const proposalDraftVersionsApi = `${baseUrl}/proposal/draft/versions`;
+export const proposalDraftDiffApi = `${baseUrl}/proposal/draft/diff`;
+export const proposalDraftSubmitApi = `${baseUrl}/proposal/draft/submit`;
 export const votePowerGetApi = `${baseUrl}/power/getPower`;
 export const getVoteDetail = `${baseUrl}/proposal/details`;
 export const getFipListApi = `${baseUrl}/fipEditor/list`;
@@ -151,6 +155,12 @@ export const VoteOptionItem: { [key: string]: string } = {
   'approve': 'Approve',
   'reject': 'Reject',
 }
+// The proposal content declares its vote options in its last line starting with this prefix, the backend
+// lowercases them, keeps at most 10 of at most 32 characters, and always offers approve and reject.
+export const VOTE_OPTIONS_PREFIX = 'Vote options:';
+export const DEFAULT_VOTE_OPTIONS = ['approve', 'reject', 'abstain'];
+export const MAX_VOTE_OPTIONS = 10;
+export const MAX_VOTE_OPTION_LENGTH = 32;
 export const GITHUB_STEP_1 = 1;
 export const GITHUB_STEP_2 = 2;
 export const FIP_EDITOR_REVOKE_TYPE = 0;
@@ -188,4 +198,5 @@ export const CAN_NOT_REVOKE_YOURSELF_MSG = 'content.revokeYourself';
 export const SAVE_DRAFT_SUCCESS = "content.saveSuccess";
 export const SAVE_DRAFT_TOO_LARGE = "content.savedDescriptionCharacters";
 export const SAVE_DRAFT_FAIL = "content.saveFail";
+export const SUBMIT_DRAFT_FAIL = "content.submitDraftFail";
 export const NO_FIP_INfO_MSG = "content.inputFipInfo";
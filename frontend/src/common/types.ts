@@ -86,6 +86,12 @@ export interface ProposalList extends ProposalData {
 }
 
 export interface ProposalDraft {
+  draftId: number
+  name: string
+  version: number
+  submittedVersion: number
+  proposalId: number
+  updatedAt: number
   timezone: string
   Time: string
   title: string
@@ -98,6 +104,48 @@ export interface ProposalDraft {
   developerPercentage: number,
   tokenHolderPercentage: number
 }
+
+// The content of a draft covered by the signature of its creator, in the order the backend hashes it
+export interface DraftPayload {
+  name: string,
+  title: string,
+  content: string,
+  startTime: number,
+  endTime: number,
+  timezone: string,
+  tokenHolderPercentage: number,
+  spPercentage: number,
+  clientPercentage: number,
+  developerPercentage: number
+}
+
+export interface ProposalDraftVersion {
+  version: number,
+  createdAt: number,
+  title: string,
+  content: string,
+  startTime: number,
+  endTime: number,
+  timezone: string,
+  spPercentage: number,
+  clientPercentage: number,
+  developerPercentage: number,
+  tokenHolderPercentage: number
+}
+
+export interface ProposalDraftDiff {
+  draftId: number,
+  from: number,
+  to: number,
+  fields: { field: string, from: string, to: string }[],
+  content: { op: 'equal' | 'insert' | 'delete', text: string }[]
+}
+
+export interface DraftAuth {
+  timestamp: number,
+  nonce: string,
+  signature?: string
+}
 export interface VotingList {
   proposalId: number,
   cid: string,
@@ -0,0 +1,124 @@
+// Copyright (C) 2023-2024 StorSwift Inc.
+// This file is part of the PowerVoting library.
+
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+// http://www.apache.org/licenses/LICENSE-2.0
+
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+import { Modal, Select } from "antd";
+import axios from "axios";
+import classNames from "classnames";
+import dayjs from "dayjs";
+import { useEffect, useState } from "react";
+import { useTranslation } from "react-i18next";
+import { proposalDraftDiffApi, proposalDraftVersionsApi } from "../common/consts";
+import type { ProposalDraftDiff, ProposalDraftVersion } from "../common/types";
+
+/**
+ * Version history of a proposal draft: compares two saved versions and loads one of them into the form.
+ */
+export default function DraftHistory({ draftId, open, onClose, onLoad }: {
+  draftId: number,
+  open: boolean,
+  onClose: () => void,
+  onLoad: (version: ProposalDraftVersion) => void
+}) {
+  const { t } = useTranslation();
+  const [versions, setVersions] = useState<ProposalDraftVersion[]>([]);
+  const [from, setFrom] = useState(0);
+  const [to, setTo] = useState(0);
+  const [diff, setDiff] = useState<ProposalDraftDiff>();
+
+  useEffect(() => {
+    if (!open || !draftId) {
+      return
+    }
+    axios.get(proposalDraftVersionsApi, { params: { draftId } }).then(resp => {
+      const list = (resp.data?.data ?? []) as ProposalDraftVersion[];
+      setVersions(list);
+      // Compare the last version with the one before it
+      const last = list.length ? list[list.length - 1].version : 0;
+      setTo(last);
+      setFrom(Math.max(last - 1, 1));
+    }).catch(e => console.log(e));
+  }, [open, draftId]);
+
+  useEffect(() => {
+    if (!open || !from || !to) {
+      setDiff(undefined);
+      return
+    }
+    axios.get(proposalDraftDiffApi, { params: { draftId, from, to } }).then(resp => {
+      setDiff(resp.data?.data as ProposalDraftDiff);
+    }).catch(e => console.log(e));
+  }, [open, draftId, from, to]);
+
+  const options = versions.map(item => ({
+    value: item.version,
+    label: `v${item.version} · ${dayjs(item.createdAt * 1000).format('YYYY-MM-DD HH:mm')}`
+  }));
+  const toVersion = versions.find(item => item.version === to);
+
+  return (
+    <Modal
+      width={800}
+      title={t('content.draftHistory')}
+      open={open}
+      onCancel={onClose}
+      okText={t('content.loadVersion')}
+      okButtonProps={{ disabled: !toVersion }}
+      onOk={() => {
+        if (toVersion) {
+          onLoad(toVersion);
+        }
+        onClose();
+      }}
+    >
+      <div className="flex items-center gap-[10px] mb-4 text-[#313D4F]">
+        <span>{t('content.compareVersions')}</span>
+        <Select className="w-[220px]" value={from || undefined} options={options} onChange={setFrom} />
+        <span>→</span>
+        <Select className="w-[220px]" value={to || undefined} options={options} onChange={setTo} />
+      </div>
+      {diff && !diff.fields.length && !diff.content.length && (
+        <div className="text-[#8896AA]">{t('content.noChanges')}</div>
+      )}
+      {diff && diff.fields.length > 0 && (
+        <table className="w-full mb-4 text-sm text-[#313D4F]">
+          <tbody>
+            {diff.fields.map(item => (
+              <tr key={item.field}>
+                <td className="pr-4 font-medium">{item.field}</td>
+                <td className="pr-4 text-[#AA0101] line-through">{item.from}</td>
+                <td className="text-[#0C7A00]">{item.to}</td>
+              </tr>
+            ))}
+          </tbody>
+        </table>
+      )}
+      {diff && diff.content.length > 0 && (
+        <pre className="max-h-[400px] overflow-auto rounded border border-[#EEEEEE] text-sm whitespace-pre-wrap">
+          {diff.content.map((line, index) => (
+            <div
+              key={index}
+              className={classNames(
+                'px-2',
+                line.op === 'insert' && 'bg-[#E6FFEC] text-[#0C7A00]',
+                line.op === 'delete' && 'bg-[#FFEBE9] text-[#AA0101]'
+              )}
+            >
+              {line.op === 'insert' ? '+ ' : line.op === 'delete' ? '- ' : '  '}{line.text}
+            </div>
+          ))}
+        </pre>
+      )}
+    </Modal>
+  )
+}
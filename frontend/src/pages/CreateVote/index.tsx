@@ -13,7 +13,7 @@
 // limitations under the License.
 
 import { useConnectModal } from "@rainbow-me/rainbowkit";
-import { DatePicker, InputNumber, message } from "antd";
+import { DatePicker, Input, InputNumber, message, Select } from "antd";
 import axios from 'axios';
 import classNames from 'classnames';
 import dayjs from "dayjs";
@@ -25,29 +25,46 @@ import { useTranslation } from 'react-i18next';
 import { Link, useNavigate } from "react-router-dom";
 import { UserRejectedRequestError } from "viem";
 import type { BaseError } from "wagmi";
-import { useAccount, useWaitForTransactionReceipt, useWriteContract } from "wagmi";
+import { useAccount, useSignMessage, useWaitForTransactionReceipt, useWriteContract } from "wagmi";
 import fileCoinAbi from "../../common/abi/power-voting.json";
-import type { ProposalDraft } from "../../common/types";
+import type { DraftPayload, ProposalDraft, ProposalDraftVersion } from "../../common/types";
 
 import {
   calibrationChainId,
   DEFAULT_TIMEZONE,
+  DEFAULT_VOTE_OPTIONS,
+  MAX_VOTE_OPTIONS,
   NOT_FIP_EDITOR_MSG,
   proposalDraftAddApi, proposalDraftDeleteApi,
-  proposalDraftGetApi,
+  proposalDraftListApi,
+  proposalDraftSubmitApi,
   SAVE_DRAFT_FAIL,
   SAVE_DRAFT_SUCCESS,
   SAVE_DRAFT_TOO_LARGE,
   STORING_DATA_MSG,
+  SUBMIT_DRAFT_FAIL,
   WRONG_EXPIRATION_TIME_MSG,
   WRONG_START_TIME_MSG
 } from "../../common/consts"
 import { useFipList, useSearchValue, useStoringCid } from "../../common/store";
 import CreateTable from "../../components/CreateTable";
+import DraftHistory from "../../components/DraftHistory";
 import LoadingButton from "../../components/LoadingButton";
 import Editor from '../../components/MDEditor';
 import timezoneOption from '../../json/timezons.json';
-import { getContractAddress, hexToString, multiplyWithPrecision, validateValue } from "../../utils"
+import {
+  draftDeleteMessage,
+  draftSaveMessage,
+  draftSubmitMessage,
+  getContractAddress,
+  hexToString,
+  multiplyWithPrecision,
+  newDraftAuth,
+  normalizeVoteOptions,
+  proposalContent,
+  splitProposalContent,
+  validateValue
+} from "../../utils"
 import './index.less';
 
 dayjs.extend(utc);
@@ -78,6 +95,7 @@ const CreateVote = () => {
       time: [] as string[],
       name: '',
       descriptions: '',
+      voteOptions: [...DEFAULT_VOTE_OPTIONS],
       percent: {
         spPercentage: 25,
         clientPercentage: 25,
@@ -106,6 +124,12 @@ const CreateVote = () => {
   // const [cid, setCid] = useState('');
   const [loading, setLoading] = useState<boolean>(writeContractPending);
   const [isDraftSave, setDraftSave] = useState(false);
+  const [drafts, setDrafts] = useState<ProposalDraft[]>([]);
+  const [draft, setDraft] = useState<ProposalDraft>();
+  const [draftName, setDraftName] = useState('');
+  const [historyOpen, setHistoryOpen] = useState(false);
+  const draftId = draft?.draftId ?? 0;
+  const { signMessageAsync } = useSignMessage();
 
   useEffect(() => {
     if (error) {
@@ -147,30 +171,61 @@ const CreateVote = () => {
   }, [address]);
 
 
-  const loadDraft = async () => {
+  /**
+   * fill the form with a draft or one of its versions
+   * @param result
+   */
+  const applyDraft = (result: ProposalDraft | ProposalDraftVersion) => {
+    // The vote options are edited apart from the description, they are the last line of the content
+    const { description, options } = splitProposalContent(result.content)
+    setValue("descriptions", description)
+    setValue("voteOptions", options)
+    setValue("name", result.title)
+    if (result.timezone) {
+      setValue("timezone", result.timezone)
+    }
+    if (result.startTime && result.endTime) {
+      // The times are shown in the timezone of the draft, as they were picked
+      const offset = dayjs().utcOffset() - dayjs().tz(result.timezone || DEFAULT_TIMEZONE).utcOffset();
+      setValue("time", [
+        dayjs(result.startTime * 1000).subtract(offset, 'minute').toString(),
+        dayjs(result.endTime * 1000).subtract(offset, 'minute').toString()
+      ])
+    }
+    setValue("percent", {
+      spPercentage: result?.spPercentage / 100,
+      clientPercentage: result?.clientPercentage / 100,
+      developerPercentage: result?.developerPercentage / 100,
+      tokenHolderPercentage: result?.tokenHolderPercentage / 100,
+    })
+  }
+
+  const selectDraft = (result?: ProposalDraft) => {
+    setDraft(result)
+    setDraftName(result?.name ?? '')
+    if (result) {
+      applyDraft(result)
+    }
+  }
+
+  /**
+   * load the drafts of the creator, the last saved draft not created as a proposal yet is opened
+   * @param current ID of the draft to keep open
+   */
+  const loadDrafts = async (current?: number) => {
     try {
-      const resp = await axios.get(proposalDraftGetApi, {
+      const resp = await axios.get(proposalDraftListApi, {
         params: {
           chainId: chainId,
           address: address
         }
       });
-      if (resp.data != null && resp.data.data) {
-        const result = (resp.data.data as ProposalDraft)
-        setValue("descriptions", result.content)
-        setValue("name", result.title)
-        if (result.startTime && result.endTime) {
-          setValue("time", [dayjs(result.startTime * 1000).toString(), dayjs(result.endTime * 1000).toString()])
-        }
-        if (result.timezone) {
-          setValue("timezone", result.timezone)
-        }
-        setValue("percent", {
-          spPercentage: result?.spPercentage / 100,
-          clientPercentage: result?.clientPercentage / 100,
-          developerPercentage: result?.developerPercentage / 100,
-          tokenHolderPercentage: result?.tokenHolderPercentage / 100,
-        })
+      const list = (resp.data?.data ?? []) as ProposalDraft[]
+      setDrafts(list)
+      if (current) {
+        setDraft(list.find(item => item.draftId === current))
+      } else {
+        selectDraft(list.find(item => !item.proposalId))
       }
     } catch (e) {
       console.log(e)
@@ -178,7 +233,7 @@ const CreateVote = () => {
   }
 
   useEffect(() => {
-    loadDraft();
+    loadDrafts();
   }, []);
 
   useEffect(() => {
@@ -187,8 +242,6 @@ const CreateVote = () => {
         type: 'success',
         content: t(STORING_DATA_MSG),
       });
-      //clear draft
-      clearDraft()
       addStoringCid([{
         hash,
       }]);
@@ -198,6 +251,99 @@ const CreateVote = () => {
     }
   }, [writeContractSuccess])
 
+  /**
+   * draft content of the form values, the times in the selected timezone and the percentages in basis points
+   * @param values
+   */
+  const draftFromValues = (values: any): DraftPayload => {
+    const offset = dayjs().utcOffset() - dayjs().tz(values.timezone).utcOffset();
+    return {
+      name: draftName,
+      title: values.name,
+      content: proposalContent(values.descriptions, values.voteOptions),
+      startTime: dayjs(values.time[0]).add(offset, 'minute').unix(),
+      endTime: dayjs(values.time[1]).add(offset, 'minute').unix(),
+      timezone: values.timezone,
+      tokenHolderPercentage: multiplyWithPrecision(values.percent.tokenHolderPercentage, 100),
+      spPercentage: multiplyWithPrecision(values.percent.spPercentage, 100),
+      clientPercentage: multiplyWithPrecision(values.percent.clientPercentage, 100),
+      developerPercentage: multiplyWithPrecision(values.percent.developerPercentage, 100),
+    }
+  }
+
+  /**
+   * sign and save the draft, returns the saved draft
+   * @param payload
+   */
+  const postDraft = async (payload: DraftPayload): Promise<ProposalDraft | undefined> => {
+    // The creator signs the draft, the backend only saves a draft signed within a few minutes
+    const auth = newDraftAuth()
+    const signature = await signMessageAsync({ message: draftSaveMessage(address as string, chainId, draftId, payload, auth) })
+    const data = {
+      ...payload,
+      draftId,
+      creator: address,
+      chainId: chainId,
+      ...auth,
+      signature,
+    }
+    const res = await axios.post(proposalDraftAddApi, data)
+    if (res.data == null || res.data.code !== 0) {
+      return undefined
+    }
+    const saved = res.data.data as ProposalDraft
+    setDraft(saved)
+    setDraftName(saved.name)
+    loadDrafts(saved.draftId)
+    return saved
+  }
+
+  /**
+   * mark the version of the open draft the proposal is created from, so that the proposal is linked to the draft once it is synced.
+   * The form is saved as a new version first when it changed since the draft was opened.
+   * @param values
+   */
+  const submitDraft = async (values: any) => {
+    if (!draft || draft.proposalId) {
+      return true
+    }
+    try {
+      const payload = draftFromValues(values)
+      let current: ProposalDraft | undefined = draft
+      if (payload.title !== draft.title
+        || payload.content !== draft.content
+        || payload.startTime !== draft.startTime
+        || payload.endTime !== draft.endTime
+        || payload.timezone !== draft.timezone
+        || payload.tokenHolderPercentage !== draft.tokenHolderPercentage
+        || payload.spPercentage !== draft.spPercentage
+        || payload.clientPercentage !== draft.clientPercentage
+        || payload.developerPercentage !== draft.developerPercentage
+      ) {
+        current = await postDraft(payload)
+      }
+      if (!current) {
+        return false
+      }
+      const auth = newDraftAuth()
+      const signature = await signMessageAsync({
+        message: draftSubmitMessage(address as string, chainId, current.draftId, current.version, auth)
+      })
+      const res = await axios.post(proposalDraftSubmitApi, {
+        creator: address,
+        chainId,
+        draftId: current.draftId,
+        version: current.version,
+        ...auth,
+        signature,
+      })
+      return res.data != null && res.data.code === 0
+    } catch (e) {
+      console.log(e)
+      return false
+    }
+  }
+
   /**
    * create proposal
    * @param values
@@ -258,6 +404,14 @@ const CreateVote = () => {
     if (isConnected) {
       // Check if user is a FIP editor
       if (isFipEditorAddress) {
+        if (!(await submitDraft(values))) {
+          messageApi.open({
+            type: 'error',
+            content: t(SUBMIT_DRAFT_FAIL),
+          });
+          setLoading(false);
+          return false;
+        }
         // Create voting using dynamic contract API
 
 
@@ -336,7 +490,7 @@ const CreateVote = () => {
             multiplyWithPrecision(values.percent.spPercentage, 100),
             multiplyWithPrecision(values.percent.clientPercentage, 100),
             multiplyWithPrecision(values.percent.developerPercentage, 100),
-            values.descriptions,
+            proposalContent(values.descriptions, values.voteOptions),
             values.name,
           ],
         });
@@ -352,15 +506,25 @@ const CreateVote = () => {
     }
     setLoading(false);
   }
-  const clearDraft = async () => {
+  const deleteDraft = async () => {
+    if (!draftId || !address) {
+      return
+    }
     try {
+      const auth = newDraftAuth()
+      const signature = await signMessageAsync({ message: draftDeleteMessage(address, chainId, draftId, auth) })
       const data = {
         address,
         chainId,
+        draftId,
+        ...auth,
+        signature,
       }
       await axios.delete(proposalDraftDeleteApi, {
         data
       })
+      setDrafts(drafts.filter(item => item.draftId !== draftId))
+      selectDraft(undefined)
     } catch (e) {
       console.log(e)
     }
@@ -408,25 +572,9 @@ const CreateVote = () => {
       return
     }
     setDraftSave(true);
-    const offset = dayjs().utcOffset() - dayjs().tz(values.timezone).utcOffset();
-    const startTimestamp = dayjs(values.time[0]).add(offset, 'minute').unix();
-    const expTimestamp = dayjs(values.time[1]).add(offset, 'minute').unix();
-    const data = {
-      creator: address,
-      title: values.name,
-      content: values.descriptions,
-      startTime: startTimestamp,
-      endTime: expTimestamp,
-      chainId: chainId,
-      spPercentage: sp,
-      clientPercentage: client,
-      developerPercentage: developer,
-      tokenHolderPercentage: tokenHolder,
-      timezone: values.timezone,
-    }
     try {
-      const res = await axios.post(proposalDraftAddApi, data)
-      if (res.data != null && res.data.code === 0) {
+      const saved = await postDraft(draftFromValues(values))
+      if (saved) {
         messageApi.open({
           type: "success",
           content: t(SAVE_DRAFT_SUCCESS),
@@ -439,6 +587,10 @@ const CreateVote = () => {
       }
     } catch (e) {
       console.log(e)
+      messageApi.open({
+        type: "error",
+        content: t(SAVE_DRAFT_FAIL),
+      });
     }
     setTimeout(() => {
       setDraftSave(false)
@@ -483,7 +635,6 @@ const CreateVote = () => {
   // const disabledDate = (current:any) => {
   //   return current && current < dayjs().startOf('day');
   // };
-  //VOTE_OPTIONS
   const list = [
     {
       name: t('content.proposalTitle'),
@@ -548,6 +699,28 @@ const CreateVote = () => {
           }}
         />
     },
+    {
+      name: t('content.voteOptions'),
+      desc: <span className="text-sm">{t('content.voteOptionsDesc', { max: MAX_VOTE_OPTIONS })}</span>,
+      comp: (
+        <Controller
+          name='voteOptions'
+          control={control}
+          render={({ field: { onChange, value } }) => {
+            return (
+              <Select
+                mode="tags"
+                className="w-[450px]"
+                value={value}
+                open={false}
+                tokenSeparators={[',']}
+                onChange={(v: string[]) => onChange(normalizeVoteOptions(v))}
+              />
+            )
+          }}
+        />
+      )
+    },
     {
       name: t('content.powerPercentage'),
       comp: (
@@ -695,12 +868,49 @@ const CreateVote = () => {
           </div>
         </div>
       )
+    },
+    {
+      name: t('content.draft'),
+      comp: (
+        <div className="flex items-center gap-[10px]">
+          <Select
+            className="w-[240px]"
+            value={draftId}
+            onChange={(value: number) => selectDraft(drafts.find(item => item.draftId === value))}
+            options={[
+              { value: 0, label: t('content.newDraft') },
+              ...drafts.map(item => ({ value: item.draftId, label: `${item.name} · v${item.version}` }))
+            ]}
+          />
+          <Input
+            className="w-[240px]"
+            maxLength={64}
+            placeholder={t('content.draftName')}
+            value={draftName}
+            onChange={(e) => setDraftName(e.target.value)}
+          />
+          {draftId > 0 && (
+            <>
+              <div className="text-sm text-[#313D4F] font-semibold cursor-pointer" onClick={() => setHistoryOpen(true)}>
+                {t('content.draftHistory')}
+              </div>
+              <div className="text-sm text-[#AA0101] font-semibold cursor-pointer" onClick={deleteDraft}>
+                {t('content.deleteDraft')}
+              </div>
+            </>
+          )}
+          {draft?.proposalId ? (
+            <div className="text-sm text-[#4B535B]">{t('content.draftLinked', { proposalId: draft.proposalId })}</div>
+          ) : null}
+        </div>
+      )
     }
   ];
 
   return (
     <>
       {contextHolder}
+      <DraftHistory draftId={draftId} open={historyOpen} onClose={() => setHistoryOpen(false)} onLoad={applyDraft} />
       <div className="px-3 mb-6 md:px-0">
         <button>
           <div className="inline-flex items-center gap-1 text-skin-text hover:text-skin-link">
@@ -18,6 +18,7 @@ import fileCoinAbi from "../../common/abi/power-voting.json"
 import {
   calibrationChainId,
   CHOOSE_VOTE_MSG,
+  DEFAULT_VOTE_OPTIONS,
   GETTING_POWER_MSG,
   getVoteDetail,
   githubApi,
@@ -177,17 +178,11 @@ const Vote = () => {
             ? PENDING_STATUS
             : IN_PROGRESS_STATUS
       }
-      // Map each option from the fetched data to include count initialized to 0
-      const option = [
-        {
-          name: "Approve",
-          count: 0
-        },
-        {
-          name: "Reject",
-          count: 0
-        }
-      ]
+      // Map each option the proposal offers to include count initialized to 0
+      const option = ((voteDetail?.options?.length ? voteDetail.options : DEFAULT_VOTE_OPTIONS) as string[]).map(name => ({
+        name,
+        count: 0
+      }))
       // Set the voting data state with the fetched data and additional properties
       setVotingData({
         ...voteDetail,
@@ -263,7 +258,7 @@ const Vote = () => {
       setLoading(true)
       // Encrypt the selected option index and weight using handleEncrypt function
       const encryptValue = await handleEncrypt([
-        [`${selectedOptionIndex === 0 ? "approve" : "reject"}`]
+        [options[selectedOptionIndex].name]
       ])
 
       // Check if user is connected to the network
@@ -522,9 +517,11 @@ const Vote = () => {
                           } hover:border-[#0190FF] flex justify-between items-center pl-8 pr-4 md:border border-solid rounded-full cursor-pointer`}
                       >
                         <div className="text-ellipsis h-[100%] overflow-hidden">
-                          {item.name === "Approve"
+                          {item.name === "approve"
                             ? t("content.approve")
-                            : t("content.reject")}
+                            : item.name === "reject"
+                              ? t("content.reject")
+                              : item.name}
                         </div>
                         {selectedOptionIndex === index && (
                           <svg
@@ -1,5 +1,5 @@
 import { describe, expect, it } from 'vitest';
-import { convertBytes } from './index';
+import { convertBytes, draftDeleteMessage, draftSaveMessage, draftSubmitMessage, proposalContent, splitProposalContent } from './index';
 
 describe('calcFromSeconds', () => {
     it('should return 1.00 KiB from 1024', () => {
@@ -13,4 +13,75 @@ describe('calcFromSeconds', () => {
     it('should return 1.00 GiB from 1024 * 1024 * 1024', () => {
         expect(convertBytes(1024 * 1024 * 1024)).toBe('1.00 GiB');
     });
-});
\ No newline at end of file
+});
+describe('draftSaveMessage', () => {
+    const draft = {
+        name: '',
+        title: 'Draft <title>',
+        content: 'content & more',
+        startTime: 1735689600,
+        endTime: 1736294400,
+        timezone: 'UTC',
+        tokenHolderPercentage: 0,
+        spPercentage: 0,
+        clientPercentage: 0,
+        developerPercentage: 0,
+    };
+    const auth = { timestamp: 1735689600, nonce: 'nonce-0001' };
+    const creator = '0x1234567890123456789012345678901234567890';
+
+    it('should hash the draft as the backend does', () => {
+        expect(draftSaveMessage(creator, 314159, 0, draft, auth)).toBe(
+            'PowerVoting draft save\n' +
+            `Creator: ${creator}\n` +
+            'Chain ID: 314159\n' +
+            'Draft: 9d82efd173ec684c2a9b2b3e65dbd4fbca90d93cbaf8a446a1674f84a1066656\n' +
+            'Timestamp: 1735689600\n' +
+            'Nonce: nonce-0001');
+    });
+
+    it('should name the draft saved again', () => {
+        expect(draftSaveMessage(creator, 314159, 12, draft, auth)).toContain('Chain ID: 314159\nDraft ID: 12\nDraft: ');
+    });
+
+    it('should name the deleted draft', () => {
+        expect(draftDeleteMessage(creator, 314159, 12, auth)).toBe(
+            'PowerVoting draft delete\n' +
+            `Creator: ${creator}\n` +
+            'Chain ID: 314159\n' +
+            'Draft ID: 12\n' +
+            'Timestamp: 1735689600\n' +
+            'Nonce: nonce-0001');
+    });
+
+    it('should name the submitted version', () => {
+        expect(draftSubmitMessage(creator, 314159, 12, 3, auth)).toBe(
+            'PowerVoting draft submit\n' +
+            `Creator: ${creator}\n` +
+            'Chain ID: 314159\n' +
+            'Draft ID: 12\n' +
+            'Version: 3\n' +
+            'Timestamp: 1735689600\n' +
+            'Nonce: nonce-0001');
+    });
+});
+describe('proposalContent', () => {
+    it('should add the vote options line to the description', () => {
+        expect(proposalContent('Description\n', ['Delay', 'approve', ' '])).toBe(
+            'Description\n\nVote options: approve, reject, delay');
+    });
+
+    it('should split the vote options line from the content', () => {
+        expect(splitProposalContent('Description\n\nVote options: approve, reject, delay')).toEqual({
+            description: 'Description',
+            options: ['approve', 'reject', 'delay'],
+        });
+    });
+
+    it('should offer the default options of a content without the line', () => {
+        expect(splitProposalContent('Description')).toEqual({
+            description: 'Description',
+            options: ['approve', 'reject', 'abstain'],
+        });
+    });
+});
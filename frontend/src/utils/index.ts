@@ -21,8 +21,13 @@ import {
   powerVotingCalibrationContractAddress,
   powerVotingMainNetContractAddress,
   powerVotingFipMainNetContractAddress,
-  powerVotingFipCalibrationContractAddress
+  powerVotingFipCalibrationContractAddress,
+  DEFAULT_VOTE_OPTIONS,
+  MAX_VOTE_OPTIONS,
+  MAX_VOTE_OPTION_LENGTH,
+  VOTE_OPTIONS_PREFIX
 } from "../common/consts";
+import type { DraftAuth, DraftPayload } from "../common/types";
 
 
 export const stringToBase64Url = (str: string) => {
@@ -214,6 +219,122 @@ export const multiplyWithPrecision = (number: number, multiple: number) => {
   return new Decimal(number).times(multiple).toNumber();
 }
 
+/**
+ * Hash of a draft as the backend computes it: the hex SHA-256 of its compact JSON.
+ * The fields are written in the order of DraftPayload. Like the backend, JSON.stringify does not escape HTML,
+ * U+2028 and U+2029 are escaped as the backend does.
+ * @param draft
+ */
+export const draftHash = (draft: DraftPayload) => {
+  const payload: DraftPayload = {
+    name: draft.name,
+    title: draft.title,
+    content: draft.content,
+    startTime: draft.startTime,
+    endTime: draft.endTime,
+    timezone: draft.timezone,
+    tokenHolderPercentage: draft.tokenHolderPercentage,
+    spPercentage: draft.spPercentage,
+    clientPercentage: draft.clientPercentage,
+    developerPercentage: draft.developerPercentage,
+  };
+  const json = JSON.stringify(payload).replace(/\u2028/g, '\\u2028').replace(/\u2029/g, '\\u2029');
+  return ethers.sha256(ethers.toUtf8Bytes(json)).slice(2);
+}
+
+/**
+ * New timestamp and nonce of a draft mutation, signed by the creator with the message of the mutation.
+ */
+export const newDraftAuth = (): DraftAuth => {
+  return {
+    timestamp: Math.floor(Date.now() / 1000),
+    nonce: ethers.hexlify(ethers.randomBytes(8)).slice(2),
+  };
+}
+
+const draftMessage = (action: string, creator: string, chainId: number, auth: DraftAuth, fields: string[]) => {
+  return [
+    `PowerVoting draft ${action}`,
+    `Creator: ${creator}`,
+    `Chain ID: ${chainId}`,
+    ...fields,
+    `Timestamp: ${auth.timestamp}`,
+    `Nonce: ${auth.nonce}`,
+  ].join('\n');
+}
+
+/**
+ * Message the creator signs to save a draft, draftId is 0 for a new draft.
+ */
+export const draftSaveMessage = (creator: string, chainId: number, draftId: number, draft: DraftPayload, auth: DraftAuth) => {
+  const fields = draftId ? [`Draft ID: ${draftId}`] : [];
+  return draftMessage('save', creator, chainId, auth, [...fields, `Draft: ${draftHash(draft)}`]);
+}
+
+/**
+ * Message the creator signs to delete a draft.
+ */
+export const draftDeleteMessage = (creator: string, chainId: number, draftId: number, auth: DraftAuth) => {
+  return draftMessage('delete', creator, chainId, auth, [`Draft ID: ${draftId}`]);
+}
+
+/**
+ * Message the creator signs to mark the version of a draft a proposal is created from.
+ */
+export const draftSubmitMessage = (creator: string, chainId: number, draftId: number, version: number, auth: DraftAuth) => {
+  return draftMessage('submit', creator, chainId, auth, [`Draft ID: ${draftId}`, `Version: ${version}`]);
+}
+
+/**
+ * Vote options as the backend records them: lowercased, deduplicated, approve and reject first,
+ * at most MAX_VOTE_OPTIONS and none longer than MAX_VOTE_OPTION_LENGTH.
+ * @param options
+ */
+export const normalizeVoteOptions = (options: string[]) => {
+  const result = ['approve', 'reject'];
+  for (const item of options) {
+    const option = item.trim().toLowerCase();
+    if (!option || option.length > MAX_VOTE_OPTION_LENGTH || result.includes(option)) {
+      continue;
+    }
+    if (result.length === MAX_VOTE_OPTIONS) {
+      break;
+    }
+    result.push(option);
+  }
+  return result;
+}
+
+/**
+ * Proposal content sent to the contract: the description followed by the line declaring the vote options,
+ * the options are not a field of the contract so the backend reads them from this line.
+ * @param description
+ * @param options
+ */
+export const proposalContent = (description: string, options: string[]) => {
+  return `${description.trimEnd()}\n\n${VOTE_OPTIONS_PREFIX} ${normalizeVoteOptions(options).join(', ')}`;
+}
+
+/**
+ * Splits a proposal content into its description and vote options, the reverse of proposalContent.
+ * A content without the vote options line offers the default options.
+ * @param content
+ */
+export const splitProposalContent = (content: string) => {
+  const lines = content.split('\n');
+  for (let i = lines.length - 1; i >= 0; i--) {
+    const line = lines[i].trim();
+    if (line.slice(0, VOTE_OPTIONS_PREFIX.length).toLowerCase() !== VOTE_OPTIONS_PREFIX.toLowerCase()) {
+      continue;
+    }
+    return {
+      description: [...lines.slice(0, i), ...lines.slice(i + 1)].join('\n').trimEnd(),
+      options: normalizeVoteOptions(line.slice(VOTE_OPTIONS_PREFIX.length).split(',')),
+    };
+  }
+  return { description: content, options: [...DEFAULT_VOTE_OPTIONS] };
+}
+
 export const getBlockExplorers = (chain: any, address: string) => {
   return `${chain?.blockExplorers?.default.url}/wallet/${address}?network=${chain?.testnet ? "calibrationnet" : ""}`
 }